@@ -20,12 +20,15 @@ var Commands = []*Command{
 	cmdFilerReplicate,
 	cmdFilerSynchronize,
 	cmdFix,
+	cmdFtp,
 	cmdMaster,
 	cmdMount,
+	cmdNfs,
 	cmdS3,
 	cmdMsgBroker,
 	cmdScaffold,
 	cmdServer,
+	cmdSftp,
 	cmdShell,
 	cmdUpload,
 	cmdVersion,
@@ -21,6 +21,8 @@ var cmdCompact = &Command{
   For method=0, it compacts based on the .dat file, works if .idx file is corrupted.
   For method=1, it compacts based on the .idx file, works if deletion happened but not written to .dat files.
 
+  If the volume is configured with compression, the uncompressed files are also compressed.
+
   `,
 }
 
@@ -14,6 +14,8 @@ import (
 	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/chrislusf/seaweedfs/weed/storage"
 	"github.com/chrislusf/seaweedfs/weed/storage/needle"
 	"github.com/chrislusf/seaweedfs/weed/storage/needle_map"
@@ -32,9 +34,10 @@ var (
 )
 
 type ExportOptions struct {
-	dir        *string
-	collection *string
-	volumeId   *int
+	dir               *string
+	collection        *string
+	volumeId          *int
+	encryptionKeyFile *string
 }
 
 var cmdExport = &Command{
@@ -52,6 +55,7 @@ func init() {
 	export.dir = cmdExport.Flag.String("dir", ".", "input data directory to store volume data files")
 	export.collection = cmdExport.Flag.String("collection", "", "the volume collection name")
 	export.volumeId = cmdExport.Flag.Int("volumeId", -1, "a volume id. The volume .dat and .idx files should already exist in the dir.")
+	export.encryptionKeyFile = cmdExport.Flag.String("encryption.keyFile", "", "file with the master key of the volume servers, to export encrypted volumes")
 }
 
 var (
@@ -91,10 +95,11 @@ func printNeedle(vid needle.VolumeId, n *needle.Needle, version needle.Version,
 }
 
 type VolumeFileScanner4Export struct {
-	version   needle.Version
-	counter   int
-	needleMap *needle_map.MemDb
-	vid       needle.VolumeId
+	version        needle.Version
+	counter        int
+	needleMap      *needle_map.MemDb
+	vid            needle.VolumeId
+	encryptionKeys []*volume_server_pb.VolumeEncryptionKey
 }
 
 func (scanner *VolumeFileScanner4Export) VisitSuperBlock(superBlock super_block.SuperBlock) error {
@@ -124,6 +129,9 @@ func (scanner *VolumeFileScanner4Export) VisitNeedle(n *needle.Needle, offset in
 			return io.EOF
 		}
 		if tarOutputFile != nil {
+			if err := storage.DecryptNeedleData(n, scanner.encryptionKeys); err != nil {
+				return err
+			}
 			return writeFile(vid, n)
 		} else {
 			printNeedle(vid, n, scanner.version, false, offset, n.DiskSize(scanner.version))
@@ -204,9 +212,20 @@ func runExport(cmd *Command, args []string) bool {
 		glog.Fatalf("cannot load needle map from %s.idx: %s", fileName, err)
 	}
 
+	if *export.encryptionKeyFile != "" {
+		if err := storage.LoadEncryptionMasterKey(*export.encryptionKeyFile); err != nil {
+			glog.Fatalf("%v", err)
+		}
+	}
+	volumeInfo, _, err := pb.MaybeLoadVolumeInfo(path.Join(util.ResolvePath(*export.dir), fileName+".vif"))
+	if err != nil {
+		glog.Fatalf("cannot load volume info from %s.vif: %s", fileName, err)
+	}
+
 	volumeFileScanner := &VolumeFileScanner4Export{
-		needleMap: needleMap,
-		vid:       vid,
+		needleMap:      needleMap,
+		vid:            vid,
+		encryptionKeys: volumeInfo.EncryptionKeys,
 	}
 
 	if tarOutputFile == nil {
@@ -245,6 +264,9 @@ func writeFile(vid needle.VolumeId, n *needle.Needle) (err error) {
 
 	fileName := fileNameTemplateBuffer.String()
 
+	if err = storage.UncompressNeedleData(n); err != nil {
+		return err
+	}
 	if n.IsCompressed() {
 		if util.IsGzippedContent(n.Data) && path.Ext(fileName) != ".gz" {
 			fileName = fileName + ".gz"
@@ -97,7 +97,7 @@ func runFilerCat(cmd *Command, args []string) bool {
 		writer = f
 	}
 
-	pb.WithFilerClient(filerCat.filerAddress, filerCat.grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+	pb.WithFilerClient(filerCat.filerAddress, filerCat.grpcDialOption, filer_pb.WithServiceIdentity(func(client filer_pb.SeaweedFilerClient) error {
 
 		request := &filer_pb.LookupDirectoryEntryRequest{
 			Name:      name,
@@ -112,7 +112,7 @@ func runFilerCat(cmd *Command, args []string) bool {
 
 		return filer.StreamContent(&filerCat, writer, respLookupEntry.Entry.Chunks, 0, math.MaxInt64)
 
-	})
+	}))
 
 	return true
 }
@@ -103,7 +103,7 @@ func runFilerMetaTail(cmd *Command, args []string) bool {
 		}
 	}
 
-	tailErr := pb.WithFilerClient(*tailFiler, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+	tailErr := pb.WithFilerClient(*tailFiler, grpcDialOption, filer_pb.WithServiceIdentity(func(client filer_pb.SeaweedFilerClient) error {
 
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
@@ -133,7 +133,7 @@ func runFilerMetaTail(cmd *Command, args []string) bool {
 			}
 		}
 
-	})
+	}))
 	if tailErr != nil {
 		fmt.Printf("tail %s: %v\n", *tailFiler, tailErr)
 	}
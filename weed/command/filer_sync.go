@@ -240,7 +240,7 @@ func doSubscribeFilerMetaChanges(grpcDialOption grpc.DialOption, sourceFiler, so
 		return nil
 	}
 
-	return pb.WithFilerClient(sourceFiler, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+	return pb.WithFilerClient(sourceFiler, grpcDialOption, filer_pb.WithServiceIdentity(func(client filer_pb.SeaweedFilerClient) error {
 
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
@@ -282,7 +282,7 @@ func doSubscribeFilerMetaChanges(grpcDialOption grpc.DialOption, sourceFiler, so
 
 		}
 
-	})
+	}))
 
 }
 
@@ -292,7 +292,7 @@ const (
 
 func readSyncOffset(grpcDialOption grpc.DialOption, filer string, filerSignature int32) (lastOffsetTsNs int64, readErr error) {
 
-	readErr = pb.WithFilerClient(filer, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+	readErr = pb.WithFilerClient(filer, grpcDialOption, filer_pb.WithServiceIdentity(func(client filer_pb.SeaweedFilerClient) error {
 		syncKey := []byte(SyncKeyPrefix + "____")
 		util.Uint32toBytes(syncKey[len(SyncKeyPrefix):len(SyncKeyPrefix)+4], uint32(filerSignature))
 
@@ -311,14 +311,14 @@ func readSyncOffset(grpcDialOption grpc.DialOption, filer string, filerSignature
 		lastOffsetTsNs = int64(util.BytesToUint64(resp.Value))
 
 		return nil
-	})
+	}))
 
 	return
 
 }
 
 func writeSyncOffset(grpcDialOption grpc.DialOption, filer string, filerSignature int32, offsetTsNs int64) error {
-	return pb.WithFilerClient(filer, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+	return pb.WithFilerClient(filer, grpcDialOption, filer_pb.WithServiceIdentity(func(client filer_pb.SeaweedFilerClient) error {
 
 		syncKey := []byte(SyncKeyPrefix + "____")
 		util.Uint32toBytes(syncKey[len(SyncKeyPrefix):len(SyncKeyPrefix)+4], uint32(filerSignature))
@@ -340,6 +340,6 @@ func writeSyncOffset(grpcDialOption grpc.DialOption, filer string, filerSignatur
 
 		return nil
 
-	})
+	}))
 
 }
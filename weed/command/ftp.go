@@ -0,0 +1,183 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/ftpd"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+var (
+	ftpStandaloneOptions FtpOption
+)
+
+type FtpOption struct {
+	filer            *string
+	ip               *string
+	ipBind           *string
+	port             *int
+	passivePortStart *int
+	passivePortStop  *int
+	collection       *string
+	replication      *string
+	disk             *string
+	chunkSizeLimitMB *int
+	cacheDir         *string
+	cacheSizeMB      *int64
+	usersConfig      *string
+	tlsCertificate   *string
+	tlsPrivateKey    *string
+}
+
+func init() {
+	cmdFtp.Run = runFtp // break init cycle
+	ftpStandaloneOptions.filer = cmdFtp.Flag.String("filer", "localhost:8888", "filer server address")
+	ftpStandaloneOptions.ip = cmdFtp.Flag.String("ip", util.DetectedHostAddress(), "ftp server public ip address for passive mode")
+	ftpStandaloneOptions.ipBind = cmdFtp.Flag.String("ip.bind", "", "ip address to bind to")
+	ftpStandaloneOptions.port = cmdFtp.Flag.Int("port", 8021, "ftp server listen port")
+	ftpStandaloneOptions.passivePortStart = cmdFtp.Flag.Int("port.passive.start", 30000, "passive port range start")
+	ftpStandaloneOptions.passivePortStop = cmdFtp.Flag.Int("port.passive.stop", 30100, "passive port range end")
+	ftpStandaloneOptions.collection = cmdFtp.Flag.String("collection", "", "collection to create the files")
+	ftpStandaloneOptions.replication = cmdFtp.Flag.String("replication", "", "replication to create the files")
+	ftpStandaloneOptions.disk = cmdFtp.Flag.String("disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
+	ftpStandaloneOptions.chunkSizeLimitMB = cmdFtp.Flag.Int("chunkSizeLimitMB", 4, "write buffer size per file, also chunk large files")
+	ftpStandaloneOptions.cacheDir = cmdFtp.Flag.String("cacheDir", os.TempDir(), "local cache directory for file chunks")
+	ftpStandaloneOptions.cacheSizeMB = cmdFtp.Flag.Int64("cacheCapacityMB", 1000, "local cache capacity in MB")
+	ftpStandaloneOptions.usersConfig = cmdFtp.Flag.String("config", "", "path to the users json file")
+	ftpStandaloneOptions.tlsCertificate = cmdFtp.Flag.String("cert.file", "", "path to the TLS certificate file, to enable FTPS with AUTH TLS")
+	ftpStandaloneOptions.tlsPrivateKey = cmdFtp.Flag.String("key.file", "", "path to the TLS private key file")
+}
+
+var cmdFtp = &Command{
+	UsageLine: "ftp -port=8021 -filer=<ip:port> -config=users.json",
+	Short:     "start an ftp server that is backed by a filer",
+	Long: `start an ftp server that is backed by a filer.
+
+	The users are configured in a json file, for example:
+
+	  {
+	    "users": [
+	      {
+	        "name": "alice",
+	        "password": "$2a$10$...",
+	        "homeDir": "/home/alice",
+	        "readOnly": false,
+	        "uid": 1000,
+	        "gid": 1000
+	      }
+	    ]
+	  }
+
+	The password can be a bcrypt hash or the plain text. Each user is confined to the home directory,
+	which is created on the first login if missing. Set -cert.file and -key.file to allow FTPS.
+
+`,
+}
+
+func runFtp(cmd *Command, args []string) bool {
+
+	util.LoadConfiguration("security", false)
+
+	glog.V(0).Infof("Starting Seaweed FTP Server %s at port %d", util.Version(), *ftpStandaloneOptions.port)
+
+	return ftpStandaloneOptions.startFtp()
+
+}
+
+func (fo *FtpOption) startFtp() bool {
+
+	option, ok := fo.serverOption()
+	if !ok {
+		return false
+	}
+
+	listenAddress := fmt.Sprintf("%s:%d", *fo.ipBind, *fo.port)
+	ftpListener, err := util.NewListener(listenAddress, 0)
+	if err != nil {
+		glog.Fatalf("FTP Server listener on %s error: %v", listenAddress, err)
+	}
+
+	ftpServer, err := ftpd.NewFtpServer(ftpListener, option)
+	if err != nil {
+		glog.Fatalf("FTP Server startup error: %v", err)
+	}
+
+	glog.V(0).Infof("Start Seaweed FTP Server %s at port %d", util.Version(), *fo.port)
+	if err = ftpServer.Serve(); err != nil {
+		glog.Fatalf("FTP Server Fail to serve: %v", err)
+	}
+
+	return true
+
+}
+
+// serverOption loads the users and connects to the filer, shared by the ftp and sftp servers
+func (fo *FtpOption) serverOption() (*ftpd.FtpServerOption, bool) {
+
+	if *fo.usersConfig == "" {
+		glog.Fatalf("missing -config for the users")
+		return nil, false
+	}
+	users, err := ftpd.LoadFtpUsers(util.ResolvePath(*fo.usersConfig))
+	if err != nil {
+		glog.Fatalf("load users: %v", err)
+		return nil, false
+	}
+
+	// parse filer grpc address
+	filerGrpcAddress, err := pb.ParseFilerGrpcAddress(*fo.filer)
+	if err != nil {
+		glog.Fatal(err)
+		return nil, false
+	}
+
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	var cipher bool
+	// connect to filer
+	for {
+		err = pb.WithGrpcFilerClient(filerGrpcAddress, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+			resp, err := client.GetFilerConfiguration(context.Background(), &filer_pb.GetFilerConfigurationRequest{})
+			if err != nil {
+				return fmt.Errorf("get filer %s configuration: %v", filerGrpcAddress, err)
+			}
+			cipher = resp.Cipher
+			return nil
+		})
+		if err != nil {
+			glog.V(0).Infof("wait to connect to filer %s grpc address %s", *fo.filer, filerGrpcAddress)
+			time.Sleep(time.Second)
+		} else {
+			glog.V(0).Infof("connected to filer %s grpc address %s", *fo.filer, filerGrpcAddress)
+			break
+		}
+	}
+
+	return &ftpd.FtpServerOption{
+		Filer:            *fo.filer,
+		IP:               *fo.ip,
+		IpBind:           *fo.ipBind,
+		Port:             *fo.port,
+		FilerGrpcAddress: filerGrpcAddress,
+		GrpcDialOption:   grpcDialOption,
+		PassivePortStart: *fo.passivePortStart,
+		PassivePortStop:  *fo.passivePortStop,
+		Collection:       *fo.collection,
+		Replication:      *fo.replication,
+		DiskType:         *fo.disk,
+		ChunkSizeLimit:   int64(*fo.chunkSizeLimitMB) * 1024 * 1024,
+		Cipher:           cipher,
+		CacheDir:         util.ResolvePath(*fo.cacheDir),
+		CacheSizeMB:      *fo.cacheSizeMB,
+		Users:            users,
+		TlsCertFile:      *fo.tlsCertificate,
+		TlsKeyFile:       *fo.tlsPrivateKey,
+	}, true
+}
@@ -18,6 +18,9 @@ type MountOptions struct {
 	concurrentWriters  *int
 	cacheDir           *string
 	cacheSizeMB        *int64
+	writeCacheSizeMB   *int64
+	readAheadSizeMB    *int64
+	readAheadMemoryMB  *int64
 	dataCenter         *string
 	allowOthers        *bool
 	umaskString        *string
@@ -25,6 +28,9 @@ type MountOptions struct {
 	volumeServerAccess *string
 	uidMap             *string
 	gidMap             *string
+	consistency        *string
+	offline            *bool
+	metricsHttpPort    *int
 }
 
 var (
@@ -48,6 +54,9 @@ func init() {
 	mountOptions.concurrentWriters = cmdMount.Flag.Int("concurrentWriters", 128, "limit concurrent goroutine writers if not 0")
 	mountOptions.cacheDir = cmdMount.Flag.String("cacheDir", os.TempDir(), "local cache directory for file chunks and meta data")
 	mountOptions.cacheSizeMB = cmdMount.Flag.Int64("cacheCapacityMB", 1000, "local file chunk cache capacity in MB (0 will disable cache)")
+	mountOptions.writeCacheSizeMB = cmdMount.Flag.Int64("writeCacheCapacityMB", 1024, "local swap file capacity in MB to buffer written data before uploading, and to recover unsaved data after restart (0 will buffer in memory)")
+	mountOptions.readAheadSizeMB = cmdMount.Flag.Int64("readAheadMB", 64, "max size in MB to prefetch into the chunk cache for each sequentially read file (0 will disable read-ahead)")
+	mountOptions.readAheadMemoryMB = cmdMount.Flag.Int64("readAheadMemoryMB", 256, "memory budget in MB for the chunks being prefetched by all files")
 	mountOptions.dataCenter = cmdMount.Flag.String("dataCenter", "", "prefer to write to the data center")
 	mountOptions.allowOthers = cmdMount.Flag.Bool("allowOthers", true, "allows other users to access the file system")
 	mountOptions.umaskString = cmdMount.Flag.String("umask", "022", "octal umask, e.g., 022, 0111")
@@ -55,6 +64,9 @@ func init() {
 	mountOptions.volumeServerAccess = cmdMount.Flag.String("volumeServerAccess", "direct", "access volume servers by [direct|publicUrl|filerProxy]")
 	mountOptions.uidMap = cmdMount.Flag.String("map.uid", "", "map local uid to uid on filer, comma-separated <local_uid>:<filer_uid>")
 	mountOptions.gidMap = cmdMount.Flag.String("map.gid", "", "map local gid to gid on filer, comma-separated <local_gid>:<filer_gid>")
+	mountOptions.consistency = cmdMount.Flag.String("consistency", "async", "[async|closeToOpen|lease] async: meta data is updated asynchronously; closeToOpen: revalidate on open via the filer; lease: cache with filer-granted leases")
+	mountOptions.offline = cmdMount.Flag.Bool("offline", false, "serve the cached content and journal the changes when the filer is unreachable, replayed with conflict copies when it is back")
+	mountOptions.metricsHttpPort = cmdMount.Flag.Int("metricsPort", 0, "Prometheus metrics listen port")
 
 	mountCpuProfile = cmdMount.Flag.String("cpuprofile", "", "cpu profile output file")
 	mountMemProfile = cmdMount.Flag.String("memprofile", "", "memory profile output file")
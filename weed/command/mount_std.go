@@ -24,6 +24,7 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/pb"
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
 	"github.com/chrislusf/seaweedfs/weed/security"
+	stats_collect "github.com/chrislusf/seaweedfs/weed/stats"
 	"github.com/chrislusf/seaweedfs/weed/util"
 	"github.com/chrislusf/seaweedfs/weed/util/grace"
 )
@@ -46,6 +47,13 @@ func runMount(cmd *Command, args []string) bool {
 		return false
 	}
 
+	if err := filesys.ValidateConsistency(*mountOptions.consistency); err != nil {
+		fmt.Printf("%v\n", err)
+		return false
+	}
+
+	go stats_collect.StartMetricsServer(*mountOptions.metricsHttpPort)
+
 	return RunMount(&mountOptions, os.FileMode(umask))
 }
 
@@ -153,6 +161,8 @@ func RunMount(option *MountOptions, umask os.FileMode) bool {
 		fuse.WritebackCache(),
 		fuse.MaxBackground(128),
 		fuse.CongestionThreshold(128),
+		fuse.LockingFlock(),
+		fuse.LockingPOSIX(),
 	}
 
 	options = append(options, osSpecificMountOptions()...)
@@ -185,8 +195,13 @@ func RunMount(option *MountOptions, umask os.FileMode) bool {
 		ConcurrentWriters:  *option.concurrentWriters,
 		CacheDir:           *option.cacheDir,
 		CacheSizeMB:        *option.cacheSizeMB,
+		WriteCacheSizeMB:   *option.writeCacheSizeMB,
+		ReadAheadSizeMB:    *option.readAheadSizeMB,
+		ReadAheadMemoryMB:  *option.readAheadMemoryMB,
 		DataCenter:         *option.dataCenter,
 		EntryCacheTtl:      3 * time.Second,
+		Consistency:        *option.consistency,
+		OfflineMode:        *option.offline,
 		MountUid:           uid,
 		MountGid:           gid,
 		MountMode:          mountMode,
@@ -0,0 +1,157 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filesys/meta_cache"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/nfs"
+	"github.com/chrislusf/seaweedfs/weed/pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+var (
+	nfsStandaloneOptions NfsOption
+)
+
+type NfsOption struct {
+	filer            *string
+	filerRootPath    *string
+	ipBind           *string
+	port             *int
+	collection       *string
+	replication      *string
+	disk             *string
+	chunkSizeLimitMB *int
+	cacheDir         *string
+	cacheSizeMB      *int64
+	uidMap           *string
+	gidMap           *string
+	rootSquash       *bool
+	allowedClients   *string
+}
+
+func init() {
+	cmdNfs.Run = runNfs // break init cycle
+	nfsStandaloneOptions.filer = cmdNfs.Flag.String("filer", "localhost:8888", "filer server address")
+	nfsStandaloneOptions.filerRootPath = cmdNfs.Flag.String("filer.path", "/", "the filer directory to export")
+	nfsStandaloneOptions.ipBind = cmdNfs.Flag.String("ip.bind", "", "ip address to bind to")
+	nfsStandaloneOptions.port = cmdNfs.Flag.Int("port", 2049, "nfs and mount protocol tcp listen port")
+	nfsStandaloneOptions.collection = cmdNfs.Flag.String("collection", "", "collection to create the files")
+	nfsStandaloneOptions.replication = cmdNfs.Flag.String("replication", "", "replication to create the files")
+	nfsStandaloneOptions.disk = cmdNfs.Flag.String("disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
+	nfsStandaloneOptions.chunkSizeLimitMB = cmdNfs.Flag.Int("chunkSizeLimitMB", 4, "write buffer size per file, also chunk large files")
+	nfsStandaloneOptions.cacheDir = cmdNfs.Flag.String("cacheDir", os.TempDir(), "local cache directory for file chunks")
+	nfsStandaloneOptions.cacheSizeMB = cmdNfs.Flag.Int64("cacheCapacityMB", 1000, "local cache capacity in MB")
+	nfsStandaloneOptions.uidMap = cmdNfs.Flag.String("map.uid", "", "map client uid to uid on filer, comma-separated <client_uid>:<filer_uid>")
+	nfsStandaloneOptions.gidMap = cmdNfs.Flag.String("map.gid", "", "map client gid to gid on filer, comma-separated <client_gid>:<filer_gid>")
+	nfsStandaloneOptions.rootSquash = cmdNfs.Flag.Bool("rootSquash", true, "map the client root user and group to nobody")
+	nfsStandaloneOptions.allowedClients = cmdNfs.Flag.String("allowedClients", "", "comma separated ip addresses or cidr ranges allowed to connect. No limit if empty.")
+}
+
+var cmdNfs = &Command{
+	UsageLine: "nfs -port=2049 -filer=<ip:port> -filer.path=/",
+	Short:     "start an nfs v3 server that is backed by a filer",
+	Long: `start an nfs v3 server that exports a filer directory.
+
+	The nfs and mount protocols are served on the same tcp port, without portmapper or locking.
+	Mount it with, for example:
+
+	  mount -t nfs -o vers=3,tcp,port=2049,mountport=2049,mountproto=tcp,nolock <nfs_server>:/ /mnt
+
+	The uid and gid sent by the clients are checked by the filer, if [jwt.filer_identity] is configured in security.toml.
+	Clients without AUTH_UNIX credentials act as nobody, and so does the client root unless -rootSquash=false.
+	Since the clients choose the uid and gid they send, limit the clients with -allowedClients.
+	File handles are derived from the file paths, so they survive restarts, but not renames.
+
+`,
+}
+
+func runNfs(cmd *Command, args []string) bool {
+
+	util.LoadConfiguration("security", false)
+
+	glog.V(0).Infof("Starting Seaweed NFS Server %s at port %d", util.Version(), *nfsStandaloneOptions.port)
+
+	return nfsStandaloneOptions.startNfs()
+
+}
+
+func (no *NfsOption) startNfs() bool {
+
+	// mapping uid, gid
+	uidGidMapper, err := meta_cache.NewUidGidMapper(*no.uidMap, *no.gidMap)
+	if err != nil {
+		glog.Fatalf("failed to parse %s %s: %v", *no.uidMap, *no.gidMap, err)
+		return false
+	}
+
+	// parse filer grpc address
+	filerGrpcAddress, err := pb.ParseFilerGrpcAddress(*no.filer)
+	if err != nil {
+		glog.Fatal(err)
+		return false
+	}
+
+	grpcDialOption := security.LoadClientTLS(util.GetViper(), "grpc.client")
+
+	var cipher bool
+	// connect to filer
+	for {
+		err = pb.WithGrpcFilerClient(filerGrpcAddress, grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+			resp, err := client.GetFilerConfiguration(context.Background(), &filer_pb.GetFilerConfigurationRequest{})
+			if err != nil {
+				return fmt.Errorf("get filer %s configuration: %v", filerGrpcAddress, err)
+			}
+			cipher = resp.Cipher
+			return nil
+		})
+		if err != nil {
+			glog.V(0).Infof("wait to connect to filer %s grpc address %s", *no.filer, filerGrpcAddress)
+			time.Sleep(time.Second)
+		} else {
+			glog.V(0).Infof("connected to filer %s grpc address %s", *no.filer, filerGrpcAddress)
+			break
+		}
+	}
+
+	nfsServer, err := nfs.NewNfsServer(&nfs.NfsServerOption{
+		Filer:            *no.filer,
+		FilerGrpcAddress: filerGrpcAddress,
+		FilerRootPath:    *no.filerRootPath,
+		GrpcDialOption:   grpcDialOption,
+		Collection:       *no.collection,
+		Replication:      *no.replication,
+		DiskType:         *no.disk,
+		ChunkSizeLimit:   int64(*no.chunkSizeLimitMB) * 1024 * 1024,
+		Cipher:           cipher,
+		CacheDir:         util.ResolvePath(*no.cacheDir),
+		CacheSizeMB:      *no.cacheSizeMB,
+		UidGidMapper:     uidGidMapper,
+		RootSquash:       *no.rootSquash,
+		AllowedClients:   strings.Split(*no.allowedClients, ","),
+	})
+	if err != nil {
+		glog.Fatalf("NFS Server startup error: %v", err)
+	}
+
+	listenAddress := fmt.Sprintf("%s:%d", *no.ipBind, *no.port)
+	nfsListener, err := util.NewListener(listenAddress, 0)
+	if err != nil {
+		glog.Fatalf("NFS Server listener on %s error: %v", listenAddress, err)
+	}
+
+	glog.V(0).Infof("Start Seaweed NFS Server %s at tcp port %d, exporting %s", util.Version(), *no.port, *no.filerRootPath)
+	if err = nfsServer.Serve(nfsListener); err != nil {
+		glog.Fatalf("NFS Server Fail to serve: %v", err)
+	}
+
+	return true
+
+}
@@ -95,6 +95,19 @@ dir = "./filerldb2"					# directory to store level db files
 enabled = false
 dir = "./filerldb3"					# directory to store level db files
 
+[pebble]
+# local on disk, pure go LSM tree, with native TTL support
+# large folders are removed as one key range, and directory listings skip tables by bloom filters
+enabled = false
+dir = "./filerpebble"					# directory to store pebble files
+sync_writes = false
+max_concurrent_compactions = 2
+l0_compaction_threshold = 4		# compact level 0 when it has this many files
+mem_table_size_mb = 64
+block_cache_size_mb = 64
+bloom_filter_bits_per_key = 10		# 0 to disable the bloom filters
+ttl_sweep_interval_seconds = 60	# how often expired entries are deleted, 0 to disable
+
 [rocksdb]
 # local on disk, similar to leveldb
 # since it is using a C wrapper, you need to install rocksdb and build it by yourself
@@ -427,6 +440,14 @@ expires_after_seconds = 10           # seconds
 key = ""
 expires_after_seconds = 10           # seconds
 
+# the filer enforces POSIX permissions if this key is set.
+# clients, e.g., "weed mount", nfs, ftp and webdav, sign the uid and gids of the caller with this key.
+# requests without a valid signed identity are rejected.
+# administrative tools with this key, e.g., "weed shell", s3, replication and "weed filer.copy", act as root.
+[jwt.filer_identity]
+key = ""
+expires_after_seconds = 60           # seconds
+
 # all grpc tls authentications are mutual
 # the values for the following ca, cert, and key are paths to the PERM files.
 # the host name is not checked, so the PERM files can be shared.
@@ -488,6 +509,37 @@ scripts = """
 """
 sleep_minutes = 17          # sleep minutes between each script execution
 
+[master.tiering]
+# periodically move volumes between disk types and remote tiers, same as "volume.tier.policy -force" in 'weed shell'
+enabled = false
+dry_run = true              # only log the planned moves
+sleep_minutes = 60          # sleep minutes between each run
+concurrency = 2             # max number of volumes moving at the same time
+# the rules are checked in the order of their names, and only the first matching rule applies to a volume
+# collection:  collection name pattern, e.g. "logs_*". Default to all collections.
+# quiet_for:   no writes for this period
+# min_full_percent: the volume reaches the percentage of max volume size
+# min_reads_per_hour, max_reads_per_hour: reads in the recent hour, of all replicas
+#   [master.tiering.rules.a_hot]
+#   from_disk_type = "hdd"
+#   to_disk_type = "ssd"
+#   min_reads_per_hour = 10000
+#   [master.tiering.rules.b_cold]
+#   from_disk_type = "ssd"
+#   to_disk_type = "hdd"
+#   quiet_for = "24h"
+#   min_full_percent = 95
+#   max_reads_per_hour = 100
+#   [master.tiering.rules.c_archive]
+#   collection = "logs_*"
+#   from_disk_type = "hdd"
+#   dest = "s3.default"         # defined in [storage.backend]
+#   quiet_for = "720h"
+#   max_reads_per_hour = 1
+#   [master.tiering.rules.d_restore]
+#   from_remote = true
+#   min_reads_per_hour = 1000
+
 [master.filer]
 default = "localhost:8888"    # used by maintenance scripts if the scripts needs to use fs related commands
 
@@ -498,6 +550,15 @@ type = "raft"     # Choose [raft|etcd] type for storing the file id sequence
 # example : http://127.0.0.1:2379,http://127.0.0.1:2389
 sequencer_etcd_urls = "http://127.0.0.1:2379"
 
+# erasure coding schemes used by ec.encode, as <data shards>+<parity shards>
+# any parity shards count of shards can be lost. At most 32 shards in total.
+[master.erasure_coding]
+default = "10+4"
+	[master.erasure_coding.collections]
+	# a collection can use a different scheme, e.g.
+	# logs = "6+3"
+	# archive = "12+4"
+
 
 # configurations for tiered cloud storage
 # old volumes are transparently moved to cloud for cost efficiency
@@ -509,6 +570,18 @@ sequencer_etcd_urls = "http://127.0.0.1:2379"
 	region = "us-east-2"
 	bucket = "your_bucket_name"    # an existing bucket
 	endpoint = ""
+	[storage.backend.local.default]
+	enabled = false
+	directory = "/mnt/nfs/seaweedfs"   # an existing directory, usually on a network file system or slower disks
+	[storage.backend.azure.default]
+	enabled = false
+	account_name = ""
+	account_key  = ""
+	container = "your_container_name"  # an existing container
+	[storage.backend.gcs.default]
+	enabled = false
+	google_application_credentials = ""  # if empty, loads from the GOOGLE_APPLICATION_CREDENTIALS env variable or the default service account
+	bucket = "your_bucket_name"          # an existing bucket
 
 # create this number of logical volumes if no more writable volumes
 # count_x means how many copies of data.
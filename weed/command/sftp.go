@@ -0,0 +1,90 @@
+package command
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/chrislusf/seaweedfs/weed/ftpd"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+var (
+	sftpStandaloneOptions SftpOption
+)
+
+type SftpOption struct {
+	FtpOption
+	hostKeyFile *string
+}
+
+func init() {
+	cmdSftp.Run = runSftp // break init cycle
+	sftpStandaloneOptions.filer = cmdSftp.Flag.String("filer", "localhost:8888", "filer server address")
+	sftpStandaloneOptions.ipBind = cmdSftp.Flag.String("ip.bind", "", "ip address to bind to")
+	sftpStandaloneOptions.port = cmdSftp.Flag.Int("port", 2022, "sftp server listen port")
+	sftpStandaloneOptions.collection = cmdSftp.Flag.String("collection", "", "collection to create the files")
+	sftpStandaloneOptions.replication = cmdSftp.Flag.String("replication", "", "replication to create the files")
+	sftpStandaloneOptions.disk = cmdSftp.Flag.String("disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
+	sftpStandaloneOptions.chunkSizeLimitMB = cmdSftp.Flag.Int("chunkSizeLimitMB", 4, "write buffer size per file, also chunk large files")
+	sftpStandaloneOptions.cacheDir = cmdSftp.Flag.String("cacheDir", os.TempDir(), "local cache directory for file chunks")
+	sftpStandaloneOptions.cacheSizeMB = cmdSftp.Flag.Int64("cacheCapacityMB", 1000, "local cache capacity in MB")
+	sftpStandaloneOptions.usersConfig = cmdSftp.Flag.String("config", "", "path to the users json file")
+	sftpStandaloneOptions.hostKeyFile = cmdSftp.Flag.String("hostKey", "sftp_host_key", "ssh host private key file, generated if missing")
+
+	// not used by sftp
+	sftpStandaloneOptions.ip = new(string)
+	sftpStandaloneOptions.passivePortStart = new(int)
+	sftpStandaloneOptions.passivePortStop = new(int)
+	sftpStandaloneOptions.tlsCertificate = new(string)
+	sftpStandaloneOptions.tlsPrivateKey = new(string)
+}
+
+var cmdSftp = &Command{
+	UsageLine: "sftp -port=2022 -filer=<ip:port> -config=users.json",
+	Short:     "start an sftp server that is backed by a filer",
+	Long: `start an sftp server over ssh that is backed by a filer.
+
+	The users are configured in the same json file as "weed ftp", and can also log in with
+	ssh public keys listed in "publicKeys", in the authorized_keys format.
+	Only the sftp subsystem is served, no shell or command execution.
+
+`,
+}
+
+func runSftp(cmd *Command, args []string) bool {
+
+	util.LoadConfiguration("security", false)
+
+	glog.V(0).Infof("Starting Seaweed SFTP Server %s at port %d", util.Version(), *sftpStandaloneOptions.port)
+
+	return sftpStandaloneOptions.startSftp()
+
+}
+
+func (so *SftpOption) startSftp() bool {
+
+	option, ok := so.serverOption()
+	if !ok {
+		return false
+	}
+
+	listenAddress := fmt.Sprintf("%s:%d", *so.ipBind, *so.port)
+	sftpListener, err := util.NewListener(listenAddress, 0)
+	if err != nil {
+		glog.Fatalf("SFTP Server listener on %s error: %v", listenAddress, err)
+	}
+
+	sftpServer, err := ftpd.NewSftpServer(sftpListener, option, util.ResolvePath(*so.hostKeyFile))
+	if err != nil {
+		glog.Fatalf("SFTP Server startup error: %v", err)
+	}
+
+	glog.V(0).Infof("Start Seaweed SFTP Server %s at port %d", util.Version(), *so.port)
+	if err = sftpServer.Serve(); err != nil {
+		glog.Fatalf("SFTP Server Fail to serve: %v", err)
+	}
+
+	return true
+
+}
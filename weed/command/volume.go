@@ -57,6 +57,12 @@ type VolumeServerOptions struct {
 	memProfile            *string
 	compactionMBPerSecond *int
 	fileSizeLimitMB       *int
+	scrubMBPerSecond      *int
+	scrubIntervalHours    *int
+	readCacheDir          *string
+	readCacheSizeMB       *int
+	encryptionKeyFile     *string
+	keepNeedleVersions    *int
 	minFreeSpacePercents  []float32
 	pprof                 *bool
 	preStopSeconds        *int
@@ -77,7 +83,7 @@ func init() {
 	v.idleConnectionTimeout = cmdVolume.Flag.Int("idleTimeout", 30, "connection idle seconds")
 	v.dataCenter = cmdVolume.Flag.String("dataCenter", "", "current volume server's data center name")
 	v.rack = cmdVolume.Flag.String("rack", "", "current volume server's rack name")
-	v.indexType = cmdVolume.Flag.String("index", "memory", "Choose [memory|leveldb|leveldbMedium|leveldbLarge] mode for memory~performance balance.")
+	v.indexType = cmdVolume.Flag.String("index", "memory", "Choose [memory|leveldb|leveldbMedium|leveldbLarge|sortedIndex] mode for memory~performance balance.")
 	v.diskType = cmdVolume.Flag.String("disk", "", "[hdd|ssd|<tag>] hard drive or solid state drive or any tag")
 	v.fixJpgOrientation = cmdVolume.Flag.Bool("images.fix.orientation", false, "Adjust jpg orientation when uploading.")
 	v.readRedirect = cmdVolume.Flag.Bool("read.redirect", true, "Redirect moved or non-local volumes.")
@@ -85,6 +91,12 @@ func init() {
 	v.memProfile = cmdVolume.Flag.String("memprofile", "", "memory profile output file")
 	v.compactionMBPerSecond = cmdVolume.Flag.Int("compactionMBps", 0, "limit background compaction or copying speed in mega bytes per second")
 	v.fileSizeLimitMB = cmdVolume.Flag.Int("fileSizeLimitMB", 256, "limit file size to avoid out of memory")
+	v.scrubMBPerSecond = cmdVolume.Flag.Int("scrubMBps", 8, "limit background scrubbing speed in mega bytes per second, 0 to disable scrubbing")
+	v.scrubIntervalHours = cmdVolume.Flag.Int("scrubIntervalHours", 24*7, "start scrubbing all volumes every this number of hours")
+	v.readCacheDir = cmdVolume.Flag.String("readCache.dir", "", "directory on a fast disk to cache the needles read from remote tier and hdd volumes, empty to disable")
+	v.readCacheSizeMB = cmdVolume.Flag.Int("readCache.sizeMB", 10*1024, "limit the read cache size in MB")
+	v.encryptionKeyFile = cmdVolume.Flag.String("encryption.keyFile", "", "file with the 32 byte master key, raw or in hex, which wraps the keys of encrypted volumes")
+	v.keepNeedleVersions = cmdVolume.Flag.Int("keepNeedleVersions", 0, "keep the previous versions of overwritten or deleted files, readable with ?version=1,2,...")
 	v.pprof = cmdVolume.Flag.Bool("pprof", false, "enable pprof http handlers. precludes --memprofile and --cpuprofile")
 	v.metricsHttpPort = cmdVolume.Flag.Int("metricsPort", 0, "Prometheus metrics listen port")
 	v.idxFolder = cmdVolume.Flag.String("dir.idx", "", "directory to store .idx files")
@@ -224,6 +236,16 @@ func (v VolumeServerOptions) startVolumeServer(volumeFolders, maxVolumeCounts, v
 		volumeNeedleMapKind = storage.NeedleMapLevelDbMedium
 	case "leveldbLarge":
 		volumeNeedleMapKind = storage.NeedleMapLevelDbLarge
+	case "sortedIndex":
+		volumeNeedleMapKind = storage.NeedleMapSortedIndex
+	}
+
+	storage.KeepNeedleVersions = *v.keepNeedleVersions
+
+	if *v.encryptionKeyFile != "" {
+		if err := storage.LoadEncryptionMasterKey(*v.encryptionKeyFile); err != nil {
+			glog.Fatalf("%v", err)
+		}
 	}
 
 	masters := *v.masters
@@ -238,6 +260,10 @@ func (v VolumeServerOptions) startVolumeServer(volumeFolders, maxVolumeCounts, v
 		*v.fixJpgOrientation, *v.readRedirect,
 		*v.compactionMBPerSecond,
 		*v.fileSizeLimitMB,
+		*v.scrubMBPerSecond,
+		*v.scrubIntervalHours,
+		*v.readCacheDir,
+		*v.readCacheSizeMB,
 	)
 	// starting grpc server
 	grpcS := v.startGrpcService(volumeServer)
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/chrislusf/seaweedfs/weed/ftpd"
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb"
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
@@ -31,6 +32,7 @@ type WebDavOption struct {
 	tlsCertificate *string
 	cacheDir       *string
 	cacheSizeMB    *int64
+	usersConfig    *string
 }
 
 func init() {
@@ -44,6 +46,7 @@ func init() {
 	webDavStandaloneOptions.tlsCertificate = cmdWebDav.Flag.String("cert.file", "", "path to the TLS certificate file")
 	webDavStandaloneOptions.cacheDir = cmdWebDav.Flag.String("cacheDir", os.TempDir(), "local cache directory for file chunks")
 	webDavStandaloneOptions.cacheSizeMB = cmdWebDav.Flag.Int64("cacheCapacityMB", 1000, "local cache capacity in MB")
+	webDavStandaloneOptions.usersConfig = cmdWebDav.Flag.String("config", "", "path to the users json file, the same format as \"weed ftp\", to require basic auth")
 }
 
 var cmdWebDav = &Command{
@@ -51,6 +54,12 @@ var cmdWebDav = &Command{
 	Short:     "start a webdav server that is backed by a filer",
 	Long: `start a webdav server that is backed by a filer.
 
+	Without -config, the whole filer is served as the current os user.
+	With -config, the users log in with basic auth, and each user sees only the home directory.
+	Use https when the passwords are sent over the network.
+
+	The locks are stored in the filer under /etc/webdav/locks, so multiple webdav servers can share them.
+
 `,
 }
 
@@ -77,6 +86,15 @@ func (wo *WebDavOption) startWebDav() bool {
 		}
 	}
 
+	var users *ftpd.FtpUsers
+	if *wo.usersConfig != "" {
+		var err error
+		if users, err = ftpd.LoadFtpUsers(util.ResolvePath(*wo.usersConfig)); err != nil {
+			glog.Fatalf("load users: %v", err)
+			return false
+		}
+	}
+
 	// parse filer grpc address
 	filerGrpcAddress, err := pb.ParseFilerGrpcAddress(*wo.filer)
 	if err != nil {
@@ -118,6 +136,7 @@ func (wo *WebDavOption) startWebDav() bool {
 		Cipher:           cipher,
 		CacheDir:         util.ResolvePath(*wo.cacheDir),
 		CacheSizeMB:      *wo.cacheSizeMB,
+		Users:            users,
 	})
 	if webdavServer_err != nil {
 		glog.Fatalf("WebDav Server startup error: %v", webdavServer_err)
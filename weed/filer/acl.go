@@ -0,0 +1,311 @@
+package filer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// POSIX ACLs are kept in the entry's extended attributes,
+// in the same binary layout as the Linux "system.posix_acl_*" xattrs,
+// so that they pass through "weed mount" unchanged.
+const (
+	XattrPosixAclAccess  = "system.posix_acl_access"
+	XattrPosixAclDefault = "system.posix_acl_default"
+
+	aclXattrVersion    = 2
+	aclHeaderSize      = 4
+	aclEntrySize       = 8
+	AclTagUserObj      = 0x01
+	AclTagUser         = 0x02
+	AclTagGroupObj     = 0x04
+	AclTagGroup        = 0x08
+	AclTagMask         = 0x10
+	AclTagOther        = 0x20
+	aclPermissionsMask = 07
+)
+
+type AclEntry struct {
+	Tag  uint16
+	Perm uint16
+	Id   uint32
+}
+
+type Acl []AclEntry
+
+func ParseAcl(data []byte) (Acl, error) {
+	if len(data) < aclHeaderSize || (len(data)-aclHeaderSize)%aclEntrySize != 0 {
+		return nil, fmt.Errorf("invalid acl size %d", len(data))
+	}
+	if version := binary.LittleEndian.Uint32(data); version != aclXattrVersion {
+		return nil, fmt.Errorf("unsupported acl version %d", version)
+	}
+	var acl Acl
+	for p := aclHeaderSize; p < len(data); p += aclEntrySize {
+		acl = append(acl, AclEntry{
+			Tag:  binary.LittleEndian.Uint16(data[p:]),
+			Perm: binary.LittleEndian.Uint16(data[p+2:]),
+			Id:   binary.LittleEndian.Uint32(data[p+4:]),
+		})
+	}
+	if err := acl.validate(); err != nil {
+		return nil, err
+	}
+	return acl, nil
+}
+
+func (acl Acl) Bytes() []byte {
+	data := make([]byte, aclHeaderSize+aclEntrySize*len(acl))
+	binary.LittleEndian.PutUint32(data, aclXattrVersion)
+	p := aclHeaderSize
+	for _, e := range acl {
+		binary.LittleEndian.PutUint16(data[p:], e.Tag)
+		binary.LittleEndian.PutUint16(data[p+2:], e.Perm)
+		binary.LittleEndian.PutUint32(data[p+4:], e.Id)
+		p += aclEntrySize
+	}
+	return data
+}
+
+// validate follows the rules of acl_valid(3): exactly one owner, owning group and other entry,
+// unique named users and groups, and a mask entry if there are any named entries.
+func (acl Acl) validate() error {
+	counts := make(map[uint16]int)
+	users := make(map[uint32]bool)
+	groups := make(map[uint32]bool)
+	for _, e := range acl {
+		if e.Perm&^aclPermissionsMask != 0 {
+			return fmt.Errorf("invalid acl permission %o", e.Perm)
+		}
+		switch e.Tag {
+		case AclTagUserObj, AclTagGroupObj, AclTagMask, AclTagOther:
+		case AclTagUser:
+			if users[e.Id] {
+				return fmt.Errorf("duplicated acl user %d", e.Id)
+			}
+			users[e.Id] = true
+		case AclTagGroup:
+			if groups[e.Id] {
+				return fmt.Errorf("duplicated acl group %d", e.Id)
+			}
+			groups[e.Id] = true
+		default:
+			return fmt.Errorf("invalid acl tag %x", e.Tag)
+		}
+		counts[e.Tag]++
+	}
+	if counts[AclTagUserObj] != 1 || counts[AclTagGroupObj] != 1 || counts[AclTagOther] != 1 || counts[AclTagMask] > 1 {
+		return fmt.Errorf("acl requires exactly one owner, group, and other entry")
+	}
+	if (len(users) > 0 || len(groups) > 0) && counts[AclTagMask] == 0 {
+		return fmt.Errorf("acl with named users or groups requires a mask entry")
+	}
+	return nil
+}
+
+// IsMinimal means the acl is fully represented by the permission bits
+func (acl Acl) IsMinimal() bool {
+	return len(acl) == 3
+}
+
+func (acl Acl) find(tag uint16) int {
+	for i, e := range acl {
+		if e.Tag == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+// Mode returns the permission bits represented by the acl, where the group bits are the mask if present
+func (acl Acl) Mode() os.FileMode {
+	var mode os.FileMode
+	for _, e := range acl {
+		switch e.Tag {
+		case AclTagUserObj:
+			mode |= os.FileMode(e.Perm) << 6
+		case AclTagOther:
+			mode |= os.FileMode(e.Perm)
+		}
+	}
+	groupIndex := acl.find(AclTagMask)
+	if groupIndex < 0 {
+		groupIndex = acl.find(AclTagGroupObj)
+	}
+	if groupIndex >= 0 {
+		mode |= os.FileMode(acl[groupIndex].Perm) << 3
+	}
+	return mode
+}
+
+// WithMode returns a copy of the acl with the owner, mask (or owning group) and other entries set by chmod
+func (acl Acl) WithMode(mode os.FileMode) Acl {
+	updated := make(Acl, len(acl))
+	copy(updated, acl)
+	hasMask := acl.find(AclTagMask) >= 0
+	for i, e := range updated {
+		switch {
+		case e.Tag == AclTagUserObj:
+			updated[i].Perm = uint16(mode>>6) & aclPermissionsMask
+		case e.Tag == AclTagMask, e.Tag == AclTagGroupObj && !hasMask:
+			updated[i].Perm = uint16(mode>>3) & aclPermissionsMask
+		case e.Tag == AclTagOther:
+			updated[i].Perm = uint16(mode) & aclPermissionsMask
+		}
+	}
+	return updated
+}
+
+// check evaluates the acl with the access check algorithm of POSIX.1e
+func (acl Acl) check(identity *Identity, uid, gid uint32, want uint32) bool {
+	mask := uint32(aclPermissionsMask)
+	if i := acl.find(AclTagMask); i >= 0 {
+		mask = uint32(acl[i].Perm)
+	}
+	if identity.Uid == uid {
+		if i := acl.find(AclTagUserObj); i >= 0 {
+			return uint32(acl[i].Perm)&want == want
+		}
+		return false
+	}
+	for _, e := range acl {
+		if e.Tag == AclTagUser && e.Id == identity.Uid {
+			return uint32(e.Perm)&mask&want == want
+		}
+	}
+	groupMatched := false
+	for _, e := range acl {
+		if e.Tag == AclTagGroupObj && identity.InGroup(gid) || e.Tag == AclTagGroup && identity.InGroup(e.Id) {
+			groupMatched = true
+			if uint32(e.Perm)&mask&want == want {
+				return true
+			}
+		}
+	}
+	if groupMatched {
+		return false
+	}
+	if i := acl.find(AclTagOther); i >= 0 {
+		return uint32(acl[i].Perm)&want == want
+	}
+	return false
+}
+
+func (acl Acl) sort() {
+	sort.SliceStable(acl, func(i, j int) bool {
+		if acl[i].Tag != acl[j].Tag {
+			return acl[i].Tag < acl[j].Tag
+		}
+		return acl[i].Id < acl[j].Id
+	})
+}
+
+// SyncAclWithMode keeps the access acl and the permission bits consistent.
+// If the access acl is changed, the permission bits follow it, and a minimal acl is folded into the bits.
+// If only the permission bits are changed, the acl follows them, as chmod does.
+// The new extended attributes are updated in place, and the new mode is returned.
+func SyncAclWithMode(oldMode os.FileMode, oldExtended map[string][]byte, newMode os.FileMode, newExtended map[string][]byte, isDirectory bool) (os.FileMode, error) {
+	if data, found := newExtended[XattrPosixAclDefault]; found {
+		if !isDirectory {
+			return newMode, fmt.Errorf("default acl is only allowed on directories")
+		}
+		if _, err := ParseAcl(data); err != nil {
+			return newMode, fmt.Errorf("default acl: %v", err)
+		}
+	}
+
+	data, found := newExtended[XattrPosixAclAccess]
+	if !found {
+		return newMode, nil
+	}
+	if string(data) != string(oldExtended[XattrPosixAclAccess]) {
+		acl, err := ParseAcl(data)
+		if err != nil {
+			return newMode, fmt.Errorf("access acl: %v", err)
+		}
+		acl.sort()
+		if acl.IsMinimal() {
+			delete(newExtended, XattrPosixAclAccess)
+		} else {
+			newExtended[XattrPosixAclAccess] = acl.Bytes()
+		}
+		return newMode&^os.ModePerm | acl.Mode(), nil
+	}
+	if newMode.Perm() != oldMode.Perm() {
+		acl, err := ParseAcl(data)
+		if err != nil {
+			return newMode, fmt.Errorf("access acl: %v", err)
+		}
+		newExtended[XattrPosixAclAccess] = acl.WithMode(newMode).Bytes()
+	}
+	return newMode, nil
+}
+
+// InheritDefaultAcl applies the default acl of the parent directory to a new entry.
+// The access acl is the default acl limited by the requested permission bits,
+// and directories also inherit the default acl itself.
+func InheritDefaultAcl(parent *Entry, entry *Entry) {
+	if parent == nil || parent.Extended == nil {
+		return
+	}
+	data, found := parent.Extended[XattrPosixAclDefault]
+	if !found {
+		return
+	}
+	if _, hasAccessAcl := entry.Extended[XattrPosixAclAccess]; hasAccessAcl {
+		return
+	}
+	defaultAcl, err := ParseAcl(data)
+	if err != nil {
+		return
+	}
+
+	acl := make(Acl, len(defaultAcl))
+	copy(acl, defaultAcl)
+	hasMask := acl.find(AclTagMask) >= 0
+	for i, e := range acl {
+		switch {
+		case e.Tag == AclTagUserObj:
+			acl[i].Perm &= uint16(entry.Mode>>6) & aclPermissionsMask
+		case e.Tag == AclTagMask, e.Tag == AclTagGroupObj && !hasMask:
+			acl[i].Perm &= uint16(entry.Mode>>3) & aclPermissionsMask
+		case e.Tag == AclTagOther:
+			acl[i].Perm &= uint16(entry.Mode) & aclPermissionsMask
+		}
+	}
+
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	if !acl.IsMinimal() {
+		entry.Extended[XattrPosixAclAccess] = acl.Bytes()
+	}
+	if entry.IsDirectory() {
+		entry.Extended[XattrPosixAclDefault] = data
+	}
+	entry.Mode = entry.Mode&^os.ModePerm | acl.Mode()
+}
+
+// MapAclIds translates the named users and groups in the acl xattrs, e.g., between local and filer ids
+func MapAclIds(extended map[string][]byte, mapUid, mapGid func(uint32) uint32) {
+	for _, name := range []string{XattrPosixAclAccess, XattrPosixAclDefault} {
+		data, found := extended[name]
+		if !found {
+			continue
+		}
+		acl, err := ParseAcl(data)
+		if err != nil {
+			continue
+		}
+		for i, e := range acl {
+			switch e.Tag {
+			case AclTagUser:
+				acl[i].Id = mapUid(e.Id)
+			case AclTagGroup:
+				acl[i].Id = mapGid(e.Id)
+			}
+		}
+		extended[name] = acl.Bytes()
+	}
+}
@@ -0,0 +1,184 @@
+package filer
+
+import (
+	"os"
+	"testing"
+)
+
+func newTestAcl() Acl {
+	return Acl{
+		{Tag: AclTagUserObj, Perm: 07},
+		{Tag: AclTagUser, Perm: 06, Id: 2000},
+		{Tag: AclTagGroupObj, Perm: 05},
+		{Tag: AclTagGroup, Perm: 07, Id: 300},
+		{Tag: AclTagMask, Perm: 06},
+		{Tag: AclTagOther, Perm: 0},
+	}
+}
+
+func TestAclEncoding(t *testing.T) {
+	acl := newTestAcl()
+	parsed, err := ParseAcl(acl.Bytes())
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(parsed) != len(acl) {
+		t.Fatalf("expected %d entries, got %d", len(acl), len(parsed))
+	}
+	for i := range acl {
+		if parsed[i] != acl[i] {
+			t.Errorf("entry %d: expected %+v, got %+v", i, acl[i], parsed[i])
+		}
+	}
+	if mode := acl.Mode(); mode != 0760 {
+		t.Errorf("expected mode 0760, got %o", mode)
+	}
+
+	noMask := Acl{
+		{Tag: AclTagUserObj, Perm: 07},
+		{Tag: AclTagUser, Perm: 06, Id: 2000},
+		{Tag: AclTagGroupObj, Perm: 05},
+		{Tag: AclTagOther, Perm: 0},
+	}
+	if _, err := ParseAcl(noMask.Bytes()); err == nil {
+		t.Errorf("named entries without a mask should be invalid")
+	}
+	if _, err := ParseAcl([]byte{2, 0, 0}); err == nil {
+		t.Errorf("truncated acl should be invalid")
+	}
+}
+
+func TestAclPermission(t *testing.T) {
+	entry := &Entry{
+		FullPath: "/projects/shared.txt",
+		Attr: Attr{
+			Mode: 0760,
+			Uid:  1000,
+			Gid:  100,
+		},
+		Extended: map[string][]byte{
+			XattrPosixAclAccess: newTestAcl().Bytes(),
+		},
+	}
+
+	tests := []struct {
+		identity *Identity
+		want     uint32
+		expected bool
+	}{
+		// owner
+		{&Identity{Uid: 1000, Gids: []uint32{100}}, PermissionRead | PermissionWrite | PermissionExecute, true},
+		// named user, limited by the mask
+		{&Identity{Uid: 2000, Gids: []uint32{2000}}, PermissionRead | PermissionWrite, true},
+		{&Identity{Uid: 2000, Gids: []uint32{300}}, PermissionExecute, false},
+		// owning group, limited by the mask
+		{&Identity{Uid: 3000, Gids: []uint32{100}}, PermissionRead, true},
+		{&Identity{Uid: 3000, Gids: []uint32{100}}, PermissionWrite, false},
+		// named group as a supplementary group
+		{&Identity{Uid: 3000, Gids: []uint32{3000, 300}}, PermissionWrite, true},
+		// any matching group grants
+		{&Identity{Uid: 3000, Gids: []uint32{100, 300}}, PermissionWrite, true},
+		// other
+		{&Identity{Uid: 4000, Gids: []uint32{4000}}, PermissionRead, false},
+	}
+
+	for i, test := range tests {
+		if actual := HasPermission(entry, test.identity, test.want); actual != test.expected {
+			t.Errorf("case %d: identity %+v want %o: expected %v, got %v", i, test.identity, test.want, test.expected, actual)
+		}
+	}
+}
+
+func TestSyncAclWithMode(t *testing.T) {
+	data := newTestAcl().Bytes()
+
+	// setting the acl updates the permission bits
+	newExtended := map[string][]byte{XattrPosixAclAccess: data}
+	mode, err := SyncAclWithMode(0644, nil, 0644, newExtended, false)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if mode != 0760 {
+		t.Errorf("expected mode 0760, got %o", mode)
+	}
+
+	// chmod updates the mask instead of the owning group
+	oldExtended := map[string][]byte{XattrPosixAclAccess: data}
+	newExtended = map[string][]byte{XattrPosixAclAccess: data}
+	mode, err = SyncAclWithMode(0760, oldExtended, 0740, newExtended, false)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	acl, _ := ParseAcl(newExtended[XattrPosixAclAccess])
+	if acl[acl.find(AclTagMask)].Perm != 04 || acl[acl.find(AclTagGroupObj)].Perm != 05 {
+		t.Errorf("unexpected acl after chmod: %+v", acl)
+	}
+
+	// a minimal acl is folded into the permission bits
+	minimal := Acl{
+		{Tag: AclTagUserObj, Perm: 06},
+		{Tag: AclTagGroupObj, Perm: 04},
+		{Tag: AclTagOther, Perm: 04},
+	}
+	newExtended = map[string][]byte{XattrPosixAclAccess: minimal.Bytes()}
+	mode, err = SyncAclWithMode(0760, oldExtended, 0760, newExtended, false)
+	if err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+	if _, found := newExtended[XattrPosixAclAccess]; found || mode != 0644 {
+		t.Errorf("expected minimal acl removed with mode 0644, got %o", mode)
+	}
+
+	// default acl is only for directories
+	newExtended = map[string][]byte{XattrPosixAclDefault: data}
+	if _, err = SyncAclWithMode(0644, nil, 0644, newExtended, false); err == nil {
+		t.Errorf("default acl on a file should be rejected")
+	}
+}
+
+func TestInheritDefaultAcl(t *testing.T) {
+	parent := &Entry{
+		FullPath: "/projects",
+		Attr: Attr{
+			Mode: os.ModeDir | 0770,
+		},
+		Extended: map[string][]byte{
+			XattrPosixAclDefault: newTestAcl().Bytes(),
+		},
+	}
+
+	file := &Entry{
+		FullPath: "/projects/a.txt",
+		Attr: Attr{
+			Mode: 0644,
+		},
+	}
+	InheritDefaultAcl(parent, file)
+	acl, err := ParseAcl(file.Extended[XattrPosixAclAccess])
+	if err != nil {
+		t.Fatalf("inherited access acl: %v", err)
+	}
+	if file.Mode != 0640 {
+		t.Errorf("expected mode 0640, got %o", file.Mode)
+	}
+	if acl[acl.find(AclTagMask)].Perm != 04 {
+		t.Errorf("mask should be limited by the requested mode: %+v", acl)
+	}
+	if _, found := file.Extended[XattrPosixAclDefault]; found {
+		t.Errorf("files should not inherit the default acl")
+	}
+
+	dir := &Entry{
+		FullPath: "/projects/sub",
+		Attr: Attr{
+			Mode: os.ModeDir | 0777,
+		},
+	}
+	InheritDefaultAcl(parent, dir)
+	if _, found := dir.Extended[XattrPosixAclDefault]; !found {
+		t.Errorf("directories should inherit the default acl")
+	}
+	if !dir.IsDirectory() || dir.Mode.Perm() != 0760 {
+		t.Errorf("expected directory mode 0760, got %v", dir.Mode)
+	}
+}
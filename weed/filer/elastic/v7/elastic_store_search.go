@@ -0,0 +1,101 @@
+package elastic
+
+import (
+	"context"
+	"strings"
+
+	jsoniter "github.com/json-iterator/go"
+	elastic "github.com/olivere/elastic/v7"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	weed_util "github.com/chrislusf/seaweedfs/weed/util"
+)
+
+var (
+	_ = filer.EntrySearcher(&ElasticStore{})
+)
+
+// SearchEntries pushes the path, name, mtime, mime and owner conditions down to elastic search.
+// The remaining conditions are checked by the filer on the returned entries.
+func (store *ElasticStore) SearchEntries(ctx context.Context, dirPath weed_util.FullPath, filter *filer.SearchFilter, eachEntryFunc filer.ListEachEntryFunc) (err error) {
+
+	index := indexPrefix + "*," + "-" + indexKV
+	if dirPath != "/" {
+		index = getIndex(dirPath, true)
+	}
+
+	query := elastic.NewBoolQuery()
+	if dirPath != "/" {
+		query.Filter(elastic.NewPrefixQuery("Entry.FullPath.keyword", string(dirPath)+"/"))
+	}
+	if filter.NamePattern != "" && !strings.ContainsAny(filter.NamePattern, "[\\") {
+		query.Filter(elastic.NewWildcardQuery("Entry.FullPath.keyword", "*/"+filter.NamePattern))
+	}
+	if !filter.MtimeAfter.IsZero() || !filter.MtimeBefore.IsZero() {
+		mtimeRange := elastic.NewRangeQuery("Entry.Mtime")
+		if !filter.MtimeAfter.IsZero() {
+			mtimeRange.Gte(filter.MtimeAfter)
+		}
+		if !filter.MtimeBefore.IsZero() {
+			mtimeRange.Lte(filter.MtimeBefore)
+		}
+		query.Filter(mtimeRange)
+	}
+	if filter.MimePrefix != "" {
+		query.Filter(elastic.NewPrefixQuery("Entry.Mime.keyword", filter.MimePrefix))
+	}
+	if len(filter.Uids) > 0 {
+		query.Filter(elastic.NewTermsQuery("Entry.Uid", toInterfaces(filter.Uids)...))
+	}
+	if len(filter.Gids) > 0 {
+		query.Filter(elastic.NewTermsQuery("Entry.Gid", toInterfaces(filter.Gids)...))
+	}
+
+	// sorting on _id is disabled by default, so the pages are sorted by the full path
+	var after []interface{}
+	for {
+		search := store.client.Search().
+			Index(index).
+			IgnoreUnavailable(true).
+			Query(query).
+			Size(store.maxPageSize).
+			Sort("Entry.FullPath.keyword", true)
+		if after != nil {
+			search = search.SearchAfter(after...)
+		}
+		result, searchErr := search.Do(ctx)
+		if elastic.IsNotFound(searchErr) {
+			return nil
+		}
+		if searchErr != nil {
+			glog.Errorf("search %s %+v: %v", dirPath, filter, searchErr)
+			return searchErr
+		}
+		for _, hit := range result.Hits.Hits {
+			after = hit.Sort
+			esEntry := &ESEntry{
+				ParentId: "",
+				Entry:    &filer.Entry{},
+			}
+			if err := jsoniter.Unmarshal(hit.Source, esEntry); err != nil {
+				glog.Errorf("search %s unmarshal %s: %v", dirPath, hit.Id, err)
+				continue
+			}
+			if !eachEntryFunc(esEntry.Entry) {
+				return nil
+			}
+		}
+		if len(result.Hits.Hits) < store.maxPageSize {
+			break
+		}
+	}
+	return nil
+}
+
+func toInterfaces(values []uint32) (list []interface{}) {
+	for _, v := range values {
+		list = append(list, v)
+	}
+	return
+}
@@ -62,9 +62,18 @@ func EntryAttributeToPb(entry *Entry) *filer_pb.FuseAttributes {
 		SymlinkTarget: entry.Attr.SymlinkTarget,
 		Md5:           entry.Attr.Md5,
 		FileSize:      entry.Attr.FileSize,
+		Atime:         unixTime(entry.Attr.Atime),
 	}
 }
 
+// unixTime keeps an unset time as 0
+func unixTime(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
 func PbToEntryAttribute(attr *filer_pb.FuseAttributes) Attr {
 
 	t := Attr{}
@@ -88,6 +97,9 @@ func PbToEntryAttribute(attr *filer_pb.FuseAttributes) Attr {
 	t.SymlinkTarget = attr.SymlinkTarget
 	t.Md5 = attr.Md5
 	t.FileSize = attr.FileSize
+	if attr.Atime != 0 {
+		t.Atime = time.Unix(attr.Atime, 0)
+	}
 
 	return t
 }
@@ -132,6 +144,22 @@ func EqualEntry(a, b *Entry) bool {
 	return true
 }
 
+// EqualContent compares the file content and extended attributes, ignoring the other attributes
+func EqualContent(a, b *Entry) bool {
+	if len(a.Chunks) != len(b.Chunks) {
+		return false
+	}
+	for i := 0; i < len(a.Chunks); i++ {
+		if !proto.Equal(a.Chunks[i], b.Chunks[i]) {
+			return false
+		}
+	}
+	if !eq(a.Extended, b.Extended) {
+		return false
+	}
+	return bytes.Equal(a.Content, b.Content)
+}
+
 func eq(a, b map[string][]byte) bool {
 	if len(a) != len(b) {
 		return false
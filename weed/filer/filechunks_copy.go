@@ -0,0 +1,59 @@
+package filer
+
+import (
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// CopyRange is a part of a file range copy whose bytes have to be read and written again
+type CopyRange struct {
+	SrcOffset int64
+	DstOffset int64
+	Size      int64
+}
+
+// PlanCopyFileRange splits copying [srcOffset, srcOffset+length) of the source file to dstOffset of the destination file.
+// A chunk can only be referenced from its beginning, so the parts of the source starting at the beginning of a chunk
+// are shared as new chunks of the destination, without moving any bytes.
+// The other parts, and the holes of the source over existing data of the destination, are returned to be copied.
+func PlanCopyFileRange(srcVisibles, dstVisibles []VisibleInterval, srcOffset, dstOffset, length int64, mtime int64) (shared []*filer_pb.FileChunk, toCopy []CopyRange) {
+
+	delta := dstOffset - srcOffset
+	addCopy := func(start, stop int64) {
+		if start >= stop {
+			return
+		}
+		if n := len(toCopy); n > 0 && toCopy[n-1].SrcOffset+toCopy[n-1].Size == start {
+			toCopy[n-1].Size += stop - start
+			return
+		}
+		toCopy = append(toCopy, CopyRange{SrcOffset: start, DstOffset: start + delta, Size: stop - start})
+	}
+	addHole := func(start, stop int64) {
+		if start < stop && len(ViewFromVisibleIntervals(dstVisibles, start+delta, stop-start)) > 0 {
+			addCopy(start, stop)
+		}
+	}
+
+	pos, stop := srcOffset, srcOffset+length
+	for _, view := range ViewFromVisibleIntervals(srcVisibles, srcOffset, length) {
+		addHole(pos, view.LogicOffset)
+		pos = view.LogicOffset + int64(view.Size)
+		if view.Offset != 0 {
+			addCopy(view.LogicOffset, pos)
+			continue
+		}
+		fid, _ := filer_pb.ToFileIdObject(view.FileId)
+		shared = append(shared, &filer_pb.FileChunk{
+			FileId:       view.FileId,
+			Offset:       view.LogicOffset + delta,
+			Size:         view.Size,
+			Mtime:        mtime,
+			CipherKey:    view.CipherKey,
+			IsCompressed: view.IsGzipped,
+			Fid:          fid,
+		})
+	}
+	addHole(pos, stop)
+
+	return
+}
@@ -0,0 +1,43 @@
+package filer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestPlanCopyFileRange(t *testing.T) {
+	src, _ := NonOverlappingVisibleIntervals(nil, []*filer_pb.FileChunk{
+		{Offset: 0, Size: 100, FileId: "1,a", Mtime: 1},
+		{Offset: 100, Size: 100, FileId: "1,b", Mtime: 1},
+		// hole at [200,300)
+		{Offset: 300, Size: 100, FileId: "1,c", Mtime: 1},
+	})
+	dst, _ := NonOverlappingVisibleIntervals(nil, []*filer_pb.FileChunk{
+		{Offset: 1000, Size: 150, FileId: "2,d", Mtime: 1},
+	})
+
+	// copy [50,400) to 1000
+	shared, toCopy := PlanCopyFileRange(src, dst, 50, 1000, 350, 9)
+
+	assert.Equal(t, 2, len(shared))
+	assert.Equal(t, "1,b", shared[0].FileId)
+	assert.Equal(t, int64(1050), shared[0].Offset)
+	assert.Equal(t, uint64(100), shared[0].Size)
+	assert.Equal(t, int64(9), shared[0].Mtime)
+	assert.Equal(t, "1,c", shared[1].FileId)
+	assert.Equal(t, int64(1250), shared[1].Offset)
+
+	// the unaligned head is copied, the hole is not over destination data
+	assert.Equal(t, []CopyRange{{SrcOffset: 50, DstOffset: 1000, Size: 50}}, toCopy)
+
+	// the hole covers existing data of the destination, and is merged with the unaligned part before it
+	shared, toCopy = PlanCopyFileRange(src, dst, 150, 1000, 200, 9)
+	assert.Equal(t, 1, len(shared))
+	assert.Equal(t, "1,c", shared[0].FileId)
+	assert.Equal(t, int64(1150), shared[0].Offset)
+	assert.Equal(t, uint64(50), shared[0].Size)
+	assert.Equal(t, []CopyRange{{SrcOffset: 150, DstOffset: 1000, Size: 150}}, toCopy)
+}
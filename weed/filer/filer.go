@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
@@ -42,6 +43,11 @@ type Filer struct {
 	MetaAggregator      *MetaAggregator
 	Signature           int32
 	FilerConf           *FilerConf
+	dedupLock           sync.Mutex
+	dedupInUse          bool
+	dedupStatsDelta     DedupStats
+	LeaseManager        *LeaseManager
+	quotas              filerQuotas
 }
 
 func NewFiler(masters []string, grpcDialOption grpc.DialOption,
@@ -51,12 +57,14 @@ func NewFiler(masters []string, grpcDialOption grpc.DialOption,
 		fileIdDeletionQueue: util.NewUnboundedQueue(),
 		GrpcDialOption:      grpcDialOption,
 		FilerConf:           NewFilerConf(),
+		LeaseManager:        NewLeaseManager(LeaseRevokeTimeout),
 	}
 	f.LocalMetaLogBuffer = log_buffer.NewLogBuffer(LogFlushInterval, f.logFlushFunc, notifyFn)
 	f.metaLogCollection = collection
 	f.metaLogReplication = replication
 
 	go f.loopProcessingDeletion()
+	go f.loopFlushDedupStats()
 
 	return f
 }
@@ -84,6 +92,10 @@ func (f *Filer) SetStore(store FilerStore) {
 
 	f.setOrLoadFilerStoreSignature(store)
 
+	f.loadQuotas()
+	go f.loopSavingQuotas()
+	go f.loopRecountingQuotas()
+
 }
 
 func (f *Filer) setOrLoadFilerStoreSignature(store FilerStore) {
@@ -147,9 +159,13 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry, o_excl bool, isFr
 	if oldEntry == nil {
 
 		dirParts := strings.Split(string(entry.FullPath), "/")
-		if err := f.ensureParentDirecotryEntry(ctx, entry, dirParts, len(dirParts)-1, isFromOtherCluster); err != nil {
+		parentEntry, err := f.ensureParentDirecotryEntry(ctx, entry, dirParts, len(dirParts)-1, isFromOtherCluster)
+		if err != nil {
 			return err
 		}
+		if !isFromOtherCluster {
+			InheritDefaultAcl(parentEntry, entry)
+		}
 
 		glog.V(4).Infof("InsertEntry %s: new entry: %v", entry.FullPath, entry.Name())
 		if err := f.Store.InsertEntry(ctx, entry); err != nil {
@@ -178,10 +194,11 @@ func (f *Filer) CreateEntry(ctx context.Context, entry *Entry, o_excl bool, isFr
 	return nil
 }
 
-func (f *Filer) ensureParentDirecotryEntry(ctx context.Context, entry *Entry, dirParts []string, level int, isFromOtherCluster bool) (err error) {
+// ensureParentDirecotryEntry creates the missing parent directories, and returns the direct parent directory
+func (f *Filer) ensureParentDirecotryEntry(ctx context.Context, entry *Entry, dirParts []string, level int, isFromOtherCluster bool) (dirEntry *Entry, err error) {
 
 	if level == 0 {
-		return nil
+		return Root, nil
 	}
 
 	dirPath := "/" + util.Join(dirParts[:level]...)
@@ -189,14 +206,15 @@ func (f *Filer) ensureParentDirecotryEntry(ctx context.Context, entry *Entry, di
 
 	// check the store directly
 	glog.V(4).Infof("find uncached directory: %s", dirPath)
-	dirEntry, _ := f.FindEntry(ctx, util.FullPath(dirPath))
+	dirEntry, _ = f.FindEntry(ctx, util.FullPath(dirPath))
 
 	// no such existing directory
 	if dirEntry == nil {
 
 		// ensure parent directory
-		if err = f.ensureParentDirecotryEntry(ctx, entry, dirParts, level-1, isFromOtherCluster); err != nil {
-			return err
+		parentEntry, err := f.ensureParentDirecotryEntry(ctx, entry, dirParts, level-1, isFromOtherCluster)
+		if err != nil {
+			return nil, err
 		}
 
 		// create the directory
@@ -217,12 +235,16 @@ func (f *Filer) ensureParentDirecotryEntry(ctx context.Context, entry *Entry, di
 			},
 		}
 
+		if !isFromOtherCluster {
+			InheritDefaultAcl(parentEntry, dirEntry)
+		}
+
 		glog.V(2).Infof("create directory: %s %v", dirPath, dirEntry.Mode)
 		mkdirErr := f.Store.InsertEntry(ctx, dirEntry)
 		if mkdirErr != nil {
 			if _, err := f.FindEntry(ctx, util.FullPath(dirPath)); err == filer_pb.ErrNotFound {
 				glog.V(3).Infof("mkdir %s: %v", dirPath, mkdirErr)
-				return fmt.Errorf("mkdir %s: %v", dirPath, mkdirErr)
+				return nil, fmt.Errorf("mkdir %s: %v", dirPath, mkdirErr)
 			}
 		} else {
 			f.maybeAddBucket(dirEntry)
@@ -231,10 +253,10 @@ func (f *Filer) ensureParentDirecotryEntry(ctx context.Context, entry *Entry, di
 
 	} else if !dirEntry.IsDirectory() {
 		glog.Errorf("CreateEntry %s: %s should be a directory", entry.FullPath, dirPath)
-		return fmt.Errorf("%s is a file", dirPath)
+		return nil, fmt.Errorf("%s is a file", dirPath)
 	}
 
-	return nil
+	return dirEntry, nil
 }
 
 func (f *Filer) UpdateEntry(ctx context.Context, oldEntry, entry *Entry) (err error) {
@@ -248,6 +270,9 @@ func (f *Filer) UpdateEntry(ctx context.Context, oldEntry, entry *Entry) (err er
 			glog.Errorf("existing %s is a file", entry.FullPath)
 			return fmt.Errorf("existing %s is a file", entry.FullPath)
 		}
+		if entry.Mode, err = SyncAclWithMode(oldEntry.Mode, oldEntry.Extended, entry.Mode, entry.Extended, entry.IsDirectory()); err != nil {
+			return fmt.Errorf("update %s: %v", entry.FullPath, err)
+		}
 	}
 	return f.Store.UpdateEntry(ctx, entry)
 }
@@ -274,6 +299,7 @@ func (f *Filer) FindEntry(ctx context.Context, p util.FullPath) (entry *Entry, e
 	if entry != nil && entry.TtlSec > 0 {
 		if entry.Crtime.Add(time.Duration(entry.TtlSec) * time.Second).Before(time.Now()) {
 			f.Store.DeleteOneEntry(ctx, entry)
+			f.updateQuotaUsage(entry, nil)
 			return nil, filer_pb.ErrNotFound
 		}
 	}
@@ -286,6 +312,7 @@ func (f *Filer) doListDirectoryEntries(ctx context.Context, p util.FullPath, sta
 		if entry.TtlSec > 0 {
 			if entry.Crtime.Add(time.Duration(entry.TtlSec) * time.Second).Before(time.Now()) {
 				f.Store.DeleteOneEntry(ctx, entry)
+				f.updateQuotaUsage(entry, nil)
 				expiredCount++
 				return true
 			}
@@ -300,5 +327,11 @@ func (f *Filer) doListDirectoryEntries(ctx context.Context, p util.FullPath, sta
 
 func (f *Filer) Shutdown() {
 	f.LocalMetaLogBuffer.Shutdown()
+	if err := f.saveQuotas(); err != nil {
+		glog.Errorf("save directory quotas: %v", err)
+	}
+	if err := f.flushDedupStats(); err != nil {
+		glog.Errorf("save dedup stats: %v", err)
+	}
 	f.Store.Shutdown()
 }
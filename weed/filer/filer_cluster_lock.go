@@ -0,0 +1,91 @@
+package filer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+)
+
+const (
+	ClusterLockTimeout       = 30 * time.Second
+	clusterLockRetryInterval = 100 * time.Millisecond
+	clusterLockRenewInterval = 4 * time.Second
+)
+
+// withClusterLock runs fn while holding the named lock leased from the master,
+// so that only one filer of the cluster runs fn at a time.
+// The lease is renewed while fn runs, and expires on the master if this filer dies.
+// A filer without a master client, e.g., in tests, runs fn directly.
+func (f *Filer) withClusterLock(ctx context.Context, lockName string, fn func() error) error {
+	if f.MasterClient == nil {
+		return fn()
+	}
+
+	var tokenLock sync.Mutex
+	var token, lockTsNs int64
+	lease := func(ctx context.Context) error {
+		tokenLock.Lock()
+		defer tokenLock.Unlock()
+		return f.MasterClient.WithClient(func(client master_pb.SeaweedClient) error {
+			resp, err := client.LeaseAdminToken(ctx, &master_pb.LeaseAdminTokenRequest{
+				PreviousToken:    token,
+				PreviousLockTime: lockTsNs,
+				LockName:         lockName,
+			})
+			if err == nil {
+				token, lockTsNs = resp.Token, resp.LockTsNs
+			}
+			return err
+		})
+	}
+
+	leaseCtx, cancel := context.WithTimeout(ctx, ClusterLockTimeout)
+	defer cancel()
+	for {
+		err := lease(leaseCtx)
+		if err == nil {
+			break
+		}
+		select {
+		case <-leaseCtx.Done():
+			return fmt.Errorf("lock %s: %v", lockName, err)
+		case <-time.After(clusterLockRetryInterval):
+		}
+	}
+
+	done, renewStopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(renewStopped)
+		ticker := time.NewTicker(clusterLockRenewInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := lease(context.Background()); err != nil {
+					glog.Errorf("renew lock %s: %v", lockName, err)
+					return
+				}
+			}
+		}
+	}()
+
+	err := fn()
+
+	close(done)
+	<-renewStopped
+	f.MasterClient.WithClient(func(client master_pb.SeaweedClient) error {
+		_, releaseErr := client.ReleaseAdminToken(context.Background(), &master_pb.ReleaseAdminTokenRequest{
+			PreviousToken:    token,
+			PreviousLockTime: lockTsNs,
+			LockName:         lockName,
+		})
+		return releaseErr
+	})
+	return err
+}
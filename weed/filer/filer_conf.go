@@ -122,6 +122,7 @@ func mergePathConf(a, b *filer_pb.FilerConf_PathConf) {
 		a.DiskType = b.DiskType
 	}
 	a.Fsync = b.Fsync || a.Fsync
+	a.Dedup = b.Dedup || a.Dedup
 	if b.VolumeGrowthCount > 0 {
 		a.VolumeGrowthCount = b.VolumeGrowthCount
 	}
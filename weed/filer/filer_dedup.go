@@ -0,0 +1,422 @@
+package filer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// Chunk deduplication keeps these records in the filer store kv:
+//   dedup.chunk.<collection>/<sha256 hex> => reference count + the stored chunk
+//   dedup.fid.<file id>                   => the dedup.chunk key
+//   dedup.stats                           => per collection statistics, also marks dedup as in use
+// Filers share the store, so the records are only updated while holding the cluster wide dedup lock.
+const (
+	dedupChunkKeyPrefix  = "dedup.chunk."
+	dedupFileIdKeyPrefix = "dedup.fid."
+	DedupStatsKey        = "dedup.stats"
+	dedupLockName        = "filer.dedup"
+)
+
+// DedupStatsFlushInterval is how often a filer adds its counted changes to the saved stats
+var DedupStatsFlushInterval = time.Minute
+
+type DedupCollectionStats struct {
+	UniqueChunks     int64 `json:"uniqueChunks"`
+	UniqueBytes      int64 `json:"uniqueBytes"`
+	ReferencedChunks int64 `json:"referencedChunks"`
+	ReferencedBytes  int64 `json:"referencedBytes"`
+}
+
+func (s *DedupCollectionStats) SavedBytes() int64 {
+	return s.ReferencedBytes - s.UniqueBytes
+}
+
+// DedupStats maps collection names to their deduplication statistics
+type DedupStats map[string]*DedupCollectionStats
+
+func DedupHash(data []byte) []byte {
+	h := sha256.Sum256(data)
+	return h[:]
+}
+
+func dedupChunkKey(collection string, hash []byte) []byte {
+	return []byte(dedupChunkKeyPrefix + collection + "/" + hex.EncodeToString(hash))
+}
+
+func dedupFileIdKey(fileId string) []byte {
+	return []byte(dedupFileIdKeyPrefix + fileId)
+}
+
+func encodeDedupRecord(refCount uint32, chunk *filer_pb.FileChunk) ([]byte, error) {
+	data, err := proto.Marshal(chunk)
+	if err != nil {
+		return nil, err
+	}
+	value := make([]byte, 4+len(data))
+	util.Uint32toBytes(value[0:4], refCount)
+	copy(value[4:], data)
+	return value, nil
+}
+
+func decodeDedupRecord(value []byte) (refCount uint32, chunk *filer_pb.FileChunk, err error) {
+	if len(value) < 4 {
+		return 0, nil, fmt.Errorf("dedup record too short: %d", len(value))
+	}
+	refCount = util.BytesToUint32(value[0:4])
+	chunk = &filer_pb.FileChunk{}
+	err = proto.Unmarshal(value[4:], chunk)
+	return
+}
+
+// DedupAcquireChunk looks up an already stored chunk with the same content hash in the collection.
+// If found, one more reference is counted, and a copy of the chunk placed at the offset is returned.
+func (f *Filer) DedupAcquireChunk(ctx context.Context, collection string, hash []byte, offset int64) (chunk *filer_pb.FileChunk, err error) {
+	key := dedupChunkKey(collection, hash)
+	if _, err = f.Store.KvGet(ctx, key); err == ErrKvNotFound {
+		// new content, checked again under the lock when registered
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.withDedupLock(ctx, func() error {
+		value, err := f.Store.KvGet(ctx, key)
+		if err == ErrKvNotFound {
+			// released by another filer meanwhile
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		refCount, stored, err := decodeDedupRecord(value)
+		if err != nil {
+			return fmt.Errorf("decode dedup record %s: %v", key, err)
+		}
+		if value, err = encodeDedupRecord(refCount+1, stored); err != nil {
+			return err
+		}
+		if err = f.Store.KvPut(ctx, key, value); err != nil {
+			return err
+		}
+		f.addDedupStats(collection, DedupCollectionStats{ReferencedChunks: 1, ReferencedBytes: int64(stored.Size)})
+		chunk = stored
+		return nil
+	})
+	if err != nil || chunk == nil {
+		return nil, err
+	}
+
+	chunk.Offset = offset
+	chunk.Mtime = time.Now().UnixNano()
+	return chunk, nil
+}
+
+// DedupRegisterChunk records a newly uploaded chunk so that later writes with the same content can reuse it.
+func (f *Filer) DedupRegisterChunk(ctx context.Context, collection string, hash []byte, chunk *filer_pb.FileChunk) error {
+	return f.withDedupLock(ctx, func() error {
+		key := dedupChunkKey(collection, hash)
+		if _, err := f.Store.KvGet(ctx, key); err == nil {
+			// a concurrent write registered the same content first, this chunk stays a plain chunk
+			return nil
+		}
+		if err := f.markDedupInUse(ctx); err != nil {
+			return err
+		}
+
+		stored := proto.Clone(chunk).(*filer_pb.FileChunk)
+		stored.Offset = 0
+		value, err := encodeDedupRecord(1, stored)
+		if err != nil {
+			return err
+		}
+		if err = f.Store.KvPut(ctx, dedupFileIdKey(chunk.GetFileIdString()), key); err != nil {
+			return err
+		}
+		if err = f.Store.KvPut(ctx, key, value); err != nil {
+			return err
+		}
+
+		f.addDedupStats(collection, DedupCollectionStats{
+			UniqueChunks:     1,
+			UniqueBytes:      int64(chunk.Size),
+			ReferencedChunks: 1,
+			ReferencedBytes:  int64(chunk.Size),
+		})
+		return nil
+	})
+}
+
+// DedupShareChunks counts one more reference for each chunk that a file range copy shares with another file.
+// Chunks not deduplicated yet are recorded under the hash of their file id, referenced by the source and the copy.
+func (f *Filer) DedupShareChunks(ctx context.Context, collection string, chunks []*filer_pb.FileChunk) error {
+	return f.withDedupLock(ctx, func() error {
+		for _, chunk := range chunks {
+			fileId := chunk.GetFileIdString()
+			key, err := f.Store.KvGet(ctx, dedupFileIdKey(fileId))
+			if err != nil && err != ErrKvNotFound {
+				return err
+			}
+			if err == nil {
+				value, err := f.Store.KvGet(ctx, key)
+				if err != nil {
+					return fmt.Errorf("read dedup record %s: %v", key, err)
+				}
+				refCount, stored, err := decodeDedupRecord(value)
+				if err != nil {
+					return fmt.Errorf("decode dedup record %s: %v", key, err)
+				}
+				if value, err = encodeDedupRecord(refCount+1, stored); err != nil {
+					return err
+				}
+				if err = f.Store.KvPut(ctx, key, value); err != nil {
+					return err
+				}
+				f.addDedupStats(dedupRecordCollection(key), DedupCollectionStats{ReferencedChunks: 1, ReferencedBytes: int64(stored.Size)})
+				continue
+			}
+
+			if err = f.markDedupInUse(ctx); err != nil {
+				return err
+			}
+			key = dedupChunkKey(collection, DedupHash([]byte(fileId)))
+			stored := proto.Clone(chunk).(*filer_pb.FileChunk)
+			stored.Offset = 0
+			value, err := encodeDedupRecord(2, stored)
+			if err != nil {
+				return err
+			}
+			if err = f.Store.KvPut(ctx, dedupFileIdKey(fileId), key); err != nil {
+				return err
+			}
+			if err = f.Store.KvPut(ctx, key, value); err != nil {
+				return err
+			}
+			f.addDedupStats(collection, DedupCollectionStats{
+				UniqueChunks:     1,
+				UniqueBytes:      int64(chunk.Size),
+				ReferencedChunks: 2,
+				ReferencedBytes:  2 * int64(chunk.Size),
+			})
+		}
+		return nil
+	})
+}
+
+// DedupReleaseReplaced releases the references held by a replaced entry for chunks that the new entry reuses.
+// These chunks are not deleted when the entry is replaced, so their references would otherwise leak.
+func (f *Filer) DedupReleaseReplaced(oldEntry, newEntry *Entry) {
+	if oldEntry == nil || newEntry == nil {
+		return
+	}
+	newFileIds := make(map[string]bool)
+	for _, chunk := range newEntry.Chunks {
+		newFileIds[chunk.GetFileIdString()] = true
+	}
+	var toRelease []string
+	for _, chunk := range oldEntry.Chunks {
+		if !chunk.IsChunkManifest && newFileIds[chunk.GetFileIdString()] {
+			toRelease = append(toRelease, chunk.GetFileIdString())
+		}
+	}
+	if len(toRelease) > 0 {
+		f.dedupReleaseFileIds(toRelease)
+	}
+}
+
+// dedupReleaseFileIds drops one reference for each deduplicated file id,
+// and returns the file ids that are no longer referenced and can be deleted.
+func (f *Filer) dedupReleaseFileIds(fileIds []string) (toDelete []string) {
+	if len(fileIds) == 0 {
+		return
+	}
+	ctx := context.Background()
+	if !f.isDedupInUse(ctx) {
+		return fileIds
+	}
+
+	err := f.withDedupLock(ctx, func() error {
+		for _, fileId := range fileIds {
+			if f.dedupReleaseFileId(ctx, fileId) {
+				continue
+			}
+			toDelete = append(toDelete, fileId)
+		}
+		return nil
+	})
+	if err != nil {
+		// keep the chunks, leaking is better than losing data
+		glog.Errorf("release dedup chunks: %v", err)
+		return nil
+	}
+	return
+}
+
+func (f *Filer) dedupReleaseFileId(ctx context.Context, fileId string) (isStillReferenced bool) {
+	fidKey := dedupFileIdKey(fileId)
+	key, err := f.Store.KvGet(ctx, fidKey)
+	if err != nil {
+		return false
+	}
+	value, err := f.Store.KvGet(ctx, key)
+	if err != nil {
+		f.Store.KvDelete(ctx, fidKey)
+		return false
+	}
+	refCount, chunk, err := decodeDedupRecord(value)
+	if err != nil || chunk.GetFileIdString() != fileId {
+		glog.Warningf("dedup record %s does not match %s: %v", key, fileId, err)
+		f.Store.KvDelete(ctx, fidKey)
+		return false
+	}
+	collection := dedupRecordCollection(key)
+
+	if refCount > 1 {
+		if value, err = encodeDedupRecord(refCount-1, chunk); err == nil {
+			err = f.Store.KvPut(ctx, key, value)
+		}
+		if err != nil {
+			// keep the chunk, leaking is better than losing data
+			glog.Errorf("release dedup chunk %s: %v", fileId, err)
+			return true
+		}
+		f.addDedupStats(collection, DedupCollectionStats{ReferencedChunks: -1, ReferencedBytes: -int64(chunk.Size)})
+		return true
+	}
+
+	f.Store.KvDelete(ctx, key)
+	f.Store.KvDelete(ctx, fidKey)
+	f.addDedupStats(collection, DedupCollectionStats{
+		UniqueChunks:     -1,
+		UniqueBytes:      -int64(chunk.Size),
+		ReferencedChunks: -1,
+		ReferencedBytes:  -int64(chunk.Size),
+	})
+	return false
+}
+
+func dedupRecordCollection(key []byte) string {
+	return string(key[len(dedupChunkKeyPrefix) : len(key)-sha256.Size*2-1])
+}
+
+// withDedupLock serializes the reference count updates of all filers sharing the store
+func (f *Filer) withDedupLock(ctx context.Context, fn func() error) error {
+	f.dedupLock.Lock()
+	defer f.dedupLock.Unlock()
+	return f.withClusterLock(ctx, dedupLockName, fn)
+}
+
+// markDedupInUse saves the stats record before the first chunk is shared,
+// so that every filer counts the references when deleting chunks from then on.
+func (f *Filer) markDedupInUse(ctx context.Context) error {
+	if f.dedupInUse {
+		return nil
+	}
+	if _, err := f.Store.KvGet(ctx, []byte(DedupStatsKey)); err == ErrKvNotFound {
+		if err = f.Store.KvPut(ctx, []byte(DedupStatsKey), []byte("{}")); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	f.dedupInUse = true
+	return nil
+}
+
+func (f *Filer) isDedupInUse(ctx context.Context) bool {
+	f.dedupLock.Lock()
+	defer f.dedupLock.Unlock()
+	if f.dedupInUse {
+		return true
+	}
+	if _, err := f.Store.KvGet(ctx, []byte(DedupStatsKey)); err != nil {
+		// deduplication has never been used
+		return false
+	}
+	f.dedupInUse = true
+	return true
+}
+
+// addDedupStats counts the change in memory, saved later by flushDedupStats
+func (f *Filer) addDedupStats(collection string, delta DedupCollectionStats) {
+	if f.dedupStatsDelta == nil {
+		f.dedupStatsDelta = make(DedupStats)
+	}
+	stats, found := f.dedupStatsDelta[collection]
+	if !found {
+		stats = &DedupCollectionStats{}
+		f.dedupStatsDelta[collection] = stats
+	}
+	stats.add(delta)
+}
+
+func (s *DedupCollectionStats) add(delta DedupCollectionStats) {
+	s.UniqueChunks += delta.UniqueChunks
+	s.UniqueBytes += delta.UniqueBytes
+	s.ReferencedChunks += delta.ReferencedChunks
+	s.ReferencedBytes += delta.ReferencedBytes
+}
+
+func (f *Filer) loopFlushDedupStats() {
+	for {
+		time.Sleep(DedupStatsFlushInterval)
+		if err := f.flushDedupStats(); err != nil {
+			glog.Errorf("save dedup stats: %v", err)
+		}
+	}
+}
+
+// flushDedupStats adds the changes counted by this filer to the saved stats
+func (f *Filer) flushDedupStats() error {
+	f.dedupLock.Lock()
+	defer f.dedupLock.Unlock()
+	if len(f.dedupStatsDelta) == 0 {
+		return nil
+	}
+	ctx := context.Background()
+	err := f.withClusterLock(ctx, dedupLockName, func() error {
+		stats, err := ReadDedupStats(f.Store.KvGet(ctx, []byte(DedupStatsKey)))
+		if err != nil {
+			return err
+		}
+		for collection, delta := range f.dedupStatsDelta {
+			collectionStats, found := stats[collection]
+			if !found {
+				collectionStats = &DedupCollectionStats{}
+				stats[collection] = collectionStats
+			}
+			collectionStats.add(*delta)
+		}
+		value, err := json.Marshal(stats)
+		if err != nil {
+			return err
+		}
+		return f.Store.KvPut(ctx, []byte(DedupStatsKey), value)
+	})
+	if err == nil {
+		f.dedupStatsDelta = nil
+	}
+	return err
+}
+
+func ReadDedupStats(value []byte, kvErr error) (stats DedupStats, err error) {
+	stats = make(DedupStats)
+	if kvErr == ErrKvNotFound || (kvErr == nil && len(value) == 0) {
+		return stats, nil
+	}
+	if kvErr != nil {
+		return nil, kvErr
+	}
+	err = json.Unmarshal(value, &stats)
+	return
+}
@@ -0,0 +1,160 @@
+package filer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+type memoryKvStore struct {
+	FilerStore
+	kv map[string][]byte
+}
+
+func (store *memoryKvStore) GetName() string {
+	return "memory"
+}
+func (store *memoryKvStore) KvPut(ctx context.Context, key []byte, value []byte) (err error) {
+	store.kv[string(key)] = value
+	return nil
+}
+func (store *memoryKvStore) KvGet(ctx context.Context, key []byte) (value []byte, err error) {
+	value, found := store.kv[string(key)]
+	if !found {
+		return nil, ErrKvNotFound
+	}
+	return value, nil
+}
+func (store *memoryKvStore) KvDelete(ctx context.Context, key []byte) (err error) {
+	delete(store.kv, string(key))
+	return nil
+}
+
+func TestDedupReferenceCounting(t *testing.T) {
+	f := &Filer{Store: NewFilerStoreWrapper(&memoryKvStore{kv: make(map[string][]byte)})}
+	ctx := context.Background()
+
+	data := []byte("some chunk content")
+	hash := DedupHash(data)
+	uploaded := &filer_pb.FileChunk{FileId: "3,01637037d6", Size: uint64(len(data))}
+
+	// nothing is deduplicated yet
+	if toDelete := f.dedupReleaseFileIds([]string{"3,01637037d6"}); len(toDelete) != 1 {
+		t.Fatalf("unexpected kept file ids: %v", toDelete)
+	}
+
+	chunk, err := f.DedupAcquireChunk(ctx, "layers", hash, 0)
+	if err != nil || chunk != nil {
+		t.Fatalf("acquire before register: %v %v", chunk, err)
+	}
+	if err = f.DedupRegisterChunk(ctx, "layers", hash, uploaded); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	// same content in another collection is not shared
+	if chunk, _ = f.DedupAcquireChunk(ctx, "other", hash, 0); chunk != nil {
+		t.Fatalf("acquired chunk across collections")
+	}
+
+	chunk, err = f.DedupAcquireChunk(ctx, "layers", hash, 1024)
+	if err != nil || chunk == nil {
+		t.Fatalf("acquire: %v %v", chunk, err)
+	}
+	if chunk.GetFileIdString() != uploaded.GetFileIdString() || chunk.Offset != 1024 {
+		t.Fatalf("acquired wrong chunk: %+v", chunk)
+	}
+
+	if err := f.flushDedupStats(); err != nil {
+		t.Fatalf("flush stats: %v", err)
+	}
+	stats, _ := ReadDedupStats(f.Store.KvGet(ctx, []byte(DedupStatsKey)))
+	if s := stats["layers"]; s.UniqueChunks != 1 || s.ReferencedChunks != 2 || s.SavedBytes() != int64(len(data)) {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+
+	// first release keeps the chunk, the second one deletes it
+	if toDelete := f.dedupReleaseFileIds([]string{uploaded.GetFileIdString()}); len(toDelete) != 0 {
+		t.Fatalf("deleted a still referenced chunk: %v", toDelete)
+	}
+	if toDelete := f.dedupReleaseFileIds([]string{uploaded.GetFileIdString()}); len(toDelete) != 1 {
+		t.Fatalf("kept an unreferenced chunk")
+	}
+	if chunk, _ = f.DedupAcquireChunk(ctx, "layers", hash, 0); chunk != nil {
+		t.Fatalf("acquired a deleted chunk")
+	}
+
+	if err := f.flushDedupStats(); err != nil {
+		t.Fatalf("flush stats: %v", err)
+	}
+	stats, _ = ReadDedupStats(f.Store.KvGet(ctx, []byte(DedupStatsKey)))
+	if s := stats["layers"]; s.UniqueChunks != 0 || s.ReferencedChunks != 0 || s.SavedBytes() != 0 {
+		t.Fatalf("unexpected stats after release: %+v", s)
+	}
+}
+
+func TestDedupShareChunks(t *testing.T) {
+	f := &Filer{Store: NewFilerStoreWrapper(&memoryKvStore{kv: make(map[string][]byte)})}
+	ctx := context.Background()
+
+	chunk := &filer_pb.FileChunk{FileId: "3,01637037d6", Size: 100, Offset: 4096}
+
+	// a chunk shared for the first time is referenced by the source and the copy
+	if err := f.DedupShareChunks(ctx, "docs", []*filer_pb.FileChunk{chunk}); err != nil {
+		t.Fatalf("share: %v", err)
+	}
+	if err := f.DedupShareChunks(ctx, "docs", []*filer_pb.FileChunk{chunk}); err != nil {
+		t.Fatalf("share again: %v", err)
+	}
+
+	if err := f.flushDedupStats(); err != nil {
+		t.Fatalf("flush stats: %v", err)
+	}
+	stats, _ := ReadDedupStats(f.Store.KvGet(ctx, []byte(DedupStatsKey)))
+	if s := stats["docs"]; s.UniqueChunks != 1 || s.ReferencedChunks != 3 || s.SavedBytes() != 200 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+
+	for i := 0; i < 2; i++ {
+		if toDelete := f.dedupReleaseFileIds([]string{chunk.GetFileIdString()}); len(toDelete) != 0 {
+			t.Fatalf("deleted a still referenced chunk: %v", toDelete)
+		}
+	}
+	if toDelete := f.dedupReleaseFileIds([]string{chunk.GetFileIdString()}); len(toDelete) != 1 {
+		t.Fatalf("kept an unreferenced chunk")
+	}
+}
+
+func TestDedupStatsFromSeveralFilers(t *testing.T) {
+	store := NewFilerStoreWrapper(&memoryKvStore{kv: make(map[string][]byte)})
+	f1, f2 := &Filer{Store: store}, &Filer{Store: store}
+	ctx := context.Background()
+
+	data := []byte("some chunk content")
+	hash := DedupHash(data)
+	uploaded := &filer_pb.FileChunk{FileId: "3,01637037d6", Size: uint64(len(data))}
+	if err := f1.DedupRegisterChunk(ctx, "layers", hash, uploaded); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	// the other filer counts the references before any stats are flushed
+	if chunk, err := f2.DedupAcquireChunk(ctx, "layers", hash, 0); err != nil || chunk == nil {
+		t.Fatalf("acquire: %v %v", chunk, err)
+	}
+	if toDelete := f2.dedupReleaseFileIds([]string{uploaded.GetFileIdString()}); len(toDelete) != 0 {
+		t.Fatalf("deleted a still referenced chunk: %v", toDelete)
+	}
+	if chunk, err := f2.DedupAcquireChunk(ctx, "layers", hash, 0); err != nil || chunk == nil {
+		t.Fatalf("acquire again: %v %v", chunk, err)
+	}
+
+	for _, f := range []*Filer{f1, f2} {
+		if err := f.flushDedupStats(); err != nil {
+			t.Fatalf("flush stats: %v", err)
+		}
+	}
+	stats, _ := ReadDedupStats(store.KvGet(ctx, []byte(DedupStatsKey)))
+	if s := stats["layers"]; s.UniqueChunks != 1 || s.ReferencedChunks != 2 || s.SavedBytes() != int64(len(data)) {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
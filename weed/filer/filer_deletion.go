@@ -93,29 +93,19 @@ func (f *Filer) doDeleteFileIds(fileIds []string) {
 }
 
 func (f *Filer) DirectDeleteChunks(chunks []*filer_pb.FileChunk) {
-	var fildIdsToDelete []string
-	for _, chunk := range chunks {
-		if !chunk.IsChunkManifest {
-			fildIdsToDelete = append(fildIdsToDelete, chunk.GetFileIdString())
-			continue
-		}
-		dataChunks, manifestResolveErr := ResolveOneChunkManifest(f.MasterClient.LookupFileId, chunk)
-		if manifestResolveErr != nil {
-			glog.V(0).Infof("failed to resolve manifest %s: %v", chunk.FileId, manifestResolveErr)
-		}
-		for _, dChunk := range dataChunks {
-			fildIdsToDelete = append(fildIdsToDelete, dChunk.GetFileIdString())
-		}
-		fildIdsToDelete = append(fildIdsToDelete, chunk.GetFileIdString())
-	}
-
-	f.doDeleteFileIds(fildIdsToDelete)
+	f.doDeleteFileIds(f.dedupReleaseFileIds(f.resolveChunkFileIds(chunks)))
 }
 
 func (f *Filer) DeleteChunks(chunks []*filer_pb.FileChunk) {
+	for _, fileId := range f.dedupReleaseFileIds(f.resolveChunkFileIds(chunks)) {
+		f.fileIdDeletionQueue.EnQueue(fileId)
+	}
+}
+
+func (f *Filer) resolveChunkFileIds(chunks []*filer_pb.FileChunk) (fileIds []string) {
 	for _, chunk := range chunks {
 		if !chunk.IsChunkManifest {
-			f.fileIdDeletionQueue.EnQueue(chunk.GetFileIdString())
+			fileIds = append(fileIds, chunk.GetFileIdString())
 			continue
 		}
 		dataChunks, manifestResolveErr := ResolveOneChunkManifest(f.MasterClient.LookupFileId, chunk)
@@ -123,10 +113,11 @@ func (f *Filer) DeleteChunks(chunks []*filer_pb.FileChunk) {
 			glog.V(0).Infof("failed to resolve manifest %s: %v", chunk.FileId, manifestResolveErr)
 		}
 		for _, dChunk := range dataChunks {
-			f.fileIdDeletionQueue.EnQueue(dChunk.GetFileIdString())
+			fileIds = append(fileIds, dChunk.GetFileIdString())
 		}
-		f.fileIdDeletionQueue.EnQueue(chunk.GetFileIdString())
+		fileIds = append(fileIds, chunk.GetFileIdString())
 	}
+	return
 }
 
 func (f *Filer) deleteChunksIfNotNew(oldEntry, newEntry *Entry) {
@@ -0,0 +1,210 @@
+package filer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/stats"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// LeaseRevokeTimeout is how long a change waits for the lease holders to give up their leases.
+// Clients stop trusting their leases well before this, if they can not hear from the filer.
+const LeaseRevokeTimeout = 15 * time.Second
+
+// LeaseManager grants leases to connected clients, e.g., mounts, to cache file metadata and content.
+// Read leases are shared, and a write lease is exclusive.
+// A lease is revoked when the file is changed by another client, or a conflicting lease is requested,
+// and the change or the request waits until the holder gives up the lease, or the revoke times out.
+type LeaseManager struct {
+	leasesLock    sync.Mutex
+	leases        map[util.FullPath]map[string]*lease
+	clients       map[string]*leaseClient
+	revokeTimeout time.Duration
+}
+
+type lease struct {
+	isWrite  bool
+	revoking bool
+	// closed once the lease is given up
+	released chan struct{}
+}
+
+type leaseClient struct {
+	signature   int32
+	revocations chan util.FullPath
+}
+
+func NewLeaseManager(revokeTimeout time.Duration) *LeaseManager {
+	return &LeaseManager{
+		leases:        make(map[util.FullPath]map[string]*lease),
+		clients:       make(map[string]*leaseClient),
+		revokeTimeout: revokeTimeout,
+	}
+}
+
+// Connect registers a client, which receives the paths of its revoked leases from the returned channel
+func (lm *LeaseManager) Connect(clientId string, signature int32) <-chan util.FullPath {
+	lm.leasesLock.Lock()
+	defer lm.leasesLock.Unlock()
+
+	lm.disconnect(clientId)
+	client := &leaseClient{
+		signature:   signature,
+		revocations: make(chan util.FullPath, 1024),
+	}
+	lm.clients[clientId] = client
+	return client.revocations
+}
+
+// Disconnect releases all leases of the client
+func (lm *LeaseManager) Disconnect(clientId string) {
+	lm.leasesLock.Lock()
+	defer lm.leasesLock.Unlock()
+	lm.disconnect(clientId)
+}
+
+func (lm *LeaseManager) disconnect(clientId string) {
+	if _, found := lm.clients[clientId]; !found {
+		return
+	}
+	delete(lm.clients, clientId)
+	for path := range lm.leases {
+		lm.release(path, clientId)
+	}
+}
+
+func (lm *LeaseManager) release(path util.FullPath, clientId string) {
+	holders := lm.leases[path]
+	if l, found := holders[clientId]; found {
+		close(l.released)
+		delete(holders, clientId)
+	}
+	if len(holders) == 0 {
+		delete(lm.leases, path)
+	}
+}
+
+// Release gives up the lease, either voluntarily or as revoked
+func (lm *LeaseManager) Release(clientId string, path util.FullPath) {
+	lm.leasesLock.Lock()
+	defer lm.leasesLock.Unlock()
+	lm.release(path, clientId)
+}
+
+// Acquire grants the lease to a connected client, after revoking the conflicting leases of other clients.
+func (lm *LeaseManager) Acquire(ctx context.Context, path util.FullPath, clientId string, isWrite bool) error {
+
+	if err := lm.revokeAndWait(ctx, []util.FullPath{path}, func(holderId string, holder *leaseClient, l *lease) bool {
+		return holderId != clientId && (isWrite || l.isWrite)
+	}); err != nil {
+		return err
+	}
+
+	lm.leasesLock.Lock()
+	defer lm.leasesLock.Unlock()
+
+	if _, found := lm.clients[clientId]; !found {
+		return fmt.Errorf("lease client %s is not connected", clientId)
+	}
+	holders := lm.leases[path]
+	for holderId, l := range holders {
+		if holderId != clientId && (isWrite || l.isWrite) {
+			// a conflicting lease is granted meanwhile
+			return fmt.Errorf("lease %s is held by %s", path, holderId)
+		}
+	}
+	if holders == nil {
+		holders = make(map[string]*lease)
+		lm.leases[path] = holders
+	}
+	if existing, found := holders[clientId]; found {
+		existing.isWrite = existing.isWrite || isWrite
+		return nil
+	}
+	holders[clientId] = &lease{
+		isWrite:  isWrite,
+		released: make(chan struct{}),
+	}
+	return nil
+}
+
+// Revoke revokes the leases on the changed paths, except those held by the clients making the change.
+func (lm *LeaseManager) Revoke(ctx context.Context, paths []util.FullPath, signatures []int32) {
+	err := lm.revokeAndWait(ctx, paths, func(holderId string, holder *leaseClient, l *lease) bool {
+		for _, sig := range signatures {
+			if sig == holder.signature {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		glog.V(1).Infof("revoke leases %v: %v", paths, err)
+	}
+}
+
+func (lm *LeaseManager) revokeAndWait(ctx context.Context, paths []util.FullPath, shouldRevoke func(holderId string, holder *leaseClient, l *lease) bool) error {
+
+	type revoking struct {
+		path     util.FullPath
+		clientId string
+		released chan struct{}
+	}
+	var waits []revoking
+
+	lm.leasesLock.Lock()
+	for _, path := range paths {
+		for clientId, l := range lm.leases[path] {
+			client := lm.clients[clientId]
+			if client == nil || !shouldRevoke(clientId, client, l) {
+				continue
+			}
+			if !l.revoking {
+				select {
+				case client.revocations <- path:
+					l.revoking = true
+					stats.FilerLeaseRevocationCounter.WithLabelValues(leaseType(l.isWrite)).Inc()
+				default:
+					// the client is too slow, just drop its lease, which will expire on the client side
+					glog.Warningf("lease client %s is not responding, drop lease %s", clientId, path)
+					lm.release(path, clientId)
+					continue
+				}
+			}
+			waits = append(waits, revoking{path: path, clientId: clientId, released: l.released})
+		}
+	}
+	lm.leasesLock.Unlock()
+
+	if len(waits) == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(lm.revokeTimeout)
+	defer timer.Stop()
+	for _, w := range waits {
+		select {
+		case <-w.released:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			glog.Warningf("lease client %s does not release %s in %v", w.clientId, w.path, lm.revokeTimeout)
+			stats.FilerLeaseRevocationCounter.WithLabelValues("timeout").Inc()
+			lm.Release(w.clientId, w.path)
+			// the timer has fired, the remaining leases are dropped without waiting
+			timer.Reset(0)
+		}
+	}
+	return nil
+}
+
+func leaseType(isWrite bool) string {
+	if isWrite {
+		return "write"
+	}
+	return "read"
+}
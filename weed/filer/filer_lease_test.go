@@ -0,0 +1,60 @@
+package filer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestLeaseRevoke(t *testing.T) {
+	lm := NewLeaseManager(time.Second)
+	path := util.FullPath("/a/b.txt")
+
+	revocations := lm.Connect("reader", 1)
+	lm.Connect("writer", 2)
+
+	if err := lm.Acquire(context.Background(), path, "reader", false); err != nil {
+		t.Fatalf("acquire read lease: %v", err)
+	}
+
+	// the reader acknowledges the revocation
+	go func() {
+		revoked := <-revocations
+		lm.Release("reader", revoked)
+	}()
+	if err := lm.Acquire(context.Background(), path, "writer", true); err != nil {
+		t.Fatalf("acquire write lease: %v", err)
+	}
+	if _, found := lm.leases[path]["reader"]; found {
+		t.Fatalf("read lease should be revoked")
+	}
+
+	// changes by the lease holder do not revoke its own lease
+	lm.Revoke(context.Background(), []util.FullPath{path}, []int32{2})
+	if _, found := lm.leases[path]["writer"]; !found {
+		t.Fatalf("write lease should be kept")
+	}
+}
+
+func TestLeaseRevokeTimeout(t *testing.T) {
+	lm := NewLeaseManager(10 * time.Millisecond)
+	path := util.FullPath("/a/b.txt")
+
+	lm.Connect("reader", 1)
+	if err := lm.Acquire(context.Background(), path, "reader", false); err != nil {
+		t.Fatalf("acquire read lease: %v", err)
+	}
+
+	// the reader does not respond
+	lm.Revoke(context.Background(), []util.FullPath{path}, []int32{2})
+	if _, found := lm.leases[path]; found {
+		t.Fatalf("lease should be dropped after timeout")
+	}
+
+	lm.Disconnect("reader")
+	if err := lm.Acquire(context.Background(), path, "reader", false); err == nil {
+		t.Fatalf("disconnected client should not get a lease")
+	}
+}
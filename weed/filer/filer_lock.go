@@ -0,0 +1,217 @@
+package filer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// FileLock is an advisory lock on the byte range [Start, End] of a file,
+// held by an owner, e.g., a process or an open file, of a client, e.g., a mount.
+// flock() locks cover the whole file, and do not conflict with POSIX byte-range locks.
+type FileLock struct {
+	ClientId string
+	Owner    uint64
+	Pid      int32
+	Start    uint64
+	End      uint64
+	IsWrite  bool
+	IsFlock  bool
+}
+
+func (l *FileLock) sameOwner(other *FileLock) bool {
+	return l.ClientId == other.ClientId && l.Owner == other.Owner && l.IsFlock == other.IsFlock
+}
+
+func (l *FileLock) overlaps(other *FileLock) bool {
+	return l.Start <= other.End && other.Start <= l.End
+}
+
+func (l *FileLock) conflicts(other *FileLock) bool {
+	return l.IsFlock == other.IsFlock && !l.sameOwner(other) && l.overlaps(other) && (l.IsWrite || other.IsWrite)
+}
+
+// LockManager keeps the advisory locks in memory.
+// The locks of a client are kept while the client renews its lease,
+// and are all released when its lease expires or it disconnects.
+// Locks are keyed by the file path, and do not follow renamed files.
+type LockManager struct {
+	locksLock     sync.Mutex
+	locks         map[util.FullPath][]*FileLock
+	leases        map[string]time.Time
+	leaseDuration time.Duration
+	// closed and replaced whenever any lock is released, to wake up the waiters
+	released chan struct{}
+}
+
+func NewLockManager(leaseDuration time.Duration) *LockManager {
+	return &LockManager{
+		locks:         make(map[util.FullPath][]*FileLock),
+		leases:        make(map[string]time.Time),
+		leaseDuration: leaseDuration,
+		released:      make(chan struct{}),
+	}
+}
+
+// RenewLease extends the lease of all locks held by the client
+func (lm *LockManager) RenewLease(clientId string) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+	lm.leases[clientId] = time.Now().Add(lm.leaseDuration)
+}
+
+// ReleaseClient releases all locks held by the client
+func (lm *LockManager) ReleaseClient(clientId string) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+	lm.releaseClient(clientId)
+}
+
+func (lm *LockManager) releaseClient(clientId string) {
+	delete(lm.leases, clientId)
+	for path, locks := range lm.locks {
+		lm.setLocks(path, locks, func(l *FileLock) bool {
+			return l.ClientId != clientId
+		})
+	}
+}
+
+func (lm *LockManager) expireLeases() {
+	now := time.Now()
+	for clientId, expiration := range lm.leases {
+		if expiration.Before(now) {
+			lm.releaseClient(clientId)
+		}
+	}
+}
+
+// setLocks keeps the locks of the file passing the filter, and wakes up the waiters if any is released
+func (lm *LockManager) setLocks(path util.FullPath, locks []*FileLock, keep func(l *FileLock) bool) {
+	var kept []*FileLock
+	for _, l := range locks {
+		if keep(l) {
+			kept = append(kept, l)
+		}
+	}
+	if len(kept) == len(locks) {
+		return
+	}
+	if len(kept) == 0 {
+		delete(lm.locks, path)
+	} else {
+		lm.locks[path] = kept
+	}
+	close(lm.released)
+	lm.released = make(chan struct{})
+}
+
+func (lm *LockManager) findConflict(path util.FullPath, lock *FileLock) *FileLock {
+	for _, l := range lm.locks[path] {
+		if l.conflicts(lock) {
+			return l
+		}
+	}
+	return nil
+}
+
+// unlockRange removes the range of the lock from the locks of the same owner,
+// keeping the parts of partially covered locks outside of the range.
+func (lm *LockManager) unlockRange(path util.FullPath, lock *FileLock) {
+	var remaining []*FileLock
+	for _, l := range lm.locks[path] {
+		if !l.sameOwner(lock) || !l.overlaps(lock) {
+			continue
+		}
+		if l.Start < lock.Start {
+			head := *l
+			head.End = lock.Start - 1
+			remaining = append(remaining, &head)
+		}
+		if l.End > lock.End {
+			tail := *l
+			tail.Start = lock.End + 1
+			remaining = append(remaining, &tail)
+		}
+	}
+	lm.setLocks(path, lm.locks[path], func(l *FileLock) bool {
+		return !l.sameOwner(lock) || !l.overlaps(lock)
+	})
+	if len(remaining) > 0 {
+		lm.locks[path] = append(lm.locks[path], remaining...)
+	}
+}
+
+// TryLock acquires the lock, replacing the locks of the same owner in the range,
+// or returns the conflicting lock without waiting.
+func (lm *LockManager) TryLock(path util.FullPath, lock *FileLock) (conflict *FileLock) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+	if found, _ := lm.tryLock(path, lock); found != nil {
+		copied := *found
+		return &copied
+	}
+	return nil
+}
+
+func (lm *LockManager) tryLock(path util.FullPath, lock *FileLock) (conflict *FileLock, released chan struct{}) {
+	lm.expireLeases()
+	if conflict = lm.findConflict(path, lock); conflict != nil {
+		return conflict, lm.released
+	}
+	if _, found := lm.leases[lock.ClientId]; !found {
+		lm.leases[lock.ClientId] = time.Now().Add(lm.leaseDuration)
+	}
+	lm.unlockRange(path, lock)
+	acquired := *lock
+	lm.locks[path] = append(lm.locks[path], &acquired)
+	return nil, nil
+}
+
+// Lock acquires the lock, waiting for the conflicting locks to be released until the context is done.
+func (lm *LockManager) Lock(ctx context.Context, path util.FullPath, lock *FileLock) error {
+	for {
+		lm.locksLock.Lock()
+		conflict, released := lm.tryLock(path, lock)
+		lm.locksLock.Unlock()
+		if conflict == nil {
+			return nil
+		}
+		// also check again periodically, in case the conflicting lease expires
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-released:
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// Unlock releases the range of the lock held by the same owner
+func (lm *LockManager) Unlock(path util.FullPath, lock *FileLock) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+	lm.unlockRange(path, lock)
+}
+
+// UnlockOwner releases all locks of the same owner on the file, e.g., when a file is closed
+func (lm *LockManager) UnlockOwner(path util.FullPath, lock *FileLock) {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+	lm.setLocks(path, lm.locks[path], func(l *FileLock) bool {
+		return !l.sameOwner(lock)
+	})
+}
+
+// Query returns a lock conflicting with the given lock, or nil if the lock could be acquired
+func (lm *LockManager) Query(path util.FullPath, lock *FileLock) *FileLock {
+	lm.locksLock.Lock()
+	defer lm.locksLock.Unlock()
+	lm.expireLeases()
+	if conflict := lm.findConflict(path, lock); conflict != nil {
+		found := *conflict
+		return &found
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package filer
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestLockConflicts(t *testing.T) {
+	lm := NewLockManager(time.Minute)
+	path := util.FullPath("/data/app.db")
+
+	a := &FileLock{ClientId: "host1", Owner: 1, Start: 0, End: 99, IsWrite: true}
+	if conflict := lm.TryLock(path, a); conflict != nil {
+		t.Fatalf("unexpected conflict %+v", conflict)
+	}
+
+	// another mount can not lock the overlapping range
+	b := &FileLock{ClientId: "host2", Owner: 1, Start: 50, End: 149}
+	if conflict := lm.TryLock(path, b); conflict == nil || conflict.ClientId != "host1" {
+		t.Fatalf("expected conflict with host1, got %+v", conflict)
+	}
+	// but can lock the range after it
+	b.Start = 100
+	if conflict := lm.TryLock(path, b); conflict != nil {
+		t.Fatalf("unexpected conflict %+v", conflict)
+	}
+	// read locks are shared
+	c := &FileLock{ClientId: "host3", Owner: 1, Start: 120, End: 130}
+	if conflict := lm.TryLock(path, c); conflict != nil {
+		t.Fatalf("read locks should not conflict: %+v", conflict)
+	}
+	// flock locks are independent of POSIX locks
+	flock := &FileLock{ClientId: "host2", Owner: 7, Start: 0, End: math.MaxUint64, IsWrite: true, IsFlock: true}
+	if conflict := lm.TryLock(path, flock); conflict != nil {
+		t.Fatalf("flock should not conflict with POSIX locks: %+v", conflict)
+	}
+
+	// unlocking the middle of a lock keeps both ends
+	lm.Unlock(path, &FileLock{ClientId: "host1", Owner: 1, Start: 40, End: 59})
+	if conflict := lm.Query(path, &FileLock{ClientId: "host2", Owner: 2, Start: 40, End: 59, IsWrite: true}); conflict != nil {
+		t.Errorf("range should be unlocked, conflicts with %+v", conflict)
+	}
+	if conflict := lm.Query(path, &FileLock{ClientId: "host2", Owner: 2, Start: 60, End: 60}); conflict == nil || conflict.Start != 60 || conflict.End != 99 {
+		t.Errorf("expected the tail [60,99] locked, got %+v", conflict)
+	}
+	if conflict := lm.Query(path, &FileLock{ClientId: "host2", Owner: 2, Start: 39, End: 39}); conflict == nil || conflict.Start != 0 || conflict.End != 39 {
+		t.Errorf("expected the head [0,39] locked, got %+v", conflict)
+	}
+
+	// disconnecting releases all locks of the client
+	lm.ReleaseClient("host1")
+	if conflict := lm.Query(path, &FileLock{ClientId: "host2", Owner: 2, Start: 0, End: 99, IsWrite: true}); conflict != nil {
+		t.Errorf("locks of host1 should be released, conflicts with %+v", conflict)
+	}
+}
+
+func TestLockWait(t *testing.T) {
+	lm := NewLockManager(time.Minute)
+	path := util.FullPath("/data/app.db")
+
+	held := &FileLock{ClientId: "host1", Owner: 1, Start: 0, End: math.MaxUint64, IsWrite: true}
+	lm.TryLock(path, held)
+
+	// waiting is interrupted when the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	waiter := &FileLock{ClientId: "host2", Owner: 1, Start: 0, End: 10, IsWrite: true}
+	if err := lm.Lock(ctx, path, waiter); err == nil {
+		t.Fatalf("expected the wait to be interrupted")
+	}
+
+	// the waiter gets the lock once it is released
+	acquired := make(chan error)
+	go func() {
+		acquired <- lm.Lock(context.Background(), path, waiter)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	lm.UnlockOwner(path, held)
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Fatalf("lock wait: %v", err)
+		}
+	case <-time.After(time.Second / 2):
+		t.Fatalf("waiter is not woken up")
+	}
+
+	// expired leases release the locks
+	lm = NewLockManager(time.Millisecond)
+	lm.TryLock(path, held)
+	time.Sleep(5 * time.Millisecond)
+	if conflict := lm.TryLock(path, waiter); conflict != nil {
+		t.Errorf("expired lock should be released, conflicts with %+v", conflict)
+	}
+}
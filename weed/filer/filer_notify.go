@@ -63,6 +63,19 @@ func (f *Filer) NotifyUpdateEvent(ctx context.Context, oldEntry, newEntry *Entry
 
 	f.logMetaEvent(ctx, fullpath, eventNotification)
 
+	f.updateQuotaUsage(oldEntry, newEntry)
+
+	if f.LeaseManager != nil {
+		var changedPaths []util.FullPath
+		if oldEntry != nil {
+			changedPaths = append(changedPaths, oldEntry.FullPath)
+		}
+		if newEntry != nil && (oldEntry == nil || newEntry.FullPath != oldEntry.FullPath) {
+			changedPaths = append(changedPaths, newEntry.FullPath)
+		}
+		f.LeaseManager.Revoke(ctx, changedPaths, signatures)
+	}
+
 }
 
 func (f *Filer) logMetaEvent(ctx context.Context, fullpath string, eventNotification *filer_pb.EventNotification) {
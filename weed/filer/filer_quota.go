@@ -0,0 +1,488 @@
+package filer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// Directory quotas are kept in the filer store kv, with the usage maintained incrementally by the filers:
+//   quota.directories => json of all directory quotas and their usage
+// Each filer counts its own changes, and adds them to the saved usage under a cluster wide lock.
+const (
+	QuotaKey             = "quota.directories"
+	quotaSaveInterval    = 3 * time.Second
+	quotaRecountCheck    = 10 * time.Minute
+	quotaLockName        = "filer.quota"
+	quotaRecountLockName = "filer.quota.recount"
+)
+
+// QuotaRecountInterval is how often the usage is recounted by walking through the quota directory,
+// correcting what the incremental counting missed, e.g., changes while a filer crashed.
+var QuotaRecountInterval = 24 * time.Hour
+
+// DirectoryQuota limits the bytes and inodes of all entries under the directory, not including the directory itself.
+// Quotas can be nested, and a change must fit in all quotas covering it.
+type DirectoryQuota struct {
+	Directory   util.FullPath `json:"directory"`
+	MaxBytes    int64         `json:"maxBytes"`
+	MaxInodes   int64         `json:"maxInodes"`
+	UsedBytes   int64         `json:"usedBytes"`
+	UsedInodes  int64         `json:"usedInodes"`
+	CountedAtNs int64         `json:"countedAtNs,omitempty"`
+}
+
+type quotaUsage struct {
+	bytes  int64
+	inodes int64
+}
+
+type filerQuotas struct {
+	sync.Mutex
+	// the saved quotas, with the pending changes applied
+	quotas map[util.FullPath]*DirectoryQuota
+	// usage changes counted by this filer and not saved yet
+	pending map[util.FullPath]*quotaUsage
+}
+
+func (q *DirectoryQuota) ToPb() *filer_pb.DirectoryQuota {
+	if q == nil {
+		return nil
+	}
+	return &filer_pb.DirectoryQuota{
+		Directory:  string(q.Directory),
+		MaxBytes:   q.MaxBytes,
+		MaxInodes:  q.MaxInodes,
+		UsedBytes:  q.UsedBytes,
+		UsedInodes: q.UsedInodes,
+	}
+}
+
+func (q *DirectoryQuota) covers(fullpath util.FullPath) bool {
+	return q.Directory == "/" && fullpath != "/" || strings.HasPrefix(string(fullpath), string(q.Directory)+"/")
+}
+
+func (q *DirectoryQuota) exceeded(deltaBytes, deltaInodes int64) bool {
+	return deltaBytes > 0 && q.MaxBytes > 0 && q.UsedBytes+deltaBytes > q.MaxBytes ||
+		deltaInodes > 0 && q.MaxInodes > 0 && q.UsedInodes+deltaInodes > q.MaxInodes
+}
+
+// entryUsage counts the file size as bytes, and each entry as one inode
+func entryUsage(entry *Entry) (bytes, inodes int64) {
+	if entry == nil {
+		return 0, 0
+	}
+	if !entry.IsDirectory() {
+		bytes = int64(maxUint64(TotalSize(entry.Chunks), entry.FileSize))
+	}
+	return bytes, 1
+}
+
+func (f *Filer) loadQuotas() {
+	quotas, err := f.readSavedQuotas(context.Background())
+	if err != nil {
+		glog.Fatalf("read %s: %v", QuotaKey, err)
+	}
+	f.quotas.quotas = quotas
+	glog.V(0).Infof("loaded %d directory quotas", len(quotas))
+}
+
+func (f *Filer) readSavedQuotas(ctx context.Context) (map[util.FullPath]*DirectoryQuota, error) {
+	saved := make(map[util.FullPath]*DirectoryQuota)
+	data, err := f.Store.KvGet(ctx, []byte(QuotaKey))
+	if err == ErrKvNotFound || err == nil && len(data) == 0 {
+		return saved, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var quotas []*DirectoryQuota
+	if err = json.Unmarshal(data, &quotas); err != nil {
+		return nil, fmt.Errorf("parse %s: %v", QuotaKey, err)
+	}
+	for _, q := range quotas {
+		saved[q.Directory] = q
+	}
+	return saved, nil
+}
+
+// updateSavedQuotas changes the saved quotas under the cluster wide lock,
+// and reloads them with the pending changes of this filer applied
+func (f *Filer) updateSavedQuotas(ctx context.Context, fn func(saved map[util.FullPath]*DirectoryQuota) error) error {
+	return f.withClusterLock(ctx, quotaLockName, func() error {
+		saved, err := f.readSavedQuotas(ctx)
+		if err != nil {
+			return err
+		}
+		if err = fn(saved); err != nil {
+			return err
+		}
+		var quotas []*DirectoryQuota
+		for _, q := range saved {
+			quotas = append(quotas, q)
+		}
+		sort.Slice(quotas, func(i, j int) bool {
+			return quotas[i].Directory < quotas[j].Directory
+		})
+		data, err := json.Marshal(quotas)
+		if err != nil {
+			return err
+		}
+		if err = f.Store.KvPut(ctx, []byte(QuotaKey), data); err != nil {
+			return err
+		}
+		f.setQuotas(saved)
+		return nil
+	})
+}
+
+// setQuotas replaces the quotas with the saved ones, keeping the pending changes of this filer
+func (f *Filer) setQuotas(saved map[util.FullPath]*DirectoryQuota) {
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+	for dir, usage := range f.quotas.pending {
+		q, found := saved[dir]
+		if !found {
+			delete(f.quotas.pending, dir)
+			continue
+		}
+		q.UsedBytes += usage.bytes
+		q.UsedInodes += usage.inodes
+	}
+	f.quotas.quotas = saved
+}
+
+func (f *Filer) loopSavingQuotas() {
+	for {
+		time.Sleep(quotaSaveInterval)
+		if err := f.saveQuotas(); err != nil {
+			glog.Errorf("save directory quotas: %v", err)
+		}
+	}
+}
+
+// saveQuotas adds the pending changes of this filer to the saved usage,
+// and reloads the quotas and usage changed by other filers
+func (f *Filer) saveQuotas() error {
+	ctx := context.Background()
+
+	f.quotas.Lock()
+	pending := f.quotas.pending
+	f.quotas.pending = nil
+	f.quotas.Unlock()
+
+	if len(pending) == 0 {
+		saved, err := f.readSavedQuotas(ctx)
+		if err != nil {
+			return err
+		}
+		f.setQuotas(saved)
+		return nil
+	}
+
+	err := f.updateSavedQuotas(ctx, func(saved map[util.FullPath]*DirectoryQuota) error {
+		for dir, usage := range pending {
+			if q, found := saved[dir]; found {
+				q.UsedBytes += usage.bytes
+				q.UsedInodes += usage.inodes
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		// keep the changes for the next save
+		f.quotas.Lock()
+		for dir, usage := range pending {
+			f.addPendingUsage(dir, usage.bytes, usage.inodes)
+		}
+		f.quotas.Unlock()
+	}
+	return err
+}
+
+func (f *Filer) listQuotas() (quotas []*DirectoryQuota) {
+	for _, q := range f.quotas.quotas {
+		t := *q
+		quotas = append(quotas, &t)
+	}
+	sort.Slice(quotas, func(i, j int) bool {
+		return quotas[i].Directory < quotas[j].Directory
+	})
+	return
+}
+
+// ListQuotas returns a copy of all directory quotas
+func (f *Filer) ListQuotas() []*DirectoryQuota {
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+	return f.listQuotas()
+}
+
+// FindQuota returns a copy of the closest quota covering the directory, or nil
+func (f *Filer) FindQuota(dir util.FullPath) *DirectoryQuota {
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+
+	var closest *DirectoryQuota
+	for _, q := range f.quotas.quotas {
+		if q.Directory != dir && !q.covers(dir) {
+			continue
+		}
+		if closest == nil || len(q.Directory) > len(closest.Directory) {
+			closest = q
+		}
+	}
+	if closest == nil {
+		return nil
+	}
+	t := *closest
+	return &t
+}
+
+// SetQuota sets the limits on the directory. The usage of a new quota is counted by walking through the directory.
+func (f *Filer) SetQuota(ctx context.Context, dir util.FullPath, maxBytes, maxInodes int64) (*DirectoryQuota, error) {
+
+	entry, err := f.FindEntry(ctx, dir)
+	if err != nil {
+		return nil, fmt.Errorf("find %s: %v", dir, err)
+	}
+	if !entry.IsDirectory() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	f.quotas.Lock()
+	_, found := f.quotas.quotas[dir]
+	f.quotas.Unlock()
+
+	// changes during the walk may be missed, until the usage is recounted
+	var usedBytes, usedInodes, countedAtNs int64
+	if !found {
+		countedAtNs = time.Now().UnixNano()
+		if usedBytes, usedInodes, err = f.countUsage(ctx, dir); err != nil {
+			return nil, fmt.Errorf("count usage of %s: %v", dir, err)
+		}
+	}
+
+	err = f.updateSavedQuotas(ctx, func(saved map[util.FullPath]*DirectoryQuota) error {
+		if existing, found := saved[dir]; found {
+			existing.MaxBytes, existing.MaxInodes = maxBytes, maxInodes
+			return nil
+		}
+		if countedAtNs == 0 {
+			// removed by another filer meanwhile
+			countedAtNs = time.Now().UnixNano()
+			if usedBytes, usedInodes, err = f.countUsage(ctx, dir); err != nil {
+				return fmt.Errorf("count usage of %s: %v", dir, err)
+			}
+		}
+		saved[dir] = &DirectoryQuota{
+			Directory:   dir,
+			MaxBytes:    maxBytes,
+			MaxInodes:   maxInodes,
+			UsedBytes:   usedBytes,
+			UsedInodes:  usedInodes,
+			CountedAtNs: countedAtNs,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+	t := *f.quotas.quotas[dir]
+	return &t, nil
+}
+
+func (f *Filer) RemoveQuota(dir util.FullPath) error {
+	return f.updateSavedQuotas(context.Background(), func(saved map[util.FullPath]*DirectoryQuota) error {
+		if _, found := saved[dir]; !found {
+			return fmt.Errorf("no quota on %s", dir)
+		}
+		delete(saved, dir)
+		return nil
+	})
+}
+
+func (f *Filer) loopRecountingQuotas() {
+	for {
+		time.Sleep(quotaRecountCheck)
+		if err := f.recountQuotas(context.Background()); err != nil {
+			glog.Errorf("recount directory quotas: %v", err)
+		}
+	}
+}
+
+// recountQuotas walks through the quota directories not counted for QuotaRecountInterval, and saves the counted usage.
+// Only one filer recounts at a time. Changes during the walk may be miscounted, until the next recount.
+func (f *Filer) recountQuotas(ctx context.Context) error {
+	if len(f.quotasToRecount()) == 0 {
+		return nil
+	}
+	return f.withClusterLock(ctx, quotaRecountLockName, func() error {
+		// another filer may have just recounted
+		if err := f.saveQuotas(); err != nil {
+			return err
+		}
+		for _, dir := range f.quotasToRecount() {
+			countedAtNs := time.Now().UnixNano()
+			usedBytes, usedInodes, err := f.countUsage(ctx, dir)
+			if err != nil {
+				return fmt.Errorf("count usage of %s: %v", dir, err)
+			}
+			err = f.updateSavedQuotas(ctx, func(saved map[util.FullPath]*DirectoryQuota) error {
+				if q, found := saved[dir]; found {
+					q.UsedBytes, q.UsedInodes, q.CountedAtNs = usedBytes, usedInodes, countedAtNs
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			glog.V(0).Infof("recounted quota on %s: %d bytes %d inodes", dir, usedBytes, usedInodes)
+		}
+		return nil
+	})
+}
+
+func (f *Filer) quotasToRecount() (dirs []util.FullPath) {
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+	for _, q := range f.quotas.quotas {
+		if time.Unix(0, q.CountedAtNs).Add(QuotaRecountInterval).Before(time.Now()) {
+			dirs = append(dirs, q.Directory)
+		}
+	}
+	return
+}
+
+// countUsage walks through the directory, counting all entries under it
+func (f *Filer) countUsage(ctx context.Context, dir util.FullPath) (bytes, inodes int64, err error) {
+	lastFileName := ""
+	for {
+		entries, hasMore, listErr := f.ListDirectoryEntries(ctx, dir, lastFileName, false, PaginationSize, "", "")
+		if listErr != nil {
+			return 0, 0, listErr
+		}
+		for _, entry := range entries {
+			lastFileName = entry.Name()
+			b, i := entryUsage(entry)
+			bytes, inodes = bytes+b, inodes+i
+			if entry.IsDirectory() {
+				b, i, err = f.countUsage(ctx, entry.FullPath)
+				if err != nil {
+					return 0, 0, err
+				}
+				bytes, inodes = bytes+b, inodes+i
+			}
+		}
+		if !hasMore {
+			break
+		}
+	}
+	return
+}
+
+// CheckQuota returns an error if creating or updating the entry exceeds any quota covering it
+func (f *Filer) CheckQuota(ctx context.Context, entry *Entry) error {
+	if !f.hasQuotaCovering(entry.FullPath) {
+		return nil
+	}
+	oldEntry, _ := f.FindEntry(ctx, entry.FullPath)
+	return f.CheckQuotaChange(oldEntry, entry)
+}
+
+// CheckQuotaChange returns an error if changing the old entry to the new entry at the same path exceeds any quota
+func (f *Filer) CheckQuotaChange(oldEntry, newEntry *Entry) error {
+	oldBytes, oldInodes := entryUsage(oldEntry)
+	newBytes, newInodes := entryUsage(newEntry)
+	return f.checkQuota(newEntry.FullPath, "", newBytes-oldBytes, newInodes-oldInodes)
+}
+
+// CheckQuotaForMove returns an error if moving the entry to the new path exceeds any quota
+// covering the new path but not the old path
+func (f *Filer) CheckQuotaForMove(ctx context.Context, entry *Entry, newPath util.FullPath) error {
+	if !f.hasQuotaCovering(newPath) {
+		return nil
+	}
+	bytes, inodes := entryUsage(entry)
+	if entry.IsDirectory() {
+		b, i, err := f.countUsage(ctx, entry.FullPath)
+		if err != nil {
+			return err
+		}
+		bytes, inodes = bytes+b, inodes+i
+	}
+	return f.checkQuota(newPath, entry.FullPath, bytes, inodes)
+}
+
+func (f *Filer) hasQuotaCovering(fullpath util.FullPath) bool {
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+	for _, q := range f.quotas.quotas {
+		if q.covers(fullpath) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Filer) checkQuota(fullpath util.FullPath, exceptPath util.FullPath, deltaBytes, deltaInodes int64) error {
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+	for _, q := range f.quotas.quotas {
+		if !q.covers(fullpath) || exceptPath != "" && q.covers(exceptPath) {
+			continue
+		}
+		if q.exceeded(deltaBytes, deltaInodes) {
+			return fmt.Errorf("%v: %s has %d/%d bytes and %d/%d inodes", filer_pb.ErrQuotaExceeded, q.Directory, q.UsedBytes, q.MaxBytes, q.UsedInodes, q.MaxInodes)
+		}
+	}
+	return nil
+}
+
+// updateQuotaUsage counts the change in all quotas covering the old or new entry
+func (f *Filer) updateQuotaUsage(oldEntry, newEntry *Entry) {
+	f.quotas.Lock()
+	defer f.quotas.Unlock()
+
+	if len(f.quotas.quotas) == 0 {
+		return
+	}
+	oldBytes, oldInodes := entryUsage(oldEntry)
+	newBytes, newInodes := entryUsage(newEntry)
+	for _, q := range f.quotas.quotas {
+		if oldEntry != nil && q.covers(oldEntry.FullPath) {
+			q.UsedBytes -= oldBytes
+			q.UsedInodes -= oldInodes
+			f.addPendingUsage(q.Directory, -oldBytes, -oldInodes)
+		}
+		if newEntry != nil && q.covers(newEntry.FullPath) {
+			q.UsedBytes += newBytes
+			q.UsedInodes += newInodes
+			f.addPendingUsage(q.Directory, newBytes, newInodes)
+		}
+	}
+}
+
+func (f *Filer) addPendingUsage(dir util.FullPath, bytes, inodes int64) {
+	if f.quotas.pending == nil {
+		f.quotas.pending = make(map[util.FullPath]*quotaUsage)
+	}
+	usage, found := f.quotas.pending[dir]
+	if !found {
+		usage = &quotaUsage{}
+		f.quotas.pending[dir] = usage
+	}
+	usage.bytes += bytes
+	usage.inodes += inodes
+}
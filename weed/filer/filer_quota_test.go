@@ -0,0 +1,135 @@
+package filer
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestDirectoryQuotaUsage(t *testing.T) {
+	f := &Filer{}
+	f.quotas.quotas = map[util.FullPath]*DirectoryQuota{
+		"/home":       {Directory: "/home", MaxBytes: 1000},
+		"/home/alice": {Directory: "/home/alice", MaxBytes: 100, MaxInodes: 2},
+		"/home/bob":   {Directory: "/home/bob"},
+	}
+
+	newFile := func(path string, size uint64) *Entry {
+		return &Entry{FullPath: util.FullPath(path), Attr: Attr{FileSize: size}}
+	}
+
+	f.updateQuotaUsage(nil, &Entry{FullPath: "/home/alice/docs", Attr: Attr{Mode: os.ModeDir}})
+	f.updateQuotaUsage(nil, newFile("/home/alice/docs/a.txt", 60))
+	if q := f.FindQuota("/home/alice/docs"); q.Directory != "/home/alice" || q.UsedBytes != 60 || q.UsedInodes != 2 {
+		t.Fatalf("unexpected quota %+v", q)
+	}
+	if q := f.FindQuota("/home"); q.UsedBytes != 60 || q.UsedInodes != 2 {
+		t.Fatalf("unexpected parent quota %+v", q)
+	}
+
+	// exceeds the inodes of /home/alice
+	if err := f.CheckQuotaChange(nil, newFile("/home/alice/b.txt", 10)); !filer_pb.IsQuotaExceeded(err) {
+		t.Fatalf("expected quota exceeded, got %v", err)
+	}
+	// exceeds the bytes of /home/alice
+	if err := f.CheckQuotaChange(newFile("/home/alice/docs/a.txt", 60), newFile("/home/alice/docs/a.txt", 120)); !filer_pb.IsQuotaExceeded(err) {
+		t.Fatalf("expected quota exceeded, got %v", err)
+	}
+	if err := f.CheckQuotaChange(newFile("/home/alice/docs/a.txt", 60), newFile("/home/alice/docs/a.txt", 30)); err != nil {
+		t.Fatalf("shrinking should be allowed: %v", err)
+	}
+	// moving within /home only counts against /home/bob
+	if err := f.checkQuota("/home/bob/a.txt", "/home/alice/docs/a.txt", 60, 1); err != nil {
+		t.Fatalf("move should be allowed: %v", err)
+	}
+
+	// rename across quota roots
+	f.updateQuotaUsage(newFile("/home/alice/docs/a.txt", 60), newFile("/home/bob/a.txt", 60))
+	alice, bob, home := f.FindQuota("/home/alice"), f.FindQuota("/home/bob"), f.FindQuota("/home")
+	if alice.UsedBytes != 0 || alice.UsedInodes != 1 || bob.UsedBytes != 60 || home.UsedBytes != 60 || home.UsedInodes != 2 {
+		t.Fatalf("unexpected quotas after rename: %+v %+v %+v", alice, bob, home)
+	}
+
+	if q := f.FindQuota("/tmp"); q != nil {
+		t.Fatalf("unexpected quota %+v", q)
+	}
+}
+
+func TestDirectoryQuotaUsageFromSeveralFilers(t *testing.T) {
+	store := NewFilerStoreWrapper(&memoryKvStore{kv: make(map[string][]byte)})
+	f1, f2 := &Filer{Store: store}, &Filer{Store: store}
+	f1.loadQuotas()
+	f2.loadQuotas()
+	ctx := context.Background()
+
+	err := f1.updateSavedQuotas(ctx, func(saved map[util.FullPath]*DirectoryQuota) error {
+		saved["/home/alice"] = &DirectoryQuota{Directory: "/home/alice", MaxBytes: 100, UsedBytes: 10, UsedInodes: 1}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("set quota: %v", err)
+	}
+
+	// the quota set on one filer is loaded by the other
+	if err = f2.saveQuotas(); err != nil {
+		t.Fatalf("reload quotas: %v", err)
+	}
+	if q := f2.FindQuota("/home/alice"); q == nil || q.MaxBytes != 100 {
+		t.Fatalf("quota not loaded: %+v", q)
+	}
+
+	newFile := func(path string, size uint64) *Entry {
+		return &Entry{FullPath: util.FullPath(path), Attr: Attr{FileSize: size}}
+	}
+	f1.updateQuotaUsage(nil, newFile("/home/alice/a.txt", 30))
+	f2.updateQuotaUsage(nil, newFile("/home/alice/b.txt", 40))
+	f2.updateQuotaUsage(newFile("/home/alice/b.txt", 40), nil)
+	f2.updateQuotaUsage(nil, newFile("/home/alice/c.txt", 20))
+
+	// the changes of both filers are counted, in any save order
+	for _, f := range []*Filer{f2, f1, f2} {
+		if err = f.saveQuotas(); err != nil {
+			t.Fatalf("save quotas: %v", err)
+		}
+	}
+	for _, f := range []*Filer{f1, f2} {
+		if q := f.FindQuota("/home/alice"); q.UsedBytes != 60 || q.UsedInodes != 3 {
+			t.Fatalf("unexpected usage %+v", q)
+		}
+	}
+
+	// a change not saved yet is kept when reloading
+	f1.updateQuotaUsage(nil, newFile("/home/alice/d.txt", 30))
+	if err = f2.RemoveQuota("/home/alice/"); err == nil {
+		t.Fatalf("removed a missing quota")
+	}
+	f1.setQuotas(mustReadSavedQuotas(t, f1))
+	if q := f1.FindQuota("/home/alice"); q.UsedBytes != 90 {
+		t.Fatalf("lost the pending change: %+v", q)
+	}
+	if err = f1.CheckQuotaChange(nil, newFile("/home/alice/e.txt", 20)); !filer_pb.IsQuotaExceeded(err) {
+		t.Fatalf("expected quota exceeded, got %v", err)
+	}
+
+	// the quota removed on one filer is removed on the other
+	if err = f2.RemoveQuota("/home/alice"); err != nil {
+		t.Fatalf("remove quota: %v", err)
+	}
+	if err = f1.saveQuotas(); err != nil {
+		t.Fatalf("save quotas: %v", err)
+	}
+	if q := f1.FindQuota("/home/alice"); q != nil {
+		t.Fatalf("quota not removed: %+v", q)
+	}
+}
+
+func mustReadSavedQuotas(t *testing.T, f *Filer) map[util.FullPath]*DirectoryQuota {
+	saved, err := f.readSavedQuotas(context.Background())
+	if err != nil {
+		t.Fatalf("read quotas: %v", err)
+	}
+	return saved
+}
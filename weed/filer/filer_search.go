@@ -1,10 +1,13 @@
 package filer
 
 import (
+	"bytes"
 	"context"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
 	"github.com/chrislusf/seaweedfs/weed/util"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 func splitPattern(pattern string) (prefix string, restPattern string) {
@@ -88,3 +91,165 @@ func (f *Filer) doListValidEntries(ctx context.Context, p util.FullPath, startFi
 	}
 	return
 }
+
+// SearchFilter selects entries during a recursive metadata search.
+// Zero values mean no restriction.
+type SearchFilter struct {
+	NamePattern        string
+	MinSize            uint64
+	MaxSize            uint64
+	MtimeAfter         time.Time
+	MtimeBefore        time.Time
+	MimePrefix         string
+	Uids               []uint32
+	Gids               []uint32
+	Extended           map[string][]byte
+	IncludeDirectories bool
+	DirectoriesOnly    bool
+	MaxDepth           int
+}
+
+func NewSearchFilter(req *filer_pb.SearchEntriesRequest) *SearchFilter {
+	filter := &SearchFilter{
+		NamePattern:        req.NamePattern,
+		MinSize:            req.MinSize,
+		MaxSize:            req.MaxSize,
+		MimePrefix:         req.MimePrefix,
+		Uids:               req.Uids,
+		Gids:               req.Gids,
+		Extended:           req.Extended,
+		IncludeDirectories: req.IncludeDirectories || req.DirectoriesOnly,
+		DirectoriesOnly:    req.DirectoriesOnly,
+		MaxDepth:           int(req.MaxDepth),
+	}
+	if req.MtimeAfter > 0 {
+		filter.MtimeAfter = time.Unix(req.MtimeAfter, 0)
+	}
+	if req.MtimeBefore > 0 {
+		filter.MtimeBefore = time.Unix(req.MtimeBefore, 0)
+	}
+	return filter
+}
+
+func (filter *SearchFilter) Match(entry *Entry) bool {
+	if entry.IsDirectory() && !filter.IncludeDirectories {
+		return false
+	}
+	if !entry.IsDirectory() && filter.DirectoriesOnly {
+		return false
+	}
+	if filter.NamePattern != "" {
+		if matched, matchErr := filepath.Match(filter.NamePattern, entry.Name()); matchErr != nil || !matched {
+			return false
+		}
+	}
+	size := entry.Size()
+	if size < filter.MinSize {
+		return false
+	}
+	if filter.MaxSize > 0 && size > filter.MaxSize {
+		return false
+	}
+	if !filter.MtimeAfter.IsZero() && entry.Mtime.Before(filter.MtimeAfter) {
+		return false
+	}
+	if !filter.MtimeBefore.IsZero() && entry.Mtime.After(filter.MtimeBefore) {
+		return false
+	}
+	if filter.MimePrefix != "" && !strings.HasPrefix(entry.Mime, filter.MimePrefix) {
+		return false
+	}
+	if len(filter.Uids) > 0 && !containsUint32(filter.Uids, entry.Uid) {
+		return false
+	}
+	if len(filter.Gids) > 0 && !containsUint32(filter.Gids, entry.Gid) {
+		return false
+	}
+	for key, value := range filter.Extended {
+		existing, found := entry.Extended[key]
+		if !found {
+			return false
+		}
+		if len(value) > 0 && !bytes.Equal(existing, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// withinDepth checks whether the entry is at most MaxDepth levels below the searched directory.
+func (filter *SearchFilter) withinDepth(dir util.FullPath, entry *Entry) bool {
+	if filter.MaxDepth <= 0 {
+		return true
+	}
+	entryDepth := len(entry.FullPath.Split())
+	if dir != "/" {
+		entryDepth -= len(dir.Split())
+	}
+	return entryDepth <= filter.MaxDepth
+}
+
+// SearchEntries visits all entries under the directory that match the filter.
+// The search is pushed down to the filer store if it supports it, otherwise the directory tree is walked.
+func (f *Filer) SearchEntries(ctx context.Context, p util.FullPath, filter *SearchFilter, eachEntryFunc ListEachEntryFunc) (err error) {
+	if strings.HasSuffix(string(p), "/") && len(p) > 1 {
+		p = p[0 : len(p)-1]
+	}
+
+	err = f.Store.SearchEntries(ctx, p, filter, func(entry *Entry) bool {
+		// stores may evaluate only part of the filter
+		if !filter.Match(entry) || !filter.withinDepth(p, entry) {
+			return true
+		}
+		return eachEntryFunc(entry)
+	})
+	if err != ErrUnsupportedSearch {
+		return err
+	}
+
+	_, err = f.doSearchEntries(ctx, p, 1, filter, eachEntryFunc)
+	return err
+}
+
+func (f *Filer) doSearchEntries(ctx context.Context, p util.FullPath, depth int, filter *SearchFilter, eachEntryFunc ListEachEntryFunc) (shouldContinue bool, err error) {
+
+	var subDirs []util.FullPath
+	lastFileName := ""
+	for {
+		var count int
+		shouldContinue = true
+		lastFileName, err = f.StreamListDirectoryEntries(ctx, p, lastFileName, false, PaginationSize, "", "", func(entry *Entry) bool {
+			count++
+			if entry.IsDirectory() && (filter.MaxDepth <= 0 || depth < filter.MaxDepth) {
+				subDirs = append(subDirs, entry.FullPath)
+			}
+			if filter.Match(entry) {
+				shouldContinue = eachEntryFunc(entry)
+			}
+			return shouldContinue
+		})
+		if err != nil || !shouldContinue {
+			return
+		}
+		if count < PaginationSize {
+			break
+		}
+	}
+
+	for _, subDir := range subDirs {
+		if shouldContinue, err = f.doSearchEntries(ctx, subDir, depth+1, filter, eachEntryFunc); err != nil || !shouldContinue {
+			return
+		}
+	}
+
+	return true, nil
+}
+
+func containsUint32(list []uint32, x uint32) bool {
+	for _, v := range list {
+		if v == x {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package filer
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestSearchFilterMatch(t *testing.T) {
+	now := time.Now()
+	entry := &Entry{
+		FullPath: "/home/chris/photos/cat.jpg",
+		Attr: Attr{
+			Mtime:    now.Add(-time.Hour),
+			Mode:     0644,
+			Uid:      1000,
+			Gid:      100,
+			Mime:     "image/jpeg",
+			FileSize: 2048,
+		},
+		Extended: map[string][]byte{
+			"X-Amz-Tagging-project": []byte("ml"),
+		},
+	}
+
+	tests := []struct {
+		filter   *SearchFilter
+		expected bool
+	}{
+		{&SearchFilter{}, true},
+		{&SearchFilter{NamePattern: "*.jpg"}, true},
+		{&SearchFilter{NamePattern: "*.png"}, false},
+		{&SearchFilter{MinSize: 1024, MaxSize: 4096}, true},
+		{&SearchFilter{MinSize: 4096}, false},
+		{&SearchFilter{MaxSize: 1024}, false},
+		{&SearchFilter{MtimeAfter: now.Add(-2 * time.Hour)}, true},
+		{&SearchFilter{MtimeAfter: now.Add(-time.Minute)}, false},
+		{&SearchFilter{MtimeBefore: now.Add(-2 * time.Hour)}, false},
+		{&SearchFilter{MimePrefix: "image/"}, true},
+		{&SearchFilter{MimePrefix: "video/"}, false},
+		{&SearchFilter{Uids: []uint32{0, 1000}}, true},
+		{&SearchFilter{Uids: []uint32{0}}, false},
+		{&SearchFilter{Gids: []uint32{100}}, true},
+		{&SearchFilter{Extended: map[string][]byte{"X-Amz-Tagging-project": nil}}, true},
+		{&SearchFilter{Extended: map[string][]byte{"X-Amz-Tagging-project": []byte("ml")}}, true},
+		{&SearchFilter{Extended: map[string][]byte{"X-Amz-Tagging-project": []byte("web")}}, false},
+		{&SearchFilter{Extended: map[string][]byte{"X-Amz-Tagging-team": nil}}, false},
+	}
+
+	for i, test := range tests {
+		if actual := test.filter.Match(entry); actual != test.expected {
+			t.Errorf("case %d %+v: expected %v, actual %v", i, test.filter, test.expected, actual)
+		}
+	}
+
+	dirEntry := &Entry{FullPath: "/home/chris/photos", Attr: Attr{Mode: os.ModeDir | 0755}}
+	if (&SearchFilter{}).Match(dirEntry) {
+		t.Errorf("directory should not match by default")
+	}
+	if !(&SearchFilter{IncludeDirectories: true}).Match(dirEntry) {
+		t.Errorf("directory should match")
+	}
+	directoriesOnly := NewSearchFilter(&filer_pb.SearchEntriesRequest{DirectoriesOnly: true})
+	if !directoriesOnly.Match(dirEntry) {
+		t.Errorf("directory should match directories only")
+	}
+	if directoriesOnly.Match(entry) {
+		t.Errorf("file should not match directories only")
+	}
+}
+
+func TestSearchFilterDepth(t *testing.T) {
+	entry := &Entry{FullPath: "/home/chris/photos/cat.jpg"}
+	filter := &SearchFilter{MaxDepth: 2}
+	if !filter.withinDepth("/home/chris", entry) {
+		t.Errorf("depth 2 entry should be within depth")
+	}
+	if filter.withinDepth("/home", entry) {
+		t.Errorf("depth 3 entry should not be within depth")
+	}
+	if filter.withinDepth("/", entry) {
+		t.Errorf("depth 4 entry should not be within depth")
+	}
+}
@@ -9,6 +9,7 @@ import (
 var (
 	ErrUnsupportedListDirectoryPrefixed      = errors.New("unsupported directory prefix listing")
 	ErrUnsupportedSuperLargeDirectoryListing = errors.New("unsupported super large directory listing")
+	ErrUnsupportedSearch                     = errors.New("unsupported search")
 	ErrKvNotImplemented                      = errors.New("kv not implemented yet")
 	ErrKvNotFound                            = errors.New("kv: not found")
 )
@@ -39,3 +40,9 @@ type FilerStore interface {
 
 	Shutdown()
 }
+
+// EntrySearcher is implemented by filer stores that can evaluate a recursive search natively.
+// The returned entries may be a superset of the matching entries.
+type EntrySearcher interface {
+	SearchEntries(ctx context.Context, dirPath util.FullPath, filter *SearchFilter, eachEntryFunc ListEachEntryFunc) error
+}
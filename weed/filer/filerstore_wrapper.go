@@ -21,6 +21,7 @@ type VirtualFilerStore interface {
 	DeleteHardLink(ctx context.Context, hardLinkId HardLinkId) error
 	DeleteOneEntry(ctx context.Context, entry *Entry) error
 	AddPathSpecificStore(path string, storeId string, store FilerStore)
+	SearchEntries(ctx context.Context, dirPath util.FullPath, filter *SearchFilter, eachEntryFunc ListEachEntryFunc) error
 }
 
 type FilerStoreWrapper struct {
@@ -272,6 +273,29 @@ func (fsw *FilerStoreWrapper) prefixFilterEntries(ctx context.Context, dirPath u
 	return
 }
 
+func (fsw *FilerStoreWrapper) SearchEntries(ctx context.Context, dirPath util.FullPath, filter *SearchFilter, eachEntryFunc ListEachEntryFunc) error {
+	// path specific stores would be missed by searching only one store
+	if len(fsw.storeIdToStore) > 0 {
+		return ErrUnsupportedSearch
+	}
+	searcher, ok := fsw.getDefaultStore().(EntrySearcher)
+	if !ok {
+		return ErrUnsupportedSearch
+	}
+	stats.FilerStoreCounter.WithLabelValues(fsw.GetName(), "search").Inc()
+	start := time.Now()
+	defer func() {
+		stats.FilerStoreHistogram.WithLabelValues(fsw.GetName(), "search").Observe(time.Since(start).Seconds())
+	}()
+
+	glog.V(4).Infof("SearchEntries %s %+v", dirPath, filter)
+	return searcher.SearchEntries(ctx, dirPath, filter, func(entry *Entry) bool {
+		fsw.maybeReadHardLink(ctx, entry)
+		filer_pb.AfterEntryDeserialization(entry.Chunks)
+		return eachEntryFunc(entry)
+	})
+}
+
 func (fsw *FilerStoreWrapper) BeginTransaction(ctx context.Context) (context.Context, error) {
 	return fsw.getDefaultStore().BeginTransaction(ctx)
 }
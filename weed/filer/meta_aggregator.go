@@ -116,7 +116,7 @@ func (ma *MetaAggregator) subscribeToOneFiler(f *Filer, self string, peer string
 	}
 
 	for {
-		err := pb.WithFilerClient(peer, ma.grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+		err := pb.WithFilerClient(peer, ma.grpcDialOption, filer_pb.WithServiceIdentity(func(client filer_pb.SeaweedFilerClient) error {
 			ctx, cancel := context.WithCancel(context.Background())
 			defer cancel()
 			stream, err := client.SubscribeLocalMetadata(ctx, &filer_pb.SubscribeMetadataRequest{
@@ -145,7 +145,7 @@ func (ma *MetaAggregator) subscribeToOneFiler(f *Filer, self string, peer string
 				f.onMetadataChangeEvent(resp)
 
 			}
-		})
+		}))
 		if err != nil {
 			glog.V(0).Infof("subscribing remote %s meta change: %v", peer, err)
 			time.Sleep(1733 * time.Millisecond)
@@ -154,14 +154,14 @@ func (ma *MetaAggregator) subscribeToOneFiler(f *Filer, self string, peer string
 }
 
 func (ma *MetaAggregator) readFilerStoreSignature(peer string) (sig int32, err error) {
-	err = pb.WithFilerClient(peer, ma.grpcDialOption, func(client filer_pb.SeaweedFilerClient) error {
+	err = pb.WithFilerClient(peer, ma.grpcDialOption, filer_pb.WithServiceIdentity(func(client filer_pb.SeaweedFilerClient) error {
 		resp, err := client.GetFilerConfiguration(context.Background(), &filer_pb.GetFilerConfigurationRequest{})
 		if err != nil {
 			return err
 		}
 		sig = resp.Signature
 		return nil
-	})
+	}))
 	return
 }
 
@@ -0,0 +1,325 @@
+package pebble
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	weed_util "github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	DIR_FILE_SEPARATOR = byte(0x00)
+)
+
+func init() {
+	filer.Stores = append(filer.Stores, &PebbleStore{})
+}
+
+type PebbleStore struct {
+	db               *pebble.DB
+	writeOptions     *pebble.WriteOptions
+	ttlSweepInterval time.Duration
+	stopCh           chan struct{}
+}
+
+func (store *PebbleStore) GetName() string {
+	return "pebble"
+}
+
+func (store *PebbleStore) Initialize(configuration weed_util.Configuration, prefix string) (err error) {
+	configuration.SetDefault(prefix+"max_concurrent_compactions", 2)
+	configuration.SetDefault(prefix+"l0_compaction_threshold", 4)
+	configuration.SetDefault(prefix+"mem_table_size_mb", 64)
+	configuration.SetDefault(prefix+"block_cache_size_mb", 64)
+	configuration.SetDefault(prefix+"bloom_filter_bits_per_key", 10)
+	configuration.SetDefault(prefix+"ttl_sweep_interval_seconds", 60)
+	dir := configuration.GetString(prefix + "dir")
+	return store.initialize(dir, &pebbleOptions{
+		syncWrites:               configuration.GetBool(prefix + "sync_writes"),
+		maxConcurrentCompactions: configuration.GetInt(prefix + "max_concurrent_compactions"),
+		l0CompactionThreshold:    configuration.GetInt(prefix + "l0_compaction_threshold"),
+		memTableSizeMB:           configuration.GetInt(prefix + "mem_table_size_mb"),
+		blockCacheSizeMB:         configuration.GetInt(prefix + "block_cache_size_mb"),
+		bloomFilterBitsPerKey:    configuration.GetInt(prefix + "bloom_filter_bits_per_key"),
+		ttlSweepIntervalSeconds:  configuration.GetInt(prefix + "ttl_sweep_interval_seconds"),
+	})
+}
+
+type pebbleOptions struct {
+	syncWrites               bool
+	maxConcurrentCompactions int
+	l0CompactionThreshold    int
+	memTableSizeMB           int
+	blockCacheSizeMB         int
+	bloomFilterBitsPerKey    int
+	ttlSweepIntervalSeconds  int
+}
+
+func (store *PebbleStore) initialize(dir string, options *pebbleOptions) (err error) {
+	glog.Infof("filer store pebble dir: %s", dir)
+	if err := weed_util.TestFolderWritable(dir); err != nil {
+		return fmt.Errorf("Check Pebble Folder %s Writable: %s", dir, err)
+	}
+
+	cache := pebble.NewCache(int64(options.blockCacheSizeMB) * 1024 * 1024)
+	defer cache.Unref()
+
+	levelOptions := pebble.LevelOptions{
+		Compression: pebble.SnappyCompression,
+	}
+	if options.bloomFilterBitsPerKey > 0 {
+		// the filters are built on the directory part of the keys, see directoryComparer
+		levelOptions.FilterPolicy = bloom.FilterPolicy(options.bloomFilterBitsPerKey)
+		levelOptions.FilterType = pebble.TableFilter
+	}
+
+	opts := &pebble.Options{
+		Cache:                    cache,
+		Comparer:                 directoryComparer,
+		EventListener:            store.eventListener(),
+		L0CompactionThreshold:    options.l0CompactionThreshold,
+		Levels:                   []pebble.LevelOptions{levelOptions},
+		Logger:                   &pebbleLogger{},
+		MaxConcurrentCompactions: options.maxConcurrentCompactions,
+		MemTableSize:             options.memTableSizeMB * 1024 * 1024,
+	}
+
+	if store.db, err = pebble.Open(dir, opts); err != nil {
+		glog.Errorf("filer store open dir %s: %v", dir, err)
+		return
+	}
+
+	store.writeOptions = pebble.NoSync
+	if options.syncWrites {
+		store.writeOptions = pebble.Sync
+	}
+	store.ttlSweepInterval = time.Duration(options.ttlSweepIntervalSeconds) * time.Second
+	store.stopCh = make(chan struct{})
+
+	go store.loopMaintenance()
+
+	return
+}
+
+// directoryComparer orders the keys bytewise, and splits the entry keys after the directory,
+// so that the bloom filters tell which tables have entries of a directory.
+var directoryComparer = func() *pebble.Comparer {
+	comparer := *pebble.DefaultComparer
+	comparer.Name = "seaweedfs.DirectoryComparator"
+	comparer.Split = func(key []byte) int {
+		if i := bytes.IndexByte(key, DIR_FILE_SEPARATOR); i >= 0 {
+			return i + 1
+		}
+		return len(key)
+	}
+	return &comparer
+}()
+
+func (store *PebbleStore) BeginTransaction(ctx context.Context) (context.Context, error) {
+	return ctx, nil
+}
+func (store *PebbleStore) CommitTransaction(ctx context.Context) error {
+	return nil
+}
+func (store *PebbleStore) RollbackTransaction(ctx context.Context) error {
+	return nil
+}
+
+func (store *PebbleStore) InsertEntry(ctx context.Context, entry *filer.Entry) (err error) {
+	key := genKey(entry.DirAndName())
+
+	value, err := entry.EncodeAttributesAndChunks()
+	if err != nil {
+		return fmt.Errorf("encoding %s %+v: %v", entry.FullPath, entry.Attr, err)
+	}
+
+	if len(entry.Chunks) > 50 {
+		value = weed_util.MaybeGzipData(value)
+	}
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+	batch.Set(key, value, nil)
+	if entry.TtlSec > 0 {
+		// the expired entry is deleted by the ttl sweeper
+		batch.Set(genTtlKey(expiresAt(entry), key), nil, nil)
+	}
+	if err = batch.Commit(store.writeOptions); err != nil {
+		return fmt.Errorf("persisting %s : %v", entry.FullPath, err)
+	}
+
+	return nil
+}
+
+func (store *PebbleStore) UpdateEntry(ctx context.Context, entry *filer.Entry) (err error) {
+
+	return store.InsertEntry(ctx, entry)
+}
+
+func (store *PebbleStore) FindEntry(ctx context.Context, fullpath weed_util.FullPath) (entry *filer.Entry, err error) {
+	key := genKey(fullpath.DirAndName())
+
+	data, err := store.get(key)
+	if err == pebble.ErrNotFound {
+		return nil, filer_pb.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s : %v", fullpath, err)
+	}
+
+	entry = &filer.Entry{
+		FullPath: fullpath,
+	}
+	err = entry.DecodeAttributesAndChunks(weed_util.MaybeDecompressData(data))
+	if err != nil {
+		return entry, fmt.Errorf("decode %s : %v", entry.FullPath, err)
+	}
+	if isExpired(entry, time.Now()) {
+		return nil, filer_pb.ErrNotFound
+	}
+
+	return entry, nil
+}
+
+func (store *PebbleStore) get(key []byte) ([]byte, error) {
+	value, closer, err := store.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return append([]byte(nil), value...), nil
+}
+
+func (store *PebbleStore) DeleteEntry(ctx context.Context, fullpath weed_util.FullPath) (err error) {
+	key := genKey(fullpath.DirAndName())
+
+	if err = store.db.Delete(key, store.writeOptions); err != nil {
+		return fmt.Errorf("delete %s : %v", fullpath, err)
+	}
+
+	return nil
+}
+
+func (store *PebbleStore) DeleteFolderChildren(ctx context.Context, fullpath weed_util.FullPath) (err error) {
+
+	// one range tombstone for all children, removed from the tables by compactions
+	directoryPrefix := genDirectoryKeyPrefix(fullpath, "")
+	if err = store.db.DeleteRange(directoryPrefix, prefixEnd(directoryPrefix), store.writeOptions); err != nil {
+		return fmt.Errorf("delete %s : %v", fullpath, err)
+	}
+
+	return nil
+}
+
+func (store *PebbleStore) ListDirectoryEntries(ctx context.Context, dirPath weed_util.FullPath, startFileName string, includeStartFile bool, limit int64, eachEntryFunc filer.ListEachEntryFunc) (lastFileName string, err error) {
+	return store.ListDirectoryPrefixedEntries(ctx, dirPath, startFileName, includeStartFile, limit, "", eachEntryFunc)
+}
+
+func (store *PebbleStore) ListDirectoryPrefixedEntries(ctx context.Context, dirPath weed_util.FullPath, startFileName string, includeStartFile bool, limit int64, prefix string, eachEntryFunc filer.ListEachEntryFunc) (lastFileName string, err error) {
+
+	directoryPrefix := genDirectoryKeyPrefix(dirPath, prefix)
+	lastFileStart := directoryPrefix
+	if startFileName != "" {
+		lastFileStart = genDirectoryKeyPrefix(dirPath, startFileName)
+	}
+	if bytes.Compare(lastFileStart, directoryPrefix) < 0 {
+		lastFileStart = directoryPrefix
+	}
+
+	iter := store.db.NewIter(&pebble.IterOptions{
+		UpperBound: prefixEnd(directoryPrefix),
+	})
+	defer iter.Close()
+
+	now := time.Now()
+	// the prefix seek skips the tables without the directory by their bloom filters
+	for iter.SeekPrefixGE(lastFileStart); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		if !bytes.HasPrefix(key, directoryPrefix) {
+			break
+		}
+		fileName := getNameFromKey(key)
+		if fileName == "" {
+			continue
+		}
+		if fileName == startFileName && !includeStartFile {
+			continue
+		}
+		entry := &filer.Entry{
+			FullPath: weed_util.NewFullPath(string(dirPath), fileName),
+		}
+		if decodeErr := entry.DecodeAttributesAndChunks(weed_util.MaybeDecompressData(iter.Value())); decodeErr != nil {
+			err = decodeErr
+			glog.V(0).Infof("list %s : %v", entry.FullPath, err)
+			break
+		}
+		if isExpired(entry, now) {
+			continue
+		}
+		limit--
+		if limit < 0 {
+			break
+		}
+		lastFileName = fileName
+		if !eachEntryFunc(entry) {
+			break
+		}
+	}
+	if err == nil {
+		err = iter.Error()
+	}
+
+	return lastFileName, err
+}
+
+func genKey(dirPath, fileName string) (key []byte) {
+	key = []byte(dirPath)
+	key = append(key, DIR_FILE_SEPARATOR)
+	key = append(key, []byte(fileName)...)
+	return key
+}
+
+func genDirectoryKeyPrefix(fullpath weed_util.FullPath, startFileName string) (keyPrefix []byte) {
+	keyPrefix = []byte(string(fullpath))
+	keyPrefix = append(keyPrefix, DIR_FILE_SEPARATOR)
+	if len(startFileName) > 0 {
+		keyPrefix = append(keyPrefix, []byte(startFileName)...)
+	}
+	return keyPrefix
+}
+
+// prefixEnd returns the smallest key after all keys with the prefix
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte(nil), prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		end[i]++
+		if end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+func getNameFromKey(key []byte) string {
+
+	sepIndex := len(key) - 1
+	for sepIndex >= 0 && key[sepIndex] != DIR_FILE_SEPARATOR {
+		sepIndex--
+	}
+
+	return string(key[sepIndex+1:])
+
+}
+
+func (store *PebbleStore) Shutdown() {
+	close(store.stopCh)
+	store.db.Close()
+}
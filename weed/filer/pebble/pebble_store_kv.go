@@ -0,0 +1,47 @@
+package pebble
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+)
+
+func (store *PebbleStore) KvPut(ctx context.Context, key []byte, value []byte) (err error) {
+
+	err = store.db.Set(key, value, store.writeOptions)
+
+	if err != nil {
+		return fmt.Errorf("kv put: %v", err)
+	}
+
+	return nil
+}
+
+func (store *PebbleStore) KvGet(ctx context.Context, key []byte) (value []byte, err error) {
+
+	value, err = store.get(key)
+
+	if err == pebble.ErrNotFound {
+		return nil, filer.ErrKvNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("kv get: %v", err)
+	}
+
+	return
+}
+
+func (store *PebbleStore) KvDelete(ctx context.Context, key []byte) (err error) {
+
+	err = store.db.Delete(key, store.writeOptions)
+
+	if err != nil {
+		return fmt.Errorf("kv delete: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+package pebble
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+var testOptions = &pebbleOptions{
+	maxConcurrentCompactions: 1,
+	l0CompactionThreshold:    4,
+	memTableSizeMB:           4,
+	blockCacheSizeMB:         8,
+	bloomFilterBitsPerKey:    10,
+}
+
+func TestCreateAndFind(t *testing.T) {
+	testFiler := filer.NewFiler(nil, nil, "", 0, "", "", "", nil)
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test")
+	defer os.RemoveAll(dir)
+	store := &PebbleStore{}
+	store.initialize(dir, testOptions)
+	testFiler.SetStore(store)
+	defer store.Shutdown()
+
+	fullpath := util.FullPath("/home/chris/this/is/one/file1.jpg")
+
+	ctx := context.Background()
+
+	entry1 := &filer.Entry{
+		FullPath: fullpath,
+		Attr: filer.Attr{
+			Mode: 0440,
+			Uid:  1234,
+			Gid:  5678,
+		},
+	}
+
+	if err := testFiler.CreateEntry(ctx, entry1, false, false, nil); err != nil {
+		t.Errorf("create entry %v: %v", entry1.FullPath, err)
+		return
+	}
+
+	entry, err := testFiler.FindEntry(ctx, fullpath)
+
+	if err != nil {
+		t.Errorf("find entry: %v", err)
+		return
+	}
+
+	if entry.FullPath != entry1.FullPath {
+		t.Errorf("find wrong entry: %v", entry.FullPath)
+		return
+	}
+
+	// checking one upper directory
+	entries, _, _ := testFiler.ListDirectoryEntries(ctx, util.FullPath("/home/chris/this/is/one"), "", false, 100, "", "")
+	if len(entries) != 1 {
+		t.Errorf("list entries count: %v", len(entries))
+		return
+	}
+
+	// checking one upper directory
+	entries, _, _ = testFiler.ListDirectoryEntries(ctx, util.FullPath("/"), "", false, 100, "", "")
+	if len(entries) != 1 {
+		t.Errorf("list entries count: %v", len(entries))
+		return
+	}
+
+}
+
+func TestDeleteFolderChildren(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test3")
+	defer os.RemoveAll(dir)
+	store := &PebbleStore{}
+	store.initialize(dir, testOptions)
+	defer store.Shutdown()
+
+	ctx := context.Background()
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		store.InsertEntry(ctx, &filer.Entry{FullPath: util.NewFullPath("/dir", name)})
+	}
+	store.InsertEntry(ctx, &filer.Entry{FullPath: util.NewFullPath("/dir/sub", "x")})
+	store.InsertEntry(ctx, &filer.Entry{FullPath: util.NewFullPath("/dir2", "y")})
+
+	if err := store.DeleteFolderChildren(ctx, "/dir"); err != nil {
+		t.Fatalf("delete folder children: %v", err)
+	}
+
+	count := 0
+	store.ListDirectoryEntries(ctx, "/dir", "", false, 100, func(entry *filer.Entry) bool {
+		count++
+		return true
+	})
+	if count != 0 {
+		t.Errorf("%d children left", count)
+	}
+	if _, err := store.FindEntry(ctx, "/dir/a"); err != filer_pb.ErrNotFound {
+		t.Errorf("child entry found: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/dir/sub/x"); err != nil {
+		t.Errorf("sub folder entry deleted: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/dir2/y"); err != nil {
+		t.Errorf("sibling folder entry deleted: %v", err)
+	}
+}
+
+func TestListDirectoryPrefixedEntries(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test6")
+	defer os.RemoveAll(dir)
+	store := &PebbleStore{}
+	store.initialize(dir, testOptions)
+	defer store.Shutdown()
+
+	ctx := context.Background()
+
+	for _, p := range []string{"/a/x1", "/a/x2", "/a/x3", "/a/y1", "/a/b/x4", "/a0/x5", "/b/x6"} {
+		store.InsertEntry(ctx, &filer.Entry{FullPath: util.FullPath(p)})
+	}
+	// flushed to a table, so the bloom filters are used
+	if err := store.db.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	list := func(startFileName string, includeStartFile bool, limit int64, prefix string) (names []string) {
+		_, err := store.ListDirectoryPrefixedEntries(ctx, "/a", startFileName, includeStartFile, limit, prefix, func(entry *filer.Entry) bool {
+			names = append(names, entry.Name())
+			return true
+		})
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		return
+	}
+
+	if names := list("", false, 100, ""); strings.Join(names, ",") != "x1,x2,x3,y1" {
+		t.Errorf("list all: %v", names)
+	}
+	if names := list("", false, 100, "x"); strings.Join(names, ",") != "x1,x2,x3" {
+		t.Errorf("list prefix: %v", names)
+	}
+	if names := list("x1", false, 2, "x"); strings.Join(names, ",") != "x2,x3" {
+		t.Errorf("list prefix after start: %v", names)
+	}
+	if names := list("a", true, 100, "x"); strings.Join(names, ",") != "x1,x2,x3" {
+		t.Errorf("list prefix after an earlier start: %v", names)
+	}
+	if names := list("", false, 100, "z"); len(names) != 0 {
+		t.Errorf("list missing prefix: %v", names)
+	}
+}
+
+func TestTtlExpiry(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test4")
+	defer os.RemoveAll(dir)
+	store := &PebbleStore{}
+	store.initialize(dir, testOptions)
+	defer store.Shutdown()
+
+	ctx := context.Background()
+
+	store.InsertEntry(ctx, &filer.Entry{
+		FullPath: "/ttl/expired",
+		Attr: filer.Attr{
+			Crtime: time.Now().Add(-time.Hour),
+			TtlSec: 60,
+		},
+	})
+	store.InsertEntry(ctx, &filer.Entry{
+		FullPath: "/ttl/alive",
+		Attr: filer.Attr{
+			Crtime: time.Now(),
+			TtlSec: 3600,
+		},
+	})
+
+	if _, err := store.FindEntry(ctx, "/ttl/expired"); err != filer_pb.ErrNotFound {
+		t.Errorf("expired entry found: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/ttl/alive"); err != nil {
+		t.Errorf("find entry: %v", err)
+	}
+
+	// replaced without a ttl before the sweep
+	store.InsertEntry(ctx, &filer.Entry{
+		FullPath: "/ttl/renewed",
+		Attr: filer.Attr{
+			Crtime: time.Now().Add(-time.Hour),
+			TtlSec: 60,
+		},
+	})
+	store.InsertEntry(ctx, &filer.Entry{FullPath: "/ttl/renewed"})
+
+	if err := store.sweepExpiredEntries(time.Now()); err != nil {
+		t.Fatalf("sweep: %v", err)
+	}
+	if _, err := store.get(genKey("/ttl", "expired")); err != pebble.ErrNotFound {
+		t.Errorf("expired entry not deleted: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/ttl/renewed"); err != nil {
+		t.Errorf("renewed entry deleted: %v", err)
+	}
+	if _, err := store.FindEntry(ctx, "/ttl/alive"); err != nil {
+		t.Errorf("alive entry deleted: %v", err)
+	}
+
+	// the alive entry is deleted once it expires
+	if err := store.sweepExpiredEntries(time.Now().Add(2 * time.Hour)); err != nil {
+		t.Fatalf("sweep: %v", err)
+	}
+	if _, err := store.get(genKey("/ttl", "alive")); err != pebble.ErrNotFound {
+		t.Errorf("expired entry not deleted: %v", err)
+	}
+}
+
+func TestSearchEntries(t *testing.T) {
+	testFiler := filer.NewFiler(nil, nil, "", 0, "", "", "", nil)
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_test5")
+	defer os.RemoveAll(dir)
+	store := &PebbleStore{}
+	store.initialize(dir, testOptions)
+	testFiler.SetStore(store)
+	defer store.Shutdown()
+
+	ctx := context.Background()
+
+	for _, p := range []string{"/a/x.jpg", "/a/b/y.jpg", "/a/b/c/z.jpg", "/a/b/c/z.txt", "/d/w.jpg"} {
+		testFiler.CreateEntry(ctx, &filer.Entry{
+			FullPath: util.FullPath(p),
+			Attr:     filer.Attr{Mode: 0644, Mtime: time.Now(), Crtime: time.Now()},
+		}, false, false, nil)
+	}
+
+	search := func(dir string, filter *filer.SearchFilter) (found []string) {
+		err := testFiler.SearchEntries(ctx, util.FullPath(dir), filter, func(entry *filer.Entry) bool {
+			found = append(found, string(entry.FullPath))
+			return true
+		})
+		if err != nil {
+			t.Fatalf("search %s: %v", dir, err)
+		}
+		return
+	}
+
+	if found := search("/a", &filer.SearchFilter{NamePattern: "*.jpg"}); len(found) != 3 {
+		t.Errorf("search jpg: %v", found)
+	}
+	if found := search("/", &filer.SearchFilter{NamePattern: "*.jpg"}); len(found) != 4 {
+		t.Errorf("search jpg from root: %v", found)
+	}
+	if found := search("/a", &filer.SearchFilter{MaxDepth: 2}); len(found) != 2 {
+		t.Errorf("search with max depth: %v", found)
+	}
+	if found := search("/a", &filer.SearchFilter{IncludeDirectories: true, NamePattern: "c"}); len(found) != 1 || found[0] != "/a/b/c" {
+		t.Errorf("search directories: %v", found)
+	}
+	if found := search("/", &filer.SearchFilter{IncludeDirectories: true, DirectoriesOnly: true}); len(found) != 4 {
+		t.Errorf("search directories only: %v", found)
+	}
+}
@@ -0,0 +1,155 @@
+package pebble
+
+import (
+	"time"
+
+	"github.com/cockroachdb/pebble"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/stats"
+	weed_util "github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// Entries with a ttl are also indexed by their expiry time:
+//   "\x00ttl" + expiry unix seconds in big endian + entry key => empty
+// Entry keys start with the "/" of the directory, so the index is kept apart from them.
+// The index is not updated when entries are changed or deleted, the sweeper checks the entry again.
+var ttlKeyPrefix = []byte{DIR_FILE_SEPARATOR, 't', 't', 'l'}
+
+func genTtlKey(expiresAt int64, key []byte) []byte {
+	ttlKey := make([]byte, len(ttlKeyPrefix)+8, len(ttlKeyPrefix)+8+len(key))
+	copy(ttlKey, ttlKeyPrefix)
+	weed_util.Uint64toBytes(ttlKey[len(ttlKeyPrefix):], uint64(expiresAt))
+	return append(ttlKey, key...)
+}
+
+// expiresAt is the time in unix seconds when the entry expires, counted from its creation time
+func expiresAt(entry *filer.Entry) int64 {
+	crtime := entry.Crtime
+	if crtime.IsZero() {
+		crtime = time.Now()
+	}
+	return crtime.Add(time.Duration(entry.TtlSec) * time.Second).Unix()
+}
+
+func isExpired(entry *filer.Entry, now time.Time) bool {
+	return entry.TtlSec > 0 && expiresAt(entry) <= now.Unix()
+}
+
+// loopMaintenance deletes the expired entries, and reports the store size
+func (store *PebbleStore) loopMaintenance() {
+
+	reportTicker := time.NewTicker(time.Minute)
+	defer reportTicker.Stop()
+
+	var sweepTick <-chan time.Time
+	if store.ttlSweepInterval > 0 {
+		sweepTicker := time.NewTicker(store.ttlSweepInterval)
+		defer sweepTicker.Stop()
+		sweepTick = sweepTicker.C
+	}
+
+	store.reportSize()
+
+	for {
+		select {
+		case <-store.stopCh:
+			return
+		case <-reportTicker.C:
+			store.reportSize()
+		case <-sweepTick:
+			if err := store.sweepExpiredEntries(time.Now()); err != nil {
+				glog.Errorf("pebble ttl sweep: %v", err)
+			}
+		}
+	}
+}
+
+// sweepExpiredEntries deletes the entries expired by now, and their ttl index
+func (store *PebbleStore) sweepExpiredEntries(now time.Time) error {
+	iter := store.db.NewIter(&pebble.IterOptions{
+		LowerBound: ttlKeyPrefix,
+		UpperBound: genTtlKey(now.Unix()+1, nil),
+	})
+	defer iter.Close()
+
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	expiredCount := 0
+	for iter.First(); iter.Valid(); iter.Next() {
+		ttlKey := iter.Key()
+		key := ttlKey[len(ttlKeyPrefix)+8:]
+		batch.Delete(ttlKey, nil)
+
+		data, err := store.get(key)
+		if err == pebble.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		entry := &filer.Entry{}
+		if err = entry.DecodeAttributesAndChunks(weed_util.MaybeDecompressData(data)); err != nil {
+			glog.V(0).Infof("decode %s : %v", key, err)
+			continue
+		}
+		// the entry may have been replaced without a ttl, or with a later expiry indexed again
+		if isExpired(entry, now) {
+			batch.Delete(key, nil)
+			expiredCount++
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if batch.Empty() {
+		return nil
+	}
+	if err := batch.Commit(store.writeOptions); err != nil {
+		return err
+	}
+	glog.V(1).Infof("pebble ttl sweep deleted %d expired entries", expiredCount)
+	return nil
+}
+
+func (store *PebbleStore) eventListener() pebble.EventListener {
+	return pebble.EventListener{
+		CompactionEnd: func(info pebble.CompactionInfo) {
+			if info.Err != nil {
+				stats.FilerStoreCompactionCounter.WithLabelValues("pebble", "error").Inc()
+				return
+			}
+			stats.FilerStoreCompactionCounter.WithLabelValues("pebble", "compaction").Inc()
+		},
+		FlushEnd: func(info pebble.FlushInfo) {
+			if info.Err != nil {
+				stats.FilerStoreCompactionCounter.WithLabelValues("pebble", "error").Inc()
+				return
+			}
+			stats.FilerStoreCompactionCounter.WithLabelValues("pebble", "flush").Inc()
+		},
+	}
+}
+
+func (store *PebbleStore) reportSize() {
+	metrics := store.db.Metrics()
+	var tableSize int64
+	for _, level := range metrics.Levels {
+		tableSize += level.Size
+	}
+	stats.FilerStoreSizeGauge.WithLabelValues("pebble", "sstable").Set(float64(tableSize))
+	stats.FilerStoreSizeGauge.WithLabelValues("pebble", "wal").Set(float64(metrics.WAL.Size))
+	stats.FilerStoreSizeGauge.WithLabelValues("pebble", "memtable").Set(float64(metrics.MemTable.Size))
+	stats.FilerStoreSizeGauge.WithLabelValues("pebble", "compaction_debt").Set(float64(metrics.Compact.EstimatedDebt))
+}
+
+type pebbleLogger struct{}
+
+func (l *pebbleLogger) Infof(format string, args ...interface{}) {
+	glog.V(1).Infof(format, args...)
+}
+func (l *pebbleLogger) Fatalf(format string, args ...interface{}) {
+	glog.Fatalf(format, args...)
+}
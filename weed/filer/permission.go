@@ -1,5 +1,120 @@
 package filer
 
+import (
+	"os"
+)
+
+const (
+	PermissionRead    = 04
+	PermissionWrite   = 02
+	PermissionExecute = 01
+)
+
+// Identity is the caller on whose behalf a filer request is made.
+// A nil *Identity means permissions are not enforced.
+type Identity struct {
+	Uid  uint32
+	Gids []uint32
+}
+
+func (identity *Identity) IsRoot() bool {
+	return identity.Uid == 0
+}
+
+// Gid is the primary group of the identity
+func (identity *Identity) Gid() uint32 {
+	if len(identity.Gids) == 0 {
+		return 0
+	}
+	return identity.Gids[0]
+}
+
+func (identity *Identity) InGroup(gid uint32) bool {
+	for _, g := range identity.Gids {
+		if g == gid {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission checks the requested rwx bits against the access acl of the entry if any,
+// or else the owner, group, or other class of the entry,
+// in the same order as the kernel does: only the first matching class is consulted.
+func HasPermission(entry *Entry, identity *Identity, want uint32) bool {
+	if identity == nil {
+		return true
+	}
+	if entry == nil {
+		return false
+	}
+	mode := uint32(entry.Mode.Perm())
+	if identity.IsRoot() {
+		// root can read and write anything, and execute if any execute bit is set
+		if want&PermissionExecute == 0 || entry.IsDirectory() || mode&0111 != 0 {
+			return true
+		}
+		return false
+	}
+	if data, found := entry.Extended[XattrPosixAclAccess]; found {
+		if acl, err := ParseAcl(data); err == nil {
+			return acl.check(identity, entry.Uid, entry.Gid, want)
+		}
+	}
+	var granted uint32
+	switch {
+	case identity.Uid == entry.Uid:
+		granted = (mode >> 6) & 07
+	case identity.InGroup(entry.Gid):
+		granted = (mode >> 3) & 07
+	default:
+		granted = mode & 07
+	}
+	return granted&want == want
+}
+
+// CanModifyDirectory checks whether the identity may add, remove, or rename entries in the directory.
+func CanModifyDirectory(dir *Entry, identity *Identity) bool {
+	return HasPermission(dir, identity, PermissionWrite|PermissionExecute)
+}
+
+// CanRemoveFromDirectory additionally applies the sticky bit:
+// only the owner of the entry, the owner of the directory, or root may remove or rename the entry.
+func CanRemoveFromDirectory(dir *Entry, entry *Entry, identity *Identity) bool {
+	if !CanModifyDirectory(dir, identity) {
+		return false
+	}
+	if identity == nil || identity.IsRoot() || dir.Mode&os.ModeSticky == 0 {
+		return true
+	}
+	return identity.Uid == entry.Uid || identity.Uid == dir.Uid
+}
+
+// CanChangeAttributes checks ownership and mode changes from oldEntry to newEntry.
+func CanChangeAttributes(oldEntry *Entry, newEntry *Entry, identity *Identity) bool {
+	if identity == nil || identity.IsRoot() {
+		return true
+	}
+	if oldEntry.Uid != newEntry.Uid {
+		// only root can give away files
+		return false
+	}
+	if oldEntry.Gid != newEntry.Gid {
+		if identity.Uid != oldEntry.Uid || !identity.InGroup(newEntry.Gid) {
+			return false
+		}
+	}
+	if oldEntry.Mode != newEntry.Mode && identity.Uid != oldEntry.Uid {
+		return false
+	}
+	for _, name := range []string{XattrPosixAclAccess, XattrPosixAclDefault} {
+		if string(oldEntry.Extended[name]) != string(newEntry.Extended[name]) && identity.Uid != oldEntry.Uid {
+			return false
+		}
+	}
+	return true
+}
+
 func hasWritePermission(dir *Entry, entry *Entry) bool {
 
 	if dir == nil {
@@ -0,0 +1,125 @@
+package filer
+
+import (
+	"os"
+	"testing"
+)
+
+func TestHasPermission(t *testing.T) {
+	entry := &Entry{
+		FullPath: "/home/chris/notes.txt",
+		Attr: Attr{
+			Mode: 0640,
+			Uid:  1000,
+			Gid:  100,
+		},
+	}
+
+	tests := []struct {
+		identity *Identity
+		want     uint32
+		expected bool
+	}{
+		{nil, PermissionWrite, true},
+		{&Identity{Uid: 0}, PermissionRead | PermissionWrite, true},
+		{&Identity{Uid: 0}, PermissionExecute, false},
+		{&Identity{Uid: 1000, Gids: []uint32{1000}}, PermissionRead | PermissionWrite, true},
+		{&Identity{Uid: 1000, Gids: []uint32{1000}}, PermissionExecute, false},
+		{&Identity{Uid: 1001, Gids: []uint32{1001, 100}}, PermissionRead, true},
+		{&Identity{Uid: 1001, Gids: []uint32{1001, 100}}, PermissionWrite, false},
+		{&Identity{Uid: 1002, Gids: []uint32{1002}}, PermissionRead, false},
+	}
+
+	for i, test := range tests {
+		if actual := HasPermission(entry, test.identity, test.want); actual != test.expected {
+			t.Errorf("case %d: identity %+v want %o: expected %v, got %v", i, test.identity, test.want, test.expected, actual)
+		}
+	}
+
+	// the owner class is used even if the group or other class grants more
+	entry.Mode = 0066
+	if HasPermission(entry, &Identity{Uid: 1000, Gids: []uint32{100}}, PermissionRead) {
+		t.Errorf("owner should not fall through to the group permission")
+	}
+}
+
+func TestCanRemoveFromStickyDirectory(t *testing.T) {
+	dir := &Entry{
+		FullPath: "/tmp",
+		Attr: Attr{
+			Mode: os.ModeDir | os.ModeSticky | 0777,
+			Uid:  0,
+			Gid:  0,
+		},
+	}
+	entry := &Entry{
+		FullPath: "/tmp/a.txt",
+		Attr: Attr{
+			Mode: 0644,
+			Uid:  1000,
+			Gid:  100,
+		},
+	}
+
+	if !CanRemoveFromDirectory(dir, entry, &Identity{Uid: 1000}) {
+		t.Errorf("owner should remove its own file")
+	}
+	if CanRemoveFromDirectory(dir, entry, &Identity{Uid: 1001}) {
+		t.Errorf("sticky bit should prevent removing other's file")
+	}
+	if !CanRemoveFromDirectory(dir, entry, &Identity{Uid: 0}) {
+		t.Errorf("root should remove any file")
+	}
+
+	dir.Mode = os.ModeDir | 0777
+	if !CanRemoveFromDirectory(dir, entry, &Identity{Uid: 1001}) {
+		t.Errorf("without sticky bit anyone with write permission can remove")
+	}
+	dir.Mode = os.ModeDir | 0755
+	if CanRemoveFromDirectory(dir, entry, &Identity{Uid: 1001}) {
+		t.Errorf("directory write permission is required")
+	}
+}
+
+func TestCanChangeAttributes(t *testing.T) {
+	oldEntry := &Entry{
+		FullPath: "/home/chris/notes.txt",
+		Attr: Attr{
+			Mode: 0644,
+			Uid:  1000,
+			Gid:  100,
+		},
+	}
+	newEntry := func(mode os.FileMode, uid, gid uint32) *Entry {
+		return &Entry{
+			FullPath: oldEntry.FullPath,
+			Attr: Attr{
+				Mode: mode,
+				Uid:  uid,
+				Gid:  gid,
+			},
+		}
+	}
+	owner := &Identity{Uid: 1000, Gids: []uint32{100, 200}}
+	other := &Identity{Uid: 1001, Gids: []uint32{100}}
+
+	tests := []struct {
+		identity *Identity
+		entry    *Entry
+		expected bool
+	}{
+		{owner, newEntry(0600, 1000, 100), true},
+		{other, newEntry(0600, 1000, 100), false},
+		{owner, newEntry(0644, 1001, 100), false},
+		{&Identity{Uid: 0}, newEntry(0644, 1001, 100), true},
+		{owner, newEntry(0644, 1000, 200), true},
+		{owner, newEntry(0644, 1000, 300), false},
+		{other, newEntry(0644, 1000, 100), true},
+	}
+
+	for i, test := range tests {
+		if actual := CanChangeAttributes(oldEntry, test.entry, test.identity); actual != test.expected {
+			t.Errorf("case %d: expected %v, got %v", i, test.expected, actual)
+		}
+	}
+}
@@ -26,6 +26,12 @@ type ChunkReadAt struct {
 	chunkCache      chunk_cache.ChunkCache
 	lastChunkFileId string
 	lastChunkData   []byte
+
+	// prefetch the chunks after sequential reads, protected by readerLock
+	readAhead          *ReadAhead
+	prefetched         map[string]bool
+	lastPrefetchOffset int64
+	prefetchStopOffset int64
 }
 
 var _ = io.ReaderAt(&ChunkReadAt{})
@@ -95,6 +101,12 @@ func NewChunkReaderAtFromClient(lookupFn wdclient.LookupFileIdFunctionType, chun
 	}
 }
 
+// SetReadAhead enables prefetching the chunks with Prefetch
+func (c *ChunkReadAt) SetReadAhead(readAhead *ReadAhead) {
+	c.readAhead = readAhead
+	c.prefetched = make(map[string]bool)
+}
+
 func (c *ChunkReadAt) Close() error {
 	c.lastChunkData = nil
 	c.lastChunkFileId = ""
@@ -172,6 +184,7 @@ func (c *ChunkReadAt) readFromWholeChunkData(chunkView *ChunkView, nextChunkView
 	if c.lastChunkFileId == chunkView.FileId {
 		return c.lastChunkData, nil
 	}
+	c.countRead(chunkView.FileId)
 
 	v, doErr := c.readOneWholeChunk(chunkView)
 
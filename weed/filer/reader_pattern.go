@@ -0,0 +1,47 @@
+package filer
+
+import "sync"
+
+// ReaderPattern detects whether a file is read sequentially, and how far to read ahead.
+// The read-ahead window starts small and doubles with the sequentially read bytes, up to the max window,
+// and is reset once the file is read randomly.
+type ReaderPattern struct {
+	sync.Mutex
+	maxWindow          int64
+	lastReadStopOffset int64
+	sequentialBytes    int64
+}
+
+func NewReaderPattern(maxWindow int64) *ReaderPattern {
+	return &ReaderPattern{
+		maxWindow: maxWindow,
+	}
+}
+
+// MonitorReadAt records a read of [offset, offset+size)
+func (rp *ReaderPattern) MonitorReadAt(offset int64, size int) {
+	rp.Lock()
+	defer rp.Unlock()
+
+	// concurrent reads from the kernel may arrive slightly out of order
+	tolerance := 4 * int64(size)
+	if rp.lastReadStopOffset-tolerance <= offset && offset <= rp.lastReadStopOffset+tolerance {
+		rp.sequentialBytes += int64(size)
+	} else {
+		rp.sequentialBytes = 0
+	}
+	rp.lastReadStopOffset = offset + int64(size)
+}
+
+func (rp *ReaderPattern) IsRandomMode() bool {
+	rp.Lock()
+	defer rp.Unlock()
+	return rp.sequentialBytes == 0
+}
+
+// ReadAheadWindow is the number of bytes to prefetch after the last read, 0 for random reads
+func (rp *ReaderPattern) ReadAheadWindow() int64 {
+	rp.Lock()
+	defer rp.Unlock()
+	return min(2*rp.sequentialBytes, rp.maxWindow)
+}
@@ -0,0 +1,29 @@
+package filer
+
+import "testing"
+
+func TestReaderPattern(t *testing.T) {
+	rp := NewReaderPattern(1024)
+
+	rp.MonitorReadAt(0, 100)
+	if rp.IsRandomMode() || rp.ReadAheadWindow() != 200 {
+		t.Fatalf("first read from the start should be sequential, window %d", rp.ReadAheadWindow())
+	}
+
+	// slightly out of order reads are still sequential
+	rp.MonitorReadAt(200, 100)
+	rp.MonitorReadAt(100, 100)
+	if rp.IsRandomMode() || rp.ReadAheadWindow() != 600 {
+		t.Fatalf("expected window 600, got %d", rp.ReadAheadWindow())
+	}
+
+	rp.MonitorReadAt(200, 400)
+	if rp.ReadAheadWindow() != 1024 {
+		t.Fatalf("window should be capped, got %d", rp.ReadAheadWindow())
+	}
+
+	rp.MonitorReadAt(100000, 100)
+	if !rp.IsRandomMode() || rp.ReadAheadWindow() != 0 {
+		t.Fatalf("expected random mode, window %d", rp.ReadAheadWindow())
+	}
+}
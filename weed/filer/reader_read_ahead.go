@@ -0,0 +1,98 @@
+package filer
+
+import (
+	"sync/atomic"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/stats"
+)
+
+// ReadAhead prefetches the chunks after sequential reads into the chunk cache.
+// The chunks being fetched are held in memory, limited by the memory budget shared by all readers.
+type ReadAhead struct {
+	memoryBudget int64
+	memoryUsed   int64
+}
+
+func NewReadAhead(memoryBudget int64) *ReadAhead {
+	return &ReadAhead{
+		memoryBudget: memoryBudget,
+	}
+}
+
+func (ra *ReadAhead) reserve(size int64) bool {
+	if atomic.AddInt64(&ra.memoryUsed, size) > ra.memoryBudget {
+		atomic.AddInt64(&ra.memoryUsed, -size)
+		return false
+	}
+	return true
+}
+
+func (ra *ReadAhead) release(size int64) {
+	atomic.AddInt64(&ra.memoryUsed, -size)
+}
+
+// Prefetch fetches the chunks overlapping [offset, offset+size) in the background,
+// skipping the ones already prefetched by this reader
+func (c *ChunkReadAt) Prefetch(offset, size int64) {
+
+	if c.readAhead == nil || size <= 0 {
+		return
+	}
+
+	c.readerLock.Lock()
+	defer c.readerLock.Unlock()
+
+	if offset < c.lastPrefetchOffset {
+		// read again from an earlier position
+		c.prefetchStopOffset = offset
+	}
+	c.lastPrefetchOffset = offset
+	start, stop := max(offset, c.prefetchStopOffset), offset+size
+	if start >= stop {
+		return
+	}
+
+	for _, chunkView := range c.chunkViews {
+		if chunkView.LogicOffset+int64(chunkView.Size) <= start {
+			continue
+		}
+		if chunkView.LogicOffset >= stop {
+			break
+		}
+		if chunkView.FileId == c.lastChunkFileId || c.prefetched[chunkView.FileId] {
+			continue
+		}
+		chunkSize := int64(chunkView.ChunkSize)
+		if !c.readAhead.reserve(chunkSize) {
+			stats.MountReadAheadCounter.WithLabelValues("skip").Inc()
+			glog.V(4).Infof("read ahead memory budget is used up, skip prefetching %s", chunkView.FileId)
+			return
+		}
+		c.prefetched[chunkView.FileId] = true
+		c.prefetchStopOffset = chunkView.LogicOffset + int64(chunkView.Size)
+		stats.MountReadAheadCounter.WithLabelValues("prefetch").Inc()
+		go func(chunkView *ChunkView) {
+			defer c.readAhead.release(chunkSize)
+			if _, err := c.readOneWholeChunk(chunkView); err != nil {
+				glog.V(1).Infof("prefetch chunk %s: %v", chunkView.FileId, err)
+			}
+		}(chunkView)
+	}
+	c.prefetchStopOffset = max(c.prefetchStopOffset, stop)
+}
+
+// countRead reports whether the chunk read by the reader has been prefetched. Must be called with readerLock held.
+func (c *ChunkReadAt) countRead(fileId string) {
+	if c.readAhead == nil {
+		return
+	}
+	prefetched := c.prefetched[fileId]
+	delete(c.prefetched, fileId)
+
+	if prefetched {
+		stats.MountReadAheadCounter.WithLabelValues("hit").Inc()
+	} else {
+		stats.MountReadAheadCounter.WithLabelValues("miss").Inc()
+	}
+}
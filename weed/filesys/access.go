@@ -0,0 +1,83 @@
+package filesys
+
+import (
+	"context"
+	"syscall"
+
+	"github.com/seaweedfs/fuse"
+	"github.com/seaweedfs/fuse/fs"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// The kernel checks the permissions itself with the default_permissions mount option,
+// and only asks for access(2) and chdir(2) when the option is off.
+
+var _ = fs.NodeAccesser(&Dir{})
+
+func (dir *Dir) Access(ctx context.Context, req *fuse.AccessRequest) error {
+
+	glog.V(4).Infof("dir Access %s mask %o uid %d", dir.FullPath(), req.Mask, req.Uid)
+
+	if dir.FullPath() == dir.wfs.option.FilerMountRootPath {
+		entry := &filer_pb.Entry{
+			IsDirectory: true,
+			Attributes: &filer_pb.FuseAttributes{
+				FileMode: uint32(dir.wfs.option.MountMode),
+				Uid:      dir.wfs.option.MountUid,
+				Gid:      dir.wfs.option.MountGid,
+			},
+		}
+		return checkAccess(dir.FullPath(), entry, req)
+	}
+
+	if err := dir.maybeLoadEntry(); err != nil {
+		return err
+	}
+
+	return checkAccess(dir.FullPath(), dir.entry, req)
+}
+
+var _ = fs.NodeAccesser(&File{})
+
+func (file *File) Access(ctx context.Context, req *fuse.AccessRequest) error {
+
+	glog.V(4).Infof("file Access %s mask %o uid %d", file.fullpath(), req.Mask, req.Uid)
+
+	entry, err := file.maybeLoadEntry(ctx)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return fuse.ENOENT
+	}
+
+	return checkAccess(file.dir.FullPath(), entry, req)
+}
+
+// checkAccess applies the same mode bits and acl rules as the filer, with the local ids of the entry and the caller
+func checkAccess(dir string, entry *filer_pb.Entry, req *fuse.AccessRequest) error {
+	want := req.Mask & (filer.PermissionRead | filer.PermissionWrite | filer.PermissionExecute)
+	if want == 0 {
+		// F_OK, the entry exists
+		return nil
+	}
+	identity := &filer.Identity{
+		Uid:  req.Uid,
+		Gids: append([]uint32{req.Gid}, lookupSupplementaryGroups(req.Uid)...),
+	}
+	if !filer.HasPermission(filer.FromPbEntry(dir, entry), identity, want) {
+		return fuse.Errno(syscall.EACCES)
+	}
+	return nil
+}
+
+// setattrError reports changing the mode or the owner without being the owner as EPERM, the same as chmod(2) and chown(2)
+func setattrError(req *fuse.SetattrRequest, err error) error {
+	if err == fuse.Errno(syscall.EACCES) && (req.Valid.Mode() || req.Valid.Uid() || req.Valid.Gid()) {
+		return fuse.EPERM
+	}
+	return err
+}
@@ -0,0 +1,278 @@
+package filesys
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/chrislusf/seaweedfs/weed/filesys/meta_cache"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	weed_server "github.com/chrislusf/seaweedfs/weed/server"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// The tests run the mount against a real filer in the same process,
+// with a fake master and a fake volume server keeping the chunks in memory.
+
+var (
+	testWfs     *WFS
+	testWfsErr  error
+	testWfsOnce sync.Once
+	testFiler   *testCluster
+	testVolume  = &fakeVolumeServer{blobs: make(map[string][]byte)}
+)
+
+// testCluster is the filer shared by the mounts of the tests
+type testCluster struct {
+	dir              string
+	filerAddress     string
+	filerGrpcAddress string
+}
+
+// testFileSystem returns the mount shared by the tests, each test working in its own directory
+func testFileSystem(t *testing.T) *WFS {
+	testWfsOnce.Do(func() {
+		if testFiler, testWfsErr = startTestCluster(); testWfsErr == nil {
+			testWfs = testFiler.newFileSystem("mnt", nil)
+		}
+	})
+	if testWfsErr != nil {
+		t.Fatalf("start test file system: %v", testWfsErr)
+	}
+	return testWfs
+}
+
+// newFileSystem mounts the filer again, with its own cache directory
+func (c *testCluster) newFileSystem(name string, configure func(option *Option)) *WFS {
+	uidGidMapper, _ := meta_cache.NewUidGidMapper("", "")
+	option := &Option{
+		MountDirectory:     c.dir + "/" + name,
+		FilerAddress:       c.filerAddress,
+		FilerGrpcAddress:   c.filerGrpcAddress,
+		GrpcDialOption:     grpc.WithInsecure(),
+		FilerMountRootPath: "/",
+		Replication:        "000",
+		ChunkSizeLimit:     1024 * 1024,
+		CacheDir:           c.dir + "/cache_" + name,
+		EntryCacheTtl:      time.Second,
+		Umask:              0022,
+		MountUid:           0,
+		MountGid:           0,
+		MountMode:          os.ModeDir | 0777,
+		MountCtime:         time.Now(),
+		MountMtime:         time.Now(),
+		VolumeServerAccess: "direct",
+		UidGidMapper:       uidGidMapper,
+	}
+	if configure != nil {
+		configure(option)
+	}
+	return NewSeaweedFileSystem(option)
+}
+
+func startTestCluster() (*testCluster, error) {
+
+	dir, err := ioutil.TempDir("", "seaweedfs_filesys_test")
+	if err != nil {
+		return nil, err
+	}
+
+	// the filer checks the identities of the callers signed by the mount
+	util.GetViper().Set("jwt.filer_identity.key", "filesys test identity key")
+
+	volumeServer := httptest.NewServer(testVolume)
+	volumeUrl := strings.TrimPrefix(volumeServer.URL, "http://")
+
+	masterListener, err := listenWithGrpcPort()
+	if err != nil {
+		return nil, err
+	}
+	masterGrpcServer := grpc.NewServer()
+	master_pb.RegisterSeaweedServer(masterGrpcServer, &fakeMaster{volumeUrl: volumeUrl})
+	go masterGrpcServer.Serve(masterListener)
+
+	filerListener, err := listenWithGrpcPort()
+	if err != nil {
+		return nil, err
+	}
+	filerPort := filerListener.Addr().(*net.TCPAddr).Port - 10000
+	fs, err := weed_server.NewFilerServer(http.NewServeMux(), http.NewServeMux(), &weed_server.FilerOption{
+		Masters:            []string{fmt.Sprintf("127.0.0.1:%d", masterListener.Addr().(*net.TCPAddr).Port-10000)},
+		DefaultReplication: "000",
+		MaxMB:              4,
+		DefaultLevelDbDir:  dir + "/filerldb2",
+		DisableHttp:        true,
+		Host:               "127.0.0.1",
+		Port:               uint32(filerPort),
+	})
+	if err != nil {
+		return nil, err
+	}
+	filerGrpcServer := grpc.NewServer()
+	filer_pb.RegisterSeaweedFilerServer(filerGrpcServer, fs)
+	go filerGrpcServer.Serve(filerListener)
+
+	return &testCluster{
+		dir:              dir,
+		filerAddress:     fmt.Sprintf("127.0.0.1:%d", filerPort),
+		filerGrpcAddress: filerListener.Addr().String(),
+	}, nil
+}
+
+// listenWithGrpcPort listens on a port usable as the grpc port of a server, which is the http port + 10000
+func listenWithGrpcPort() (net.Listener, error) {
+	for i := 0; i < 100; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			return nil, err
+		}
+		if listener.Addr().(*net.TCPAddr).Port > 10000 {
+			return listener, nil
+		}
+		listener.Close()
+	}
+	return nil, fmt.Errorf("no port above 10000")
+}
+
+// fakeMaster assigns file ids on the only volume, served by the fake volume server,
+// and grants the locks of the only filer
+type fakeMaster struct {
+	master_pb.UnimplementedSeaweedServer
+	volumeUrl string
+
+	sync.Mutex
+	lastKey uint64
+}
+
+func (m *fakeMaster) LeaseAdminToken(ctx context.Context, req *master_pb.LeaseAdminTokenRequest) (*master_pb.LeaseAdminTokenResponse, error) {
+	return &master_pb.LeaseAdminTokenResponse{Token: 1, LockTsNs: time.Now().UnixNano()}, nil
+}
+
+func (m *fakeMaster) ReleaseAdminToken(ctx context.Context, req *master_pb.ReleaseAdminTokenRequest) (*master_pb.ReleaseAdminTokenResponse, error) {
+	return &master_pb.ReleaseAdminTokenResponse{}, nil
+}
+
+func (m *fakeMaster) GetMasterConfiguration(ctx context.Context, req *master_pb.GetMasterConfigurationRequest) (*master_pb.GetMasterConfigurationResponse, error) {
+	return &master_pb.GetMasterConfigurationResponse{DefaultReplication: "000"}, nil
+}
+
+func (m *fakeMaster) KeepConnected(stream master_pb.Seaweed_KeepConnectedServer) error {
+	if _, err := stream.Recv(); err != nil {
+		return err
+	}
+	if err := stream.Send(&master_pb.VolumeLocation{Url: m.volumeUrl, PublicUrl: m.volumeUrl, NewVids: []uint32{1}}); err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return nil
+		}
+	}
+}
+
+func (m *fakeMaster) Assign(ctx context.Context, req *master_pb.AssignRequest) (*master_pb.AssignResponse, error) {
+	m.Lock()
+	defer m.Unlock()
+	count := req.Count
+	if count == 0 {
+		count = 1
+	}
+	m.lastKey++
+	fid := needle.NewFileId(1, m.lastKey, 0x12345678)
+	m.lastKey += count - 1
+	return &master_pb.AssignResponse{Fid: fid.String(), Url: m.volumeUrl, PublicUrl: m.volumeUrl, Count: count}, nil
+}
+
+func (m *fakeMaster) LookupVolume(ctx context.Context, req *master_pb.LookupVolumeRequest) (*master_pb.LookupVolumeResponse, error) {
+	resp := &master_pb.LookupVolumeResponse{}
+	for _, vid := range req.VolumeIds {
+		resp.VolumeIdLocations = append(resp.VolumeIdLocations, &master_pb.LookupVolumeResponse_VolumeIdLocation{
+			VolumeId:  vid,
+			Locations: []*master_pb.Location{{Url: m.volumeUrl, PublicUrl: m.volumeUrl}},
+		})
+	}
+	return resp, nil
+}
+
+// fakeVolumeServer keeps the uploaded chunks uncompressed in memory
+type fakeVolumeServer struct {
+	sync.Mutex
+	blobs         map[string][]byte
+	uploadedBytes int64
+}
+
+func (v *fakeVolumeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fid := strings.TrimPrefix(r.URL.Path, "/")
+	switch r.Method {
+	case http.MethodPost, http.MethodPut:
+		reader, err := r.MultipartReader()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		part, err := reader.NextPart()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, err := ioutil.ReadAll(part)
+		if err == nil && part.Header.Get("Content-Encoding") == "gzip" {
+			var gz *gzip.Reader
+			if gz, err = gzip.NewReader(bytes.NewReader(data)); err == nil {
+				data, err = ioutil.ReadAll(gz)
+			}
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		v.Lock()
+		v.blobs[fid] = data
+		v.uploadedBytes += int64(len(data))
+		v.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"name": part.FileName(),
+			"size": len(data),
+			"eTag": util.Md5String(data),
+		})
+	case http.MethodGet, http.MethodHead:
+		v.Lock()
+		data, found := v.blobs[fid]
+		v.Unlock()
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(data))
+	case http.MethodDelete:
+		v.Lock()
+		delete(v.blobs, fid)
+		v.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// uploaded returns the number of bytes written to the volume server so far
+func (v *fakeVolumeServer) uploaded() int64 {
+	v.Lock()
+	defer v.Unlock()
+	return v.uploadedBytes
+}
@@ -62,6 +62,7 @@ func (dir *Dir) Attr(ctx context.Context, attr *fuse.Attr) error {
 	attr.Mode = os.FileMode(dir.entry.Attributes.FileMode) | os.ModeDir
 	attr.Mtime = time.Unix(dir.entry.Attributes.Mtime, 0)
 	attr.Crtime = time.Unix(dir.entry.Attributes.Crtime, 0)
+	attr.Atime = accessTime(dir.entry.Attributes)
 	attr.Gid = dir.entry.Attributes.Gid
 	attr.Uid = dir.entry.Attributes.Uid
 
@@ -180,7 +181,7 @@ func (dir *Dir) doCreateEntry(name string, mode os.FileMode, uid, gid uint32, ex
 	}
 	glog.V(1).Infof("create %s/%s", dir.FullPath(), name)
 
-	err := dir.wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+	err := dir.wfs.asCaller(uid, gid).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		dir.wfs.mapPbIdFromLocalToFiler(request.Entry)
 		defer dir.wfs.mapPbIdFromFilerToLocal(request.Entry)
@@ -190,7 +191,7 @@ func (dir *Dir) doCreateEntry(name string, mode os.FileMode, uid, gid uint32, ex
 				return fuse.EEXIST
 			}
 			glog.V(0).Infof("create %s/%s: %v", dir.FullPath(), name, err)
-			return fuse.EIO
+			return toFuseError(err)
 		}
 
 		dir.wfs.metaCache.InsertEntry(context.Background(), filer.FromPbEntry(request.Directory, request.Entry))
@@ -216,7 +217,7 @@ func (dir *Dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, err
 		},
 	}
 
-	err := dir.wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+	err := dir.wfs.asCaller(req.Header.Uid, req.Header.Gid).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		dir.wfs.mapPbIdFromLocalToFiler(newEntry)
 		defer dir.wfs.mapPbIdFromFilerToLocal(newEntry)
@@ -246,7 +247,7 @@ func (dir *Dir) Mkdir(ctx context.Context, req *fuse.MkdirRequest) (fs.Node, err
 
 	glog.V(0).Infof("mkdir %s/%s: %v", dir.FullPath(), req.Name, err)
 
-	return nil, fuse.EIO
+	return nil, toFuseError(err)
 }
 
 func (dir *Dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.LookupResponse) (node fs.Node, err error) {
@@ -255,7 +256,8 @@ func (dir *Dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.
 
 	fullFilePath := util.NewFullPath(dir.FullPath(), req.Name)
 	dirPath := util.FullPath(dir.FullPath())
-	visitErr := meta_cache.EnsureVisited(dir.wfs.metaCache, dir.wfs, dirPath)
+	caller := dir.wfs.asCaller(req.Header.Uid, req.Header.Gid)
+	visitErr := meta_cache.EnsureVisited(dir.wfs.metaCache, caller, dirPath)
 	if visitErr != nil {
 		glog.Errorf("dir Lookup %s: %v", dirPath, visitErr)
 		return nil, fuse.EIO
@@ -268,7 +270,7 @@ func (dir *Dir) Lookup(ctx context.Context, req *fuse.LookupRequest, resp *fuse.
 
 	if entry == nil {
 		// glog.V(3).Infof("dir Lookup cache miss %s", fullFilePath)
-		entry, err = filer_pb.GetEntry(dir.wfs, fullFilePath)
+		entry, err = filer_pb.GetEntry(caller, fullFilePath)
 		if err != nil {
 			glog.V(1).Infof("dir GetEntry %s: %v", fullFilePath, err)
 			return nil, fuse.ENOENT
@@ -367,7 +369,7 @@ func (dir *Dir) Remove(ctx context.Context, req *fuse.RemoveRequest) error {
 func (dir *Dir) removeOneFile(req *fuse.RemoveRequest) error {
 
 	filePath := util.NewFullPath(dir.FullPath(), req.Name)
-	entry, err := filer_pb.GetEntry(dir.wfs, filePath)
+	entry, err := filer_pb.GetEntry(dir.wfs.asCaller(req.Header.Uid, req.Header.Gid), filePath)
 	if err != nil {
 		return err
 	}
@@ -378,9 +380,12 @@ func (dir *Dir) removeOneFile(req *fuse.RemoveRequest) error {
 	// first, ensure the filer store can correctly delete
 	glog.V(3).Infof("remove file: %v", req)
 	isDeleteData := entry.HardLinkCounter <= 1
-	err = filer_pb.Remove(dir.wfs, dir.FullPath(), req.Name, isDeleteData, false, false, false, []int32{dir.wfs.signature})
+	err = filer_pb.Remove(dir.wfs.asCaller(req.Header.Uid, req.Header.Gid), dir.FullPath(), req.Name, isDeleteData, false, false, false, []int32{dir.wfs.signature})
 	if err != nil {
 		glog.V(3).Infof("not found remove file %s/%s: %v", dir.FullPath(), req.Name, err)
+		if filer_pb.IsPermissionDenied(err) {
+			return toFuseError(err)
+		}
 		return fuse.ENOENT
 	}
 
@@ -410,12 +415,15 @@ func (dir *Dir) removeFolder(req *fuse.RemoveRequest) error {
 
 	glog.V(3).Infof("remove directory entry: %v", req)
 	ignoreRecursiveErr := true // ignore recursion error since the OS should manage it
-	err := filer_pb.Remove(dir.wfs, dir.FullPath(), req.Name, true, false, ignoreRecursiveErr, false, []int32{dir.wfs.signature})
+	err := filer_pb.Remove(dir.wfs.asCaller(req.Header.Uid, req.Header.Gid), dir.FullPath(), req.Name, true, false, ignoreRecursiveErr, false, []int32{dir.wfs.signature})
 	if err != nil {
 		glog.V(0).Infof("remove %s/%s: %v", dir.FullPath(), req.Name, err)
 		if strings.Contains(err.Error(), "non-empty") {
 			return fuse.EEXIST
 		}
+		if filer_pb.IsPermissionDenied(err) {
+			return toFuseError(err)
+		}
 		return fuse.ENOENT
 	}
 
@@ -451,7 +459,11 @@ func (dir *Dir) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *fus
 		dir.entry.Attributes.Mtime = req.Mtime.Unix()
 	}
 
-	return dir.saveEntry()
+	if req.Valid.Atime() {
+		dir.entry.Attributes.Atime = setattrTime(req.Atime, req.Valid.AtimeNow())
+	}
+
+	return setattrError(req, dir.saveEntry(req.Header.Uid, req.Header.Gid))
 
 }
 
@@ -467,7 +479,7 @@ func (dir *Dir) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error {
 		return err
 	}
 
-	return dir.saveEntry()
+	return dir.saveEntry(req.Header.Uid, req.Header.Gid)
 
 }
 
@@ -483,7 +495,7 @@ func (dir *Dir) Removexattr(ctx context.Context, req *fuse.RemovexattrRequest) e
 		return err
 	}
 
-	return dir.saveEntry()
+	return dir.saveEntry(req.Header.Uid, req.Header.Gid)
 
 }
 
@@ -521,11 +533,11 @@ func (dir *Dir) maybeLoadEntry() error {
 	return nil
 }
 
-func (dir *Dir) saveEntry() error {
+func (dir *Dir) saveEntry(uid, gid uint32) error {
 
 	parentDir, name := util.FullPath(dir.FullPath()).DirAndName()
 
-	return dir.wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+	return dir.wfs.asCaller(uid, gid).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		dir.wfs.mapPbIdFromLocalToFiler(dir.entry)
 		defer dir.wfs.mapPbIdFromFilerToLocal(dir.entry)
@@ -540,7 +552,7 @@ func (dir *Dir) saveEntry() error {
 		_, err := client.UpdateEntry(context.Background(), request)
 		if err != nil {
 			glog.Errorf("UpdateEntry dir %s/%s: %v", parentDir, name, err)
-			return fuse.EIO
+			return toFuseError(err)
 		}
 
 		dir.wfs.metaCache.UpdateEntry(context.Background(), filer.FromPbEntry(request.Directory, request.Entry))
@@ -28,7 +28,7 @@ func (dir *Dir) Rename(ctx context.Context, req *fuse.RenameRequest, newDirector
 	}
 
 	// update remote filer
-	err = dir.wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+	err = dir.wfs.asCaller(req.Header.Uid, req.Header.Gid).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
 
@@ -42,6 +42,9 @@ func (dir *Dir) Rename(ctx context.Context, req *fuse.RenameRequest, newDirector
 		_, err := client.AtomicRenameEntry(ctx, request)
 		if err != nil {
 			glog.Errorf("dir AtomicRenameEntry %s => %s : %v", oldPath, newPath, err)
+			if filer_pb.IsPermissionDenied(err) {
+				return err
+			}
 			return fuse.EXDEV
 		}
 
@@ -50,7 +53,7 @@ func (dir *Dir) Rename(ctx context.Context, req *fuse.RenameRequest, newDirector
 	})
 	if err != nil {
 		glog.V(0).Infof("dir Rename %s => %s : %v", oldPath, newPath, err)
-		return fuse.EIO
+		return toFuseError(err)
 	}
 
 	// TODO: replicate renaming logic on filer
@@ -80,3 +83,73 @@ func (dir *Dir) Rename(ctx context.Context, req *fuse.RenameRequest, newDirector
 
 	return err
 }
+
+// RenameExchange atomically swaps two existing entries, for renameat2(2) with RENAME_EXCHANGE.
+func (dir *Dir) RenameExchange(ctx context.Context, header fuse.Header, oldName string, newDir *Dir, newName string) error {
+
+	oldPath := util.NewFullPath(dir.FullPath(), oldName)
+	newPath := util.NewFullPath(newDir.FullPath(), newName)
+
+	glog.V(4).Infof("dir RenameExchange %s <=> %s", oldPath, newPath)
+
+	oldEntry, err := dir.wfs.metaCache.FindEntry(context.Background(), oldPath)
+	if err != nil {
+		return fuse.ENOENT
+	}
+	newEntry, err := dir.wfs.metaCache.FindEntry(context.Background(), newPath)
+	if err != nil {
+		return fuse.ENOENT
+	}
+	if oldPath == newPath {
+		return nil
+	}
+
+	err = dir.wfs.asCaller(header.Uid, header.Gid).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		_, err := client.AtomicRenameEntry(ctx, &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: dir.FullPath(),
+			OldName:      oldName,
+			NewDirectory: newDir.FullPath(),
+			NewName:      newName,
+			Exchange:     true,
+		})
+		return err
+	})
+	if err != nil {
+		glog.V(0).Infof("dir RenameExchange %s <=> %s : %v", oldPath, newPath, err)
+		return toFuseError(err)
+	}
+
+	// swap the local entries, the nodes, and the open file handles
+	if err := dir.wfs.metaCache.DeleteEntry(context.Background(), oldPath); err != nil {
+		return fuse.EIO
+	}
+	if err := dir.wfs.metaCache.DeleteEntry(context.Background(), newPath); err != nil {
+		return fuse.EIO
+	}
+	oldEntry.FullPath, newEntry.FullPath = newPath, oldPath
+	if err := dir.wfs.metaCache.InsertEntry(context.Background(), oldEntry); err != nil {
+		return fuse.EIO
+	}
+	if err := dir.wfs.metaCache.InsertEntry(context.Background(), newEntry); err != nil {
+		return fuse.EIO
+	}
+
+	tempPath := util.NewFullPath(dir.FullPath(), "."+oldName+".exchange")
+	dir.wfs.fsNodeCache.Move(oldPath, tempPath)
+	dir.wfs.fsNodeCache.Move(newPath, oldPath)
+	dir.wfs.fsNodeCache.Move(tempPath, newPath)
+
+	dir.wfs.handlesLock.Lock()
+	defer dir.wfs.handlesLock.Unlock()
+	oldHandle, newHandle := dir.wfs.handles[oldPath.AsInode()], dir.wfs.handles[newPath.AsInode()]
+	delete(dir.wfs.handles, oldPath.AsInode())
+	delete(dir.wfs.handles, newPath.AsInode())
+	if oldHandle != nil {
+		dir.wfs.handles[newPath.AsInode()] = oldHandle
+	}
+	if newHandle != nil {
+		dir.wfs.handles[oldPath.AsInode()] = newHandle
+	}
+
+	return nil
+}
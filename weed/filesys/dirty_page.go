@@ -10,6 +10,20 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
 )
 
+// DirtyPages buffers the data written to a file handle, and uploads it to volume servers
+type DirtyPages interface {
+	AddPage(offset int64, data []byte)
+	// FlushData uploads all buffered data, and waits for the uploads
+	FlushData() error
+	ReadDirtyDataAt(data []byte, startOffset int64) (maxStop int64)
+	GetStorageOptions() (collection, replication string)
+	// Commit is called after the file metadata, with all uploaded chunks, is saved to the filer
+	Commit()
+}
+
+var _ = DirtyPages(&ContinuousDirtyPages{})
+var _ = DirtyPages(&SwapDirtyPages{})
+
 type ContinuousDirtyPages struct {
 	intervals      *ContinuousIntervals
 	f              *File
@@ -20,7 +34,14 @@ type ContinuousDirtyPages struct {
 	replication    string
 }
 
-func newDirtyPages(file *File) *ContinuousDirtyPages {
+func newDirtyPages(file *File) DirtyPages {
+	if file.wfs.swapFile != nil {
+		return newSwapDirtyPages(file, file.wfs.swapFile)
+	}
+	return newContinuousDirtyPages(file)
+}
+
+func newContinuousDirtyPages(file *File) *ContinuousDirtyPages {
 	dirtyPages := &ContinuousDirtyPages{
 		intervals: &ContinuousIntervals{},
 		f:         file,
@@ -96,9 +117,9 @@ func (pages *ContinuousDirtyPages) saveToStorage(reader io.Reader, offset int64,
 			return
 		}
 		chunk.Mtime = mtime
-		pages.collection, pages.replication = collection, replication
 		pages.chunkAddLock.Lock()
 		defer pages.chunkAddLock.Unlock()
+		pages.collection, pages.replication = collection, replication
 		pages.f.addChunks([]*filer_pb.FileChunk{chunk})
 		glog.V(3).Infof("%s saveToStorage [%d,%d)", pages.f.fullpath(), offset, offset+size)
 	}
@@ -126,3 +147,16 @@ func min(x, y int64) int64 {
 func (pages *ContinuousDirtyPages) ReadDirtyDataAt(data []byte, startOffset int64) (maxStop int64) {
 	return pages.intervals.ReadDataAt(data, startOffset)
 }
+
+func (pages *ContinuousDirtyPages) FlushData() error {
+	pages.saveExistingPagesToStorage()
+	pages.writeWaitGroup.Wait()
+	return pages.lastErr
+}
+
+func (pages *ContinuousDirtyPages) GetStorageOptions() (collection, replication string) {
+	return pages.collection, pages.replication
+}
+
+func (pages *ContinuousDirtyPages) Commit() {
+}
@@ -0,0 +1,258 @@
+package filesys
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// a file may have this many slots being written at the same time, e.g., for random writes
+const maxWritableSlotsPerFile = 16
+
+// SwapDirtyPages buffers the dirty pages of a file in the swap file.
+// The file is divided into slot-sized chunks, and each chunk being written has a slot.
+// A slot is sealed once it is fully written, or when the file has too many slots,
+// and the sealed slot is uploaded asynchronously.
+type SwapDirtyPages struct {
+	f              *File
+	swapFile       *SwapFile
+	slotsLock      sync.Mutex
+	writableSlots  map[int64]*swapSlot
+	sealedSlots    []*swapSlot
+	writeWaitGroup sync.WaitGroup
+	chunkAddLock   sync.Mutex
+	lastErr        error
+	collection     string
+	replication    string
+}
+
+type swapSlot struct {
+	slot        int
+	chunkIndex  int64
+	intervals   writtenIntervals
+	lastWriteNs int64
+}
+
+func newSwapDirtyPages(file *File, swapFile *SwapFile) *SwapDirtyPages {
+	return &SwapDirtyPages{
+		f:             file,
+		swapFile:      swapFile,
+		writableSlots: make(map[int64]*swapSlot),
+	}
+}
+
+func (pages *SwapDirtyPages) AddPage(offset int64, data []byte) {
+
+	glog.V(4).Infof("%s AddPage [%d,%d) of %d bytes", pages.f.fullpath(), offset, offset+int64(len(data)), pages.f.entry.Attributes.FileSize)
+
+	slotSize := pages.swapFile.slotSize
+	for len(data) > 0 {
+		chunkIndex := offset / slotSize
+		inSlotOffset := offset % slotSize
+		n := min(int64(len(data)), slotSize-inSlotOffset)
+		pages.execute(pages.addToSlot(chunkIndex, inSlotOffset, data[:n]))
+		offset += n
+		data = data[n:]
+	}
+}
+
+// addToSlot writes the data to the slot of the chunk, and returns the uploads to run without holding the lock
+func (pages *SwapDirtyPages) addToSlot(chunkIndex int64, inSlotOffset int64, data []byte) (uploads []func()) {
+
+	pages.slotsLock.Lock()
+	defer pages.slotsLock.Unlock()
+
+	slot, found := pages.writableSlots[chunkIndex]
+	if !found {
+		if len(pages.writableSlots) >= maxWritableSlotsPerFile {
+			uploads = append(uploads, pages.sealLeastRecentlyWritten()...)
+		}
+		slotIndex := pages.swapFile.AllocateSlot()
+		if slotIndex < 0 {
+			// the swap file is full, upload the data directly
+			glog.V(3).Infof("%s swap file is full", pages.f.fullpath())
+			uploads = append(uploads, pages.sealAll()...)
+			return append(uploads, pages.uploadDirectly(chunkIndex*pages.swapFile.slotSize+inSlotOffset, data))
+		}
+		slot = &swapSlot{
+			slot:       slotIndex,
+			chunkIndex: chunkIndex,
+		}
+		pages.writableSlots[chunkIndex] = slot
+	}
+
+	if err := pages.swapFile.WriteSlot(slot.slot, pages.f.fullpath(), chunkIndex, inSlotOffset, data); err != nil {
+		glog.Errorf("%s write swap file: %v", pages.f.fullpath(), err)
+		return append(uploads, pages.uploadDirectly(chunkIndex*pages.swapFile.slotSize+inSlotOffset, data))
+	}
+	slot.intervals.add(inSlotOffset, inSlotOffset+int64(len(data)))
+	slot.lastWriteNs = time.Now().UnixNano()
+
+	if slot.intervals.covers(pages.swapFile.slotSize) {
+		uploads = append(uploads, pages.seal(slot))
+	}
+	return
+}
+
+func (pages *SwapDirtyPages) sealLeastRecentlyWritten() (uploads []func()) {
+	var oldest *swapSlot
+	for _, slot := range pages.writableSlots {
+		if oldest == nil || slot.lastWriteNs < oldest.lastWriteNs {
+			oldest = slot
+		}
+	}
+	if oldest != nil {
+		uploads = append(uploads, pages.seal(oldest))
+	}
+	return
+}
+
+func (pages *SwapDirtyPages) sealAll() (uploads []func()) {
+	for _, slot := range pages.writableSlots {
+		uploads = append(uploads, pages.seal(slot))
+	}
+	return
+}
+
+// seal stops writing to the slot, and returns the upload of its data. Must be called with slotsLock held.
+func (pages *SwapDirtyPages) seal(slot *swapSlot) (upload func()) {
+
+	delete(pages.writableSlots, slot.chunkIndex)
+	pages.sealedSlots = append(pages.sealedSlots, slot)
+
+	// the data beyond the file size has been truncated
+	slotOffset := slot.chunkIndex * pages.swapFile.slotSize
+	fileSize := int64(pages.f.entry.Attributes.FileSize)
+	mtime := time.Now().UnixNano()
+	fullpath := pages.f.fullpath()
+
+	pages.writeWaitGroup.Add(1)
+	return func() {
+		defer pages.writeWaitGroup.Done()
+
+		var chunks []*filer_pb.FileChunk
+		for _, t := range slot.intervals {
+			start, stop := slotOffset+t.start, min(slotOffset+t.stop, fileSize)
+			if start >= stop {
+				continue
+			}
+			reader := pages.swapFile.SlotReader(slot.slot, t.start, stop-slotOffset)
+			chunk, collection, replication, err := pages.f.wfs.saveDataAsChunk(fullpath)(reader, pages.f.Name, start)
+			if err != nil {
+				glog.V(0).Infof("%s saveToStorage [%d,%d): %v", fullpath, start, stop, err)
+				pages.lastErr = err
+				continue
+			}
+			chunk.Mtime = mtime
+			pages.collection, pages.replication = collection, replication
+			chunks = append(chunks, chunk)
+			glog.V(3).Infof("%s saveToStorage [%d,%d)", fullpath, start, stop)
+		}
+
+		if len(chunks) > 0 {
+			pages.chunkAddLock.Lock()
+			pages.f.addChunks(chunks)
+			pages.chunkAddLock.Unlock()
+		}
+
+		pages.slotsLock.Lock()
+		for i, s := range pages.sealedSlots {
+			if s == slot {
+				pages.sealedSlots = append(pages.sealedSlots[:i], pages.sealedSlots[i+1:]...)
+				break
+			}
+		}
+		pages.slotsLock.Unlock()
+
+		pages.swapFile.ReleaseSlot(slot.slot, fullpath, chunks)
+	}
+}
+
+func (pages *SwapDirtyPages) uploadDirectly(offset int64, data []byte) (upload func()) {
+
+	mtime := time.Now().UnixNano()
+	fullpath := pages.f.fullpath()
+	// the write request buffer is reused once the request is answered
+	data = append([]byte(nil), data...)
+
+	pages.writeWaitGroup.Add(1)
+	return func() {
+		defer pages.writeWaitGroup.Done()
+
+		chunk, collection, replication, err := pages.f.wfs.saveDataAsChunk(fullpath)(bytes.NewReader(data), pages.f.Name, offset)
+		if err != nil {
+			glog.V(0).Infof("%s saveToStorage [%d,%d): %v", fullpath, offset, offset+int64(len(data)), err)
+			pages.lastErr = err
+			return
+		}
+		chunk.Mtime = mtime
+		pages.collection, pages.replication = collection, replication
+		pages.chunkAddLock.Lock()
+		pages.f.addChunks([]*filer_pb.FileChunk{chunk})
+		pages.chunkAddLock.Unlock()
+
+		pages.swapFile.ReleaseSlot(-1, fullpath, []*filer_pb.FileChunk{chunk})
+	}
+}
+
+// execute runs the uploads through the concurrent writers
+func (pages *SwapDirtyPages) execute(uploads []func()) {
+	for _, upload := range uploads {
+		if pages.f.wfs.concurrentWriters != nil {
+			pages.f.wfs.concurrentWriters.Execute(upload)
+		} else {
+			go upload()
+		}
+	}
+}
+
+func (pages *SwapDirtyPages) FlushData() error {
+	pages.slotsLock.Lock()
+	uploads := pages.sealAll()
+	pages.slotsLock.Unlock()
+
+	pages.execute(uploads)
+	pages.writeWaitGroup.Wait()
+
+	return pages.lastErr
+}
+
+// ReadDirtyDataAt overlays the data of the sealed slots, and then the newer writable slots
+func (pages *SwapDirtyPages) ReadDirtyDataAt(data []byte, startOffset int64) (maxStop int64) {
+
+	pages.slotsLock.Lock()
+	defer pages.slotsLock.Unlock()
+
+	slots := append([]*swapSlot{}, pages.sealedSlots...)
+	for _, slot := range pages.writableSlots {
+		slots = append(slots, slot)
+	}
+
+	stopOffset := startOffset + int64(len(data))
+	for _, slot := range slots {
+		slotOffset := slot.chunkIndex * pages.swapFile.slotSize
+		for _, t := range slot.intervals {
+			start, stop := max(startOffset, slotOffset+t.start), min(stopOffset, slotOffset+t.stop)
+			if start >= stop {
+				continue
+			}
+			if err := pages.swapFile.ReadSlot(slot.slot, data[start-startOffset:stop-startOffset], start-slotOffset); err != nil {
+				glog.Errorf("%s read swap file: %v", pages.f.fullpath(), err)
+				continue
+			}
+			maxStop = max(maxStop, stop)
+		}
+	}
+	return
+}
+
+func (pages *SwapDirtyPages) GetStorageOptions() (collection, replication string) {
+	return pages.collection, pages.replication
+}
+
+func (pages *SwapDirtyPages) Commit() {
+	pages.swapFile.Commit(pages.f.fullpath())
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"sort"
+	"sync"
 	"time"
 
 	"github.com/seaweedfs/fuse"
@@ -32,6 +33,7 @@ type File struct {
 	Name           string
 	dir            *Dir
 	wfs            *WFS
+	entryLock      sync.Mutex // guards replacing entry, entryViewCache and reader
 	entry          *filer_pb.Entry
 	entryViewCache []filer.VisibleInterval
 	isOpen         int
@@ -56,6 +58,10 @@ func (file *File) Attr(ctx context.Context, attr *fuse.Attr) (err error) {
 
 	// attr.Inode = file.fullpath().AsInode()
 	attr.Valid = time.Second
+	if file.wfs.isStrictConsistency() {
+		// the kernel asks again, so that the size revalidated on open is used
+		attr.Valid = 0
+	}
 	attr.Mode = os.FileMode(entry.Attributes.FileMode)
 	attr.Size = filer.FileSize(entry)
 	if file.isOpen > 0 {
@@ -64,6 +70,7 @@ func (file *File) Attr(ctx context.Context, attr *fuse.Attr) (err error) {
 	}
 	attr.Crtime = time.Unix(entry.Attributes.Crtime, 0)
 	attr.Mtime = time.Unix(entry.Attributes.Mtime, 0)
+	attr.Atime = accessTime(entry.Attributes)
 	attr.Gid = entry.Attributes.Gid
 	attr.Uid = entry.Attributes.Uid
 	attr.Blocks = attr.Size/blockSize + 1
@@ -92,6 +99,12 @@ func (file *File) Open(ctx context.Context, req *fuse.OpenRequest, resp *fuse.Op
 
 	glog.V(4).Infof("file %v open %+v", file.fullpath(), req)
 
+	if file.isOpen <= 0 {
+		if err := file.wfs.revalidateOnOpen(ctx, file, req); err != nil {
+			return nil, err
+		}
+	}
+
 	handle := file.wfs.AcquireHandle(file, req.Uid, req.Gid)
 
 	resp.Handle = fuse.HandleID(handle.handle)
@@ -116,8 +129,8 @@ func (file *File) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *f
 		file.wfs.handlesLock.Unlock()
 
 		if fileHandle != nil {
-			fileHandle.Lock()
-			defer fileHandle.Unlock()
+			fileHandle.handleLock.Lock()
+			defer fileHandle.handleLock.Unlock()
 		}
 	}
 
@@ -176,6 +189,11 @@ func (file *File) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *f
 		file.dirtyMetadata = true
 	}
 
+	if req.Valid.Atime() {
+		file.entry.Attributes.Atime = setattrTime(req.Atime, req.Valid.AtimeNow())
+		file.dirtyMetadata = true
+	}
+
 	if req.Valid.Handle() {
 		// fmt.Printf("file handle => %d\n", req.Handle)
 	}
@@ -188,7 +206,7 @@ func (file *File) Setattr(ctx context.Context, req *fuse.SetattrRequest, resp *f
 		return nil
 	}
 
-	return file.saveEntry(file.entry)
+	return setattrError(req, file.saveEntry(req.Header.Uid, req.Header.Gid, file.entry))
 
 }
 
@@ -205,7 +223,7 @@ func (file *File) Setxattr(ctx context.Context, req *fuse.SetxattrRequest) error
 		return err
 	}
 
-	return file.saveEntry(entry)
+	return file.saveEntry(req.Header.Uid, req.Header.Gid, entry)
 
 }
 
@@ -222,7 +240,7 @@ func (file *File) Removexattr(ctx context.Context, req *fuse.RemovexattrRequest)
 		return err
 	}
 
-	return file.saveEntry(entry)
+	return file.saveEntry(req.Header.Uid, req.Header.Gid, entry)
 
 }
 
@@ -255,6 +273,7 @@ func (file *File) Forget() {
 	t := util.NewFullPath(file.dir.FullPath(), file.Name)
 	glog.V(4).Infof("Forget file %s", t)
 	file.wfs.fsNodeCache.DeleteFsNode(t)
+	file.wfs.releaseLease(t)
 }
 
 func (file *File) maybeLoadEntry(ctx context.Context) (entry *filer_pb.Entry, err error) {
@@ -290,6 +309,9 @@ func lessThan(a, b *filer_pb.FileChunk) bool {
 
 func (file *File) addChunks(chunks []*filer_pb.FileChunk) {
 
+	file.entryLock.Lock()
+	defer file.entryLock.Unlock()
+
 	// find the earliest incoming chunk
 	newChunks := chunks
 	earliestChunk := newChunks[0]
@@ -324,19 +346,23 @@ func (file *File) addChunks(chunks []*filer_pb.FileChunk) {
 }
 
 func (file *File) setEntry(entry *filer_pb.Entry) {
+	file.entryLock.Lock()
+	defer file.entryLock.Unlock()
 	file.entry = entry
 	file.entryViewCache, _ = filer.NonOverlappingVisibleIntervals(file.wfs.LookupFn(), entry.Chunks)
 	file.reader = nil
 }
 
 func (file *File) clearEntry() {
+	file.entryLock.Lock()
+	defer file.entryLock.Unlock()
 	file.entry = nil
 	file.entryViewCache = nil
 	file.reader = nil
 }
 
-func (file *File) saveEntry(entry *filer_pb.Entry) error {
-	return file.wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+func (file *File) saveEntry(uid, gid uint32, entry *filer_pb.Entry) error {
+	return file.wfs.asCaller(uid, gid).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		file.wfs.mapPbIdFromLocalToFiler(entry)
 		defer file.wfs.mapPbIdFromFilerToLocal(entry)
@@ -351,7 +377,7 @@ func (file *File) saveEntry(entry *filer_pb.Entry) error {
 		_, err := client.UpdateEntry(context.Background(), request)
 		if err != nil {
 			glog.Errorf("UpdateEntry file %s/%s: %v", file.dir.FullPath(), file.Name, err)
-			return fuse.EIO
+			return toFuseError(err)
 		}
 
 		file.wfs.metaCache.UpdateEntry(context.Background(), filer.FromPbEntry(request.Directory, request.Entry))
@@ -359,3 +385,30 @@ func (file *File) saveEntry(entry *filer_pb.Entry) error {
 		return nil
 	})
 }
+
+// relatime tells whether reading the entry should update its access time,
+// with the default relatime policy of linux: only once after each change, or once a day.
+func relatime(attributes *filer_pb.FuseAttributes, now time.Time) bool {
+	if attributes == nil {
+		return false
+	}
+	if attributes.Atime <= attributes.Mtime || attributes.Atime <= attributes.Crtime {
+		return true
+	}
+	return now.Unix()-attributes.Atime >= int64(24*time.Hour/time.Second)
+}
+
+// accessTime falls back to the modification time for entries saved without an access time
+func accessTime(attributes *filer_pb.FuseAttributes) time.Time {
+	if attributes.Atime == 0 {
+		return time.Unix(attributes.Mtime, 0)
+	}
+	return time.Unix(attributes.Atime, 0)
+}
+
+func setattrTime(t time.Time, now bool) int64 {
+	if now {
+		return time.Now().Unix()
+	}
+	return t.Unix()
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/seaweedfs/fuse"
@@ -20,10 +21,16 @@ import (
 
 type FileHandle struct {
 	// cache file has been written to
-	dirtyPages  *ContinuousDirtyPages
+	dirtyPages  DirtyPages
 	contentType string
 	handle      uint64
-	sync.RWMutex
+	handleLock  sync.RWMutex
+
+	// detects sequential reads to read ahead
+	readerPattern *filer.ReaderPattern
+
+	// set by reads when the access time is due to be updated, accessed atomically
+	atimeDirty int32
 
 	f         *File
 	RequestId fuse.RequestID // unique ID for request
@@ -31,14 +38,19 @@ type FileHandle struct {
 	Uid       uint32         // user ID of process making request
 	Gid       uint32         // group ID of process making request
 
+	// owners holding advisory locks on the file, released when the file is closed
+	locksLock   sync.Mutex
+	posixOwners map[fuse.LockOwner]bool
+	flockOwners map[fuse.LockOwner]bool
 }
 
 func newFileHandle(file *File, uid, gid uint32) *FileHandle {
 	fh := &FileHandle{
-		f:          file,
-		dirtyPages: newDirtyPages(file),
-		Uid:        uid,
-		Gid:        gid,
+		f:             file,
+		dirtyPages:    newDirtyPages(file),
+		readerPattern: filer.NewReaderPattern(file.wfs.option.ReadAheadSizeMB * 1024 * 1024),
+		Uid:           uid,
+		Gid:           gid,
 	}
 	if fh.f.entry != nil {
 		fh.f.entry.Attributes.FileSize = filer.FileSize(fh.f.entry)
@@ -58,8 +70,8 @@ var _ = fs.HandleReleaser(&FileHandle{})
 func (fh *FileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fuse.ReadResponse) error {
 
 	glog.V(4).Infof("%s read fh %d: [%d,%d) size %d resp.Data cap=%d", fh.f.fullpath(), fh.handle, req.Offset, req.Offset+int64(req.Size), req.Size, cap(resp.Data))
-	fh.RLock()
-	defer fh.RUnlock()
+	fh.handleLock.RLock()
+	defer fh.handleLock.RUnlock()
 
 	if req.Size <= 0 {
 		return nil
@@ -92,6 +104,9 @@ func (fh *FileHandle) Read(ctx context.Context, req *fuse.ReadRequest, resp *fus
 	}
 	if err == nil {
 		resp.Data = buff[:totalRead]
+		if entry := fh.f.entry; entry != nil && relatime(entry.Attributes, time.Now()) {
+			atomic.StoreInt32(&fh.atimeDirty, 1)
+		}
 	}
 
 	return err
@@ -129,12 +144,21 @@ func (fh *FileHandle) readFromChunks(buff []byte, offset int64) (int64, error) {
 	reader := fh.f.reader
 	if reader == nil {
 		chunkViews := filer.ViewFromVisibleIntervals(fh.f.entryViewCache, 0, math.MaxInt64)
-		reader = filer.NewChunkReaderAtFromClient(fh.f.wfs.LookupFn(), chunkViews, fh.f.wfs.chunkCache, fileSize)
+		chunkReader := filer.NewChunkReaderAtFromClient(fh.f.wfs.LookupFn(), chunkViews, fh.f.wfs.chunkCacheForRead(), fileSize)
+		if fh.f.wfs.readAhead != nil {
+			chunkReader.SetReadAhead(fh.f.wfs.readAhead)
+		}
+		reader = chunkReader
 	}
 	fh.f.reader = reader
 
+	fh.readerPattern.MonitorReadAt(offset, len(buff))
 	totalRead, err := reader.ReadAt(buff, offset)
 
+	if chunkReader, ok := reader.(*filer.ChunkReadAt); ok && err == nil {
+		chunkReader.Prefetch(offset+int64(totalRead), fh.readerPattern.ReadAheadWindow())
+	}
+
 	if err != nil && err != io.EOF {
 		glog.Errorf("file handle read %s: %v", fh.f.fullpath(), err)
 	}
@@ -147,8 +171,8 @@ func (fh *FileHandle) readFromChunks(buff []byte, offset int64) (int64, error) {
 // Write to the file handle
 func (fh *FileHandle) Write(ctx context.Context, req *fuse.WriteRequest, resp *fuse.WriteResponse) error {
 
-	fh.Lock()
-	defer fh.Unlock()
+	fh.handleLock.Lock()
+	defer fh.handleLock.Unlock()
 
 	// write the request to volume servers
 	data := req.Data
@@ -158,15 +182,21 @@ func (fh *FileHandle) Write(ctx context.Context, req *fuse.WriteRequest, resp *f
 		copy(data, req.Data)
 	}
 
+	offset := req.Offset
+	if req.FileFlags&fuse.OpenAppend != 0 {
+		// the kernel appends at the size it has cached, which misses the appends of other handles still being written
+		offset = max(offset, int64(fh.f.entry.Attributes.FileSize))
+	}
+
 	fh.f.entry.Content = nil
-	fh.f.entry.Attributes.FileSize = uint64(max(req.Offset+int64(len(data)), int64(fh.f.entry.Attributes.FileSize)))
-	glog.V(4).Infof("%v write [%d,%d) %d", fh.f.fullpath(), req.Offset, req.Offset+int64(len(req.Data)), len(req.Data))
+	fh.f.entry.Attributes.FileSize = uint64(max(offset+int64(len(data)), int64(fh.f.entry.Attributes.FileSize)))
+	glog.V(4).Infof("%v write [%d,%d) %d", fh.f.fullpath(), offset, offset+int64(len(req.Data)), len(req.Data))
 
-	fh.dirtyPages.AddPage(req.Offset, data)
+	fh.dirtyPages.AddPage(offset, data)
 
 	resp.Size = len(data)
 
-	if req.Offset == 0 {
+	if offset == 0 {
 		// detect mime type
 		fh.contentType = http.DetectContentType(data)
 		fh.f.dirtyMetadata = true
@@ -181,8 +211,12 @@ func (fh *FileHandle) Release(ctx context.Context, req *fuse.ReleaseRequest) err
 
 	glog.V(4).Infof("Release %v fh %d", fh.f.fullpath(), fh.handle)
 
-	fh.Lock()
-	defer fh.Unlock()
+	if req.ReleaseFlags&fuse.ReleaseFlockUnlock != 0 {
+		fh.releaseFlocks(req.LockOwner)
+	}
+
+	fh.handleLock.Lock()
+	defer fh.handleLock.Unlock()
 
 	if fh.f.isOpen <= 0 {
 		glog.V(0).Infof("Release reset %s open count %d => %d", fh.f.Name, fh.f.isOpen, 0)
@@ -210,8 +244,11 @@ func (fh *FileHandle) Flush(ctx context.Context, req *fuse.FlushRequest) error {
 
 	glog.V(4).Infof("Flush %v fh %d", fh.f.fullpath(), fh.handle)
 
-	fh.Lock()
-	defer fh.Unlock()
+	fh.handleLock.Lock()
+	defer fh.handleLock.Unlock()
+
+	// closing any file descriptor releases the POSIX locks of the process, after the data is flushed
+	defer fh.releasePosixLocks(req.LockOwner)
 
 	if err := fh.doFlush(ctx, req.Header); err != nil {
 		glog.Errorf("Flush doFlush %s: %v", fh.f.Name, err)
@@ -227,20 +264,24 @@ func (fh *FileHandle) doFlush(ctx context.Context, header fuse.Header) error {
 	// send the data to the OS
 	glog.V(4).Infof("doFlush %s fh %d", fh.f.fullpath(), fh.handle)
 
-	fh.dirtyPages.saveExistingPagesToStorage()
-
-	fh.dirtyPages.writeWaitGroup.Wait()
-
-	if fh.dirtyPages.lastErr != nil {
-		glog.Errorf("%v doFlush last err: %v", fh.f.fullpath(), fh.dirtyPages.lastErr)
+	if err := fh.dirtyPages.FlushData(); err != nil {
+		glog.Errorf("%v doFlush last err: %v", fh.f.fullpath(), err)
 		return fuse.EIO
 	}
 
+	atimeDirty := atomic.SwapInt32(&fh.atimeDirty, 0) == 1
 	if !fh.f.dirtyMetadata {
+		if atimeDirty {
+			// only the access time changed, and the modification time is kept
+			fh.f.entry.Attributes.Atime = time.Now().Unix()
+			if err := fh.f.saveEntry(header.Uid, header.Gid, fh.f.entry); err != nil {
+				glog.V(1).Infof("update access time of %s: %v", fh.f.fullpath(), err)
+			}
+		}
 		return nil
 	}
 
-	err := fh.f.wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+	err := fh.f.wfs.asCaller(header.Uid, header.Gid).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
 
 		if fh.f.entry.Attributes != nil {
 			fh.f.entry.Attributes.Mime = fh.contentType
@@ -254,9 +295,11 @@ func (fh *FileHandle) doFlush(ctx context.Context, header fuse.Header) error {
 				fh.f.entry.Attributes.Crtime = time.Now().Unix()
 			}
 			fh.f.entry.Attributes.Mtime = time.Now().Unix()
+			if atimeDirty {
+				fh.f.entry.Attributes.Atime = fh.f.entry.Attributes.Mtime
+			}
 			fh.f.entry.Attributes.FileMode = uint32(os.FileMode(fh.f.entry.Attributes.FileMode) &^ fh.f.wfs.option.Umask)
-			fh.f.entry.Attributes.Collection = fh.dirtyPages.collection
-			fh.f.entry.Attributes.Replication = fh.dirtyPages.replication
+			fh.f.entry.Attributes.Collection, fh.f.entry.Attributes.Replication = fh.dirtyPages.GetStorageOptions()
 		}
 
 		request := &filer_pb.CreateEntryRequest{
@@ -273,10 +316,14 @@ func (fh *FileHandle) doFlush(ctx context.Context, header fuse.Header) error {
 		manifestChunks, nonManifestChunks := filer.SeparateManifestChunks(fh.f.entry.Chunks)
 
 		chunks, _ := filer.CompactFileChunks(fh.f.wfs.LookupFn(), nonManifestChunks)
-		chunks, manifestErr := filer.MaybeManifestize(fh.f.wfs.saveDataAsChunk(fh.f.fullpath()), chunks)
-		if manifestErr != nil {
-			// not good, but should be ok
-			glog.V(0).Infof("MaybeManifestize: %v", manifestErr)
+		if !hasLocalChunks(chunks) {
+			// the local chunks written offline are manifestized after they are uploaded
+			var manifestErr error
+			chunks, manifestErr = filer.MaybeManifestize(fh.f.wfs.saveDataAsChunk(fh.f.fullpath()), chunks)
+			if manifestErr != nil {
+				// not good, but should be ok
+				glog.V(0).Infof("MaybeManifestize: %v", manifestErr)
+			}
 		}
 		fh.f.entry.Chunks = append(chunks, manifestChunks...)
 
@@ -285,7 +332,7 @@ func (fh *FileHandle) doFlush(ctx context.Context, header fuse.Header) error {
 
 		if err := filer_pb.CreateEntry(client, request); err != nil {
 			glog.Errorf("fh flush create %s: %v", fh.f.fullpath(), err)
-			return fmt.Errorf("fh flush create %s: %v", fh.f.fullpath(), err)
+			return filer_pb.WrapError(err, "fh flush create %s", fh.f.fullpath())
 		}
 
 		fh.f.wfs.metaCache.InsertEntry(context.Background(), filer.FromPbEntry(request.Directory, request.Entry))
@@ -295,11 +342,12 @@ func (fh *FileHandle) doFlush(ctx context.Context, header fuse.Header) error {
 
 	if err == nil {
 		fh.f.dirtyMetadata = false
+		fh.dirtyPages.Commit()
 	}
 
 	if err != nil {
 		glog.Errorf("%v fh %d flush: %v", fh.f.fullpath(), fh.handle, err)
-		return fuse.EIO
+		return toFuseError(err)
 	}
 
 	return nil
@@ -0,0 +1,111 @@
+package filesys
+
+import (
+	"context"
+
+	"github.com/seaweedfs/fuse"
+	"github.com/seaweedfs/fuse/fs"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+var _ = fs.HandleFlockLocker(&FileHandle{})
+var _ = fs.HandlePOSIXLocker(&FileHandle{})
+
+func (fh *FileHandle) Lock(ctx context.Context, req *fuse.LockRequest) error {
+
+	glog.V(4).Infof("%v lock %+v", fh.f.fullpath(), req)
+
+	return fh.lock(ctx, req.LockOwner, req.Lock, req.LockFlags, false)
+}
+
+func (fh *FileHandle) LockWait(ctx context.Context, req *fuse.LockWaitRequest) error {
+
+	glog.V(4).Infof("%v lock wait %+v", fh.f.fullpath(), req)
+
+	return fh.lock(ctx, req.LockOwner, req.Lock, req.LockFlags, true)
+}
+
+func (fh *FileHandle) Unlock(ctx context.Context, req *fuse.UnlockRequest) error {
+
+	glog.V(4).Infof("%v unlock %+v", fh.f.fullpath(), req)
+
+	return fh.f.wfs.releaseLock(ctx, fh.f.fullpath(), fh.f.wfs.toPbFileLock(req.LockOwner, req.Lock, req.LockFlags), false)
+}
+
+func (fh *FileHandle) QueryLock(ctx context.Context, req *fuse.QueryLockRequest, resp *fuse.QueryLockResponse) error {
+
+	glog.V(4).Infof("%v query lock %+v", fh.f.fullpath(), req)
+
+	conflict, err := fh.f.wfs.queryLock(ctx, fh.f.fullpath(), fh.f.wfs.toPbFileLock(req.LockOwner, req.Lock, req.LockFlags))
+	if err != nil {
+		return err
+	}
+	if conflict != nil {
+		resp.Lock = fuse.FileLock{
+			Start: conflict.Start,
+			End:   conflict.End,
+			Type:  fuse.LockRead,
+			PID:   conflict.Pid,
+		}
+		if conflict.IsWrite {
+			resp.Lock.Type = fuse.LockWrite
+		}
+	}
+	return nil
+}
+
+func (fh *FileHandle) lock(ctx context.Context, owner fuse.LockOwner, lock fuse.FileLock, flags fuse.LockFlags, wait bool) error {
+
+	isFlock := flags&fuse.LockFlock != 0
+	if err := fh.f.wfs.acquireLock(ctx, fh.f.fullpath(), fh.f.wfs.toPbFileLock(owner, lock, flags), wait); err != nil {
+		return err
+	}
+
+	fh.locksLock.Lock()
+	defer fh.locksLock.Unlock()
+	if isFlock {
+		if fh.flockOwners == nil {
+			fh.flockOwners = make(map[fuse.LockOwner]bool)
+		}
+		fh.flockOwners[owner] = true
+	} else {
+		if fh.posixOwners == nil {
+			fh.posixOwners = make(map[fuse.LockOwner]bool)
+		}
+		fh.posixOwners[owner] = true
+	}
+	return nil
+}
+
+// releasePosixLocks releases all POSIX locks of the owner, which is the closing process
+func (fh *FileHandle) releasePosixLocks(owner uint64) {
+	fh.locksLock.Lock()
+	defer fh.locksLock.Unlock()
+
+	lockOwner := fuse.LockOwner(owner)
+	if !fh.posixOwners[lockOwner] {
+		return
+	}
+	lock := fh.f.wfs.toPbFileLock(lockOwner, fuse.FileLock{}, 0)
+	if err := fh.f.wfs.releaseLock(context.Background(), fh.f.fullpath(), lock, true); err == nil {
+		delete(fh.posixOwners, lockOwner)
+	}
+}
+
+// releaseFlocks releases the flock of the owner, which is the last closed file descriptor of an open file.
+// The release request only carries the lower 32 bits of the lock owner.
+func (fh *FileHandle) releaseFlocks(owner uint32) {
+	fh.locksLock.Lock()
+	defer fh.locksLock.Unlock()
+
+	for lockOwner := range fh.flockOwners {
+		if uint32(lockOwner) != owner {
+			continue
+		}
+		lock := fh.f.wfs.toPbFileLock(lockOwner, fuse.FileLock{}, fuse.LockFlock)
+		if err := fh.f.wfs.releaseLock(context.Background(), fh.f.fullpath(), lock, true); err == nil {
+			delete(fh.flockOwners, lockOwner)
+		}
+	}
+}
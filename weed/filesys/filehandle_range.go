@@ -0,0 +1,152 @@
+package filesys
+
+import (
+	"context"
+	"io"
+	"syscall"
+
+	"github.com/seaweedfs/fuse"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+// The fallocate, copy_file_range, and rename with flags requests need FUSE protocol 7.19 and later,
+// which the fuse library does not negotiate yet. Until it does, the kernel falls back to
+// writing zeros, reading and writing the data, or failing RENAME_EXCHANGE with EINVAL.
+
+const fallocKeepSize = 0x01 // FALLOC_FL_KEEP_SIZE
+
+// Fallocate reserves the range for fallocate(2). The volume servers only allocate space when the data is written,
+// so the range is not reserved, and only the file size is extended unless FALLOC_FL_KEEP_SIZE is set.
+func (fh *FileHandle) Fallocate(ctx context.Context, header fuse.Header, mode uint32, offset, length int64) error {
+
+	glog.V(4).Infof("%v fallocate mode %x [%d,%d)", fh.f.fullpath(), mode, offset, offset+length)
+
+	if offset < 0 || length <= 0 {
+		return fuse.Errno(syscall.EINVAL)
+	}
+	if mode&^fallocKeepSize != 0 {
+		// punching holes and zeroing ranges would need to write zeros
+		return fuse.Errno(syscall.EOPNOTSUPP)
+	}
+
+	fh.handleLock.Lock()
+	defer fh.handleLock.Unlock()
+
+	if mode&fallocKeepSize != 0 || uint64(offset+length) <= fh.f.entry.Attributes.FileSize {
+		return nil
+	}
+	fh.f.entry.Attributes.FileSize = uint64(offset + length)
+	fh.f.dirtyMetadata = true
+
+	return nil
+}
+
+// CopyFileRange copies a range of this file to the destination file, for copy_file_range(2).
+// The filer shares the source chunks with the destination, so the data does not go through the mount.
+// If the filer can not copy the range, the data is read and written by the mount.
+func (fh *FileHandle) CopyFileRange(ctx context.Context, header fuse.Header, srcOffset int64, dst *FileHandle, dstOffset int64, length int64) (copied int64, err error) {
+
+	glog.V(4).Infof("%v copy [%d,%d) to %v at %d", fh.f.fullpath(), srcOffset, srcOffset+length, dst.f.fullpath(), dstOffset)
+
+	if srcOffset < 0 || dstOffset < 0 || length < 0 {
+		return 0, fuse.Errno(syscall.EINVAL)
+	}
+	if fh.f.fullpath() == dst.f.fullpath() && srcOffset < dstOffset+length && dstOffset < srcOffset+length {
+		return 0, fuse.Errno(syscall.EINVAL)
+	}
+
+	// lock the handles in a fixed order, the same file shares one handle
+	first, second := fh, dst
+	if first.handle > second.handle {
+		first, second = second, first
+	}
+	first.handleLock.Lock()
+	defer first.handleLock.Unlock()
+	if second != first {
+		second.handleLock.Lock()
+		defer second.handleLock.Unlock()
+	}
+
+	// the filer copies what has been saved
+	if err = fh.doFlush(ctx, header); err != nil {
+		return 0, err
+	}
+	if dst != fh {
+		if err = dst.doFlush(ctx, header); err != nil {
+			return 0, err
+		}
+	}
+
+	err = fh.f.wfs.asCaller(header.Uid, header.Gid).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.CopyFileRange(ctx, &filer_pb.CopyFileRangeRequest{
+			SrcDirectory: fh.f.dir.FullPath(),
+			SrcName:      fh.f.Name,
+			SrcOffset:    srcOffset,
+			DstDirectory: dst.f.dir.FullPath(),
+			DstName:      dst.f.Name,
+			DstOffset:    dstOffset,
+			Length:       length,
+			Signatures:   []int32{fh.f.wfs.signature},
+		})
+		if err != nil {
+			return err
+		}
+		copied = resp.Copied
+		if resp.Entry != nil {
+			fh.f.wfs.mapPbIdFromFilerToLocal(resp.Entry)
+			dst.f.setEntry(resp.Entry)
+			fh.f.wfs.metaCache.UpdateEntry(ctx, filer.FromPbEntry(dst.f.dir.FullPath(), resp.Entry))
+		}
+		return nil
+	})
+	if err == nil {
+		return copied, nil
+	}
+	if filer_pb.IsPermissionDenied(err) || filer_pb.IsQuotaExceeded(err) {
+		return 0, toFuseError(err)
+	}
+
+	glog.V(1).Infof("copy %s to %s by the filer: %v", fh.f.fullpath(), dst.f.fullpath(), err)
+	return fh.copyRangeThroughMount(srcOffset, dst, dstOffset, length)
+}
+
+// copyRangeThroughMount reads the range and writes it to the destination as dirty pages, with both handles locked
+func (fh *FileHandle) copyRangeThroughMount(srcOffset int64, dst *FileHandle, dstOffset int64, length int64) (copied int64, err error) {
+
+	fileSize := int64(fh.f.entry.Attributes.FileSize)
+	if srcOffset >= fileSize {
+		return 0, nil
+	}
+	if length > fileSize-srcOffset {
+		length = fileSize - srcOffset
+	}
+
+	for copied < length {
+		size := length - copied
+		if size > fh.f.wfs.option.ChunkSizeLimit {
+			size = fh.f.wfs.option.ChunkSizeLimit
+		}
+		data := make([]byte, size)
+		totalRead, readErr := fh.readFromChunks(data, srcOffset+copied)
+		if readErr != nil && readErr != io.EOF {
+			return copied, fuse.EIO
+		}
+		maxStop := fh.readFromDirtyPages(data, srcOffset+copied)
+		totalRead = max(maxStop-srcOffset-copied, totalRead)
+		if totalRead <= 0 {
+			break
+		}
+		data = data[:totalRead]
+
+		dst.f.entry.Content = nil
+		dst.dirtyPages.AddPage(dstOffset+copied, data)
+		dst.f.entry.Attributes.FileSize = uint64(max(dstOffset+copied+totalRead, int64(dst.f.entry.Attributes.FileSize)))
+		dst.f.dirtyMetadata = true
+		copied += totalRead
+	}
+
+	return copied, nil
+}
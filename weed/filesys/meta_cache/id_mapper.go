@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
 )
 
 type UidGidMapper struct {
@@ -43,6 +45,14 @@ func (m *UidGidMapper) FilerToLocal(uid, gid uint32) (uint32, uint32) {
 	return m.uidMapper.FilerToLocal(uid), m.gidMapper.FilerToLocal(gid)
 }
 
+// LocalToFilerAcl translates the named users and groups in the acl xattrs
+func (m *UidGidMapper) LocalToFilerAcl(extended map[string][]byte) {
+	filer.MapAclIds(extended, m.uidMapper.LocalToFiler, m.gidMapper.LocalToFiler)
+}
+func (m *UidGidMapper) FilerToLocalAcl(extended map[string][]byte) {
+	filer.MapAclIds(extended, m.uidMapper.FilerToLocal, m.gidMapper.FilerToLocal)
+}
+
 func (m *IdMapper) LocalToFiler(id uint32) uint32 {
 	value, found := m.localToFiler[id]
 	if found {
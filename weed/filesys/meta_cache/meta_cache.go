@@ -149,4 +149,5 @@ func (mc *MetaCache) Shutdown() {
 
 func (mc *MetaCache) mapIdFromFilerToLocal(entry *filer.Entry) {
 	entry.Attr.Uid, entry.Attr.Gid = mc.uidGidMapper.FilerToLocal(entry.Attr.Uid, entry.Attr.Gid)
+	mc.uidGidMapper.FilerToLocalAcl(entry.Extended)
 }
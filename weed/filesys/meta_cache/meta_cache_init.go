@@ -2,7 +2,6 @@ package meta_cache
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/chrislusf/seaweedfs/weed/filer"
 	"github.com/chrislusf/seaweedfs/weed/glog"
@@ -35,7 +34,7 @@ func EnsureVisited(mc *MetaCache, client filer_pb.FilerClient, dirPath util.Full
 		})
 
 		if err != nil {
-			err = fmt.Errorf("list %s: %v", dirPath, err)
+			err = filer_pb.WrapError(err, "list %s", dirPath)
 		}
 
 		return
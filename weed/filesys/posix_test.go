@@ -0,0 +1,494 @@
+package filesys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/fuse"
+)
+
+// These tests follow pjdfstest, calling the fuse node methods the kernel would call for the system calls.
+
+type posixTest struct {
+	t   *testing.T
+	wfs *WFS
+	dir *Dir
+}
+
+// newPosixTest creates a directory for the test, writable by everyone
+func newPosixTest(t *testing.T) *posixTest {
+	wfs := testFileSystem(t)
+	name := fmt.Sprintf("%s_%d", t.Name(), time.Now().UnixNano())
+	node, err := wfs.root.(*Dir).Mkdir(context.Background(), &fuse.MkdirRequest{
+		Header: rootHeader(),
+		Name:   name,
+		Mode:   os.ModeDir | 0777,
+	})
+	if err != nil {
+		t.Fatalf("mkdir %s: %v", name, err)
+	}
+	dir := node.(*Dir)
+	if err = dir.Setattr(context.Background(), &fuse.SetattrRequest{
+		Header: rootHeader(),
+		Valid:  fuse.SetattrMode,
+		Mode:   os.ModeDir | 0777,
+	}, &fuse.SetattrResponse{}); err != nil {
+		t.Fatalf("chmod %s: %v", name, err)
+	}
+	return &posixTest{t: t, wfs: wfs, dir: dir}
+}
+
+func rootHeader() fuse.Header {
+	return fuse.Header{Uid: 0, Gid: 0}
+}
+
+func userHeader(uid, gid uint32) fuse.Header {
+	return fuse.Header{Uid: uid, Gid: gid}
+}
+
+func (p *posixTest) create(name string, mode os.FileMode) (*File, *FileHandle) {
+	node, handle, err := p.dir.Create(context.Background(), &fuse.CreateRequest{
+		Header: rootHeader(),
+		Name:   name,
+		Mode:   mode,
+		Flags:  fuse.OpenReadWrite,
+	}, &fuse.CreateResponse{})
+	if err != nil {
+		p.t.Fatalf("create %s: %v", name, err)
+	}
+	return node.(*File), handle.(*FileHandle)
+}
+
+func (p *posixTest) open(file *File) *FileHandle {
+	handle, err := file.Open(context.Background(), &fuse.OpenRequest{
+		Header: rootHeader(),
+		Flags:  fuse.OpenReadWrite,
+	}, &fuse.OpenResponse{})
+	if err != nil {
+		p.t.Fatalf("open %s: %v", file.Name, err)
+	}
+	return handle.(*FileHandle)
+}
+
+func (p *posixTest) write(fh *FileHandle, offset int64, data []byte, flags fuse.OpenFlags) {
+	resp := &fuse.WriteResponse{}
+	if err := fh.Write(context.Background(), &fuse.WriteRequest{
+		Header:    rootHeader(),
+		Offset:    offset,
+		Data:      data,
+		FileFlags: flags,
+	}, resp); err != nil {
+		p.t.Fatalf("write %s: %v", fh.f.Name, err)
+	}
+	if resp.Size != len(data) {
+		p.t.Fatalf("write %s: %d of %d bytes", fh.f.Name, resp.Size, len(data))
+	}
+}
+
+func (p *posixTest) flush(fh *FileHandle) {
+	if err := fh.Flush(context.Background(), &fuse.FlushRequest{Header: rootHeader()}); err != nil {
+		p.t.Fatalf("flush %s: %v", fh.f.Name, err)
+	}
+}
+
+func (p *posixTest) close(fh *FileHandle) {
+	p.flush(fh)
+	if err := fh.Release(context.Background(), &fuse.ReleaseRequest{Header: rootHeader()}); err != nil {
+		p.t.Fatalf("release %s: %v", fh.f.Name, err)
+	}
+}
+
+func (p *posixTest) read(fh *FileHandle, offset int64, size int) []byte {
+	resp := &fuse.ReadResponse{}
+	if err := fh.Read(context.Background(), &fuse.ReadRequest{
+		Header: rootHeader(),
+		Offset: offset,
+		Size:   size,
+	}, resp); err != nil {
+		p.t.Fatalf("read %s: %v", fh.f.Name, err)
+	}
+	return resp.Data
+}
+
+func (p *posixTest) attr(file *File) fuse.Attr {
+	var attr fuse.Attr
+	if err := file.Attr(context.Background(), &attr); err != nil {
+		p.t.Fatalf("stat %s: %v", file.Name, err)
+	}
+	return attr
+}
+
+func (p *posixTest) lookup(name string) (interface{}, error) {
+	return p.dir.Lookup(context.Background(), &fuse.LookupRequest{Header: rootHeader(), Name: name}, &fuse.LookupResponse{})
+}
+
+// writeFile creates a file with the data, flushed in chunks of the chunk size limit
+func (p *posixTest) writeFile(name string, data []byte) *File {
+	file, fh := p.create(name, 0644)
+	chunkSize := p.wfs.option.ChunkSizeLimit
+	for offset := int64(0); offset < int64(len(data)); offset += chunkSize {
+		p.write(fh, offset, data[offset:min(offset+chunkSize, int64(len(data)))], 0)
+	}
+	p.close(fh)
+	return file
+}
+
+func (p *posixTest) readFile(file *File) []byte {
+	fh := p.open(file)
+	defer p.close(fh)
+	return p.read(fh, 0, int(p.attr(file).Size)+1)
+}
+
+func randomBytes(size int) []byte {
+	data := make([]byte, size)
+	rand.Read(data)
+	return data
+}
+
+func expectErrno(t *testing.T, op string, err error, errno syscall.Errno) {
+	t.Helper()
+	fuseErr, ok := err.(fuse.ErrorNumber)
+	if !ok || fuseErr.Errno() != fuse.Errno(errno) {
+		t.Fatalf("%s: expected %v, got %v", op, errno, err)
+	}
+}
+
+func TestPosixAccess(t *testing.T) {
+	p := newPosixTest(t)
+	file, fh := p.create("file", 0640)
+	p.close(fh)
+	if err := file.Setattr(context.Background(), &fuse.SetattrRequest{
+		Header: rootHeader(),
+		Valid:  fuse.SetattrUid | fuse.SetattrGid,
+		Uid:    1000,
+		Gid:    1000,
+	}, &fuse.SetattrResponse{}); err != nil {
+		t.Fatalf("chown: %v", err)
+	}
+
+	tests := []struct {
+		uid, gid uint32
+		mask     uint32
+		errno    syscall.Errno
+	}{
+		{1000, 1000, 06, 0},
+		{1000, 1000, 01, syscall.EACCES},
+		{2000, 1000, 04, 0},
+		{2000, 1000, 02, syscall.EACCES},
+		{2000, 2000, 04, syscall.EACCES},
+		{2000, 2000, 0, 0},
+		{0, 0, 06, 0},
+		{0, 0, 01, syscall.EACCES},
+	}
+	for _, tt := range tests {
+		err := file.Access(context.Background(), &fuse.AccessRequest{Header: userHeader(tt.uid, tt.gid), Mask: tt.mask})
+		op := fmt.Sprintf("access uid %d gid %d mask %o", tt.uid, tt.gid, tt.mask)
+		if tt.errno == 0 {
+			if err != nil {
+				t.Errorf("%s: %v", op, err)
+			}
+			continue
+		}
+		expectErrno(t, op, err, tt.errno)
+	}
+
+	if err := p.dir.Access(context.Background(), &fuse.AccessRequest{Header: userHeader(2000, 2000), Mask: 07}); err != nil {
+		t.Errorf("access directory: %v", err)
+	}
+}
+
+func TestPosixAppend(t *testing.T) {
+	p := newPosixTest(t)
+	file, fh1 := p.create("file", 0644)
+	fh2 := p.open(file)
+
+	// both handles write at offset 0, the data is appended
+	p.write(fh1, 0, []byte("hello "), fuse.OpenAppend)
+	p.write(fh2, 0, []byte("world"), fuse.OpenAppend)
+	p.close(fh2)
+	p.close(fh1)
+
+	if data := p.readFile(file); string(data) != "hello world" {
+		t.Fatalf("appended content: %q", data)
+	}
+
+	fh := p.open(file)
+	p.write(fh, 0, []byte("H"), 0)
+	p.close(fh)
+	if data := p.readFile(file); string(data) != "Hello world" {
+		t.Fatalf("overwritten content: %q", data)
+	}
+}
+
+func TestPosixAtime(t *testing.T) {
+	p := newPosixTest(t)
+	file := p.writeFile("file", []byte("some data"))
+
+	past := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	if err := file.Setattr(context.Background(), &fuse.SetattrRequest{
+		Header: rootHeader(),
+		Valid:  fuse.SetattrAtime,
+		Atime:  past,
+	}, &fuse.SetattrResponse{}); err != nil {
+		t.Fatalf("set atime: %v", err)
+	}
+	if atime := p.attr(file).Atime; !atime.Equal(past) {
+		t.Fatalf("atime %v, expected %v", atime, past)
+	}
+
+	before := time.Now().Truncate(time.Second)
+	p.readFile(file)
+	if atime := p.attr(file).Atime; atime.Before(before) {
+		t.Fatalf("atime %v not updated by reading", atime)
+	}
+
+	// relatime: a recent atime after the mtime is kept
+	future := time.Now().Add(time.Hour).Truncate(time.Second)
+	if err := file.Setattr(context.Background(), &fuse.SetattrRequest{
+		Header: rootHeader(),
+		Valid:  fuse.SetattrAtime,
+		Atime:  future,
+	}, &fuse.SetattrResponse{}); err != nil {
+		t.Fatalf("set atime: %v", err)
+	}
+	p.readFile(file)
+	if atime := p.attr(file).Atime; !atime.Equal(future) {
+		t.Fatalf("atime %v, expected %v", atime, future)
+	}
+}
+
+func TestPosixCopyFileRange(t *testing.T) {
+	p := newPosixTest(t)
+	chunkSize := int(p.wfs.option.ChunkSizeLimit)
+	data := randomBytes(3 * chunkSize)
+	src := p.writeFile("src", data)
+	dst := p.writeFile("dst", nil)
+
+	srcHandle, dstHandle := p.open(src), p.open(dst)
+	defer p.close(srcHandle)
+	defer p.close(dstHandle)
+
+	// aligned to the chunks, the chunks are shared
+	uploaded := testVolume.uploaded()
+	copied, err := srcHandle.CopyFileRange(context.Background(), rootHeader(), int64(chunkSize), dstHandle, 0, int64(2*chunkSize))
+	if err != nil {
+		t.Fatalf("copy aligned range: %v", err)
+	}
+	if copied != int64(2*chunkSize) {
+		t.Fatalf("copied %d bytes, expected %d", copied, 2*chunkSize)
+	}
+	if n := testVolume.uploaded() - uploaded; n != 0 {
+		t.Fatalf("aligned copy uploaded %d bytes", n)
+	}
+
+	// not aligned, and past the end of the source
+	uploaded = testVolume.uploaded()
+	copied, err = srcHandle.CopyFileRange(context.Background(), rootHeader(), 100, dstHandle, int64(2*chunkSize), int64(3*chunkSize))
+	if err != nil {
+		t.Fatalf("copy unaligned range: %v", err)
+	}
+	if copied != int64(3*chunkSize-100) {
+		t.Fatalf("copied %d bytes, expected %d", copied, 3*chunkSize-100)
+	}
+	if n := testVolume.uploaded() - uploaded; n != int64(chunkSize-100) {
+		t.Fatalf("unaligned copy uploaded %d bytes, expected %d", n, chunkSize-100)
+	}
+
+	expected := append(append([]byte{}, data[chunkSize:]...), data[100:]...)
+	if got := p.readFile(dst); !bytes.Equal(got, expected) {
+		t.Fatalf("copied content differs, size %d expected %d", len(got), len(expected))
+	}
+	if got := p.readFile(src); !bytes.Equal(got, data) {
+		t.Fatalf("source content changed")
+	}
+
+	_, err = srcHandle.CopyFileRange(context.Background(), rootHeader(), 0, srcHandle, 10, 100)
+	expectErrno(t, "copy overlapping range", err, syscall.EINVAL)
+}
+
+func TestPosixFallocate(t *testing.T) {
+	p := newPosixTest(t)
+	file, fh := p.create("file", 0644)
+	p.write(fh, 0, []byte("data"), 0)
+
+	if err := fh.Fallocate(context.Background(), rootHeader(), 0, 0, 1024); err != nil {
+		t.Fatalf("fallocate: %v", err)
+	}
+	if size := p.attr(file).Size; size != 1024 {
+		t.Fatalf("size %d after fallocate", size)
+	}
+	if err := fh.Fallocate(context.Background(), rootHeader(), fallocKeepSize, 0, 4096); err != nil {
+		t.Fatalf("fallocate keep size: %v", err)
+	}
+	if size := p.attr(file).Size; size != 1024 {
+		t.Fatalf("size %d after fallocate with keep size", size)
+	}
+	expectErrno(t, "punch hole", fh.Fallocate(context.Background(), rootHeader(), 0x02|fallocKeepSize, 0, 10), syscall.EOPNOTSUPP)
+	expectErrno(t, "empty range", fh.Fallocate(context.Background(), rootHeader(), 0, 0, 0), syscall.EINVAL)
+	p.close(fh)
+
+	expected := make([]byte, 1024)
+	copy(expected, "data")
+	if data := p.readFile(file); !bytes.Equal(data, expected) {
+		t.Fatalf("allocated content: %q", data[:16])
+	}
+}
+
+func TestPosixRenameExchange(t *testing.T) {
+	p := newPosixTest(t)
+	a := p.writeFile("a", []byte("content of a"))
+	p.writeFile("b", []byte("content of b"))
+	if _, err := p.dir.Mkdir(context.Background(), &fuse.MkdirRequest{Header: rootHeader(), Name: "d", Mode: os.ModeDir | 0755}); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	if err := p.dir.RenameExchange(context.Background(), rootHeader(), "a", p.dir, "b"); err != nil {
+		t.Fatalf("exchange files: %v", err)
+	}
+	if a.Name != "b" {
+		t.Fatalf("node of a is named %s", a.Name)
+	}
+	for name, content := range map[string]string{"a": "content of b", "b": "content of a"} {
+		node, err := p.lookup(name)
+		if err != nil {
+			t.Fatalf("lookup %s: %v", name, err)
+		}
+		if data := p.readFile(node.(*File)); string(data) != content {
+			t.Fatalf("%s has %q, expected %q", name, data, content)
+		}
+	}
+
+	if err := p.dir.RenameExchange(context.Background(), rootHeader(), "a", p.dir, "d"); err != nil {
+		t.Fatalf("exchange file and directory: %v", err)
+	}
+	if node, err := p.lookup("a"); err != nil {
+		t.Fatalf("lookup a: %v", err)
+	} else if _, isDir := node.(*Dir); !isDir {
+		t.Fatalf("a is not a directory after the exchange")
+	}
+	if node, err := p.lookup("d"); err != nil {
+		t.Fatalf("lookup d: %v", err)
+	} else if data := p.readFile(node.(*File)); string(data) != "content of b" {
+		t.Fatalf("d has %q", data)
+	}
+
+	expectErrno(t, "exchange with missing entry", p.dir.RenameExchange(context.Background(), rootHeader(), "a", p.dir, "missing"), syscall.ENOENT)
+}
+
+func TestPosixRenameOverwrite(t *testing.T) {
+	p := newPosixTest(t)
+	p.writeFile("a", []byte("new"))
+	p.writeFile("b", []byte("old"))
+
+	if err := p.dir.Rename(context.Background(), &fuse.RenameRequest{Header: rootHeader(), OldName: "a", NewName: "b"}, p.dir); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	if _, err := p.lookup("a"); err != fuse.ENOENT {
+		t.Fatalf("lookup renamed a: %v", err)
+	}
+	node, err := p.lookup("b")
+	if err != nil {
+		t.Fatalf("lookup b: %v", err)
+	}
+	if data := p.readFile(node.(*File)); string(data) != "new" {
+		t.Fatalf("b has %q", data)
+	}
+}
+
+func TestPosixXattr(t *testing.T) {
+	p := newPosixTest(t)
+	file := p.writeFile("file", nil)
+	ctx := context.Background()
+
+	expectErrno(t, "get missing xattr", file.Getxattr(ctx, &fuse.GetxattrRequest{Header: rootHeader(), Name: "user.a"}, &fuse.GetxattrResponse{}), syscall.ENODATA)
+	expectErrno(t, "replace missing xattr", file.Setxattr(ctx, &fuse.SetxattrRequest{Header: rootHeader(), Name: "user.a", Xattr: []byte("x"), Flags: xattrReplace}), syscall.ENODATA)
+
+	if err := file.Setxattr(ctx, &fuse.SetxattrRequest{Header: rootHeader(), Name: "user.a", Xattr: []byte("value"), Flags: xattrCreate}); err != nil {
+		t.Fatalf("create xattr: %v", err)
+	}
+	expectErrno(t, "create existing xattr", file.Setxattr(ctx, &fuse.SetxattrRequest{Header: rootHeader(), Name: "user.a", Xattr: []byte("x"), Flags: xattrCreate}), syscall.EEXIST)
+	if err := file.Setxattr(ctx, &fuse.SetxattrRequest{Header: rootHeader(), Name: "user.a", Xattr: []byte("other"), Flags: xattrReplace}); err != nil {
+		t.Fatalf("replace xattr: %v", err)
+	}
+	if err := file.Setxattr(ctx, &fuse.SetxattrRequest{Header: rootHeader(), Name: "user.b", Xattr: []byte("b")}); err != nil {
+		t.Fatalf("set xattr: %v", err)
+	}
+
+	resp := &fuse.GetxattrResponse{}
+	if err := file.Getxattr(ctx, &fuse.GetxattrRequest{Header: rootHeader(), Name: "user.a", Size: 64}, resp); err != nil || string(resp.Xattr) != "other" {
+		t.Fatalf("get xattr: %q %v", resp.Xattr, err)
+	}
+	resp = &fuse.GetxattrResponse{}
+	if err := file.Getxattr(ctx, &fuse.GetxattrRequest{Header: rootHeader(), Name: "user.a"}, resp); err != nil || len(resp.Xattr) != 5 {
+		t.Fatalf("get xattr size: %d %v", len(resp.Xattr), err)
+	}
+	expectErrno(t, "get xattr into a small buffer", file.Getxattr(ctx, &fuse.GetxattrRequest{Header: rootHeader(), Name: "user.a", Size: 2}, &fuse.GetxattrResponse{}), syscall.ERANGE)
+
+	list := &fuse.ListxattrResponse{}
+	if err := file.Listxattr(ctx, &fuse.ListxattrRequest{Header: rootHeader(), Size: 64}, list); err != nil {
+		t.Fatalf("list xattrs: %v", err)
+	}
+	names := bytes.Split(bytes.TrimSuffix(list.Xattr, []byte{0}), []byte{0})
+	if len(names) != 2 {
+		t.Fatalf("listed xattrs: %q", list.Xattr)
+	}
+	expectErrno(t, "list xattrs into a small buffer", file.Listxattr(ctx, &fuse.ListxattrRequest{Header: rootHeader(), Size: 4}, &fuse.ListxattrResponse{}), syscall.ERANGE)
+
+	if err := file.Removexattr(ctx, &fuse.RemovexattrRequest{Header: rootHeader(), Name: "user.a"}); err != nil {
+		t.Fatalf("remove xattr: %v", err)
+	}
+	expectErrno(t, "remove missing xattr", file.Removexattr(ctx, &fuse.RemovexattrRequest{Header: rootHeader(), Name: "user.a"}), syscall.ENODATA)
+}
+
+func TestPosixChmodChown(t *testing.T) {
+	p := newPosixTest(t)
+	file := p.writeFile("file", []byte("data"))
+
+	err := file.Setattr(context.Background(), &fuse.SetattrRequest{
+		Header: userHeader(1000, 1000),
+		Valid:  fuse.SetattrMode,
+		Mode:   0777,
+	}, &fuse.SetattrResponse{})
+	expectErrno(t, "chmod by another user", err, syscall.EPERM)
+
+	if err = file.Setattr(context.Background(), &fuse.SetattrRequest{
+		Header: rootHeader(),
+		Valid:  fuse.SetattrMode,
+		Mode:   0600,
+	}, &fuse.SetattrResponse{}); err != nil {
+		t.Fatalf("chmod by the owner: %v", err)
+	}
+	if mode := p.attr(file).Mode; mode.Perm() != 0600 {
+		t.Fatalf("mode %v after chmod", mode)
+	}
+}
+
+func TestPosixTruncate(t *testing.T) {
+	p := newPosixTest(t)
+	file := p.writeFile("file", []byte("0123456789"))
+
+	truncate := func(size uint64) {
+		if err := file.Setattr(context.Background(), &fuse.SetattrRequest{
+			Header: rootHeader(),
+			Valid:  fuse.SetattrSize,
+			Size:   size,
+		}, &fuse.SetattrResponse{}); err != nil {
+			t.Fatalf("truncate to %d: %v", size, err)
+		}
+	}
+
+	truncate(4)
+	if data := p.readFile(file); string(data) != "0123" {
+		t.Fatalf("shrunk content: %q", data)
+	}
+	truncate(8)
+	if data := p.readFile(file); string(data) != "0123\x00\x00\x00\x00" {
+		t.Fatalf("grown content: %q", data)
+	}
+}
@@ -0,0 +1,370 @@
+package filesys
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// the journal is rewritten with only the live records once it grows beyond this size
+const swapJournalCompactSize = 64 * 1024 * 1024
+
+const (
+	journalOpInit   = "init"
+	journalOpWrite  = "write"
+	journalOpUpload = "upload"
+	journalOpFree   = "free"
+	journalOpCommit = "commit"
+)
+
+// SwapFile keeps the dirty pages of all open files on local disk, in fixed size slots.
+// Every write to a slot, every uploaded chunk, and every saved file is recorded in a journal,
+// so that the data not yet saved to the filer can be recovered after the mount process restarts.
+// The journal is not synced, so the data survives a crash of the mount process, but not of the operating system.
+type SwapFile struct {
+	file     *os.File
+	slotSize int64
+
+	slotsLock sync.Mutex
+	freeSlots []int
+	nextSlot  int
+	slotCount int
+
+	journalLock sync.Mutex
+	journalPath string
+	journal     *os.File
+	journalSize int64
+	state       *swapJournalState
+}
+
+type journalRecord struct {
+	Op         string        `json:"op"`
+	SlotSize   int64         `json:"slot_size,omitempty"`
+	Slot       int           `json:"slot"`
+	Path       util.FullPath `json:"path,omitempty"`
+	ChunkIndex int64         `json:"chunk_index,omitempty"`
+	Start      int64         `json:"start,omitempty"`
+	Stop       int64         `json:"stop,omitempty"`
+	TsNs       int64         `json:"ts_ns,omitempty"`
+	Chunk      []byte        `json:"chunk,omitempty"`
+}
+
+// swapSlotState is the journaled content of a slot in use
+type swapSlotState struct {
+	path       util.FullPath
+	chunkIndex int64
+	intervals  writtenIntervals
+	tsNs       int64
+}
+
+// swapJournalState is what the journal describes, i.e., the data not yet saved to the filer
+type swapJournalState struct {
+	slotSize int64
+	slots    map[int]*swapSlotState
+	uploaded map[util.FullPath][]*filer_pb.FileChunk
+}
+
+func newSwapJournalState(slotSize int64) *swapJournalState {
+	return &swapJournalState{
+		slotSize: slotSize,
+		slots:    make(map[int]*swapSlotState),
+		uploaded: make(map[util.FullPath][]*filer_pb.FileChunk),
+	}
+}
+
+func (s *swapJournalState) apply(record *journalRecord) {
+	switch record.Op {
+	case journalOpInit:
+		s.slotSize = record.SlotSize
+	case journalOpWrite:
+		slot, found := s.slots[record.Slot]
+		if !found || slot.path != record.Path || slot.chunkIndex != record.ChunkIndex {
+			slot = &swapSlotState{path: record.Path, chunkIndex: record.ChunkIndex}
+			s.slots[record.Slot] = slot
+		}
+		slot.intervals.add(record.Start, record.Stop)
+		slot.tsNs = record.TsNs
+	case journalOpUpload:
+		if record.Slot >= 0 {
+			delete(s.slots, record.Slot)
+		}
+		chunk := &filer_pb.FileChunk{}
+		if err := proto.Unmarshal(record.Chunk, chunk); err == nil {
+			s.uploaded[record.Path] = append(s.uploaded[record.Path], chunk)
+		}
+	case journalOpFree:
+		delete(s.slots, record.Slot)
+	case journalOpCommit:
+		delete(s.uploaded, record.Path)
+	}
+}
+
+func (s *swapJournalState) isEmpty() bool {
+	return len(s.slots) == 0 && len(s.uploaded) == 0
+}
+
+func (s *swapJournalState) records() (records []*journalRecord) {
+	records = append(records, &journalRecord{Op: journalOpInit, SlotSize: s.slotSize})
+	var slotIndexes []int
+	for slotIndex := range s.slots {
+		slotIndexes = append(slotIndexes, slotIndex)
+	}
+	sort.Ints(slotIndexes)
+	for _, slotIndex := range slotIndexes {
+		slot := s.slots[slotIndex]
+		for _, t := range slot.intervals {
+			records = append(records, &journalRecord{Op: journalOpWrite, Slot: slotIndex, Path: slot.path, ChunkIndex: slot.chunkIndex, Start: t.start, Stop: t.stop, TsNs: slot.tsNs})
+		}
+	}
+	for path, chunks := range s.uploaded {
+		for _, chunk := range chunks {
+			data, _ := proto.Marshal(chunk)
+			records = append(records, &journalRecord{Op: journalOpUpload, Slot: -1, Path: path, Chunk: data})
+		}
+	}
+	return
+}
+
+// readSwapJournal replays the journal. A partially written last record is ignored.
+func readSwapJournal(journalPath string) (*swapJournalState, error) {
+	state := newSwapJournalState(0)
+	f, err := os.Open(journalPath)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		record := &journalRecord{}
+		if err := json.Unmarshal(line, record); err != nil {
+			glog.Warningf("skip journal record %s: %v", string(line), err)
+			continue
+		}
+		state.apply(record)
+	}
+	return state, nil
+}
+
+// OpenSwapFile opens the swap file and its journal in the directory,
+// and returns the data left by the previous mount process to be recovered.
+func OpenSwapFile(dir string, slotSize int64, capacity int64) (swapFile *SwapFile, recovered *swapJournalState, err error) {
+
+	swapFile = &SwapFile{
+		slotSize:    slotSize,
+		slotCount:   int(capacity / slotSize),
+		journalPath: dir + "/swap.journal",
+		state:       newSwapJournalState(slotSize),
+	}
+	if swapFile.slotCount < 1 {
+		swapFile.slotCount = 1
+	}
+
+	if recovered, err = readSwapJournal(swapFile.journalPath); err != nil {
+		return nil, nil, fmt.Errorf("read swap journal %s: %v", swapFile.journalPath, err)
+	}
+
+	if swapFile.file, err = os.OpenFile(dir+"/swap", os.O_RDWR|os.O_CREATE, 0600); err != nil {
+		return nil, nil, fmt.Errorf("open swap file: %v", err)
+	}
+
+	return swapFile, recovered, nil
+}
+
+// ResetJournal discards the recovered data, and starts a new journal.
+// The swap file is reused, and the slots are allocated from the start.
+func (sf *SwapFile) ResetJournal() error {
+	sf.journalLock.Lock()
+	defer sf.journalLock.Unlock()
+
+	sf.state = newSwapJournalState(sf.slotSize)
+	return sf.rewriteJournal()
+}
+
+// rewriteJournal replaces the journal with the records of the current state
+func (sf *SwapFile) rewriteJournal() error {
+	tmpPath := sf.journalPath + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	var size int64
+	writer := bufio.NewWriter(f)
+	for _, record := range sf.state.records() {
+		data, _ := json.Marshal(record)
+		writer.Write(append(data, '\n'))
+		size += int64(len(data)) + 1
+	}
+	if err = writer.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err = os.Rename(tmpPath, sf.journalPath); err != nil {
+		f.Close()
+		return err
+	}
+	if sf.journal != nil {
+		sf.journal.Close()
+	}
+	sf.journal, sf.journalSize = f, size
+	return nil
+}
+
+func (sf *SwapFile) appendJournal(records ...*journalRecord) {
+	sf.journalLock.Lock()
+	defer sf.journalLock.Unlock()
+
+	for _, record := range records {
+		sf.state.apply(record)
+	}
+
+	if sf.state.isEmpty() {
+		// nothing to recover, start over
+		records = sf.state.records()
+		if err := sf.journal.Truncate(0); err != nil {
+			glog.Errorf("truncate swap journal %s: %v", sf.journalPath, err)
+		}
+		sf.journal.Seek(0, io.SeekStart)
+		sf.journalSize = 0
+	} else if sf.journalSize >= swapJournalCompactSize {
+		if err := sf.rewriteJournal(); err != nil {
+			glog.Errorf("rewrite swap journal %s: %v", sf.journalPath, err)
+		}
+		return
+	}
+
+	var buf []byte
+	for _, record := range records {
+		data, _ := json.Marshal(record)
+		buf = append(buf, data...)
+		buf = append(buf, '\n')
+	}
+	n, err := sf.journal.Write(buf)
+	sf.journalSize += int64(n)
+	if err != nil {
+		glog.Errorf("write swap journal %s: %v", sf.journalPath, err)
+	}
+}
+
+// AllocateSlot returns a free slot, or -1 if the swap file is full
+func (sf *SwapFile) AllocateSlot() int {
+	sf.slotsLock.Lock()
+	defer sf.slotsLock.Unlock()
+
+	if n := len(sf.freeSlots); n > 0 {
+		slot := sf.freeSlots[n-1]
+		sf.freeSlots = sf.freeSlots[:n-1]
+		return slot
+	}
+	if sf.nextSlot < sf.slotCount {
+		sf.nextSlot++
+		return sf.nextSlot - 1
+	}
+	return -1
+}
+
+func (sf *SwapFile) freeSlot(slot int) {
+	sf.slotsLock.Lock()
+	defer sf.slotsLock.Unlock()
+	sf.freeSlots = append(sf.freeSlots, slot)
+}
+
+// WriteSlot writes the data at the offset within the slot, which holds the chunkIndex-th slot-sized part of the file
+func (sf *SwapFile) WriteSlot(slot int, path util.FullPath, chunkIndex int64, offset int64, data []byte) error {
+	if _, err := sf.file.WriteAt(data, int64(slot)*sf.slotSize+offset); err != nil {
+		return err
+	}
+	sf.appendJournal(&journalRecord{
+		Op:         journalOpWrite,
+		Slot:       slot,
+		Path:       path,
+		ChunkIndex: chunkIndex,
+		Start:      offset,
+		Stop:       offset + int64(len(data)),
+		TsNs:       time.Now().UnixNano(),
+	})
+	return nil
+}
+
+func (sf *SwapFile) ReadSlot(slot int, buf []byte, offset int64) error {
+	_, err := sf.file.ReadAt(buf, int64(slot)*sf.slotSize+offset)
+	if err == io.EOF {
+		err = nil
+	}
+	return err
+}
+
+// SlotReader reads the range [start, stop) of the slot
+func (sf *SwapFile) SlotReader(slot int, start, stop int64) io.Reader {
+	return io.NewSectionReader(sf.file, int64(slot)*sf.slotSize+start, stop-start)
+}
+
+// ReleaseSlot records the chunks uploaded from the slot, and frees the slot.
+// A negative slot means the chunks are uploaded directly without going through the swap file.
+func (sf *SwapFile) ReleaseSlot(slot int, path util.FullPath, chunks []*filer_pb.FileChunk) {
+	var records []*journalRecord
+	for _, chunk := range chunks {
+		data, _ := proto.Marshal(chunk)
+		records = append(records, &journalRecord{Op: journalOpUpload, Slot: slot, Path: path, Chunk: data})
+	}
+	if slot >= 0 {
+		records = append(records, &journalRecord{Op: journalOpFree, Slot: slot})
+	}
+	sf.appendJournal(records...)
+	if slot >= 0 {
+		sf.freeSlot(slot)
+	}
+}
+
+// Commit records that the file metadata, including all uploaded chunks, is saved to the filer
+func (sf *SwapFile) Commit(path util.FullPath) {
+	sf.appendJournal(&journalRecord{Op: journalOpCommit, Path: path})
+}
+
+// writtenIntervals are the sorted and non-overlapping ranges [start, stop) written into a slot
+type writtenIntervals []writtenInterval
+
+type writtenInterval struct {
+	start, stop int64
+}
+
+func (intervals *writtenIntervals) add(start, stop int64) {
+	var merged writtenIntervals
+	for _, t := range *intervals {
+		if t.stop < start || stop < t.start {
+			merged = append(merged, t)
+			continue
+		}
+		start, stop = min(start, t.start), max(stop, t.stop)
+	}
+	merged = append(merged, writtenInterval{start: start, stop: stop})
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].start < merged[j].start
+	})
+	*intervals = merged
+}
+
+func (intervals writtenIntervals) covers(size int64) bool {
+	return len(intervals) == 1 && intervals[0].start == 0 && intervals[0].stop >= size
+}
@@ -0,0 +1,88 @@
+package filesys
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+)
+
+func TestWrittenIntervals(t *testing.T) {
+	var intervals writtenIntervals
+	intervals.add(10, 20)
+	intervals.add(30, 40)
+	intervals.add(0, 5)
+	if len(intervals) != 3 || intervals[0].start != 0 || intervals[2].start != 30 {
+		t.Fatalf("unexpected intervals %+v", intervals)
+	}
+	intervals.add(15, 35)
+	if len(intervals) != 2 || intervals[1].start != 10 || intervals[1].stop != 40 {
+		t.Fatalf("unexpected intervals %+v", intervals)
+	}
+	intervals.add(5, 10)
+	if !intervals.covers(40) || intervals.covers(41) {
+		t.Fatalf("unexpected intervals %+v", intervals)
+	}
+}
+
+func TestSwapFileJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	swapFile, recovered, err := OpenSwapFile(dir, 1024, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !recovered.isEmpty() {
+		t.Fatalf("expected nothing to recover")
+	}
+	if err = swapFile.ResetJournal(); err != nil {
+		t.Fatal(err)
+	}
+
+	first, second := swapFile.AllocateSlot(), swapFile.AllocateSlot()
+	if first != 0 || second != 1 || swapFile.AllocateSlot() != -1 {
+		t.Fatalf("expected 2 slots")
+	}
+
+	data := []byte("hello world")
+	swapFile.WriteSlot(first, "/a.txt", 3, 100, data)
+	swapFile.WriteSlot(second, "/b.txt", 0, 0, data)
+	swapFile.ReleaseSlot(second, "/b.txt", []*filer_pb.FileChunk{{FileId: "3,01637037d6", Offset: 0, Size: uint64(len(data))}})
+	if swapFile.AllocateSlot() != second {
+		t.Fatalf("released slot should be reused")
+	}
+
+	// the mount process restarts
+	state, err := readSwapJournal(swapFile.journalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state.slotSize != 1024 || len(state.slots) != 1 || len(state.uploaded["/b.txt"]) != 1 {
+		t.Fatalf("unexpected recovered state %+v", state)
+	}
+	slot := state.slots[first]
+	if slot.path != "/a.txt" || slot.chunkIndex != 3 || len(slot.intervals) != 1 || slot.intervals[0].start != 100 {
+		t.Fatalf("unexpected recovered slot %+v", slot)
+	}
+	buf := make([]byte, len(data))
+	if err = swapFile.ReadSlot(first, buf, 100); err != nil || !bytes.Equal(buf, data) {
+		t.Fatalf("read slot: %v %q", err, buf)
+	}
+
+	// saving the files leaves nothing to recover
+	swapFile.ReleaseSlot(first, "/a.txt", nil)
+	swapFile.Commit("/b.txt")
+	state, err = readSwapJournal(swapFile.journalPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.isEmpty() {
+		t.Fatalf("expected nothing to recover, got %+v", state)
+	}
+}
@@ -40,8 +40,12 @@ type Option struct {
 	ConcurrentWriters  int
 	CacheDir           string
 	CacheSizeMB        int64
+	WriteCacheSizeMB   int64
+	ReadAheadSizeMB    int64
+	ReadAheadMemoryMB  int64
 	DataCenter         string
 	EntryCacheTtl      time.Duration
+	Consistency        string
 	Umask              os.FileMode
 
 	MountUid   uint32
@@ -52,6 +56,7 @@ type Option struct {
 
 	VolumeServerAccess string // how to access volume servers
 	Cipher             bool   // whether encrypt data on volume server
+	OfflineMode        bool   // whether journal the changes when the filer is unreachable
 	UidGidMapper       *meta_cache.UidGidMapper
 }
 
@@ -73,15 +78,32 @@ type WFS struct {
 	fsNodeCache *FsCache
 
 	chunkCache *chunk_cache.TieredChunkCache
+	readAhead  *filer.ReadAhead
 	metaCache  *meta_cache.MetaCache
+	swapFile   *SwapFile
 	signature  int32
 
+	// journals the changes when the filer is unreachable, nil unless in offline mode
+	offline *offlineState
+
+	// identifies this mount as the holder of advisory locks and leases
+	lockClientId    string
+	lockSessionOnce sync.Once
+
+	// the files this mount holds leases on, valid only if the filer is heard recently
+	leasesLock         sync.Mutex
+	leases             map[util.FullPath]bool
+	lastHeardFromFiler time.Time
+	leaseRevoked       int64
+	releasedLeases     chan util.FullPath
+
 	// throttle writers
 	concurrentWriters *util.LimitedConcurrentExecutor
 	Server            *fs.Server
 }
 type statsCache struct {
 	filer_pb.StatisticsResponse
+	quota       *filer_pb.DirectoryQuota
 	lastChecked int64 // unix time in seconds
 }
 
@@ -94,36 +116,34 @@ func NewSeaweedFileSystem(option *Option) *WFS {
 				return make([]byte, option.ChunkSizeLimit)
 			},
 		},
-		signature: util.RandomInt32(),
+		signature:      util.RandomInt32(),
+		leases:         make(map[util.FullPath]bool),
+		releasedLeases: make(chan util.FullPath, 1024),
 	}
+	wfs.lockClientId = newLockClientId(wfs.signature)
 	cacheUniqueId := util.Md5String([]byte(option.MountDirectory + option.FilerGrpcAddress + option.FilerMountRootPath + util.Version()))[0:8]
 	cacheDir := path.Join(option.CacheDir, cacheUniqueId)
 	if option.CacheSizeMB > 0 {
 		os.MkdirAll(cacheDir, os.FileMode(0777)&^option.Umask)
 		wfs.chunkCache = chunk_cache.NewTieredChunkCache(256, cacheDir, option.CacheSizeMB, 1024*1024)
+		if option.ReadAheadSizeMB > 0 && option.ReadAheadMemoryMB > 0 {
+			wfs.readAhead = filer.NewReadAhead(option.ReadAheadMemoryMB * 1024 * 1024)
+		}
 	}
 
-	wfs.metaCache = meta_cache.NewMetaCache(path.Join(cacheDir, "meta"), util.FullPath(option.FilerMountRootPath), option.UidGidMapper, func(filePath util.FullPath) {
-		fsNode := wfs.fsNodeCache.GetFsNode(filePath)
-		if fsNode != nil {
-			if file, ok := fsNode.(*File); ok {
-				if err := wfs.Server.InvalidateNodeData(file); err != nil {
-					glog.V(4).Infof("InvalidateNodeData %s : %v", filePath, err)
-				}
-				file.clearEntry()
-			}
-		}
-		dir, name := filePath.DirAndName()
-		parent := wfs.root
-		if dir != "/" {
-			parent = wfs.fsNodeCache.GetFsNode(util.FullPath(dir))
-		}
-		if parent != nil {
-			if err := wfs.Server.InvalidateEntry(parent, name); err != nil {
-				glog.V(4).Infof("InvalidateEntry %s : %v", filePath, err)
-			}
+	// the fuse server is set after the meta data subscription starts, so the kernel may not be there yet
+	wfs.metaCache = meta_cache.NewMetaCache(path.Join(cacheDir, "meta"), util.FullPath(option.FilerMountRootPath), option.UidGidMapper, wfs.invalidateFile)
+	if option.OfflineMode {
+		// the journal is kept across versions, unlike the caches
+		offlineDir := path.Join(option.CacheDir, "offline_"+util.Md5String([]byte(option.MountDirectory + option.FilerGrpcAddress + option.FilerMountRootPath))[0:8])
+		offline, err := newOfflineState(wfs, offlineDir)
+		if err != nil {
+			glog.Errorf("offline mode is disabled: %v", err)
+		} else {
+			wfs.offline = offline
 		}
-	})
+	}
+
 	startTime := time.Now()
 	go meta_cache.SubscribeMetaEvents(wfs.metaCache, wfs.signature, wfs, wfs.option.FilerMountRootPath, startTime.UnixNano())
 	grace.OnInterrupt(func() {
@@ -134,10 +154,22 @@ func NewSeaweedFileSystem(option *Option) *WFS {
 	wfs.root = &Dir{name: wfs.option.FilerMountRootPath, wfs: wfs, entry: entry}
 	wfs.fsNodeCache = newFsCache(wfs.root)
 
+	if wfs.offline != nil {
+		wfs.offline.start()
+	}
+
 	if wfs.option.ConcurrentWriters > 0 {
 		wfs.concurrentWriters = util.NewLimitedConcurrentExecutor(wfs.option.ConcurrentWriters)
 	}
 
+	if option.WriteCacheSizeMB > 0 {
+		wfs.openSwapFile(cacheDir)
+	}
+
+	if option.Consistency == ConsistencyLease {
+		wfs.startLockSession()
+	}
+
 	return wfs
 }
 
@@ -145,6 +177,38 @@ func (wfs *WFS) Root() (fs.Node, error) {
 	return wfs.root, nil
 }
 
+// invalidateFile drops the cached entry and data of a file changed on the filer, also from the kernel
+func (wfs *WFS) invalidateFile(filePath util.FullPath) {
+	fsNode := wfs.fsNodeCache.GetFsNode(filePath)
+	if fsNode != nil {
+		if file, ok := fsNode.(*File); ok {
+			if wfs.Server != nil {
+				if err := wfs.Server.InvalidateNodeData(file); err != nil {
+					glog.V(4).Infof("InvalidateNodeData %s : %v", filePath, err)
+				}
+			}
+			file.clearEntry()
+		}
+	}
+	dir, name := filePath.DirAndName()
+	parent := wfs.root
+	if dir != "/" {
+		parent = wfs.fsNodeCache.GetFsNode(util.FullPath(dir))
+	}
+	if parent != nil && wfs.Server != nil {
+		if err := wfs.Server.InvalidateEntry(parent, name); err != nil {
+			glog.V(4).Infof("InvalidateEntry %s : %v", filePath, err)
+		}
+	}
+}
+
+func (wfs *WFS) isFileOpen(fullpath util.FullPath) bool {
+	wfs.handlesLock.Lock()
+	defer wfs.handlesLock.Unlock()
+	_, found := wfs.handles[fullpath.AsInode()]
+	return found
+}
+
 func (wfs *WFS) AcquireHandle(file *File, uid, gid uint32) (fileHandle *FileHandle) {
 
 	fullpath := file.fullpath()
@@ -210,6 +274,16 @@ func (wfs *WFS) Statfs(ctx context.Context, req *fuse.StatfsRequest, resp *fuse.
 			wfs.stats.TotalSize = resp.TotalSize
 			wfs.stats.UsedSize = resp.UsedSize
 			wfs.stats.FileCount = resp.FileCount
+
+			// the quota on the mounted directory is reported as the file system size
+			quotaResp, err := client.GetQuota(context.Background(), &filer_pb.GetQuotaRequest{
+				Directory: wfs.option.FilerMountRootPath,
+			})
+			if err != nil {
+				glog.V(1).Infof("reading quota of %s: %v", wfs.option.FilerMountRootPath, err)
+			} else {
+				wfs.stats.quota = quotaResp.Quota
+			}
 			wfs.stats.lastChecked = time.Now().Unix()
 
 			return nil
@@ -223,6 +297,15 @@ func (wfs *WFS) Statfs(ctx context.Context, req *fuse.StatfsRequest, resp *fuse.
 	totalDiskSize := wfs.stats.TotalSize
 	usedDiskSize := wfs.stats.UsedSize
 	actualFileCount := wfs.stats.FileCount
+	maxFileCount := uint64(math.MaxInt64)
+	if quota := wfs.stats.quota; quota != nil {
+		if quota.MaxBytes > 0 {
+			totalDiskSize, usedDiskSize = uint64(quota.MaxBytes), uint64(min(quota.UsedBytes, quota.MaxBytes))
+		}
+		if quota.MaxInodes > 0 {
+			maxFileCount, actualFileCount = uint64(quota.MaxInodes), uint64(min(quota.UsedInodes, quota.MaxInodes))
+		}
+	}
 
 	// Compute the total number of available blocks
 	resp.Blocks = totalDiskSize / blockSize
@@ -236,8 +319,8 @@ func (wfs *WFS) Statfs(ctx context.Context, req *fuse.StatfsRequest, resp *fuse.
 	resp.Bsize = uint32(blockSize)
 
 	// Report the total number of possible files in the file system (and those free)
-	resp.Files = math.MaxInt64
-	resp.Ffree = math.MaxInt64 - actualFileCount
+	resp.Files = maxFileCount
+	resp.Ffree = maxFileCount - actualFileCount
 
 	// Report the maximum length of a name and the minimum fragment size
 	resp.Namelen = 1024
@@ -251,12 +334,14 @@ func (wfs *WFS) mapPbIdFromFilerToLocal(entry *filer_pb.Entry) {
 		return
 	}
 	entry.Attributes.Uid, entry.Attributes.Gid = wfs.option.UidGidMapper.FilerToLocal(entry.Attributes.Uid, entry.Attributes.Gid)
+	wfs.option.UidGidMapper.FilerToLocalAcl(entry.Extended)
 }
 func (wfs *WFS) mapPbIdFromLocalToFiler(entry *filer_pb.Entry) {
 	if entry.Attributes == nil {
 		return
 	}
 	entry.Attributes.Uid, entry.Attributes.Gid = wfs.option.UidGidMapper.LocalToFiler(entry.Attributes.Uid, entry.Attributes.Gid)
+	wfs.option.UidGidMapper.LocalToFilerAcl(entry.Extended)
 }
 
 func (wfs *WFS) LookupFn() wdclient.LookupFileIdFunctionType {
@@ -268,3 +353,11 @@ func (wfs *WFS) LookupFn() wdclient.LookupFileIdFunctionType {
 	return filer.LookupFn(wfs)
 
 }
+
+// chunkCacheForRead also reads the local chunks written offline
+func (wfs *WFS) chunkCacheForRead() chunk_cache.ChunkCache {
+	if wfs.offline == nil {
+		return wfs.chunkCache
+	}
+	return &offlineChunkCache{ChunkCache: wfs.chunkCache, state: wfs.offline}
+}
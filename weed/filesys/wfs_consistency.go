@@ -0,0 +1,221 @@
+package filesys
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/seaweedfs/fuse"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/stats"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// the consistency modes of the mount
+const (
+	// the meta data cache is updated asynchronously by the filer meta data subscription
+	ConsistencyAsync = "async"
+	// the entry and its chunk list is read from the filer when a file is opened
+	ConsistencyCloseToOpen = "closeToOpen"
+	// the entry is read from the filer when a file is opened, unless this mount holds a lease on it,
+	// which the filer revokes before another client changes the file
+	ConsistencyLease = "lease"
+)
+
+// a lease is trusted only if the filer is heard recently, well within filer.LeaseRevokeTimeout,
+// otherwise the filer may have dropped the lease without this mount knowing it
+const leaseTrustDuration = 3 * lockHeartbeatInterval
+
+func ValidateConsistency(consistency string) error {
+	switch consistency {
+	case ConsistencyAsync, ConsistencyCloseToOpen, ConsistencyLease:
+		return nil
+	}
+	return fmt.Errorf("unknown consistency mode %s, should be one of %s|%s|%s", consistency, ConsistencyAsync, ConsistencyCloseToOpen, ConsistencyLease)
+}
+
+func (wfs *WFS) isStrictConsistency() bool {
+	return wfs.option.Consistency == ConsistencyCloseToOpen || wfs.option.Consistency == ConsistencyLease
+}
+
+// revalidateOnOpen refreshes the entry of a file that is not open yet from the filer
+func (wfs *WFS) revalidateOnOpen(ctx context.Context, file *File, req *fuse.OpenRequest) error {
+
+	var entry *filer_pb.Entry
+	var err error
+	reason := "close_to_open"
+
+	if wfs.offline != nil && wfs.offline.offline() {
+		// serve the cached entry until the filer is reachable
+		return nil
+	}
+
+	switch wfs.option.Consistency {
+	case ConsistencyCloseToOpen:
+		entry, err = filer_pb.GetEntry(wfs.asCaller(req.Uid, req.Gid), file.fullpath())
+	case ConsistencyLease:
+		if wfs.hasLease(file.fullpath()) {
+			return nil
+		}
+		reason = "lease_open"
+		entry, err = wfs.acquireLease(ctx, file, req)
+	default:
+		return nil
+	}
+
+	if err != nil && wfs.offline != nil && isUnreachable(err) {
+		glog.V(1).Infof("revalidate %s on open offline: %v", file.fullpath(), err)
+		return nil
+	}
+	if err != nil {
+		glog.Errorf("revalidate %s on open: %v", file.fullpath(), err)
+		return toFuseError(err)
+	}
+	if entry == nil {
+		return fuse.ENOENT
+	}
+	if req.Flags.IsWriteOnly() {
+		// the filer leaves out the content of files the caller cannot read, but the writes are added to the content
+		if entry, err = filer_pb.GetEntry(wfs, file.fullpath()); err != nil || entry == nil {
+			glog.Errorf("revalidate %s on open: %v", file.fullpath(), err)
+			return fuse.EIO
+		}
+	}
+
+	wfs.refreshEntry(file, entry, reason)
+	return nil
+}
+
+func (wfs *WFS) acquireLease(ctx context.Context, file *File, req *fuse.OpenRequest) (entry *filer_pb.Entry, err error) {
+
+	wfs.startLockSession()
+	dir, name := file.fullpath().DirAndName()
+	revokedCount := wfs.leaseRevokedCount()
+
+	err = wfs.asCaller(req.Uid, req.Gid).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.AcquireLease(ctx, &filer_pb.AcquireLeaseRequest{
+			Directory: dir,
+			Name:      name,
+			ClientId:  wfs.lockClientId,
+			IsWrite:   req.Flags.IsWriteOnly() || req.Flags.IsReadWrite(),
+		})
+		if err != nil {
+			if strings.Contains(err.Error(), filer_pb.ErrNotFound.Error()) {
+				return nil
+			}
+			return err
+		}
+		if resp.Granted {
+			wfs.grantLease(file.fullpath(), revokedCount)
+		}
+		entry = resp.Entry
+		return nil
+	})
+
+	return
+}
+
+// refreshEntry saves the entry read from the filer to the meta cache and the file
+func (wfs *WFS) refreshEntry(file *File, entry *filer_pb.Entry, reason string) {
+
+	dir, _ := file.fullpath().DirAndName()
+	if err := wfs.metaCache.AtomicUpdateEntryFromFiler(context.Background(), "", filer.FromPbEntry(dir, entry)); err != nil {
+		glog.Warningf("update meta cache %s: %v", file.fullpath(), err)
+	}
+
+	wfs.mapPbIdFromFilerToLocal(entry)
+	if file.entry != nil && proto.Equal(file.entry, entry) {
+		return
+	}
+	if file.entry != nil {
+		stats.MountCacheInvalidationCounter.WithLabelValues(reason).Inc()
+	}
+	file.setEntry(entry)
+}
+
+func (wfs *WFS) hasLease(path util.FullPath) bool {
+	wfs.leasesLock.Lock()
+	defer wfs.leasesLock.Unlock()
+	return wfs.leases[path] && time.Since(wfs.lastHeardFromFiler) < leaseTrustDuration
+}
+
+func (wfs *WFS) leaseRevokedCount() int64 {
+	wfs.leasesLock.Lock()
+	defer wfs.leasesLock.Unlock()
+	return wfs.leaseRevoked
+}
+
+// grantLease records the lease, unless some lease is revoked while requesting it,
+// since the revocation may come before the response granting the lease
+func (wfs *WFS) grantLease(path util.FullPath, revokedCount int64) {
+	wfs.leasesLock.Lock()
+	defer wfs.leasesLock.Unlock()
+	if wfs.leaseRevoked != revokedCount {
+		wfs.queueReleasedLease(path)
+		return
+	}
+	wfs.leases[path] = true
+}
+
+func (wfs *WFS) heardFromFiler() {
+	wfs.leasesLock.Lock()
+	defer wfs.leasesLock.Unlock()
+	wfs.lastHeardFromFiler = time.Now()
+}
+
+func (wfs *WFS) dropLeases() {
+	wfs.leasesLock.Lock()
+	defer wfs.leasesLock.Unlock()
+	wfs.leases = make(map[util.FullPath]bool)
+	wfs.leaseRevoked++
+}
+
+// releaseLease gives up the lease, e.g., when the kernel forgets the file
+func (wfs *WFS) releaseLease(path util.FullPath) {
+	wfs.leasesLock.Lock()
+	held := wfs.leases[path]
+	delete(wfs.leases, path)
+	wfs.leasesLock.Unlock()
+
+	if held {
+		wfs.queueReleasedLease(path)
+	}
+}
+
+// revokeLease invalidates the cached file, and then acknowledges the revocation to the filer
+func (wfs *WFS) revokeLease(path util.FullPath) {
+
+	wfs.leasesLock.Lock()
+	delete(wfs.leases, path)
+	wfs.leaseRevoked++
+	wfs.leasesLock.Unlock()
+
+	glog.V(3).Infof("lease revoked %s", path)
+	if fsNode := wfs.fsNodeCache.GetFsNode(path); fsNode != nil {
+		if file, ok := fsNode.(*File); ok {
+			if file.isOpen <= 0 {
+				file.clearEntry()
+			}
+			if err := wfs.Server.InvalidateNodeData(file); err != nil {
+				glog.V(4).Infof("InvalidateNodeData %s : %v", path, err)
+			}
+		}
+	}
+	stats.MountCacheInvalidationCounter.WithLabelValues("lease_revoked").Inc()
+
+	wfs.queueReleasedLease(path)
+}
+
+func (wfs *WFS) queueReleasedLease(path util.FullPath) {
+	select {
+	case wfs.releasedLeases <- path:
+	default:
+		// the filer drops the lease after filer.LeaseRevokeTimeout
+		glog.Warningf("too many released leases, skip acknowledging %s", path)
+	}
+}
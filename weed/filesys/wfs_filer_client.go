@@ -10,7 +10,22 @@ import (
 
 var _ = filer_pb.FilerClient(&WFS{})
 
+// WithFilerClient acts as the mount itself, for the requests not made on behalf of a calling process,
+// e.g. following the metadata changes, reloading entries already looked up, and listing opened directories.
+// Requests on behalf of a calling process go through asCaller.
 func (wfs *WFS) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
+	return wfs.withFilerClient(nil, filer_pb.WithServiceIdentity(fn))
+}
+
+// withFilerClient goes through the offline journal in offline mode, the caller is the local process if known
+func (wfs *WFS) withFilerClient(caller *filerCaller, fn func(filer_pb.SeaweedFilerClient) error) error {
+	if wfs.offline != nil {
+		return wfs.offline.withFilerClient(caller, fn)
+	}
+	return wfs.withFilerGrpcClient(fn)
+}
+
+func (wfs *WFS) withFilerGrpcClient(fn func(filer_pb.SeaweedFilerClient) error) error {
 
 	err := util.Retry("filer grpc "+wfs.option.FilerGrpcAddress, func() error {
 		return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
@@ -0,0 +1,106 @@
+package filesys
+
+import (
+	"os/user"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/seaweedfs/fuse"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+)
+
+var (
+	supplementaryGroups     = make(map[uint32][]uint32)
+	supplementaryGroupsLock sync.Mutex
+)
+
+// callerFilerClient sends filer requests on behalf of the process calling into the mount,
+// so that the filer can check permissions against the caller instead of the mount process.
+type callerFilerClient struct {
+	wfs *WFS
+	uid uint32
+	gid uint32
+}
+
+var _ = filer_pb.FilerClient(&callerFilerClient{})
+
+func (wfs *WFS) asCaller(uid, gid uint32) filer_pb.FilerClient {
+	return &callerFilerClient{
+		wfs: wfs,
+		uid: uid,
+		gid: gid,
+	}
+}
+
+func (c *callerFilerClient) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
+	encoded := c.wfs.callerIdentityJwt(c.uid, c.gid)
+	return c.wfs.withFilerClient(&filerCaller{uid: c.uid, gid: c.gid}, func(client filer_pb.SeaweedFilerClient) error {
+		if encoded == "" {
+			return fn(client)
+		}
+		return fn(filer_pb.NewIdentityFilerClient(client, encoded))
+	})
+}
+
+func (c *callerFilerClient) AdjustedUrl(location *filer_pb.Location) string {
+	return c.wfs.AdjustedUrl(location)
+}
+
+// callerIdentityJwt signs the caller identity, mapped to the uid and gids known by the filer
+func (wfs *WFS) callerIdentityJwt(uid, gid uint32) security.EncodedJwt {
+	key, _ := security.IdentitySigningKey()
+	if len(key) == 0 {
+		return ""
+	}
+	gids := []uint32{gid}
+	for _, g := range lookupSupplementaryGroups(uid) {
+		if g != gid {
+			gids = append(gids, g)
+		}
+	}
+	filerUid, _ := wfs.option.UidGidMapper.LocalToFiler(uid, gid)
+	for i, g := range gids {
+		_, gids[i] = wfs.option.UidGidMapper.LocalToFiler(uid, g)
+	}
+	return security.GenProcessIdentityJwt(filerUid, gids)
+}
+
+// lookupSupplementaryGroups caches the local group membership of a user
+func lookupSupplementaryGroups(uid uint32) []uint32 {
+	supplementaryGroupsLock.Lock()
+	defer supplementaryGroupsLock.Unlock()
+
+	if gids, found := supplementaryGroups[uid]; found {
+		return gids
+	}
+
+	var gids []uint32
+	if u, err := user.LookupId(strconv.Itoa(int(uid))); err == nil {
+		if groupIds, err := u.GroupIds(); err == nil {
+			for _, groupId := range groupIds {
+				if g, parseErr := strconv.ParseUint(groupId, 10, 32); parseErr == nil {
+					gids = append(gids, uint32(g))
+				}
+			}
+		}
+	} else {
+		glog.V(3).Infof("lookup groups of uid %d: %v", uid, err)
+	}
+	supplementaryGroups[uid] = gids
+	return gids
+}
+
+// toFuseError reports permission errors from the filer as EACCES, and exceeded quotas as EDQUOT
+func toFuseError(err error) error {
+	if filer_pb.IsPermissionDenied(err) {
+		return fuse.Errno(syscall.EACCES)
+	}
+	if filer_pb.IsQuotaExceeded(err) {
+		return fuse.Errno(syscall.EDQUOT)
+	}
+	return fuse.EIO
+}
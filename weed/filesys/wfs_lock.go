@@ -0,0 +1,191 @@
+package filesys
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/seaweedfs/fuse"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// advisory locks and leases are kept by the filer. The locks are forwarded to the first of the sorted filer peers,
+// so that they are visible to all mounts of the filers sharing the same store.
+// The filer releases the locks of this mount if it does not hear from this mount for a while,
+// and this mount stops trusting its leases if it does not hear from the filer for a while.
+const lockHeartbeatInterval = 3 * time.Second
+
+func newLockClientId(signature int32) string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s-%d", hostname, signature)
+}
+
+func (wfs *WFS) toPbFileLock(owner fuse.LockOwner, lock fuse.FileLock, flags fuse.LockFlags) *filer_pb.FileLock {
+	return &filer_pb.FileLock{
+		ClientId: wfs.lockClientId,
+		Owner:    uint64(owner),
+		Pid:      lock.PID,
+		Start:    lock.Start,
+		End:      lock.End,
+		IsWrite:  lock.Type == fuse.LockWrite,
+		IsFlock:  flags&fuse.LockFlock != 0,
+	}
+}
+
+// acquireLock returns EAGAIN if the lock is held by others, or waits for it if wait is set
+func (wfs *WFS) acquireLock(ctx context.Context, fullpath util.FullPath, lock *filer_pb.FileLock, wait bool) error {
+
+	wfs.startLockSession()
+
+	err := wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.AcquireLock(ctx, &filer_pb.AcquireLockRequest{
+			Path: string(fullpath),
+			Lock: lock,
+			Wait: wait,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("%s", resp.Error)
+		}
+		if resp.Conflict != nil {
+			return fuse.Errno(syscall.EAGAIN)
+		}
+		return nil
+	})
+
+	if err == nil {
+		return nil
+	}
+	if errno, ok := err.(fuse.Errno); ok {
+		return errno
+	}
+	if ctx.Err() != nil {
+		return fuse.EINTR
+	}
+	glog.Errorf("lock %s %+v: %v", fullpath, lock, err)
+	return toLockError(err)
+}
+
+func (wfs *WFS) releaseLock(ctx context.Context, fullpath util.FullPath, lock *filer_pb.FileLock, allRanges bool) error {
+	err := wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		_, err := client.ReleaseLock(ctx, &filer_pb.ReleaseLockRequest{
+			Path:      string(fullpath),
+			Lock:      lock,
+			AllRanges: allRanges,
+		})
+		return err
+	})
+	if err != nil {
+		glog.Errorf("unlock %s %+v: %v", fullpath, lock, err)
+		return toLockError(err)
+	}
+	return nil
+}
+
+func (wfs *WFS) queryLock(ctx context.Context, fullpath util.FullPath, lock *filer_pb.FileLock) (conflict *filer_pb.FileLock, err error) {
+	err = wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.QueryLock(ctx, &filer_pb.QueryLockRequest{
+			Path: string(fullpath),
+			Lock: lock,
+		})
+		if err != nil {
+			return err
+		}
+		conflict = resp.Conflict
+		return nil
+	})
+	if err != nil {
+		glog.Errorf("query lock %s %+v: %v", fullpath, lock, err)
+		return nil, toLockError(err)
+	}
+	return
+}
+
+// toLockError reports ENOSYS if the filer does not support locks, so that the kernel falls back to local locks
+func toLockError(err error) error {
+	if status.Code(err) == codes.Unimplemented {
+		return fuse.ENOSYS
+	}
+	return fuse.EIO
+}
+
+func (wfs *WFS) startLockSession() {
+	wfs.lockSessionOnce.Do(func() {
+		go wfs.keepLocksConnected()
+	})
+}
+
+// keepLocksConnected renews the lease of the locks held by this mount,
+// which are released by the filer once this connection is lost.
+// The filer also pushes the revoked leases through this connection, which are acknowledged after the cache is invalidated.
+func (wfs *WFS) keepLocksConnected() {
+
+	for {
+		wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stream, err := client.KeepConnected(ctx)
+			if err != nil {
+				glog.V(0).Infof("lock client %s failed to keep connected to %s: %v", wfs.lockClientId, wfs.option.FilerGrpcAddress, err)
+				return err
+			}
+
+			if err := stream.Send(&filer_pb.KeepConnectedRequest{
+				Name:         wfs.lockClientId,
+				LockClientId: wfs.lockClientId,
+				Signature:    wfs.signature,
+			}); err != nil {
+				glog.V(0).Infof("lock client %s failed to init at %s: %v", wfs.lockClientId, wfs.option.FilerGrpcAddress, err)
+				return err
+			}
+			// the filer drops all leases of this mount once the connection is lost
+			defer wfs.dropLeases()
+
+			go func() {
+				ticker := time.NewTicker(lockHeartbeatInterval)
+				defer ticker.Stop()
+				for {
+					request := &filer_pb.KeepConnectedRequest{
+						Name:         wfs.lockClientId,
+						LockClientId: wfs.lockClientId,
+					}
+					select {
+					case path := <-wfs.releasedLeases:
+						request.ReleasedLeases = []string{string(path)}
+					case <-ticker.C:
+					case <-ctx.Done():
+						return
+					}
+					if err := stream.Send(request); err != nil {
+						glog.Warningf("lock client %s lost connection, locks held by this mount are released: %v", wfs.lockClientId, err)
+						cancel()
+						return
+					}
+				}
+			}()
+
+			for {
+				resp, err := stream.Recv()
+				if err != nil {
+					glog.Warningf("lock client %s lost connection, locks held by this mount are released: %v", wfs.lockClientId, err)
+					return err
+				}
+				wfs.heardFromFiler()
+				for _, path := range resp.RevokedLeases {
+					wfs.revokeLease(util.FullPath(path))
+				}
+			}
+		})
+		time.Sleep(3 * time.Second)
+	}
+
+}
@@ -0,0 +1,567 @@
+package filesys
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/util/chunk_cache"
+)
+
+// In offline mode, the mount keeps working when the filer can not be reached.
+// The meta cache and the chunk cache serve the cached content, the written data is kept in local chunks,
+// and the changes to the entries are appended to a journal. When the filer is reachable again,
+// the journal is replayed. An entry changed on the filer since it was last seen is kept,
+// and the local version is saved next to it with a conflict suffix.
+//
+// The journal and the local chunks are kept in the cache directory across restarts,
+// and a journal left by the previous mount process is replayed when the filer is reachable.
+
+const (
+	offlineJournalFileName  = "journal"
+	offlineReplayedFileName = "replayed"
+	offlineChunkDirName     = "chunks"
+	localFileIdPrefix       = "local_"
+	offlineProbeInterval    = 5 * time.Second
+)
+
+const (
+	offlineOpCreate = "create"
+	offlineOpUpdate = "update"
+	offlineOpDelete = "delete"
+	offlineOpRename = "rename"
+)
+
+// offlineRecord is one journaled change, with the entries in the filer uid and gid space
+type offlineRecord struct {
+	TsNs                 int64          `json:"tsNs"`
+	Op                   string         `json:"op"`
+	Directory            string         `json:"directory"`
+	Name                 string         `json:"name"`
+	Entry                []byte         `json:"entry,omitempty"`
+	NewDirectory         string         `json:"newDirectory,omitempty"`
+	NewName              string         `json:"newName,omitempty"`
+	IsDeleteData         bool           `json:"isDeleteData,omitempty"`
+	IsRecursive          bool           `json:"isRecursive,omitempty"`
+	IgnoreRecursiveError bool           `json:"ignoreRecursiveError,omitempty"`
+	Caller               bool           `json:"caller,omitempty"`
+	Uid                  uint32         `json:"uid,omitempty"`
+	Gid                  uint32         `json:"gid,omitempty"`
+	Bases                []*offlineBase `json:"bases,omitempty"`
+}
+
+// offlineBase is the version of an entry last seen from the filer, before it is changed offline
+type offlineBase struct {
+	Path        string `json:"path"`
+	Exists      bool   `json:"exists"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// filerCaller is the local process calling into the mount
+type filerCaller struct {
+	uid uint32
+	gid uint32
+}
+
+type offlineState struct {
+	wfs *WFS
+	dir string
+
+	isOffline int32 // accessed atomically
+	isProbing int32 // accessed atomically
+
+	// protects the journal, and serializes replaying with journaling
+	sync.Mutex
+	journalFile  *os.File
+	recordCount  int
+	journaledAt  map[util.FullPath]bool         // paths whose base is already journaled
+	uploaded     map[string]*filer_pb.FileChunk // local file id => the uploaded chunk
+	localChunkId int64
+}
+
+func newOfflineState(wfs *WFS, dir string) (*offlineState, error) {
+	if err := os.MkdirAll(filepath.Join(dir, offlineChunkDirName), 0700); err != nil {
+		return nil, err
+	}
+	journal, err := os.OpenFile(filepath.Join(dir, offlineJournalFileName), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+	o := &offlineState{
+		wfs:         wfs,
+		dir:         dir,
+		journalFile: journal,
+		journaledAt: make(map[util.FullPath]bool),
+		uploaded:    make(map[string]*filer_pb.FileChunk),
+	}
+
+	records, err := o.readJournal()
+	if err != nil {
+		journal.Close()
+		return nil, fmt.Errorf("read offline journal in %s: %v", dir, err)
+	}
+	o.recordCount = len(records)
+	for _, record := range records {
+		for _, base := range record.Bases {
+			o.journaledAt[util.FullPath(base.Path)] = true
+		}
+	}
+
+	if o.recordCount == 0 {
+		o.removeLocalChunks()
+	}
+	return o, nil
+}
+
+// start replays the changes journaled by the previous mount process, after the file system is set up
+func (o *offlineState) start() {
+	if o.recordCount == 0 {
+		return
+	}
+	glog.V(0).Infof("replay %d offline changes left by the previous mount", o.recordCount)
+	atomic.StoreInt32(&o.isOffline, 1)
+	if err := o.replay(); err != nil {
+		o.goOffline(err)
+	}
+}
+
+func (o *offlineState) offline() bool {
+	return atomic.LoadInt32(&o.isOffline) == 1
+}
+
+// goOffline journals the following changes, until the filer is reachable and the journal is replayed
+func (o *offlineState) goOffline(reason error) {
+	if atomic.CompareAndSwapInt32(&o.isOffline, 0, 1) && reason != nil {
+		glog.Warningf("filer %s is unreachable, working offline: %v", o.wfs.option.FilerGrpcAddress, reason)
+	}
+	if atomic.CompareAndSwapInt32(&o.isProbing, 0, 1) {
+		go o.probe()
+	}
+}
+
+// probe waits for the filer to be reachable, and replays the journal
+func (o *offlineState) probe() {
+	defer atomic.StoreInt32(&o.isProbing, 0)
+	for {
+		if err := o.ping(); err != nil {
+			glog.V(1).Infof("filer %s is still unreachable: %v", o.wfs.option.FilerGrpcAddress, err)
+			time.Sleep(offlineProbeInterval)
+			continue
+		}
+		if err := o.replay(); err != nil {
+			glog.Errorf("replay offline changes: %v", err)
+			time.Sleep(offlineProbeInterval)
+			continue
+		}
+		return
+	}
+}
+
+func (o *offlineState) ping() error {
+	return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
+		ctx, cancel := context.WithTimeout(context.Background(), offlineProbeInterval)
+		defer cancel()
+		resp, err := filer_pb.NewSeaweedFilerClient(grpcConnection).GetFilerConfiguration(ctx, &filer_pb.GetFilerConfigurationRequest{})
+		if err != nil {
+			return err
+		}
+		o.wfs.option.Cipher = resp.Cipher
+		return nil
+	}, o.wfs.option.FilerGrpcAddress, o.wfs.option.GrpcDialOption)
+}
+
+// withFilerClient runs fn against the filer, or against the journal when the filer can not be reached
+func (o *offlineState) withFilerClient(caller *filerCaller, fn func(filer_pb.SeaweedFilerClient) error) error {
+	if !o.offline() {
+		err := o.wfs.withFilerGrpcClient(func(client filer_pb.SeaweedFilerClient) error {
+			return fn(&offlineFilerClient{SeaweedFilerClient: client, state: o, caller: caller})
+		})
+		if !isUnreachable(err) {
+			return err
+		}
+		o.goOffline(err)
+	}
+	return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
+		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		return fn(&offlineFilerClient{SeaweedFilerClient: client, state: o, caller: caller})
+	}, o.wfs.option.FilerGrpcAddress, o.wfs.option.GrpcDialOption)
+}
+
+// isUnreachable tells whether the filer or the volume servers could not be connected
+func isUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if status.Code(err) == codes.Unavailable {
+		return true
+	}
+	message := err.Error()
+	return strings.Contains(message, "code = Unavailable") ||
+		strings.Contains(message, "connection refused") ||
+		strings.Contains(message, "no route to host") ||
+		strings.Contains(message, "network is unreachable") ||
+		strings.Contains(message, "i/o timeout")
+}
+
+// journal appends the change if the mount is offline, with the bases of the paths changed for the first time
+func (o *offlineState) journal(record *offlineRecord, paths ...util.FullPath) (journaled bool, err error) {
+	o.Lock()
+	defer o.Unlock()
+
+	if !o.offline() {
+		return false, nil
+	}
+
+	for _, p := range paths {
+		if o.journaledAt[p] {
+			continue
+		}
+		record.Bases = append(record.Bases, o.cachedBase(p))
+	}
+	record.TsNs = time.Now().UnixNano()
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return true, err
+	}
+	if _, err = o.journalFile.Write(append(data, '\n')); err != nil {
+		return true, fmt.Errorf("write offline journal: %v", err)
+	}
+	if err = o.journalFile.Sync(); err != nil {
+		return true, fmt.Errorf("sync offline journal: %v", err)
+	}
+	for _, p := range paths {
+		o.journaledAt[p] = true
+	}
+	o.recordCount++
+
+	glog.V(3).Infof("journaled offline %s %s/%s", record.Op, record.Directory, record.Name)
+	return true, nil
+}
+
+// cachedBase is the version of the entry in the meta cache, which has not been changed offline yet
+func (o *offlineState) cachedBase(p util.FullPath) *offlineBase {
+	entry, err := o.wfs.metaCache.FindEntry(context.Background(), p)
+	if err != nil || entry == nil {
+		return &offlineBase{Path: string(p)}
+	}
+	return &offlineBase{Path: string(p), Exists: true, Fingerprint: entryFingerprint(entry.ToProtoEntry())}
+}
+
+// entryFingerprint identifies a version of an entry, only the existence of directories matters
+func entryFingerprint(entry *filer_pb.Entry) string {
+	if entry.IsDirectory {
+		return "directory"
+	}
+	h := md5.New()
+	for _, chunk := range entry.Chunks {
+		io.WriteString(h, chunk.GetFileIdString())
+	}
+	var mtime int64
+	var size uint64
+	if entry.Attributes != nil {
+		mtime, size = entry.Attributes.Mtime, entry.Attributes.FileSize
+	}
+	return fmt.Sprintf("%d/%d/%s", mtime, size, hex.EncodeToString(h.Sum(nil)))
+}
+
+func (o *offlineState) readJournal() (records []*offlineRecord, err error) {
+	if _, err = o.journalFile.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(o.journalFile)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		record := &offlineRecord{}
+		if err = json.Unmarshal(line, record); err != nil {
+			// a record partially written when the process stopped
+			glog.Warningf("skip broken offline journal record: %v", err)
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
+
+func isLocalFileId(fileId string) bool {
+	return strings.HasPrefix(fileId, localFileIdPrefix)
+}
+
+func (o *offlineState) localChunkPath(fileId string) string {
+	return filepath.Join(o.dir, offlineChunkDirName, fileId)
+}
+
+// saveLocalChunk keeps the data written offline in a local chunk
+func (o *offlineState) saveLocalChunk(data []byte, offset int64) (*filer_pb.FileChunk, error) {
+	fileId := fmt.Sprintf("%s%d_%d", localFileIdPrefix, time.Now().UnixNano(), atomic.AddInt64(&o.localChunkId, 1))
+	if err := ioutil.WriteFile(o.localChunkPath(fileId), data, 0600); err != nil {
+		return nil, fmt.Errorf("save local chunk: %v", err)
+	}
+	return &filer_pb.FileChunk{
+		FileId: fileId,
+		Offset: offset,
+		Size:   uint64(len(data)),
+		Mtime:  time.Now().UnixNano(),
+		ETag:   util.Md5String(data),
+	}, nil
+}
+
+func (o *offlineState) readLocalChunk(fileId string) ([]byte, error) {
+	return ioutil.ReadFile(o.localChunkPath(fileId))
+}
+
+// removeLocalChunks removes the local chunks, after all of them are uploaded
+func (o *offlineState) removeLocalChunks() {
+	dir := filepath.Join(o.dir, offlineChunkDirName)
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		os.Remove(filepath.Join(dir, f.Name()))
+	}
+}
+
+// uploadLocalChunks replaces the local chunks of the entry with uploaded chunks, with o locked
+func (o *offlineState) uploadLocalChunks(fullpath util.FullPath, entry *filer_pb.Entry) error {
+	if entry == nil {
+		return nil
+	}
+	for i, chunk := range entry.Chunks {
+		if !isLocalFileId(chunk.FileId) {
+			continue
+		}
+		uploaded, found := o.uploaded[chunk.FileId]
+		if !found {
+			data, err := o.readLocalChunk(chunk.FileId)
+			if err != nil {
+				return fmt.Errorf("read local chunk %s of %s: %v", chunk.FileId, fullpath, err)
+			}
+			uploaded, _, _, err = o.wfs.uploadAsChunk(fullpath)(bytes.NewReader(data), fullpath.Name(), chunk.Offset)
+			if err != nil {
+				return fmt.Errorf("upload local chunk %s of %s: %v", chunk.FileId, fullpath, err)
+			}
+			o.uploaded[chunk.FileId] = uploaded
+		}
+		replaced := proto.Clone(uploaded).(*filer_pb.FileChunk)
+		replaced.Offset, replaced.Size, replaced.Mtime = chunk.Offset, chunk.Size, chunk.Mtime
+		entry.Chunks[i] = replaced
+	}
+	return nil
+}
+
+func hasLocalChunks(chunks []*filer_pb.FileChunk) bool {
+	for _, chunk := range chunks {
+		if isLocalFileId(chunk.FileId) {
+			return true
+		}
+	}
+	return false
+}
+
+// offlineChunkCache reads the local chunks, and the other chunks from the chunk cache
+type offlineChunkCache struct {
+	chunk_cache.ChunkCache
+	state *offlineState
+}
+
+func (c *offlineChunkCache) GetChunk(fileId string, minSize uint64) []byte {
+	if !isLocalFileId(fileId) {
+		return c.ChunkCache.GetChunk(fileId, minSize)
+	}
+	data, err := c.state.readLocalChunk(fileId)
+	if err != nil {
+		glog.Errorf("read local chunk %s: %v", fileId, err)
+		return nil
+	}
+	return data
+}
+
+// offlineFilerClient journals the changes when the mount is offline, and serves the lookups from the meta cache.
+// When online, the local chunks left in the entries are uploaded first.
+type offlineFilerClient struct {
+	filer_pb.SeaweedFilerClient
+	state  *offlineState
+	caller *filerCaller
+}
+
+func (c *offlineFilerClient) newRecord(op, directory, name string) *offlineRecord {
+	record := &offlineRecord{Op: op, Directory: directory, Name: name}
+	if c.caller != nil {
+		record.Caller, record.Uid, record.Gid = true, c.caller.uid, c.caller.gid
+	}
+	return record
+}
+
+func (c *offlineFilerClient) LookupDirectoryEntry(ctx context.Context, in *filer_pb.LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*filer_pb.LookupDirectoryEntryResponse, error) {
+	if !c.state.offline() {
+		return c.SeaweedFilerClient.LookupDirectoryEntry(ctx, in, opts...)
+	}
+	entry, err := c.state.wfs.metaCache.FindEntry(ctx, util.NewFullPath(in.Directory, in.Name))
+	if err != nil || entry == nil {
+		return nil, filer_pb.ErrNotFound
+	}
+	pbEntry := entry.ToProtoEntry()
+	c.state.wfs.mapPbIdFromLocalToFiler(pbEntry)
+	return &filer_pb.LookupDirectoryEntryResponse{Entry: pbEntry}, nil
+}
+
+func (c *offlineFilerClient) CreateEntry(ctx context.Context, in *filer_pb.CreateEntryRequest, opts ...grpc.CallOption) (*filer_pb.CreateEntryResponse, error) {
+	fullpath := util.NewFullPath(in.Directory, in.Entry.Name)
+	if c.state.offline() && in.OExcl {
+		if existing, _ := c.state.wfs.metaCache.FindEntry(ctx, fullpath); existing != nil {
+			return &filer_pb.CreateEntryResponse{Error: fmt.Sprintf("EEXIST: entry %s already exists", fullpath)}, nil
+		}
+	}
+	data, err := proto.Marshal(in.Entry)
+	if err != nil {
+		return nil, err
+	}
+	record := c.newRecord(offlineOpCreate, in.Directory, in.Entry.Name)
+	record.Entry = data
+	if journaled, err := c.state.journal(record, fullpath); journaled {
+		if err != nil {
+			return nil, err
+		}
+		return &filer_pb.CreateEntryResponse{}, nil
+	}
+	if err := c.state.uploadEntryChunks(fullpath, in.Entry); err != nil {
+		return nil, err
+	}
+	return c.SeaweedFilerClient.CreateEntry(ctx, in, opts...)
+}
+
+func (c *offlineFilerClient) UpdateEntry(ctx context.Context, in *filer_pb.UpdateEntryRequest, opts ...grpc.CallOption) (*filer_pb.UpdateEntryResponse, error) {
+	fullpath := util.NewFullPath(in.Directory, in.Entry.Name)
+	data, err := proto.Marshal(in.Entry)
+	if err != nil {
+		return nil, err
+	}
+	record := c.newRecord(offlineOpUpdate, in.Directory, in.Entry.Name)
+	record.Entry = data
+	if journaled, err := c.state.journal(record, fullpath); journaled {
+		if err != nil {
+			return nil, err
+		}
+		return &filer_pb.UpdateEntryResponse{}, nil
+	}
+	if err := c.state.uploadEntryChunks(fullpath, in.Entry); err != nil {
+		return nil, err
+	}
+	return c.SeaweedFilerClient.UpdateEntry(ctx, in, opts...)
+}
+
+func (c *offlineFilerClient) DeleteEntry(ctx context.Context, in *filer_pb.DeleteEntryRequest, opts ...grpc.CallOption) (*filer_pb.DeleteEntryResponse, error) {
+	fullpath := util.NewFullPath(in.Directory, in.Name)
+	if c.state.offline() && !in.IsRecursive && !c.state.isEmptyCachedDirectory(ctx, fullpath) {
+		return &filer_pb.DeleteEntryResponse{Error: fmt.Sprintf("fail to delete non-empty folder: %s", fullpath)}, nil
+	}
+	record := c.newRecord(offlineOpDelete, in.Directory, in.Name)
+	record.IsDeleteData, record.IsRecursive, record.IgnoreRecursiveError = in.IsDeleteData, in.IsRecursive, in.IgnoreRecursiveError
+	if journaled, err := c.state.journal(record, fullpath); journaled {
+		if err != nil {
+			return nil, err
+		}
+		return &filer_pb.DeleteEntryResponse{}, nil
+	}
+	return c.SeaweedFilerClient.DeleteEntry(ctx, in, opts...)
+}
+
+func (c *offlineFilerClient) AtomicRenameEntry(ctx context.Context, in *filer_pb.AtomicRenameEntryRequest, opts ...grpc.CallOption) (*filer_pb.AtomicRenameEntryResponse, error) {
+	if in.Exchange && c.state.offline() {
+		return nil, status.Error(codes.Unavailable, "exchanging entries needs the filer")
+	}
+	oldPath, newPath := util.NewFullPath(in.OldDirectory, in.OldName), util.NewFullPath(in.NewDirectory, in.NewName)
+	record := c.newRecord(offlineOpRename, in.OldDirectory, in.OldName)
+	record.NewDirectory, record.NewName = in.NewDirectory, in.NewName
+	if journaled, err := c.state.journal(record, oldPath, newPath); journaled {
+		if err != nil {
+			return nil, err
+		}
+		// the filer would move the cached children with its meta data events
+		c.state.moveCachedChildren(ctx, oldPath, newPath)
+		return &filer_pb.AtomicRenameEntryResponse{}, nil
+	}
+	return c.SeaweedFilerClient.AtomicRenameEntry(ctx, in, opts...)
+}
+
+func (c *offlineFilerClient) CopyFileRange(ctx context.Context, in *filer_pb.CopyFileRangeRequest, opts ...grpc.CallOption) (*filer_pb.CopyFileRangeResponse, error) {
+	if c.state.offline() {
+		return nil, status.Error(codes.Unavailable, "copying ranges by the filer is not available offline")
+	}
+	return c.SeaweedFilerClient.CopyFileRange(ctx, in, opts...)
+}
+
+// uploadEntryChunks uploads the local chunks still referenced by an entry saved online
+func (o *offlineState) uploadEntryChunks(fullpath util.FullPath, entry *filer_pb.Entry) error {
+	if entry == nil || !hasLocalChunks(entry.Chunks) {
+		return nil
+	}
+	o.Lock()
+	defer o.Unlock()
+	return o.uploadLocalChunks(fullpath, entry)
+}
+
+// isEmptyCachedDirectory tells whether the cached directory has no entries, unknown directories are not empty
+func (o *offlineState) isEmptyCachedDirectory(ctx context.Context, dirPath util.FullPath) bool {
+	entry, err := o.wfs.metaCache.FindEntry(ctx, dirPath)
+	if err != nil || entry == nil || !entry.IsDirectory() {
+		return true
+	}
+	isEmpty := true
+	if err = o.wfs.metaCache.ListDirectoryEntries(ctx, dirPath, "", false, 1, func(entry *filer.Entry) bool {
+		isEmpty = false
+		return false
+	}); err != nil {
+		return false
+	}
+	return isEmpty
+}
+
+// moveCachedChildren moves the cached entries under a renamed directory
+func (o *offlineState) moveCachedChildren(ctx context.Context, oldDir, newDir util.FullPath) {
+	var children []*filer.Entry
+	o.wfs.metaCache.ListDirectoryEntries(ctx, oldDir, "", false, math.MaxInt32, func(entry *filer.Entry) bool {
+		children = append(children, entry)
+		return true
+	})
+	for _, child := range children {
+		oldPath := child.FullPath
+		newPath := newDir.Child(oldPath.Name())
+		if child.IsDirectory() {
+			o.moveCachedChildren(ctx, oldPath, newPath)
+		}
+		// the meta cache keeps the filer uid and gid
+		pbEntry := child.ToProtoEntry()
+		o.wfs.mapPbIdFromLocalToFiler(pbEntry)
+		o.wfs.metaCache.DeleteEntry(ctx, oldPath)
+		o.wfs.metaCache.InsertEntry(ctx, filer.FromPbEntry(string(newDir), pbEntry))
+	}
+}
@@ -0,0 +1,397 @@
+package filesys
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// offlineReplay is the progress of replaying the journal, saved after each record
+// so that a replay interrupted by a restart or the filer going away again is resumed.
+type offlineReplay struct {
+	Replayed  int                     `json:"replayed"`
+	Known     map[string]*offlineBase `json:"known"`     // the entries as saved by the replay
+	Redirects map[string]string       `json:"redirects"` // paths replaced by their conflict copies
+	Conflicts int                     `json:"conflicts"`
+
+	state *offlineState
+	bases map[string]*offlineBase // the entries as seen before the first offline change
+}
+
+// replay saves the journaled changes to the filer, and goes online once all of them are saved
+func (o *offlineState) replay() error {
+	o.Lock()
+	defer o.Unlock()
+
+	records, err := o.readJournal()
+	if err != nil {
+		return fmt.Errorf("read offline journal: %v", err)
+	}
+	r, err := o.loadReplay()
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		for _, base := range record.Bases {
+			if _, found := r.bases[base.Path]; !found {
+				r.bases[base.Path] = base
+			}
+		}
+	}
+
+	if r.Replayed < len(records) {
+		glog.V(0).Infof("replaying %d offline changes", len(records)-r.Replayed)
+	}
+	for ; r.Replayed < len(records); r.Replayed++ {
+		record := records[r.Replayed]
+		if err = r.apply(record); err != nil {
+			if isUnreachable(err) {
+				return err
+			}
+			glog.Errorf("drop offline change %s %s/%s: %v", record.Op, record.Directory, record.Name, err)
+			r.refreshCache(util.NewFullPath(record.Directory, record.Name))
+			if record.Op == offlineOpRename {
+				r.refreshCache(util.NewFullPath(record.NewDirectory, record.NewName))
+			}
+		}
+		if err = o.saveReplay(r); err != nil {
+			return err
+		}
+	}
+
+	if err = o.journalFile.Truncate(0); err != nil {
+		return fmt.Errorf("truncate offline journal: %v", err)
+	}
+	os.Remove(filepath.Join(o.dir, offlineReplayedFileName))
+	o.recordCount = 0
+	o.journaledAt = make(map[util.FullPath]bool)
+	atomic.StoreInt32(&o.isOffline, 0)
+
+	// the open files may still read the local chunks
+	o.wfs.handlesLock.Lock()
+	if len(o.wfs.handles) == 0 {
+		o.removeLocalChunks()
+		o.uploaded = make(map[string]*filer_pb.FileChunk)
+	}
+	o.wfs.handlesLock.Unlock()
+
+	glog.V(0).Infof("back online with filer %s: replayed %d offline changes, %d conflicts", o.wfs.option.FilerGrpcAddress, len(records), r.Conflicts)
+	return nil
+}
+
+func (o *offlineState) loadReplay() (*offlineReplay, error) {
+	r := &offlineReplay{
+		Known:     make(map[string]*offlineBase),
+		Redirects: make(map[string]string),
+		state:     o,
+		bases:     make(map[string]*offlineBase),
+	}
+	data, err := ioutil.ReadFile(filepath.Join(o.dir, offlineReplayedFileName))
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("read offline replay progress: %v", err)
+	}
+	return r, nil
+}
+
+func (o *offlineState) saveReplay(r *offlineReplay) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	target := filepath.Join(o.dir, offlineReplayedFileName)
+	if err = ioutil.WriteFile(target+".tmp", data, 0600); err != nil {
+		return fmt.Errorf("save offline replay progress: %v", err)
+	}
+	return os.Rename(target+".tmp", target)
+}
+
+func (r *offlineReplay) apply(record *offlineRecord) error {
+	switch record.Op {
+	case offlineOpCreate, offlineOpUpdate:
+		entry := &filer_pb.Entry{}
+		if err := proto.Unmarshal(record.Entry, entry); err != nil {
+			return fmt.Errorf("decode entry: %v", err)
+		}
+		return r.saveEntry(record, entry)
+	case offlineOpDelete:
+		return r.deleteEntry(record)
+	case offlineOpRename:
+		return r.renameEntry(record)
+	}
+	return fmt.Errorf("unknown offline change %s", record.Op)
+}
+
+func (r *offlineReplay) saveEntry(record *offlineRecord, entry *filer_pb.Entry) error {
+	client := r.client(record)
+	original := r.resolve(util.NewFullPath(record.Directory, record.Name))
+	current, err := filer_pb.GetEntry(client, original)
+	if err != nil {
+		return err
+	}
+
+	target := original
+	if !r.matches(original, current, entry.IsDirectory) {
+		if target, err = r.conflictPath(client, original); err != nil {
+			return err
+		}
+		glog.Warningf("%s is changed on the filer while offline, save the local version as %s", original, target)
+		r.Redirects[string(original)] = string(target)
+		r.Conflicts++
+	}
+
+	if err = r.state.uploadLocalChunks(target, entry); err != nil {
+		return err
+	}
+	dir, name := target.DirAndName()
+	entry.Name = name
+	if err = client.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(client, &filer_pb.CreateEntryRequest{
+			Directory:  dir,
+			Entry:      entry,
+			Signatures: []int32{r.state.wfs.signature},
+		})
+	}); err != nil {
+		return err
+	}
+	r.Known[string(target)] = savedBase(target, entry)
+
+	r.state.wfs.metaCache.InsertEntry(context.Background(), filer.FromPbEntry(dir, entry))
+	if target != original {
+		r.refreshCache(original)
+	}
+	return nil
+}
+
+func (r *offlineReplay) deleteEntry(record *offlineRecord) error {
+	client := r.client(record)
+	target := r.resolve(util.NewFullPath(record.Directory, record.Name))
+	current, err := filer_pb.GetEntry(client, target)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		r.Known[string(target)] = &offlineBase{Path: string(target)}
+		return nil
+	}
+	if !r.matches(target, current, current.IsDirectory) {
+		glog.Warningf("%s is changed on the filer while offline, keep it instead of deleting it", target)
+		r.Known[string(target)] = savedBase(target, current)
+		r.Conflicts++
+		r.refreshCache(target)
+		return nil
+	}
+
+	dir, name := target.DirAndName()
+	if err = filer_pb.Remove(client, dir, name, record.IsDeleteData, record.IsRecursive, record.IgnoreRecursiveError, false, []int32{r.state.wfs.signature}); err != nil {
+		return err
+	}
+	r.Known[string(target)] = &offlineBase{Path: string(target)}
+	return nil
+}
+
+func (r *offlineReplay) renameEntry(record *offlineRecord) error {
+	client := r.client(record)
+	oldPath := r.resolve(util.NewFullPath(record.Directory, record.Name))
+	newPath := util.NewFullPath(record.NewDirectory, record.NewName)
+	target := r.resolve(newPath)
+
+	current, err := filer_pb.GetEntry(client, oldPath)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return fmt.Errorf("%s is removed on the filer while offline", oldPath)
+	}
+	existing, err := filer_pb.GetEntry(client, target)
+	if err != nil {
+		return err
+	}
+	if existing != nil && !r.matches(target, existing, current.IsDirectory) {
+		renamedTarget, err := r.conflictPath(client, target)
+		if err != nil {
+			return err
+		}
+		glog.Warningf("%s is changed on the filer while offline, rename %s to %s instead", target, oldPath, renamedTarget)
+		r.Redirects[string(newPath)] = string(renamedTarget)
+		r.Conflicts++
+		target = renamedTarget
+	}
+
+	oldDir, oldName := oldPath.DirAndName()
+	newDir, newName := target.DirAndName()
+	if err = client.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		_, err := client.AtomicRenameEntry(context.Background(), &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: oldDir,
+			OldName:      oldName,
+			NewDirectory: newDir,
+			NewName:      newName,
+		})
+		return err
+	}); err != nil {
+		return err
+	}
+
+	r.moveKnown(oldPath, target)
+	r.Known[string(target)] = savedBase(target, current)
+	r.Known[string(oldPath)] = &offlineBase{Path: string(oldPath)}
+
+	if target != newPath {
+		ctx := context.Background()
+		if cached, _ := r.state.wfs.metaCache.FindEntry(ctx, newPath); cached != nil {
+			pbEntry := cached.ToProtoEntry()
+			r.state.wfs.mapPbIdFromLocalToFiler(pbEntry)
+			r.state.wfs.metaCache.InsertEntry(ctx, filer.FromPbEntry(newDir, pbEntry))
+			if cached.IsDirectory() {
+				r.state.moveCachedChildren(ctx, newPath, target)
+			}
+		}
+		r.refreshCache(newPath)
+	}
+	return nil
+}
+
+// resolve maps a journaled path to where the entry is saved, following the conflict copies of the path or its parents
+func (r *offlineReplay) resolve(p util.FullPath) util.FullPath {
+	for candidate := p; candidate != "/" && candidate != ""; {
+		if to, found := r.Redirects[string(candidate)]; found {
+			return util.FullPath(to + string(p[len(candidate):]))
+		}
+		dir, _ := candidate.DirAndName()
+		candidate = util.FullPath(dir)
+	}
+	return p
+}
+
+// matches tells whether the entry on the filer is still the version the offline changes are based on
+func (r *offlineReplay) matches(p util.FullPath, current *filer_pb.Entry, isDirectory bool) bool {
+	expected, found := r.Known[string(p)]
+	if !found {
+		expected, found = r.bases[string(p)]
+	}
+	if !found {
+		return true
+	}
+	if current == nil {
+		return !expected.Exists
+	}
+	if current.IsDirectory && isDirectory {
+		// directories are merged
+		return true
+	}
+	return expected.Exists && entryFingerprint(current) == expected.Fingerprint
+}
+
+// moveKnown moves the replayed versions of the entries under a renamed directory
+func (r *offlineReplay) moveKnown(oldPath, newPath util.FullPath) {
+	prefix := string(oldPath) + "/"
+	for p, known := range r.Known {
+		if strings.HasPrefix(p, prefix) {
+			delete(r.Known, p)
+			moved := string(newPath) + "/" + p[len(prefix):]
+			known.Path = moved
+			r.Known[moved] = known
+		}
+	}
+	for from, to := range r.Redirects {
+		if strings.HasPrefix(to, prefix) {
+			r.Redirects[from] = string(newPath) + "/" + to[len(prefix):]
+		}
+	}
+}
+
+// conflictPath names the local version of an entry changed on both sides
+func (r *offlineReplay) conflictPath(client filer_pb.FilerClient, p util.FullPath) (util.FullPath, error) {
+	host, _ := os.Hostname()
+	if host == "" {
+		host = "mount"
+	}
+	dir, name := p.DirAndName()
+	base := fmt.Sprintf("%s.conflict-%s-%s", name, host, time.Now().Format("20060102-150405"))
+	for i := 0; ; i++ {
+		candidate := util.NewFullPath(dir, base)
+		if i > 0 {
+			candidate = util.NewFullPath(dir, fmt.Sprintf("%s-%d", base, i))
+		}
+		existing, err := filer_pb.GetEntry(client, candidate)
+		if err != nil {
+			return "", err
+		}
+		if existing == nil {
+			return candidate, nil
+		}
+	}
+}
+
+// refreshCache replaces the cached entry with the one on the filer
+func (r *offlineReplay) refreshCache(p util.FullPath) {
+	ctx := context.Background()
+	entry, err := filer_pb.GetEntry(&offlineReplayClient{wfs: r.state.wfs, identity: security.GenProcessIdentityJwt(0, nil)}, p)
+	if err != nil {
+		glog.V(1).Infof("refresh cached %s: %v", p, err)
+		return
+	}
+	if entry == nil {
+		r.state.wfs.metaCache.DeleteEntry(ctx, p)
+	} else {
+		dir, _ := p.DirAndName()
+		r.state.wfs.metaCache.InsertEntry(ctx, filer.FromPbEntry(dir, entry))
+	}
+	// the same as a change from the metadata subscription, except for the open files
+	if !r.state.wfs.isFileOpen(p) {
+		r.state.wfs.invalidateFile(p)
+	}
+}
+
+func savedBase(p util.FullPath, entry *filer_pb.Entry) *offlineBase {
+	return &offlineBase{Path: string(p), Exists: true, Fingerprint: entryFingerprint(entry)}
+}
+
+// client replays the change as the process that made it
+func (r *offlineReplay) client(record *offlineRecord) filer_pb.FilerClient {
+	client := &offlineReplayClient{wfs: r.state.wfs}
+	if record.Caller {
+		client.identity = r.state.wfs.callerIdentityJwt(record.Uid, record.Gid)
+	} else {
+		client.identity = security.GenProcessIdentityJwt(0, nil)
+	}
+	return client
+}
+
+// offlineReplayClient talks to the filer directly, without journaling
+type offlineReplayClient struct {
+	wfs      *WFS
+	identity security.EncodedJwt
+}
+
+func (c *offlineReplayClient) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
+	return c.wfs.withFilerGrpcClient(func(client filer_pb.SeaweedFilerClient) error {
+		if c.identity == "" {
+			return fn(client)
+		}
+		return fn(filer_pb.NewIdentityFilerClient(client, c.identity))
+	})
+}
+
+func (c *offlineReplayClient) AdjustedUrl(location *filer_pb.Location) string {
+	return c.wfs.AdjustedUrl(location)
+}
@@ -0,0 +1,178 @@
+package filesys
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/seaweedfs/fuse"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// newOfflineTest mounts the directory of the test again in offline mode, and makes the mount offline
+func newOfflineTest(p *posixTest) *posixTest {
+	name := fmt.Sprintf("offline_%d", time.Now().UnixNano())
+	wfs := testFiler.newFileSystem(name, func(option *Option) {
+		option.OfflineMode = true
+		option.CacheSizeMB = 16
+	})
+	if wfs.offline == nil {
+		p.t.Fatalf("offline mode is not enabled")
+	}
+	node, err := wfs.root.(*Dir).Lookup(context.Background(), &fuse.LookupRequest{Header: rootHeader(), Name: p.dir.name}, &fuse.LookupResponse{})
+	if err != nil {
+		p.t.Fatalf("lookup %s: %v", p.dir.name, err)
+	}
+	return &posixTest{t: p.t, wfs: wfs, dir: node.(*Dir)}
+}
+
+// goOffline stops talking to the filer, without probing it in the background
+func (p *posixTest) goOffline() {
+	atomic.StoreInt32(&p.wfs.offline.isProbing, 1)
+	atomic.StoreInt32(&p.wfs.offline.isOffline, 1)
+}
+
+func (p *posixTest) filerEntry(name string) *filer_pb.Entry {
+	entry, err := filer_pb.GetEntry(p.wfs, util.NewFullPath(p.dir.FullPath(), name))
+	if err != nil {
+		p.t.Fatalf("get %s from the filer: %v", name, err)
+	}
+	return entry
+}
+
+func (p *posixTest) overwrite(file *File, data []byte) {
+	fh := p.open(file)
+	p.write(fh, 0, data, 0)
+	p.close(fh)
+}
+
+func TestOfflineReplay(t *testing.T) {
+	p := newPosixTest(t)
+	keptData, conflictData, removedData := randomBytes(3000), randomBytes(4000), randomBytes(100)
+	p.writeFile("kept", keptData)
+	conflict := p.writeFile("conflict", conflictData)
+	p.writeFile("removed", removedData)
+
+	q := newOfflineTest(p)
+	files := make(map[string]*File)
+	for _, name := range []string{"kept", "conflict", "removed"} {
+		node, err := q.lookup(name)
+		if err != nil {
+			t.Fatalf("lookup %s: %v", name, err)
+		}
+		files[name] = node.(*File)
+	}
+	if !bytes.Equal(q.readFile(files["kept"]), keptData) {
+		t.Fatalf("read kept before going offline")
+	}
+
+	q.goOffline()
+	offlineKept, offlineConflict, newData := randomBytes(5000), randomBytes(6000), randomBytes(7000)
+	q.overwrite(files["kept"], offlineKept)
+	q.overwrite(files["conflict"], offlineConflict)
+	q.writeFile("new", newData)
+	if err := q.dir.Rename(context.Background(), &fuse.RenameRequest{Header: rootHeader(), OldName: "new", NewName: "renamed"}, q.dir); err != nil {
+		t.Fatalf("rename offline: %v", err)
+	}
+	if err := q.dir.Remove(context.Background(), &fuse.RemoveRequest{Header: rootHeader(), Name: "removed"}); err != nil {
+		t.Fatalf("remove offline: %v", err)
+	}
+
+	// the changes are served locally, and not seen by the filer
+	if !bytes.Equal(q.readFile(files["kept"]), offlineKept) {
+		t.Fatalf("read kept offline")
+	}
+	if _, err := q.lookup("removed"); err != fuse.ENOENT {
+		t.Fatalf("lookup removed offline: %v", err)
+	}
+	if p.filerEntry("renamed") != nil || p.filerEntry("removed") == nil {
+		t.Fatalf("offline changes reached the filer")
+	}
+
+	// the journal is kept for the next mount process
+	reloaded, err := newOfflineState(q.wfs, q.wfs.offline.dir)
+	if err != nil {
+		t.Fatalf("reload offline journal: %v", err)
+	}
+	reloaded.journalFile.Close()
+	if reloaded.recordCount != q.wfs.offline.recordCount || reloaded.recordCount < 5 {
+		t.Fatalf("reloaded %d offline changes, expected %d", reloaded.recordCount, q.wfs.offline.recordCount)
+	}
+
+	// changed on both sides
+	onlineConflict := randomBytes(5000)
+	p.overwrite(conflict, onlineConflict)
+
+	if err := q.wfs.offline.replay(); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if q.wfs.offline.offline() {
+		t.Fatalf("still offline after replaying")
+	}
+
+	for name, expected := range map[string][]byte{"kept": offlineKept, "conflict": onlineConflict, "renamed": newData} {
+		entry := p.filerEntry(name)
+		if entry == nil {
+			t.Fatalf("%s is not replayed", name)
+		}
+		if hasLocalChunks(entry.Chunks) {
+			t.Fatalf("%s refers to local chunks", name)
+		}
+		if entry.Attributes.FileSize != uint64(len(expected)) {
+			t.Fatalf("%s has %d bytes, expected %d", name, entry.Attributes.FileSize, len(expected))
+		}
+	}
+	if p.filerEntry("new") != nil || p.filerEntry("removed") != nil {
+		t.Fatalf("rename or remove is not replayed")
+	}
+
+	var conflictCopies []string
+	if err := filer_pb.ReadDirAllEntries(p.wfs, util.FullPath(p.dir.FullPath()), "conflict.conflict-", func(entry *filer_pb.Entry, isLast bool) error {
+		conflictCopies = append(conflictCopies, entry.Name)
+		return nil
+	}); err != nil {
+		t.Fatalf("list conflict copies: %v", err)
+	}
+	if len(conflictCopies) != 1 {
+		t.Fatalf("conflict copies: %v", conflictCopies)
+	}
+
+	// the data is uploaded, readable from the other mount
+	r := newOfflineTest(p)
+	for name, expected := range map[string][]byte{"kept": offlineKept, "renamed": newData, conflictCopies[0]: offlineConflict} {
+		node, err := r.lookup(name)
+		if err != nil {
+			t.Fatalf("lookup %s: %v", name, err)
+		}
+		if !bytes.Equal(r.readFile(node.(*File)), expected) {
+			t.Fatalf("read replayed %s", name)
+		}
+	}
+}
+
+func TestOfflineCreateExisting(t *testing.T) {
+	p := newPosixTest(t)
+	p.writeFile("existing", randomBytes(10))
+	q := newOfflineTest(p)
+	if _, err := q.lookup("existing"); err != nil {
+		t.Fatalf("lookup existing: %v", err)
+	}
+	q.goOffline()
+
+	_, _, err := q.dir.Create(context.Background(), &fuse.CreateRequest{
+		Header: rootHeader(),
+		Name:   "existing",
+		Mode:   0644,
+		Flags:  fuse.OpenReadWrite | fuse.OpenExclusive,
+	}, &fuse.CreateResponse{})
+	expectErrno(t, "create existing offline", err, syscall.EEXIST)
+	if q.wfs.offline.recordCount != 0 {
+		t.Fatalf("journaled a failed create")
+	}
+}
@@ -0,0 +1,123 @@
+package filesys
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// openSwapFile buffers the written data in the swap file,
+// after saving the data left by the previous mount process to the filer.
+// If the data can not be recovered, the swap file is kept for the next restart, and the data is buffered in memory.
+func (wfs *WFS) openSwapFile(cacheDir string) {
+
+	os.MkdirAll(cacheDir, os.FileMode(0700))
+	swapFile, recovered, err := OpenSwapFile(cacheDir, wfs.option.ChunkSizeLimit, wfs.option.WriteCacheSizeMB*1024*1024)
+	if err != nil {
+		glog.Errorf("open swap file in %s: %v", cacheDir, err)
+		return
+	}
+
+	if !recovered.isEmpty() {
+		glog.V(0).Infof("recovering unsaved data of %d files", recovered.fileCount())
+		if err := wfs.recoverDirtyPages(swapFile, recovered); err != nil {
+			glog.Errorf("recover unsaved data from %s, retry on next restart: %v", cacheDir, err)
+			swapFile.file.Close()
+			return
+		}
+	}
+
+	if err := swapFile.ResetJournal(); err != nil {
+		glog.Errorf("reset swap journal in %s: %v", cacheDir, err)
+		swapFile.file.Close()
+		return
+	}
+
+	wfs.swapFile = swapFile
+}
+
+func (s *swapJournalState) fileCount() int {
+	paths := make(map[util.FullPath]bool)
+	for path := range s.uploaded {
+		paths[path] = true
+	}
+	for _, slot := range s.slots {
+		paths[slot.path] = true
+	}
+	return len(paths)
+}
+
+// recoverDirtyPages uploads the data left in the slots, and adds all uploaded chunks to the files
+func (wfs *WFS) recoverDirtyPages(swapFile *SwapFile, recovered *swapJournalState) error {
+
+	chunksByPath := make(map[util.FullPath][]*filer_pb.FileChunk)
+	for path, chunks := range recovered.uploaded {
+		chunksByPath[path] = append(chunksByPath[path], chunks...)
+	}
+
+	for slotIndex, slot := range recovered.slots {
+		slotOffset := int64(slotIndex) * recovered.slotSize
+		for _, t := range slot.intervals {
+			reader := io.NewSectionReader(swapFile.file, slotOffset+t.start, t.stop-t.start)
+			offset := slot.chunkIndex*recovered.slotSize + t.start
+			chunk, _, _, err := wfs.saveDataAsChunk(slot.path)(reader, slot.path.Name(), offset)
+			if err != nil {
+				return fmt.Errorf("upload %s [%d,%d): %v", slot.path, offset, offset+t.stop-t.start, err)
+			}
+			// keep the write time, so that newer data already saved to the filer is not overwritten
+			chunk.Mtime = slot.tsNs
+			chunksByPath[slot.path] = append(chunksByPath[slot.path], chunk)
+		}
+	}
+
+	for path, chunks := range chunksByPath {
+		if err := wfs.saveRecoveredChunks(path, chunks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (wfs *WFS) saveRecoveredChunks(path util.FullPath, chunks []*filer_pb.FileChunk) error {
+
+	entry, err := filer_pb.GetEntry(wfs, path)
+	if err != nil {
+		return fmt.Errorf("lookup %s: %v", path, err)
+	}
+	if entry == nil {
+		glog.Warningf("skip recovering %s: file is removed", path)
+		return nil
+	}
+
+	existing := make(map[string]bool)
+	for _, chunk := range entry.Chunks {
+		existing[chunk.GetFileIdString()] = true
+	}
+	added := 0
+	for _, chunk := range chunks {
+		if !existing[chunk.GetFileIdString()] {
+			entry.Chunks = append(entry.Chunks, chunk)
+			added++
+		}
+	}
+	if added == 0 {
+		return nil
+	}
+
+	dir, _ := path.DirAndName()
+	return wfs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		if err := filer_pb.UpdateEntry(client, &filer_pb.UpdateEntryRequest{
+			Directory: dir,
+			Entry:     entry,
+		}); err != nil {
+			return fmt.Errorf("save recovered %s: %v", path, err)
+		}
+		glog.V(0).Infof("recovered %d chunks of %s", added, path)
+		return nil
+	})
+}
@@ -1,9 +1,11 @@
 package filesys
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 
 	"github.com/chrislusf/seaweedfs/weed/filer"
 	"github.com/chrislusf/seaweedfs/weed/glog"
@@ -15,6 +17,32 @@ import (
 
 func (wfs *WFS) saveDataAsChunk(fullPath util.FullPath) filer.SaveDataAsChunkFunctionType {
 
+	if wfs.offline == nil {
+		return wfs.uploadAsChunk(fullPath)
+	}
+
+	// in offline mode, the data is kept in a local chunk if it can not be uploaded
+	upload := wfs.uploadAsChunk(fullPath)
+	return func(reader io.Reader, filename string, offset int64) (chunk *filer_pb.FileChunk, collection, replication string, err error) {
+		data, err := ioutil.ReadAll(reader)
+		if err != nil {
+			return nil, "", "", err
+		}
+		if !wfs.offline.offline() {
+			chunk, collection, replication, err = upload(bytes.NewReader(data), filename, offset)
+			if !isUnreachable(err) {
+				return chunk, collection, replication, err
+			}
+			wfs.offline.goOffline(err)
+		}
+		chunk, err = wfs.offline.saveLocalChunk(data, offset)
+		return chunk, wfs.option.Collection, wfs.option.Replication, err
+	}
+}
+
+// uploadAsChunk uploads the data to a volume server assigned by the filer
+func (wfs *WFS) uploadAsChunk(fullPath util.FullPath) filer.SaveDataAsChunkFunctionType {
+
 	return func(reader io.Reader, filename string, offset int64) (chunk *filer_pb.FileChunk, collection, replication string, err error) {
 		var fileId, host string
 		var auth security.EncodedJwt
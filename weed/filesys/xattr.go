@@ -2,10 +2,14 @@ package filesys
 
 import (
 	"context"
+	"os"
+	"syscall"
 
 	"github.com/seaweedfs/fuse"
 
+	"github.com/chrislusf/seaweedfs/weed/filer"
 	"github.com/chrislusf/seaweedfs/weed/filesys/meta_cache"
+	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
 	"github.com/chrislusf/seaweedfs/weed/util"
 )
@@ -22,17 +26,15 @@ func getxattr(entry *filer_pb.Entry, req *fuse.GetxattrRequest, resp *fuse.Getxa
 	if !found {
 		return fuse.ErrNoXattr
 	}
-	if req.Position < uint32(len(data)) {
-		size := req.Size
-		if req.Position+size >= uint32(len(data)) {
-			size = uint32(len(data)) - req.Position
-		}
-		if size == 0 {
-			resp.Xattr = data[req.Position:]
-		} else {
-			resp.Xattr = data[req.Position : req.Position+size]
-		}
+	if req.Position > uint32(len(data)) {
+		return fuse.Errno(syscall.ERANGE)
+	}
+	data = data[req.Position:]
+	// a zero size asks for the size of the value
+	if req.Size != 0 && req.Size < uint32(len(data)) {
+		return fuse.Errno(syscall.ERANGE)
 	}
+	resp.Xattr = data
 
 	return nil
 
@@ -47,7 +49,13 @@ func setxattr(entry *filer_pb.Entry, req *fuse.SetxattrRequest) error {
 	if entry.Extended == nil {
 		entry.Extended = make(map[string][]byte)
 	}
-	data, _ := entry.Extended[req.Name]
+	data, found := entry.Extended[req.Name]
+	if found && req.Flags&xattrCreate != 0 {
+		return fuse.Errno(syscall.EEXIST)
+	}
+	if !found && req.Flags&xattrReplace != 0 {
+		return fuse.ErrNoXattr
+	}
 
 	newData := make([]byte, int(req.Position)+len(req.Xattr))
 
@@ -57,6 +65,23 @@ func setxattr(entry *filer_pb.Entry, req *fuse.SetxattrRequest) error {
 
 	entry.Extended[req.Name] = newData
 
+	if entry.Attributes != nil && (req.Name == filer.XattrPosixAclAccess || req.Name == filer.XattrPosixAclDefault) {
+		// keep the permission bits in sync, the same as the filer does.
+		// note that Linux only passes these xattrs to fuse file systems negotiating POSIX ACL support.
+		oldExtended := map[string][]byte{req.Name: data}
+		mode, err := filer.SyncAclWithMode(os.FileMode(entry.Attributes.FileMode), oldExtended, os.FileMode(entry.Attributes.FileMode), entry.Extended, entry.IsDirectory)
+		if err != nil {
+			glog.V(0).Infof("setxattr %s: %v", req.Name, err)
+			if data == nil {
+				delete(entry.Extended, req.Name)
+			} else {
+				entry.Extended[req.Name] = data
+			}
+			return fuse.Errno(syscall.EINVAL)
+		}
+		entry.Attributes.FileMode = uint32(mode)
+	}
+
 	return nil
 
 }
@@ -93,15 +118,13 @@ func listxattr(entry *filer_pb.Entry, req *fuse.ListxattrRequest, resp *fuse.Lis
 		resp.Append(k)
 	}
 
-	size := req.Size
-	if req.Position+size >= uint32(len(resp.Xattr)) {
-		size = uint32(len(resp.Xattr)) - req.Position
+	if req.Position > uint32(len(resp.Xattr)) {
+		return fuse.Errno(syscall.ERANGE)
 	}
-
-	if size == 0 {
-		resp.Xattr = resp.Xattr[req.Position:]
-	} else {
-		resp.Xattr = resp.Xattr[req.Position : req.Position+size]
+	resp.Xattr = resp.Xattr[req.Position:]
+	// a zero size asks for the size of the list
+	if req.Size != 0 && req.Size < uint32(len(resp.Xattr)) {
+		return fuse.Errno(syscall.ERANGE)
 	}
 
 	return nil
@@ -0,0 +1,9 @@
+// +build !darwin
+
+package filesys
+
+// setxattr(2) flags
+const (
+	xattrCreate  = 0x1 // XATTR_CREATE
+	xattrReplace = 0x2 // XATTR_REPLACE
+)
@@ -0,0 +1,7 @@
+package filesys
+
+// setxattr(2) flags
+const (
+	xattrCreate  = 0x2 // XATTR_CREATE
+	xattrReplace = 0x4 // XATTR_REPLACE
+)
@@ -0,0 +1,7 @@
+// +build windows plan9
+
+package ftpd
+
+func (fi *fileInfo) sys() interface{} {
+	return nil
+}
@@ -0,0 +1,10 @@
+// +build !windows,!plan9
+
+package ftpd
+
+import "syscall"
+
+// sys lets the sftp server list the owner of the files
+func (fi *fileInfo) sys() interface{} {
+	return &syscall.Stat_t{Uid: fi.uid, Gid: fi.gid}
+}
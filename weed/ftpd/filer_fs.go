@@ -0,0 +1,838 @@
+package ftpd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/afero"
+	"google.golang.org/grpc"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/util/chunk_cache"
+	"github.com/chrislusf/seaweedfs/weed/wdclient"
+)
+
+// FilerFs is the file system of one user, confined to the home directory of the user.
+// The filer checks the permissions against the uid and gid of the user.
+type FilerFs struct {
+	option     *FtpServerOption
+	user       *FtpUser
+	home       util.FullPath
+	chunkCache chunk_cache.ChunkCache
+	lookupFn   wdclient.LookupFileIdFunctionType
+	signature  int32
+}
+
+var _ = afero.Fs(&FilerFs{})
+var _ = filer_pb.FilerClient(&FilerFs{})
+
+func NewFilerFs(option *FtpServerOption, user *FtpUser, chunkCache chunk_cache.ChunkCache) (*FilerFs, error) {
+	fs := &FilerFs{
+		option:     option,
+		user:       user,
+		home:       util.FullPath(user.HomeDir),
+		chunkCache: chunkCache,
+		signature:  util.RandomInt32(),
+	}
+	fs.lookupFn = filer.LookupFn(fs)
+
+	entry, err := filer_pb.GetEntry(fs, fs.home)
+	if err != nil {
+		return nil, fmt.Errorf("read home directory %s: %v", fs.home, err)
+	}
+	if entry == nil {
+		// create the home directory owned by the user
+		dir, name := fs.home.DirAndName()
+		if err = filer_pb.Mkdir(&serviceFilerClient{option}, dir, name, func(entry *filer_pb.Entry) {
+			entry.Attributes.FileMode = uint32(0700 | os.ModeDir)
+			entry.Attributes.Uid, entry.Attributes.Gid = user.Uid, user.Gid
+		}); err != nil {
+			return nil, fmt.Errorf("create home directory %s: %v", fs.home, err)
+		}
+	} else if !entry.IsDirectory {
+		return nil, fmt.Errorf("home %s is not a directory", fs.home)
+	}
+	return fs, nil
+}
+
+func (fs *FilerFs) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
+
+	// act as the user, so the filer checks the permissions and acls against it
+	encoded := security.GenProcessIdentityJwt(fs.user.Uid, []uint32{fs.user.Gid})
+
+	return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
+		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		if encoded != "" {
+			client = filer_pb.NewIdentityFilerClient(client, encoded)
+		}
+		return fn(client)
+	}, fs.option.FilerGrpcAddress, fs.option.GrpcDialOption)
+
+}
+
+// serviceFilerClient sends the requests as a trusted service, to create the home directories
+type serviceFilerClient struct {
+	option *FtpServerOption
+}
+
+func (c *serviceFilerClient) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
+	return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
+		return fn(filer_pb.NewServiceFilerClient(filer_pb.NewSeaweedFilerClient(grpcConnection)))
+	}, c.option.FilerGrpcAddress, c.option.GrpcDialOption)
+}
+
+func (c *serviceFilerClient) AdjustedUrl(location *filer_pb.Location) string {
+	return location.Url
+}
+
+func (fs *FilerFs) AdjustedUrl(location *filer_pb.Location) string {
+	return location.Url
+}
+
+// fullpath maps the path seen by the user to the path on the filer
+func (fs *FilerFs) fullpath(name string) util.FullPath {
+	cleaned := path.Clean("/" + strings.ReplaceAll(name, "\\", "/"))
+	if cleaned == "/" {
+		return fs.home
+	}
+	return fs.home.Child(cleaned[1:])
+}
+
+func (fs *FilerFs) checkWritable(name string) error {
+	if fs.user.ReadOnly {
+		return &os.PathError{Op: "write", Path: name, Err: os.ErrPermission}
+	}
+	if fs.fullpath(name) == fs.home {
+		return &os.PathError{Op: "write", Path: name, Err: os.ErrPermission}
+	}
+	return nil
+}
+
+func (fs *FilerFs) Name() string {
+	return "SeaweedFS"
+}
+
+// toOsError maps the filer errors to the errors expected from a file system
+func toOsError(op, name string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case err == filer_pb.ErrNotFound:
+		err = os.ErrNotExist
+	case filer_pb.IsPermissionDenied(err):
+		err = os.ErrPermission
+	case strings.Contains(err.Error(), "EEXIST") || strings.Contains(err.Error(), "already exists"):
+		err = os.ErrExist
+	}
+	return &os.PathError{Op: op, Path: name, Err: err}
+}
+
+func (fs *FilerFs) getEntry(op, name string) (*filer_pb.Entry, error) {
+	entry, err := filer_pb.GetEntry(fs, fs.fullpath(name))
+	if err != nil {
+		return nil, toOsError(op, name, err)
+	}
+	if entry == nil {
+		return nil, &os.PathError{Op: op, Path: name, Err: os.ErrNotExist}
+	}
+	return entry, nil
+}
+
+func (fs *FilerFs) Stat(name string) (os.FileInfo, error) {
+	entry, err := fs.getEntry("stat", name)
+	if err != nil {
+		return nil, err
+	}
+	return newFileInfo(entry), nil
+}
+
+func (fs *FilerFs) Mkdir(name string, perm os.FileMode) error {
+	if err := fs.checkWritable(name); err != nil {
+		return err
+	}
+	dir, dirName := fs.fullpath(name).DirAndName()
+	err := filer_pb.Mkdir(fs, dir, dirName, func(entry *filer_pb.Entry) {
+		entry.Attributes.FileMode = uint32(perm.Perm() | os.ModeDir)
+		entry.Attributes.Uid, entry.Attributes.Gid = fs.user.Uid, fs.user.Gid
+		entry.Attributes.Collection = fs.option.Collection
+		entry.Attributes.Replication = fs.option.Replication
+		entry.Attributes.DiskType = fs.option.DiskType
+	})
+	return toOsError("mkdir", name, err)
+}
+
+func (fs *FilerFs) MkdirAll(name string, perm os.FileMode) error {
+	fullpath := fs.fullpath(name)
+	if fullpath == fs.home {
+		return nil
+	}
+	if entry, err := filer_pb.GetEntry(fs, fullpath); err == nil && entry != nil {
+		if entry.IsDirectory {
+			return nil
+		}
+		return &os.PathError{Op: "mkdir", Path: name, Err: os.ErrExist}
+	}
+	if err := fs.MkdirAll(path.Dir(path.Clean("/"+name)), perm); err != nil {
+		return err
+	}
+	if err := fs.Mkdir(name, perm); err != nil && !os.IsExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (fs *FilerFs) Create(name string) (afero.File, error) {
+	return fs.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+}
+
+func (fs *FilerFs) Open(name string) (afero.File, error) {
+	return fs.OpenFile(name, os.O_RDONLY, 0)
+}
+
+// new files are not executable nor writable by others, ftp clients always ask for 0777
+const newFileMode = 0644
+
+func (fs *FilerFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	fullpath := fs.fullpath(name)
+	writable := flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0
+	if writable {
+		if err := fs.checkWritable(name); err != nil {
+			return nil, err
+		}
+	}
+
+	entry, err := filer_pb.GetEntry(fs, fullpath)
+	if err != nil {
+		return nil, toOsError("open", name, err)
+	}
+	switch {
+	case entry == nil && flag&os.O_CREATE == 0:
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	case entry != nil && flag&(os.O_CREATE|os.O_EXCL) == os.O_CREATE|os.O_EXCL:
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrExist}
+	case entry != nil && entry.IsDirectory && writable:
+		return nil, &os.PathError{Op: "open", Path: name, Err: fmt.Errorf("is a directory")}
+	case entry == nil:
+		now := time.Now().Unix()
+		entry = &filer_pb.Entry{
+			Name: fullpath.Name(),
+			Attributes: &filer_pb.FuseAttributes{
+				Mtime:       now,
+				Crtime:      now,
+				FileMode:    uint32(perm.Perm() & newFileMode),
+				Uid:         fs.user.Uid,
+				Gid:         fs.user.Gid,
+				Collection:  fs.option.Collection,
+				Replication: fs.option.Replication,
+				DiskType:    fs.option.DiskType,
+			},
+		}
+		dir, _ := fullpath.DirAndName()
+		if err = fs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+			return filer_pb.CreateEntry(client, &filer_pb.CreateEntryRequest{
+				Directory:  dir,
+				Entry:      entry,
+				OExcl:      true,
+				Signatures: []int32{fs.signature},
+			})
+		}); err != nil {
+			return nil, toOsError("create", name, err)
+		}
+	}
+	if entry.Attributes == nil {
+		entry.Attributes = &filer_pb.FuseAttributes{}
+	}
+
+	f := &filerFile{
+		fs:       fs,
+		name:     name,
+		fullpath: fullpath,
+		entry:    entry,
+		writable: writable,
+	}
+	if flag&os.O_TRUNC != 0 {
+		if err = f.Truncate(0); err != nil {
+			return nil, err
+		}
+	}
+	if flag&os.O_APPEND != 0 {
+		f.offset = int64(filer.FileSize(entry))
+	} else if writable && flag&os.O_CREATE != 0 && filer.FileSize(entry) > 0 {
+		// ftp STOR opens without O_TRUNC, and may seek to resume an upload before writing
+		f.truncateOnWrite = true
+	}
+	return f, nil
+}
+
+func (fs *FilerFs) Remove(name string) error {
+	if err := fs.checkWritable(name); err != nil {
+		return err
+	}
+	fullpath := fs.fullpath(name)
+	dir, entryName := fullpath.DirAndName()
+	if _, err := fs.getEntry("remove", name); err != nil {
+		return err
+	}
+	err := filer_pb.Remove(fs, dir, entryName, true, false, false, false, []int32{fs.signature})
+	return toOsError("remove", name, err)
+}
+
+func (fs *FilerFs) RemoveAll(name string) error {
+	if err := fs.checkWritable(name); err != nil {
+		return err
+	}
+	dir, entryName := fs.fullpath(name).DirAndName()
+	err := filer_pb.Remove(fs, dir, entryName, true, true, false, false, []int32{fs.signature})
+	return toOsError("remove", name, err)
+}
+
+func (fs *FilerFs) Rename(oldName, newName string) error {
+	if err := fs.checkWritable(oldName); err != nil {
+		return err
+	}
+	if err := fs.checkWritable(newName); err != nil {
+		return err
+	}
+	oldDir, oldEntryName := fs.fullpath(oldName).DirAndName()
+	newDir, newEntryName := fs.fullpath(newName).DirAndName()
+	err := fs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		_, err := client.AtomicRenameEntry(context.Background(), &filer_pb.AtomicRenameEntryRequest{
+			OldDirectory: oldDir,
+			OldName:      oldEntryName,
+			NewDirectory: newDir,
+			NewName:      newEntryName,
+		})
+		return err
+	})
+	return toOsError("rename", oldName, err)
+}
+
+func (fs *FilerFs) updateEntry(name string, fn func(entry *filer_pb.Entry)) error {
+	if err := fs.checkWritable(name); err != nil {
+		return err
+	}
+	entry, err := fs.getEntry("update", name)
+	if err != nil {
+		return err
+	}
+	if entry.Attributes == nil {
+		entry.Attributes = &filer_pb.FuseAttributes{}
+	}
+	fn(entry)
+	dir, _ := fs.fullpath(name).DirAndName()
+	err = fs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(client, &filer_pb.UpdateEntryRequest{
+			Directory:  dir,
+			Entry:      entry,
+			Signatures: []int32{fs.signature},
+		})
+	})
+	return toOsError("update", name, err)
+}
+
+func (fs *FilerFs) Chmod(name string, mode os.FileMode) error {
+	return fs.updateEntry(name, func(entry *filer_pb.Entry) {
+		existing := os.FileMode(entry.Attributes.FileMode)
+		entry.Attributes.FileMode = uint32(existing&^os.ModePerm | mode.Perm())
+	})
+}
+
+func (fs *FilerFs) Chtimes(name string, atime time.Time, mtime time.Time) error {
+	return fs.updateEntry(name, func(entry *filer_pb.Entry) {
+		entry.Attributes.Mtime = mtime.Unix()
+	})
+}
+
+// Symlink creates a symbolic link, the target is kept as is
+func (fs *FilerFs) Symlink(target, name string) error {
+	if err := fs.checkWritable(name); err != nil {
+		return err
+	}
+	dir, entryName := fs.fullpath(name).DirAndName()
+	now := time.Now().Unix()
+	err := fs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(client, &filer_pb.CreateEntryRequest{
+			Directory: dir,
+			Entry: &filer_pb.Entry{
+				Name: entryName,
+				Attributes: &filer_pb.FuseAttributes{
+					Mtime:         now,
+					Crtime:        now,
+					FileMode:      uint32(os.ModeSymlink | 0777),
+					Uid:           fs.user.Uid,
+					Gid:           fs.user.Gid,
+					SymlinkTarget: target,
+				},
+			},
+			OExcl:      true,
+			Signatures: []int32{fs.signature},
+		})
+	})
+	return toOsError("symlink", name, err)
+}
+
+// Readlink returns the target of a symbolic link
+func (fs *FilerFs) Readlink(name string) (string, error) {
+	entry, err := fs.getEntry("readlink", name)
+	if err != nil {
+		return "", err
+	}
+	if os.FileMode(entry.Attributes.GetFileMode())&os.ModeSymlink == 0 {
+		return "", &os.PathError{Op: "readlink", Path: name, Err: fmt.Errorf("not a symbolic link")}
+	}
+	return entry.Attributes.SymlinkTarget, nil
+}
+
+// ReadDir lists all entries of the directory
+func (fs *FilerFs) ReadDir(name string) ([]os.FileInfo, error) {
+	var infos []os.FileInfo
+	err := filer_pb.ReadDirAllEntries(fs, fs.fullpath(name), "", func(entry *filer_pb.Entry, isLast bool) error {
+		infos = append(infos, newFileInfo(entry))
+		return nil
+	})
+	if err != nil {
+		return nil, toOsError("readdir", name, err)
+	}
+	return infos, nil
+}
+
+type fileInfo struct {
+	name  string
+	size  int64
+	mode  os.FileMode
+	mtime time.Time
+	uid   uint32
+	gid   uint32
+}
+
+func newFileInfo(entry *filer_pb.Entry) *fileInfo {
+	mode := os.FileMode(entry.Attributes.GetFileMode())
+	if entry.IsDirectory {
+		mode |= os.ModeDir
+	}
+	return &fileInfo{
+		name:  entry.Name,
+		size:  int64(filer.FileSize(entry)),
+		mode:  mode,
+		mtime: time.Unix(entry.Attributes.GetMtime(), 0),
+		uid:   entry.Attributes.GetUid(),
+		gid:   entry.Attributes.GetGid(),
+	}
+}
+
+func (fi *fileInfo) Name() string       { return fi.name }
+func (fi *fileInfo) Size() int64        { return fi.size }
+func (fi *fileInfo) Mode() os.FileMode  { return fi.mode }
+func (fi *fileInfo) ModTime() time.Time { return fi.mtime }
+func (fi *fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi *fileInfo) Sys() interface{}   { return fi.sys() }
+
+type writtenInterval struct {
+	start, stop int64
+}
+
+// filerFile reads the content through the chunks, and writes the content as new chunks,
+// buffering the writes in one chunk size aligned window. The chunks are added to the entry on Close.
+type filerFile struct {
+	sync.Mutex
+	fs       *FilerFs
+	name     string
+	fullpath util.FullPath
+	entry    *filer_pb.Entry
+	offset   int64
+	reader   io.ReaderAt
+	dirList  []os.FileInfo
+	dirRead  int
+
+	writable        bool
+	truncateOnWrite bool
+	dirty           bool
+	windowStart     int64
+	data            []byte
+	written         []writtenInterval
+}
+
+var _ = afero.File(&filerFile{})
+
+func (f *filerFile) Name() string {
+	return f.name
+}
+
+func (f *filerFile) Stat() (os.FileInfo, error) {
+	f.Lock()
+	defer f.Unlock()
+	info := newFileInfo(f.entry)
+	if size := f.bufferedSize(); size > info.size {
+		info.size = size
+	}
+	return info, nil
+}
+
+func (f *filerFile) Readdir(count int) ([]os.FileInfo, error) {
+	f.Lock()
+	defer f.Unlock()
+	if !f.entry.IsDirectory {
+		return nil, &os.PathError{Op: "readdir", Path: f.name, Err: fmt.Errorf("not a directory")}
+	}
+	if f.dirList == nil {
+		list, err := f.fs.ReadDir(f.name)
+		if err != nil {
+			return nil, err
+		}
+		if list == nil {
+			list = []os.FileInfo{}
+		}
+		f.dirList = list
+	}
+	remaining := f.dirList[f.dirRead:]
+	if count <= 0 {
+		f.dirRead = len(f.dirList)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(remaining) {
+		count = len(remaining)
+	}
+	f.dirRead += count
+	return remaining[:count], nil
+}
+
+func (f *filerFile) Readdirnames(n int) ([]string, error) {
+	infos, err := f.Readdir(n)
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	return names, err
+}
+
+func (f *filerFile) Read(p []byte) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+	n, err := f.readAt(p, f.offset)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *filerFile) ReadAt(p []byte, offset int64) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+	return f.readAt(p, offset)
+}
+
+func (f *filerFile) readAt(p []byte, offset int64) (int, error) {
+	if f.entry.IsDirectory {
+		return 0, &os.PathError{Op: "read", Path: f.name, Err: fmt.Errorf("is a directory")}
+	}
+	if f.dirty {
+		if err := f.flush(); err != nil {
+			return 0, err
+		}
+	}
+	fileSize := int64(filer.FileSize(f.entry))
+	if offset >= fileSize {
+		return 0, io.EOF
+	}
+	if len(f.entry.Content) > 0 {
+		n := copy(p, f.entry.Content[offset:])
+		if offset+int64(n) >= fileSize {
+			return n, io.EOF
+		}
+		return n, nil
+	}
+	if f.reader == nil {
+		visibles, err := filer.NonOverlappingVisibleIntervals(f.fs.lookupFn, f.entry.Chunks)
+		if err != nil {
+			return 0, err
+		}
+		chunkViews := filer.ViewFromVisibleIntervals(visibles, 0, math.MaxInt64)
+		f.reader = filer.NewChunkReaderAtFromClient(f.fs.lookupFn, chunkViews, f.fs.chunkCache, fileSize)
+	}
+	n, err := f.reader.ReadAt(p, offset)
+	if err == nil && offset+int64(n) >= fileSize {
+		err = io.EOF
+	}
+	return n, err
+}
+
+func (f *filerFile) Seek(offset int64, whence int) (int64, error) {
+	f.Lock()
+	defer f.Unlock()
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		size := int64(filer.FileSize(f.entry))
+		if buffered := f.bufferedSize(); buffered > size {
+			size = buffered
+		}
+		offset += size
+	default:
+		return 0, os.ErrInvalid
+	}
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *filerFile) Write(p []byte) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+	n, err := f.writeAt(p, f.offset)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *filerFile) WriteAt(p []byte, offset int64) (int, error) {
+	f.Lock()
+	defer f.Unlock()
+	return f.writeAt(p, offset)
+}
+
+func (f *filerFile) WriteString(s string) (int, error) {
+	return f.Write([]byte(s))
+}
+
+func (f *filerFile) writeAt(p []byte, offset int64) (int, error) {
+	if !f.writable {
+		return 0, &os.PathError{Op: "write", Path: f.name, Err: os.ErrPermission}
+	}
+	if f.truncateOnWrite {
+		// overwrite the file, keeping the content before the resumed position
+		f.truncateOnWrite = false
+		if err := f.truncate(offset); err != nil {
+			return 0, err
+		}
+	}
+
+	chunkSize := f.fs.option.ChunkSizeLimit
+	written := 0
+	for written < len(p) {
+		windowStart := offset / chunkSize * chunkSize
+		if len(f.written) > 0 && windowStart != f.windowStart {
+			if err := f.saveWindow(); err != nil {
+				return written, err
+			}
+		}
+		if f.data == nil {
+			f.data = make([]byte, chunkSize)
+		}
+		f.windowStart = windowStart
+		n := copy(f.data[offset-windowStart:], p[written:])
+		f.addInterval(offset, offset+int64(n))
+		offset, written = offset+int64(n), written+n
+	}
+	f.dirty = true
+	return written, nil
+}
+
+func (f *filerFile) addInterval(start, stop int64) {
+	f.written = append(f.written, writtenInterval{start, stop})
+	sort.Slice(f.written, func(i, j int) bool {
+		return f.written[i].start < f.written[j].start
+	})
+	merged := f.written[:1]
+	for _, t := range f.written[1:] {
+		last := &merged[len(merged)-1]
+		if t.start <= last.stop {
+			if t.stop > last.stop {
+				last.stop = t.stop
+			}
+			continue
+		}
+		merged = append(merged, t)
+	}
+	f.written = merged
+}
+
+func (f *filerFile) bufferedSize() int64 {
+	var size int64
+	for _, t := range f.written {
+		if t.stop > size {
+			size = t.stop
+		}
+	}
+	return size
+}
+
+// saveWindow uploads the buffered writes as chunks of the entry
+func (f *filerFile) saveWindow() error {
+	if len(f.written) == 0 {
+		return nil
+	}
+	saveFunc := f.fs.saveDataAsChunk(f.fullpath)
+	if len(f.entry.Content) > 0 {
+		// keep the inlined content as a chunk, before adding the new chunks over it
+		contentChunk, _, _, err := saveFunc(bytes.NewReader(f.entry.Content), f.fullpath.Name(), 0)
+		if err != nil {
+			return err
+		}
+		contentChunk.Mtime = 0
+		f.entry.Chunks = append(f.entry.Chunks, contentChunk)
+		f.entry.Content = nil
+	}
+	for _, t := range f.written {
+		chunk, _, _, err := saveFunc(bytes.NewReader(f.data[t.start-f.windowStart:t.stop-f.windowStart]), f.fullpath.Name(), t.start)
+		if err != nil {
+			return err
+		}
+		f.entry.Chunks = append(f.entry.Chunks, chunk)
+		if uint64(t.stop) > f.entry.Attributes.FileSize {
+			f.entry.Attributes.FileSize = uint64(t.stop)
+		}
+	}
+	f.written = f.written[:0]
+	f.reader = nil
+	return nil
+}
+
+// flush saves the buffered writes and the entry
+func (f *filerFile) flush() error {
+	if err := f.saveWindow(); err != nil {
+		return err
+	}
+	if manifestedChunks, err := filer.MaybeManifestize(f.fs.saveDataAsChunk(f.fullpath), f.entry.Chunks); err != nil {
+		// not good, but should be ok
+		glog.V(0).Infof("file %s flush MaybeManifestize: %v", f.fullpath, err)
+	} else {
+		f.entry.Chunks = manifestedChunks
+	}
+	f.entry.Attributes.Mtime = time.Now().Unix()
+
+	dir, _ := f.fullpath.DirAndName()
+	err := f.fs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(client, &filer_pb.UpdateEntryRequest{
+			Directory:  dir,
+			Entry:      f.entry,
+			Signatures: []int32{f.fs.signature},
+		})
+	})
+	if err != nil {
+		return toOsError("write", f.name, err)
+	}
+	f.dirty = false
+	return nil
+}
+
+func (f *filerFile) Sync() error {
+	f.Lock()
+	defer f.Unlock()
+	if !f.dirty {
+		return nil
+	}
+	return f.flush()
+}
+
+func (f *filerFile) Truncate(size int64) error {
+	f.Lock()
+	defer f.Unlock()
+	if !f.writable {
+		return &os.PathError{Op: "truncate", Path: f.name, Err: os.ErrPermission}
+	}
+	return f.truncate(size)
+}
+
+// truncate changes the file size, dropping or cutting the chunks beyond the new size
+func (f *filerFile) truncate(size int64) error {
+	if err := f.saveWindow(); err != nil {
+		return err
+	}
+	if uint64(size) < filer.FileSize(f.entry) {
+		if int64(len(f.entry.Content)) > size {
+			f.entry.Content = f.entry.Content[:size]
+		}
+		dataChunks, _, err := filer.ResolveChunkManifest(f.fs.lookupFn, f.entry.Chunks)
+		if err != nil {
+			return err
+		}
+		var chunks []*filer_pb.FileChunk
+		for _, chunk := range dataChunks {
+			if chunk.Offset >= size {
+				continue
+			}
+			if chunk.Offset+int64(chunk.Size) > size {
+				chunk.Size = uint64(size - chunk.Offset)
+			}
+			chunks = append(chunks, chunk)
+		}
+		f.entry.Chunks = chunks
+	}
+	f.entry.Attributes.FileSize = uint64(size)
+	return f.flush()
+}
+
+func (f *filerFile) Close() error {
+	f.Lock()
+	defer f.Unlock()
+	if !f.dirty {
+		return nil
+	}
+	return f.flush()
+}
+
+func (fs *FilerFs) saveDataAsChunk(fullpath util.FullPath) filer.SaveDataAsChunkFunctionType {
+	return func(reader io.Reader, name string, offset int64) (chunk *filer_pb.FileChunk, collection, replication string, err error) {
+
+		var fileId, host string
+		var auth security.EncodedJwt
+
+		if err = fs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+			request := &filer_pb.AssignVolumeRequest{
+				Count:       1,
+				Replication: fs.option.Replication,
+				Collection:  fs.option.Collection,
+				DiskType:    fs.option.DiskType,
+				Path:        string(fullpath),
+			}
+
+			resp, err := client.AssignVolume(context.Background(), request)
+			if err != nil {
+				glog.V(0).Infof("assign volume failure %v: %v", request, err)
+				return err
+			}
+			if resp.Error != "" {
+				return fmt.Errorf("assign volume failure %v: %v", request, resp.Error)
+			}
+
+			fileId, host, auth = resp.FileId, resp.Url, security.EncodedJwt(resp.Auth)
+			collection, replication = resp.Collection, resp.Replication
+
+			return nil
+		}); err != nil {
+			return nil, "", "", fmt.Errorf("filerGrpcAddress assign volume: %v", err)
+		}
+
+		fileUrl := fmt.Sprintf("http://%s/%s", host, fileId)
+		uploadResult, err, _ := operation.Upload(fileUrl, name, fs.option.Cipher, reader, false, "", nil, auth)
+		if err != nil {
+			glog.V(0).Infof("upload data %v to %s: %v", name, fileUrl, err)
+			return nil, "", "", fmt.Errorf("upload data: %v", err)
+		}
+		if uploadResult.Error != "" {
+			glog.V(0).Infof("upload failure %v to %s: %v", name, fileUrl, uploadResult.Error)
+			return nil, "", "", fmt.Errorf("upload result: %v", uploadResult.Error)
+		}
+		return uploadResult.ToPbFileChunk(fileId, offset), collection, replication, nil
+	}
+}
@@ -5,9 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path"
 
 	ftpserver "github.com/fclairamb/ftpserverlib"
 	"google.golang.org/grpc"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/util/chunk_cache"
 )
 
 type FtpServerOption struct {
@@ -16,31 +22,64 @@ type FtpServerOption struct {
 	IpBind           string
 	Port             int
 	FilerGrpcAddress string
-	FtpRoot          string
 	GrpcDialOption   grpc.DialOption
 	PassivePortStart int
 	PassivePortStop  int
+	Collection       string
+	Replication      string
+	DiskType         string
+	ChunkSizeLimit   int64
+	Cipher           bool
+	CacheDir         string
+	CacheSizeMB      int64
+	Users            *FtpUsers
+	TlsCertFile      string
+	TlsKeyFile       string
 }
 
-type SftpServer struct {
+type FtpServer struct {
 	option      *FtpServerOption
 	ftpListener net.Listener
+	tlsConfig   *tls.Config
+	chunkCache  *chunk_cache.TieredChunkCache
 }
 
-var _ = ftpserver.MainDriver(&SftpServer{})
+var _ = ftpserver.MainDriver(&FtpServer{})
 
 // NewServer returns a new FTP server driver
-func NewFtpServer(ftpListener net.Listener, option *FtpServerOption) (*SftpServer, error) {
-	var err error
-	server := &SftpServer{
+func NewFtpServer(ftpListener net.Listener, option *FtpServerOption) (*FtpServer, error) {
+	server := &FtpServer{
 		option:      option,
 		ftpListener: ftpListener,
+		chunkCache:  newChunkCache(option, "ftp"),
+	}
+	if option.TlsCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(option.TlsCertFile, option.TlsKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load tls certificate %s: %v", option.TlsCertFile, err)
+		}
+		server.tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
 	}
-	return server, err
+	return server, nil
+}
+
+func newChunkCache(option *FtpServerOption, protocol string) *chunk_cache.TieredChunkCache {
+	cacheUniqueId := util.Md5String([]byte(protocol + option.FilerGrpcAddress + util.Version()))[0:8]
+	cacheDir := path.Join(option.CacheDir, cacheUniqueId)
+	os.MkdirAll(cacheDir, os.FileMode(0755))
+	return chunk_cache.NewTieredChunkCache(256, cacheDir, option.CacheSizeMB, 1024*1024)
+}
+
+// Serve answers the ftp clients until the listener fails
+func (s *FtpServer) Serve() error {
+	return ftpserver.NewFtpServer(s).ListenAndServe()
 }
 
 // GetSettings returns some general settings around the server setup
-func (s *SftpServer) GetSettings() (*ftpserver.Settings, error) {
+func (s *FtpServer) GetSettings() (*ftpserver.Settings, error) {
 	var portRange *ftpserver.PortRange
 	if s.option.PassivePortStart > 0 && s.option.PassivePortStop > s.option.PassivePortStart {
 		portRange = &ftpserver.PortRange{
@@ -61,21 +100,34 @@ func (s *SftpServer) GetSettings() (*ftpserver.Settings, error) {
 }
 
 // ClientConnected is called to send the very first welcome message
-func (s *SftpServer) ClientConnected(cc ftpserver.ClientContext) (string, error) {
+func (s *FtpServer) ClientConnected(cc ftpserver.ClientContext) (string, error) {
 	return "Welcome to SeaweedFS FTP Server", nil
 }
 
 // ClientDisconnected is called when the user disconnects, even if he never authenticated
-func (s *SftpServer) ClientDisconnected(cc ftpserver.ClientContext) {
+func (s *FtpServer) ClientDisconnected(cc ftpserver.ClientContext) {
 }
 
 // AuthUser authenticates the user and selects an handling driver
-func (s *SftpServer) AuthUser(cc ftpserver.ClientContext, username, password string) (ftpserver.ClientDriver, error) {
-	return nil, nil
+func (s *FtpServer) AuthUser(cc ftpserver.ClientContext, username, password string) (ftpserver.ClientDriver, error) {
+	user, err := s.option.Users.AuthenticatePassword(username, password)
+	if err != nil {
+		glog.V(0).Infof("ftp user %s from %s: %v", username, cc.RemoteAddr(), err)
+		return nil, err
+	}
+	glog.V(1).Infof("ftp user %s logged in from %s", username, cc.RemoteAddr())
+	fs, err := NewFilerFs(s.option, user, s.chunkCache)
+	if err != nil {
+		return nil, err
+	}
+	return fs, nil
 }
 
 // GetTLSConfig returns a TLS Certificate to use
 // The certificate could frequently change if we use something like "let's encrypt"
-func (s *SftpServer) GetTLSConfig() (*tls.Config, error) {
-	return nil, errors.New("no TLS certificate configured")
+func (s *FtpServer) GetTLSConfig() (*tls.Config, error) {
+	if s.tlsConfig == nil {
+		return nil, errors.New("no TLS certificate configured")
+	}
+	return s.tlsConfig, nil
 }
@@ -0,0 +1,128 @@
+package ftpd
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/ssh"
+)
+
+var errAuthFailed = errors.New("authentication failed")
+
+// FtpUser is one user of the ftp and sftp servers, configured in a json file:
+//
+//	{
+//	  "users": [
+//	    {
+//	      "name": "alice",
+//	      "password": "$2a$10$...",            # bcrypt hash, or the plain password
+//	      "publicKeys": ["ssh-ed25519 AAAA..."], # in authorized_keys format, for sftp
+//	      "homeDir": "/home/alice",            # the filer directory the user is confined to
+//	      "readOnly": false,
+//	      "uid": 1000,
+//	      "gid": 1000
+//	    }
+//	  ]
+//	}
+type FtpUser struct {
+	Name       string   `json:"name"`
+	Password   string   `json:"password"`
+	PublicKeys []string `json:"publicKeys"`
+	HomeDir    string   `json:"homeDir"`
+	ReadOnly   bool     `json:"readOnly"`
+	Uid        uint32   `json:"uid"`
+	Gid        uint32   `json:"gid"`
+
+	publicKeys []ssh.PublicKey
+}
+
+type FtpUsers struct {
+	Users []*FtpUser `json:"users"`
+}
+
+// LoadFtpUsers reads and checks the users config file
+func LoadFtpUsers(fileName string) (*FtpUsers, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %v", fileName, err)
+	}
+	return parseFtpUsers(data)
+}
+
+func parseFtpUsers(data []byte) (*FtpUsers, error) {
+	users := &FtpUsers{}
+	if err := json.Unmarshal(data, users); err != nil {
+		return nil, fmt.Errorf("parse users: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, user := range users.Users {
+		if user.Name == "" {
+			return nil, fmt.Errorf("user without a name")
+		}
+		if names[user.Name] {
+			return nil, fmt.Errorf("duplicated user %s", user.Name)
+		}
+		names[user.Name] = true
+		if user.HomeDir == "" {
+			user.HomeDir = "/home/" + user.Name
+		}
+		user.HomeDir = path.Clean("/" + user.HomeDir)
+		for _, key := range user.PublicKeys {
+			publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key))
+			if err != nil {
+				return nil, fmt.Errorf("user %s public key %s: %v", user.Name, key, err)
+			}
+			user.publicKeys = append(user.publicKeys, publicKey)
+		}
+	}
+	return users, nil
+}
+
+func (users *FtpUsers) find(name string) *FtpUser {
+	for _, user := range users.Users {
+		if user.Name == name {
+			return user
+		}
+	}
+	return nil
+}
+
+// AuthenticatePassword checks the password of the user
+func (users *FtpUsers) AuthenticatePassword(name, password string) (*FtpUser, error) {
+	user := users.find(name)
+	if user == nil || user.Password == "" {
+		return nil, errAuthFailed
+	}
+	if strings.HasPrefix(user.Password, "$2") {
+		if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)) != nil {
+			return nil, errAuthFailed
+		}
+		return user, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(user.Password), []byte(password)) != 1 {
+		return nil, errAuthFailed
+	}
+	return user, nil
+}
+
+// AuthenticatePublicKey checks the public key is one of the keys of the user
+func (users *FtpUsers) AuthenticatePublicKey(name string, key ssh.PublicKey) (*FtpUser, error) {
+	user := users.find(name)
+	if user == nil {
+		return nil, errAuthFailed
+	}
+	marshaled := key.Marshal()
+	for _, publicKey := range user.publicKeys {
+		if bytes.Equal(publicKey.Marshal(), marshaled) {
+			return user, nil
+		}
+	}
+	return nil, errAuthFailed
+}
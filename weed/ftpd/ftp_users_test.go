@@ -0,0 +1,58 @@
+package ftpd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestFtpUsers(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	sshKey, _ := ssh.NewPublicKey(publicKey)
+	otherKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	otherSshKey, _ := ssh.NewPublicKey(otherKey)
+
+	users, err := parseFtpUsers([]byte(fmt.Sprintf(`{"users": [
+		{"name": "alice", "password": %q, "publicKeys": [%q]},
+		{"name": "bob", "password": "plain", "homeDir": "data/../bob/", "readOnly": true}
+	]}`, hash, ssh.MarshalAuthorizedKey(sshKey))))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if users.find("alice").HomeDir != "/home/alice" || users.find("bob").HomeDir != "/bob" {
+		t.Errorf("unexpected home dirs %s %s", users.find("alice").HomeDir, users.find("bob").HomeDir)
+	}
+
+	if _, err := users.AuthenticatePassword("alice", "secret"); err != nil {
+		t.Errorf("bcrypt password: %v", err)
+	}
+	if _, err := users.AuthenticatePassword("alice", "wrong"); err == nil {
+		t.Errorf("wrong bcrypt password accepted")
+	}
+	if _, err := users.AuthenticatePassword("bob", "plain"); err != nil {
+		t.Errorf("plain password: %v", err)
+	}
+	if _, err := users.AuthenticatePassword("carol", ""); err == nil {
+		t.Errorf("unknown user accepted")
+	}
+
+	if _, err := users.AuthenticatePublicKey("alice", sshKey); err != nil {
+		t.Errorf("public key: %v", err)
+	}
+	if _, err := users.AuthenticatePublicKey("alice", otherSshKey); err == nil {
+		t.Errorf("unknown public key accepted")
+	}
+	if _, err := users.AuthenticatePublicKey("bob", sshKey); err == nil {
+		t.Errorf("public key of another user accepted")
+	}
+
+	if _, err := parseFtpUsers([]byte(`{"users": [{"name": "a"}, {"name": "a"}]}`)); err == nil {
+		t.Errorf("duplicated users accepted")
+	}
+}
@@ -0,0 +1,290 @@
+package ftpd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/util/chunk_cache"
+)
+
+const sftpUserExtension = "seaweedfs-user"
+
+// SftpServer serves the sftp subsystem over ssh, authenticating the users by password or public key
+type SftpServer struct {
+	option     *FtpServerOption
+	listener   net.Listener
+	config     *ssh.ServerConfig
+	chunkCache *chunk_cache.TieredChunkCache
+}
+
+// NewSftpServer loads the host key, or generates one if the host key file does not exist
+func NewSftpServer(listener net.Listener, option *FtpServerOption, hostKeyFile string) (*SftpServer, error) {
+	s := &SftpServer{
+		option:     option,
+		listener:   listener,
+		chunkCache: newChunkCache(option, "sftp"),
+	}
+
+	s.config = &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if _, err := option.Users.AuthenticatePassword(conn.User(), string(password)); err != nil {
+				glog.V(0).Infof("sftp user %s from %s: %v", conn.User(), conn.RemoteAddr(), err)
+				return nil, err
+			}
+			return &ssh.Permissions{Extensions: map[string]string{sftpUserExtension: conn.User()}}, nil
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if _, err := option.Users.AuthenticatePublicKey(conn.User(), key); err != nil {
+				return nil, err
+			}
+			return &ssh.Permissions{Extensions: map[string]string{sftpUserExtension: conn.User()}}, nil
+		},
+	}
+
+	hostKey, err := loadOrGenerateHostKey(hostKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	s.config.AddHostKey(hostKey)
+
+	return s, nil
+}
+
+func loadOrGenerateHostKey(hostKeyFile string) (ssh.Signer, error) {
+	data, err := ioutil.ReadFile(hostKeyFile)
+	if err == nil {
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse host key %s: %v", hostKeyFile, err)
+		}
+		return signer, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("read host key %s: %v", hostKeyFile, err)
+	}
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	data = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err = ioutil.WriteFile(hostKeyFile, data, 0600); err != nil {
+		return nil, fmt.Errorf("save host key %s: %v", hostKeyFile, err)
+	}
+	glog.V(0).Infof("generated sftp host key %s", hostKeyFile)
+	return ssh.ParsePrivateKey(data)
+}
+
+// Serve answers the sftp clients until the listener fails
+func (s *SftpServer) Serve() error {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *SftpServer) handleConn(conn net.Conn) {
+	defer conn.Close()
+
+	// finish the handshake in time
+	conn.SetDeadline(time.Now().Add(30 * time.Second))
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		glog.V(1).Infof("ssh handshake with %s: %v", conn.RemoteAddr(), err)
+		return
+	}
+	conn.SetDeadline(time.Time{})
+	defer sshConn.Close()
+	go ssh.DiscardRequests(requests)
+
+	user := s.option.Users.find(sshConn.Permissions.Extensions[sftpUserExtension])
+	if user == nil {
+		return
+	}
+	glog.V(1).Infof("sftp user %s logged in from %s", user.Name, conn.RemoteAddr())
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			glog.V(0).Infof("accept channel from %s: %v", conn.RemoteAddr(), err)
+			continue
+		}
+		go s.handleSession(user, channel, channelRequests)
+	}
+}
+
+// handleSession only serves the sftp subsystem, no shell or command execution
+func (s *SftpServer) handleSession(user *FtpUser, channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		isSftp := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+		req.Reply(isSftp, nil)
+		if !isSftp {
+			continue
+		}
+
+		fs, err := NewFilerFs(s.option, user, s.chunkCache)
+		if err != nil {
+			glog.Errorf("sftp user %s: %v", user.Name, err)
+			return
+		}
+		handler := &sftpHandler{fs: fs}
+		server := sftp.NewRequestServer(channel, sftp.Handlers{
+			FileGet:  handler,
+			FilePut:  handler,
+			FileCmd:  handler,
+			FileList: handler,
+		})
+		if err := server.Serve(); err != nil && err != io.EOF {
+			glog.V(1).Infof("sftp user %s: %v", user.Name, err)
+		}
+		server.Close()
+		return
+	}
+}
+
+// sftpHandler maps the sftp requests to the filer file system of the user
+type sftpHandler struct {
+	fs *FilerFs
+}
+
+func (h *sftpHandler) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	f, err := h.fs.OpenFile(r.Filepath, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	return f.(*filerFile), nil
+}
+
+func (h *sftpHandler) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	pflags := r.Pflags()
+	flag := os.O_WRONLY
+	if pflags.Read {
+		flag = os.O_RDWR
+	}
+	if pflags.Append {
+		flag |= os.O_APPEND
+	}
+	if pflags.Creat {
+		flag |= os.O_CREATE
+	}
+	if pflags.Trunc {
+		flag |= os.O_TRUNC
+	}
+	if pflags.Excl {
+		flag |= os.O_EXCL
+	}
+	f, err := h.fs.OpenFile(r.Filepath, flag, 0644)
+	if err != nil {
+		return nil, err
+	}
+	file := f.(*filerFile)
+	// sftp writes at the given offsets, a resumed upload does not truncate
+	file.truncateOnWrite = false
+	return file, nil
+}
+
+func (h *sftpHandler) Filecmd(r *sftp.Request) error {
+	switch r.Method {
+	case "Setstat":
+		return h.setstat(r)
+	case "Rename":
+		return h.fs.Rename(r.Filepath, r.Target)
+	case "Rmdir", "Remove":
+		return h.fs.Remove(r.Filepath)
+	case "Mkdir":
+		return h.fs.Mkdir(r.Filepath, 0755)
+	case "Symlink":
+		// the request follows openssh, with the target path first
+		return h.fs.Symlink(r.Filepath, r.Target)
+	}
+	return sftp.ErrSSHFxOpUnsupported
+}
+
+func (h *sftpHandler) setstat(r *sftp.Request) error {
+	flags, attrs := r.AttrFlags(), r.Attributes()
+	if flags.Size {
+		f, err := h.fs.OpenFile(r.Filepath, os.O_WRONLY, 0)
+		if err != nil {
+			return err
+		}
+		if err = f.Truncate(int64(attrs.Size)); err != nil {
+			f.Close()
+			return err
+		}
+		if err = f.Close(); err != nil {
+			return err
+		}
+	}
+	if flags.Permissions {
+		if err := h.fs.Chmod(r.Filepath, attrs.FileMode()); err != nil {
+			return err
+		}
+	}
+	if flags.Acmodtime {
+		if err := h.fs.Chtimes(r.Filepath, time.Unix(int64(attrs.Atime), 0), time.Unix(int64(attrs.Mtime), 0)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *sftpHandler) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	switch r.Method {
+	case "List":
+		infos, err := h.fs.ReadDir(r.Filepath)
+		if err != nil {
+			return nil, err
+		}
+		return listerAt(infos), nil
+	case "Stat":
+		info, err := h.fs.Stat(r.Filepath)
+		if err != nil {
+			return nil, err
+		}
+		return listerAt{info}, nil
+	case "Readlink":
+		target, err := h.fs.Readlink(r.Filepath)
+		if err != nil {
+			return nil, err
+		}
+		return listerAt{&fileInfo{name: target}}, nil
+	}
+	return nil, sftp.ErrSSHFxOpUnsupported
+}
+
+type listerAt []os.FileInfo
+
+func (l listerAt) ListAt(ls []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(ls, l[offset:])
+	if n < len(ls) {
+		return n, io.EOF
+	}
+	return n, nil
+}
@@ -94,7 +94,7 @@ var _ = filer_pb.FilerClient(&MessageBroker{})
 func (broker *MessageBroker) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) (err error) {
 
 	for _, filer := range broker.option.Filers {
-		if err = pb.WithFilerClient(filer, broker.grpcDialOption, fn); err != nil {
+		if err = pb.WithFilerClient(filer, broker.grpcDialOption, filer_pb.WithServiceIdentity(fn)); err != nil {
 			if err == io.EOF {
 				return
 			}
@@ -101,7 +101,7 @@ func (broker *MessageBroker) keepConnectedToOneFiler() {
 
 func (broker *MessageBroker) withFilerClient(filer string, fn func(filer_pb.SeaweedFilerClient) error) error {
 
-	return pb.WithFilerClient(filer, broker.grpcDialOption, fn)
+	return pb.WithFilerClient(filer, broker.grpcDialOption, filer_pb.WithServiceIdentity(fn))
 
 }
 
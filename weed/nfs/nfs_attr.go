@@ -0,0 +1,199 @@
+package nfs
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	nf3Reg = 1
+	nf3Dir = 2
+	nf3Lnk = 5
+
+	timeDontChange   = 0
+	timeSetToServer  = 1
+	timeSetToClient  = 2
+	defaultBlockSize = 4096
+)
+
+// toNfsMode converts the permission bits of a go file mode to the unix mode bits
+func toNfsMode(mode os.FileMode) uint32 {
+	m := uint32(mode.Perm())
+	if mode&os.ModeSetuid != 0 {
+		m |= 04000
+	}
+	if mode&os.ModeSetgid != 0 {
+		m |= 02000
+	}
+	if mode&os.ModeSticky != 0 {
+		m |= 01000
+	}
+	return m
+}
+
+// fromNfsMode replaces the permission bits of the go file mode with the unix mode bits
+func fromNfsMode(existing os.FileMode, m uint32) os.FileMode {
+	mode := existing&os.ModeType | os.FileMode(m&0777)
+	if m&04000 != 0 {
+		mode |= os.ModeSetuid
+	}
+	if m&02000 != 0 {
+		mode |= os.ModeSetgid
+	}
+	if m&01000 != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode
+}
+
+func (s *NfsServer) writeFattr(w *xdrWriter, fullpath util.FullPath, entry *filer_pb.Entry) {
+	attr := entry.Attributes
+	if attr == nil {
+		attr = &filer_pb.FuseAttributes{}
+	}
+	mode := os.FileMode(attr.FileMode)
+
+	ftype, nlink, size := uint32(nf3Reg), uint32(1), filer.FileSize(entry)
+	switch {
+	case entry.IsDirectory:
+		ftype, nlink, size = nf3Dir, 2, defaultBlockSize
+	case mode&os.ModeSymlink != 0:
+		ftype, size = nf3Lnk, uint64(len(attr.SymlinkTarget))
+	}
+	if entry.HardLinkCounter > 1 {
+		nlink = uint32(entry.HardLinkCounter)
+	}
+	if size < attr.FileSize {
+		size = attr.FileSize
+	}
+	uid, gid := s.option.UidGidMapper.FilerToLocal(attr.Uid, attr.Gid)
+
+	w.uint32(ftype)
+	w.uint32(toNfsMode(mode))
+	w.uint32(nlink)
+	w.uint32(uid)
+	w.uint32(gid)
+	w.uint64(size)
+	w.uint64((size + defaultBlockSize - 1) / defaultBlockSize * defaultBlockSize) // used
+	w.uint32(0)                                                                   // rdev
+	w.uint32(0)
+	w.uint64(s.fsid)
+	w.uint64(fullpath.AsInode())
+	w.uint32(uint32(attr.Mtime)) // atime
+	w.uint32(0)
+	w.uint32(uint32(attr.Mtime))
+	w.uint32(0)
+	w.uint32(uint32(attr.Mtime)) // ctime
+	w.uint32(0)
+}
+
+func (s *NfsServer) writePostOpAttr(w *xdrWriter, fullpath util.FullPath, entry *filer_pb.Entry) {
+	if entry == nil {
+		w.bool(false)
+		return
+	}
+	w.bool(true)
+	s.writeFattr(w, fullpath, entry)
+}
+
+// writeWccData writes the attributes after the change, without the attributes before it
+func (s *NfsServer) writeWccData(w *xdrWriter, fullpath util.FullPath, entry *filer_pb.Entry) {
+	w.bool(false)
+	s.writePostOpAttr(w, fullpath, entry)
+}
+
+func (s *NfsServer) writePostOpFh(w *xdrWriter, fullpath util.FullPath) {
+	w.bool(true)
+	w.opaque(s.handles.toHandle(fullpath))
+}
+
+// sattr is the attributes to set in SETATTR, CREATE, MKDIR, and SYMLINK
+type sattr struct {
+	mode, uid, gid     *uint32
+	size               *uint64
+	setAtime, setMtime bool
+	mtime              int64
+}
+
+func readSattr(r *xdrReader) *sattr {
+	a := &sattr{}
+	if r.bool() {
+		v := r.uint32()
+		a.mode = &v
+	}
+	if r.bool() {
+		v := r.uint32()
+		a.uid = &v
+	}
+	if r.bool() {
+		v := r.uint32()
+		a.gid = &v
+	}
+	if r.bool() {
+		v := r.uint64()
+		a.size = &v
+	}
+	readTime := func() (bool, int64) {
+		switch r.uint32() {
+		case timeSetToServer:
+			return true, time.Now().Unix()
+		case timeSetToClient:
+			sec := r.uint32()
+			r.uint32() // nseconds
+			return true, int64(sec)
+		}
+		return false, 0
+	}
+	a.setAtime, _ = readTime()
+	a.setMtime, a.mtime = readTime()
+	return a
+}
+
+// apply sets the attributes except the size, mapping the uid and gid to the filer ones
+func (s *NfsServer) applySattr(entry *filer_pb.Entry, a *sattr) {
+	if entry.Attributes == nil {
+		entry.Attributes = &filer_pb.FuseAttributes{}
+	}
+	attr := entry.Attributes
+	if a.mode != nil {
+		attr.FileMode = uint32(fromNfsMode(os.FileMode(attr.FileMode), *a.mode))
+	}
+	if a.uid != nil {
+		attr.Uid, _ = s.option.UidGidMapper.LocalToFiler(*a.uid, 0)
+	}
+	if a.gid != nil {
+		_, attr.Gid = s.option.UidGidMapper.LocalToFiler(0, *a.gid)
+	}
+	if a.setMtime {
+		attr.Mtime = a.mtime
+	}
+}
+
+// toNfsStatus maps the errors from the filer to the nfs status
+func toNfsStatus(err error) uint32 {
+	switch {
+	case err == nil:
+		return nfs3Ok
+	case err == filer_pb.ErrNotFound:
+		return nfs3ErrNoEnt
+	case filer_pb.IsPermissionDenied(err):
+		return nfs3ErrAcces
+	case filer_pb.IsQuotaExceeded(err):
+		return nfs3ErrDquot
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "EEXIST") || strings.Contains(msg, "already exists"):
+		return nfs3ErrExist
+	case strings.Contains(msg, "non-empty folder"):
+		return nfs3ErrNotEmpty
+	case strings.Contains(msg, "not found") || strings.Contains(msg, "no entry is found"):
+		return nfs3ErrNoEnt
+	}
+	return nfs3ErrIO
+}
@@ -0,0 +1,484 @@
+package nfs
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	createUnchecked = 0
+	createGuarded   = 1
+	createExclusive = 2
+
+	nfs3ErrTooSmall = 10005
+
+	// the reply size besides the directory entries
+	readdirReplyOverhead = 128
+	readdirPageSize      = 1024
+)
+
+// readDirOp reads the directory handle and the entry name
+func (s *NfsServer) readDirOp(call *rpcCall) (util.FullPath, string, uint32) {
+	dirPath, status := s.readHandle(call)
+	name := call.args.string(maxPathLen)
+	return dirPath, name, status
+}
+
+// checkDirOp checks the directory exists and the name is valid
+func (s *NfsServer) checkDirOp(call *rpcCall, dirPath util.FullPath, name string, status uint32) uint32 {
+	if status != nfs3Ok {
+		return status
+	}
+	dirEntry, status := s.getEntry(call, dirPath)
+	if status != nfs3Ok {
+		return status
+	}
+	if !dirEntry.IsDirectory {
+		return nfs3ErrNotDir
+	}
+	return checkName(name)
+}
+
+// newEntry creates an entry owned by the caller
+func (s *NfsServer) newEntry(call *rpcCall, name string, mode os.FileMode, attrs *sattr) *filer_pb.Entry {
+	identity := s.identity(call)
+	now := time.Now().Unix()
+	entry := &filer_pb.Entry{
+		Name:        name,
+		IsDirectory: mode&os.ModeDir != 0,
+		Attributes: &filer_pb.FuseAttributes{
+			Mtime:       now,
+			Crtime:      now,
+			FileMode:    uint32(mode),
+			Uid:         identity.Uid,
+			Gid:         identity.Gid(),
+			Collection:  s.option.Collection,
+			Replication: s.option.Replication,
+			DiskType:    s.option.DiskType,
+		},
+	}
+	if attrs != nil {
+		s.applySattr(entry, attrs)
+	}
+	return entry
+}
+
+func (s *NfsServer) createEntry(call *rpcCall, dirPath util.FullPath, entry *filer_pb.Entry) uint32 {
+	err := s.asCaller(call).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.CreateEntry(client, &filer_pb.CreateEntryRequest{
+			Directory:  string(dirPath),
+			Entry:      entry,
+			OExcl:      true,
+			Signatures: []int32{s.signature},
+		})
+	})
+	if err != nil {
+		glog.V(0).Infof("nfs create %s: %v", dirPath.Child(entry.Name), err)
+	}
+	return toNfsStatus(err)
+}
+
+// writeCreated writes the result of CREATE, MKDIR, and SYMLINK
+func (s *NfsServer) writeCreated(w *xdrWriter, status uint32, dirPath util.FullPath, fullpath util.FullPath, entry *filer_pb.Entry) {
+	w.uint32(status)
+	if status == nfs3Ok {
+		s.writePostOpFh(w, fullpath)
+		s.writePostOpAttr(w, fullpath, entry)
+	}
+	// the directory attributes are not returned, so the client revalidates the directory
+	s.writeWccData(w, dirPath, nil)
+}
+
+func (s *NfsServer) create(call *rpcCall, w *xdrWriter) uint32 {
+	dirPath, name, status := s.readDirOp(call)
+	how := call.args.uint32()
+	var attrs *sattr
+	var verifier int64
+	switch how {
+	case createUnchecked, createGuarded:
+		attrs = readSattr(call.args)
+	case createExclusive:
+		verifier = int64(binary.BigEndian.Uint64(call.args.fixedOpaque(8)))
+	default:
+		return acceptGarbageArgs
+	}
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	status = s.checkDirOp(call, dirPath, name, status)
+	fullpath := dirPath.Child(name)
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		existing, err := s.lookupEntry(call, fullpath)
+		switch {
+		case err != nil:
+			status = toNfsStatus(err)
+		case existing == nil:
+			mode := os.FileMode(0644)
+			entry = s.newEntry(call, name, mode, attrs)
+			if how == createExclusive {
+				// keep the verifier in the mtime, which the client sets after the exclusive create
+				entry.Attributes.Mtime = verifier
+			}
+			status = s.createEntry(call, dirPath, entry)
+		case how == createExclusive && existing.Attributes.GetMtime() == verifier:
+			// a retransmitted exclusive create
+			entry = existing
+		case how != createUnchecked:
+			status = nfs3ErrExist
+		case existing.IsDirectory:
+			status = nfs3ErrIsDir
+		default:
+			entry, status = s.setExisting(call, fullpath, attrs)
+		}
+	}
+
+	s.writeCreated(w, status, dirPath, fullpath, entry)
+	return acceptSuccess
+}
+
+// setExisting sets the attributes of an existing file, when creating it unchecked
+func (s *NfsServer) setExisting(call *rpcCall, fullpath util.FullPath, attrs *sattr) (*filer_pb.Entry, uint32) {
+	if err := s.flushFile(fullpath); err != nil {
+		return nil, toNfsStatus(err)
+	}
+	entry, status := s.getEntry(call, fullpath)
+	if status != nfs3Ok {
+		return nil, status
+	}
+	s.applySattr(entry, attrs)
+	if attrs.size != nil {
+		s.truncate(entry, *attrs.size)
+	}
+	if err := s.updateEntry(call, fullpath, entry); err != nil {
+		return nil, toNfsStatus(err)
+	}
+	return entry, nfs3Ok
+}
+
+func (s *NfsServer) mkdir(call *rpcCall, w *xdrWriter) uint32 {
+	dirPath, name, status := s.readDirOp(call)
+	attrs := readSattr(call.args)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	status = s.checkDirOp(call, dirPath, name, status)
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		entry = s.newEntry(call, name, os.ModeDir|0755, attrs)
+		status = s.createEntry(call, dirPath, entry)
+	}
+
+	s.writeCreated(w, status, dirPath, dirPath.Child(name), entry)
+	return acceptSuccess
+}
+
+func (s *NfsServer) symlink(call *rpcCall, w *xdrWriter) uint32 {
+	dirPath, name, status := s.readDirOp(call)
+	attrs := readSattr(call.args)
+	target := call.args.string(maxPathLen)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	status = s.checkDirOp(call, dirPath, name, status)
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		entry = s.newEntry(call, name, os.ModeSymlink|0777, attrs)
+		entry.Attributes.SymlinkTarget = target
+		status = s.createEntry(call, dirPath, entry)
+	}
+
+	s.writeCreated(w, status, dirPath, dirPath.Child(name), entry)
+	return acceptSuccess
+}
+
+// mknod is not supported, the filer only keeps files, directories, and symlinks
+func (s *NfsServer) mknod(call *rpcCall, w *xdrWriter) uint32 {
+	w.uint32(nfs3ErrNotSupp)
+	s.writeWccData(w, "", nil)
+	return acceptSuccess
+}
+
+// link is not supported, a hard link has no path of its own to derive the file handle from
+func (s *NfsServer) link(call *rpcCall, w *xdrWriter) uint32 {
+	w.uint32(nfs3ErrNotSupp)
+	s.writePostOpAttr(w, "", nil)
+	s.writeWccData(w, "", nil)
+	return acceptSuccess
+}
+
+func (s *NfsServer) remove(call *rpcCall, w *xdrWriter) uint32 {
+	return s.doRemove(call, w, false)
+}
+
+func (s *NfsServer) rmdir(call *rpcCall, w *xdrWriter) uint32 {
+	return s.doRemove(call, w, true)
+}
+
+func (s *NfsServer) doRemove(call *rpcCall, w *xdrWriter, isDirectory bool) uint32 {
+	dirPath, name, status := s.readDirOp(call)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	status = s.checkDirOp(call, dirPath, name, status)
+	fullpath := dirPath.Child(name)
+	if status == nfs3Ok {
+		entry, err := s.lookupEntry(call, fullpath)
+		switch {
+		case err != nil:
+			status = toNfsStatus(err)
+		case entry == nil:
+			status = nfs3ErrNoEnt
+		case isDirectory && !entry.IsDirectory:
+			status = nfs3ErrNotDir
+		case !isDirectory && entry.IsDirectory:
+			status = nfs3ErrIsDir
+		}
+	}
+	if status == nfs3Ok {
+		s.dropFile(fullpath)
+		if err := filer_pb.Remove(s.asCaller(call), string(dirPath), name, true, false, false, false, []int32{s.signature}); err != nil {
+			glog.V(0).Infof("nfs remove %s: %v", fullpath, err)
+			status = toNfsStatus(err)
+		}
+	}
+
+	w.uint32(status)
+	s.writeWccData(w, dirPath, nil)
+	return acceptSuccess
+}
+
+func (s *NfsServer) rename(call *rpcCall, w *xdrWriter) uint32 {
+	fromDir, fromName, status := s.readDirOp(call)
+	toDir, toName, toStatus := s.readDirOp(call)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	status = s.checkDirOp(call, fromDir, fromName, status)
+	if status == nfs3Ok {
+		status = s.checkDirOp(call, toDir, toName, toStatus)
+	}
+	from, to := fromDir.Child(fromName), toDir.Child(toName)
+	if status == nfs3Ok {
+		status = s.checkRenameTarget(call, from, to)
+	}
+	if status == nfs3Ok && from != to {
+		if err := s.flushFile(from); err != nil {
+			status = toNfsStatus(err)
+		}
+	}
+	if status == nfs3Ok && from != to {
+		s.dropFile(to)
+		err := s.asCaller(call).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+			_, err := client.AtomicRenameEntry(context.Background(), &filer_pb.AtomicRenameEntryRequest{
+				OldDirectory: string(fromDir),
+				OldName:      fromName,
+				NewDirectory: string(toDir),
+				NewName:      toName,
+			})
+			return err
+		})
+		if err != nil {
+			glog.V(0).Infof("nfs rename %s => %s: %v", from, to, err)
+			status = toNfsStatus(err)
+		}
+	}
+
+	w.uint32(status)
+	s.writeWccData(w, fromDir, nil)
+	s.writeWccData(w, toDir, nil)
+	return acceptSuccess
+}
+
+// checkRenameTarget checks an existing target can be replaced, and removes it if it is an empty directory
+func (s *NfsServer) checkRenameTarget(call *rpcCall, from, to util.FullPath) uint32 {
+	source, err := s.lookupEntry(call, from)
+	if err != nil {
+		return toNfsStatus(err)
+	}
+	if source == nil {
+		return nfs3ErrNoEnt
+	}
+	if source.IsDirectory && s.isUnderRoot(to) && len(to) > len(from) && to[:len(from)+1] == from+"/" {
+		return nfs3ErrInval
+	}
+	target, err := s.lookupEntry(call, to)
+	if err != nil {
+		return toNfsStatus(err)
+	}
+	switch {
+	case target == nil || from == to:
+		return nfs3Ok
+	case source.IsDirectory && !target.IsDirectory:
+		return nfs3ErrNotDir
+	case !source.IsDirectory && target.IsDirectory:
+		return nfs3ErrIsDir
+	case target.IsDirectory:
+		dir, name := to.DirAndName()
+		if err := filer_pb.Remove(s.asCaller(call), dir, name, true, false, false, false, []int32{s.signature}); err != nil {
+			return toNfsStatus(err)
+		}
+	}
+	return nfs3Ok
+}
+
+type dirEntry struct {
+	name     string
+	cookie   uint64
+	fullpath util.FullPath
+	entry    *filer_pb.Entry
+}
+
+func (s *NfsServer) readdir(call *rpcCall, w *xdrWriter) uint32 {
+	return s.doReaddir(call, w, false)
+}
+
+func (s *NfsServer) readdirplus(call *rpcCall, w *xdrWriter) uint32 {
+	return s.doReaddir(call, w, true)
+}
+
+func (s *NfsServer) doReaddir(call *rpcCall, w *xdrWriter, plus bool) uint32 {
+	dirPath, status := s.readHandle(call)
+	cookie := call.args.uint64()
+	call.args.fixedOpaque(8) // cookie verifier
+	maxCount := call.args.uint32()
+	if plus {
+		// dircount only counts the names, the max reply size is more restrictive
+		maxCount = call.args.uint32()
+	}
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	var dir *filer_pb.Entry
+	if status == nfs3Ok {
+		dir, status = s.getEntry(call, dirPath)
+	}
+	if status == nfs3Ok && !dir.IsDirectory {
+		status = nfs3ErrNotDir
+	}
+
+	var entries []*dirEntry
+	var eof bool
+	if status == nfs3Ok {
+		entries, eof, status = s.listDir(call, dirPath, dir, cookie, int(maxCount)-readdirReplyOverhead, plus)
+	}
+
+	w.uint32(status)
+	s.writePostOpAttr(w, dirPath, dir)
+	if status != nfs3Ok {
+		return acceptSuccess
+	}
+	w.fixedOpaque(make([]byte, 8)) // cookie verifier
+	for _, e := range entries {
+		w.bool(true)
+		w.uint64(e.fullpath.AsInode())
+		w.string(e.name)
+		w.uint64(e.cookie)
+		if plus {
+			s.writePostOpAttr(w, e.fullpath, e.entry)
+			s.writePostOpFh(w, e.fullpath)
+		}
+	}
+	w.bool(false)
+	w.bool(eof)
+	return acceptSuccess
+}
+
+func entrySize(name string, plus bool) int {
+	size := 4 + 8 + 4 + len(name) + padding(len(name)) + 8
+	if plus {
+		size += 4 + 84 + 4 + 4 + maxHandleSize
+	}
+	return size
+}
+
+// listDir lists the entries after the cookie, within the reply size
+func (s *NfsServer) listDir(call *rpcCall, dirPath util.FullPath, dir *filer_pb.Entry, cookie uint64, budget int, plus bool) (entries []*dirEntry, eof bool, status uint32) {
+
+	add := func(e *dirEntry) bool {
+		budget -= entrySize(e.name, plus)
+		if budget < 0 {
+			return false
+		}
+		entries = append(entries, e)
+		return true
+	}
+	tooSmall := func() ([]*dirEntry, bool, uint32) {
+		if len(entries) == 0 {
+			return nil, false, nfs3ErrTooSmall
+		}
+		return entries, false, nfs3Ok
+	}
+
+	if cookie < 1 && !add(&dirEntry{name: ".", cookie: 1, fullpath: dirPath, entry: dir}) {
+		return tooSmall()
+	}
+	if cookie < 2 {
+		parent := s.parentOf(dirPath)
+		parentEntry := dir
+		if parent != dirPath && plus {
+			parentEntry, _ = s.lookupEntry(call, parent)
+		}
+		if !add(&dirEntry{name: "..", cookie: 2, fullpath: parent, entry: parentEntry}) {
+			return tooSmall()
+		}
+	}
+
+	// continue after the entry at the cookie, or skip the entries before it if forgotten
+	position, startFrom, skip := uint64(2), "", uint64(0)
+	if cookie > 2 {
+		if name, found := s.cookies.get(dirPath, cookie); found {
+			position, startFrom = cookie, name
+		} else {
+			skip = cookie - 2
+		}
+	}
+
+	client := s.asCaller(call)
+	for {
+		var page []*filer_pb.Entry
+		err := filer_pb.List(client, string(dirPath), "", func(entry *filer_pb.Entry, isLast bool) error {
+			page = append(page, entry)
+			return nil
+		}, startFrom, false, readdirPageSize)
+		if err != nil {
+			glog.V(0).Infof("nfs list %s: %v", dirPath, err)
+			return nil, false, toNfsStatus(err)
+		}
+		for _, entry := range page {
+			startFrom = entry.Name
+			position++
+			if skip > 0 {
+				skip--
+				continue
+			}
+			fullpath := dirPath.Child(entry.Name)
+			if plus {
+				if df := s.getDirtyFile(fullpath); df != nil && entry.Attributes != nil {
+					if size := df.size(); size > entry.Attributes.FileSize {
+						entry.Attributes.FileSize = size
+					}
+				}
+			}
+			if !add(&dirEntry{name: entry.Name, cookie: position, fullpath: fullpath, entry: entry}) {
+				return tooSmall()
+			}
+			s.cookies.set(dirPath, position, entry.Name)
+		}
+		if len(page) < readdirPageSize {
+			return entries, true, nfs3Ok
+		}
+	}
+}
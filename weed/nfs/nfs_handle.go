@@ -0,0 +1,165 @@
+package nfs
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/karlseguin/ccache/v2"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// A file handle is derived from the path of the entry, so it stays the same across restarts
+// and across multiple nfs servers exporting the same directory:
+//   1, 0, <path relative to the exported directory>     if the relative path fits in the handle
+//   1, 1, <8 bytes hash of the full path>               otherwise, kept in the filer kv as
+//   nfs.handle.<hash> => full path
+// Renaming an entry changes its handle, and the old handle becomes stale.
+const (
+	handleVersion   = 1
+	handleInline    = 0
+	handleHashed    = 1
+	maxHandleSize   = 64
+	handleKeyPrefix = "nfs.handle."
+	cachedHandles   = 64 * 1024
+	cachedCookies   = 64 * 1024
+	cookieTtl       = 10 * time.Minute
+)
+
+type handleMap struct {
+	s     *NfsServer
+	paths *ccache.Cache
+}
+
+func newHandleMap(s *NfsServer) *handleMap {
+	return &handleMap{
+		s:     s,
+		paths: ccache.New(ccache.Configure().MaxSize(cachedHandles).ItemsToPrune(cachedHandles / 16)),
+	}
+}
+
+func handleKey(hash uint64) []byte {
+	return []byte(fmt.Sprintf("%s%016x", handleKeyPrefix, hash))
+}
+
+// toHandle returns the file handle of an entry under the exported directory
+func (m *handleMap) toHandle(fullpath util.FullPath) []byte {
+	relative := strings.TrimPrefix(strings.TrimPrefix(string(fullpath), string(m.s.root)), "/")
+	if len(relative)+2 <= maxHandleSize {
+		return append([]byte{handleVersion, handleInline}, relative...)
+	}
+
+	hash := fullpath.AsInode()
+	fh := make([]byte, 10)
+	fh[0], fh[1] = handleVersion, handleHashed
+	binary.BigEndian.PutUint64(fh[2:], hash)
+
+	key := string(handleKey(hash))
+	if item := m.paths.Get(key); item != nil && item.Value().(util.FullPath) == fullpath {
+		return fh
+	}
+	err := m.s.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.KvPut(context.Background(), &filer_pb.KvPutRequest{
+			Key:   []byte(key),
+			Value: []byte(fullpath),
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("%s", resp.Error)
+		}
+		return nil
+	})
+	if err != nil {
+		// still usable until the cache evicts it
+		glog.Errorf("save nfs handle of %s: %v", fullpath, err)
+	}
+	m.paths.Set(key, fullpath, time.Hour)
+	return fh
+}
+
+// toPath resolves a file handle to the full path of the entry
+func (m *handleMap) toPath(fh []byte) (util.FullPath, uint32) {
+	if len(fh) < 2 || fh[0] != handleVersion {
+		return "", nfs3ErrBadHandle
+	}
+	switch fh[1] {
+	case handleInline:
+		if len(fh) == 2 {
+			return m.s.root, nfs3Ok
+		}
+		// handles come from the clients, so only the clean paths made by toHandle are accepted
+		fullpath := m.s.root.Child(string(fh[2:]))
+		if path.Clean(string(fullpath)) != string(fullpath) || !m.s.isUnderRoot(fullpath) {
+			return "", nfs3ErrBadHandle
+		}
+		return fullpath, nfs3Ok
+	case handleHashed:
+		if len(fh) != 10 {
+			return "", nfs3ErrBadHandle
+		}
+	default:
+		return "", nfs3ErrBadHandle
+	}
+
+	key := string(handleKey(binary.BigEndian.Uint64(fh[2:])))
+	if item := m.paths.Get(key); item != nil {
+		return item.Value().(util.FullPath), nfs3Ok
+	}
+	var fullpath util.FullPath
+	err := m.s.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.KvGet(context.Background(), &filer_pb.KvGetRequest{Key: []byte(key)})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("%s", resp.Error)
+		}
+		fullpath = util.FullPath(resp.Value)
+		return nil
+	})
+	if err != nil {
+		glog.Errorf("read nfs handle %s: %v", key, err)
+		return "", nfs3ErrServerFault
+	}
+	if fullpath == "" || !m.s.isUnderRoot(fullpath) {
+		return "", nfs3ErrStale
+	}
+	m.paths.Set(key, fullpath, time.Hour)
+	return fullpath, nfs3Ok
+}
+
+// cookieCache remembers the entry name at each readdir cookie, so listing can continue from it.
+// A cookie is the position of the entry in the directory, starting from 1 for "." and 2 for "..".
+type cookieCache struct {
+	names *ccache.Cache
+}
+
+func newCookieCache() *cookieCache {
+	return &cookieCache{
+		names: ccache.New(ccache.Configure().MaxSize(cachedCookies).ItemsToPrune(cachedCookies / 16)),
+	}
+}
+
+func cookieKey(dir util.FullPath, cookie uint64) string {
+	return fmt.Sprintf("%d:%s", cookie, dir)
+}
+
+func (c *cookieCache) set(dir util.FullPath, cookie uint64, name string) {
+	c.names.Set(cookieKey(dir, cookie), name, cookieTtl)
+}
+
+func (c *cookieCache) get(dir util.FullPath, cookie uint64) (string, bool) {
+	item := c.names.Get(cookieKey(dir, cookie))
+	if item == nil {
+		return "", false
+	}
+	return item.Value().(string), true
+}
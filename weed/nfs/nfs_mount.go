@@ -0,0 +1,113 @@
+package nfs
+
+import (
+	"path"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// the mount v3 protocol, as in RFC 1813 appendix I
+
+const (
+	mountProgram = 100005
+	mountVersion = 3
+
+	mountProcNull    = 0
+	mountProcMnt     = 1
+	mountProcDump    = 2
+	mountProcUmnt    = 3
+	mountProcUmntAll = 4
+	mountProcExport  = 5
+
+	mnt3Ok          = 0
+	mnt3ErrNoEnt    = 2
+	mnt3ErrAcces    = 13
+	mnt3ErrNotDir   = 20
+	mnt3ErrServFail = 10006
+
+	maxPathLen = 1024
+)
+
+func (s *NfsServer) mountProcs() map[uint32]rpcHandler {
+	return map[uint32]rpcHandler{
+		mountProcNull:    nullProc,
+		mountProcMnt:     s.mnt,
+		mountProcDump:    s.dump,
+		mountProcUmnt:    s.umnt,
+		mountProcUmntAll: nullProc,
+		mountProcExport:  s.export,
+	}
+}
+
+func nullProc(call *rpcCall, w *xdrWriter) uint32 {
+	return acceptSuccess
+}
+
+// mnt returns the handle of the exported directory, or of a directory under it
+func (s *NfsServer) mnt(call *rpcCall, w *xdrWriter) uint32 {
+	dirPath := call.args.string(maxPathLen)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	fullpath := util.FullPath(path.Clean("/" + dirPath))
+	if !s.isUnderRoot(fullpath) {
+		glog.V(0).Infof("nfs mount %s is not under %s", fullpath, s.root)
+		w.uint32(mnt3ErrAcces)
+		return acceptSuccess
+	}
+
+	entry, err := s.lookupEntry(call, fullpath)
+	switch {
+	case err != nil && toNfsStatus(err) == nfs3ErrAcces:
+		w.uint32(mnt3ErrAcces)
+		return acceptSuccess
+	case err != nil:
+		glog.Errorf("nfs mount %s: %v", fullpath, err)
+		w.uint32(mnt3ErrServFail)
+		return acceptSuccess
+	case entry == nil:
+		w.uint32(mnt3ErrNoEnt)
+		return acceptSuccess
+	case !entry.IsDirectory:
+		w.uint32(mnt3ErrNotDir)
+		return acceptSuccess
+	}
+
+	machineName := ""
+	if call.cred != nil {
+		machineName = call.cred.machineName
+	}
+	glog.V(0).Infof("nfs client %s mounts %s", machineName, fullpath)
+
+	w.uint32(mnt3Ok)
+	w.opaque(s.handles.toHandle(fullpath))
+	w.uint32(2) // auth flavors
+	w.uint32(authUnix)
+	w.uint32(authNone)
+	return acceptSuccess
+}
+
+// dump returns an empty list, the mounts are not tracked
+func (s *NfsServer) dump(call *rpcCall, w *xdrWriter) uint32 {
+	w.bool(false)
+	return acceptSuccess
+}
+
+func (s *NfsServer) umnt(call *rpcCall, w *xdrWriter) uint32 {
+	call.args.string(maxPathLen)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+	return acceptSuccess
+}
+
+// export lists the exported directory, open to all clients
+func (s *NfsServer) export(call *rpcCall, w *xdrWriter) uint32 {
+	w.bool(true)
+	w.string(string(s.root))
+	w.bool(false) // groups
+	w.bool(false)
+	return acceptSuccess
+}
@@ -0,0 +1,642 @@
+package nfs
+
+import (
+	"context"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/karlseguin/ccache/v2"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// the nfs v3 protocol, as in RFC 1813
+
+const (
+	nfsProgram = 100003
+	nfsVersion = 3
+
+	nfsProcNull        = 0
+	nfsProcGetattr     = 1
+	nfsProcSetattr     = 2
+	nfsProcLookup      = 3
+	nfsProcAccess      = 4
+	nfsProcReadlink    = 5
+	nfsProcRead        = 6
+	nfsProcWrite       = 7
+	nfsProcCreate      = 8
+	nfsProcMkdir       = 9
+	nfsProcSymlink     = 10
+	nfsProcMknod       = 11
+	nfsProcRemove      = 12
+	nfsProcRmdir       = 13
+	nfsProcRename      = 14
+	nfsProcLink        = 15
+	nfsProcReaddir     = 16
+	nfsProcReaddirplus = 17
+	nfsProcFsstat      = 18
+	nfsProcFsinfo      = 19
+	nfsProcPathconf    = 20
+	nfsProcCommit      = 21
+
+	nfs3Ok             = 0
+	nfs3ErrPerm        = 1
+	nfs3ErrNoEnt       = 2
+	nfs3ErrIO          = 5
+	nfs3ErrAcces       = 13
+	nfs3ErrExist       = 17
+	nfs3ErrXDev        = 18
+	nfs3ErrNotDir      = 20
+	nfs3ErrIsDir       = 21
+	nfs3ErrInval       = 22
+	nfs3ErrNameTooLong = 63
+	nfs3ErrNotEmpty    = 66
+	nfs3ErrDquot       = 69
+	nfs3ErrStale       = 70
+	nfs3ErrBadHandle   = 10001
+	nfs3ErrBadCookie   = 10003
+	nfs3ErrNotSupp     = 10004
+	nfs3ErrServerFault = 10006
+
+	access3Read    = 0x01
+	access3Lookup  = 0x02
+	access3Modify  = 0x04
+	access3Extend  = 0x08
+	access3Delete  = 0x10
+	access3Execute = 0x20
+
+	stableUnstable = 0
+	stableFileSync = 2
+
+	fsf3Link        = 0x01
+	fsf3Symlink     = 0x02
+	fsf3Homogeneous = 0x08
+	fsf3CanSetTime  = 0x10
+
+	maxReadWriteSize = 1024 * 1024
+	maxNameLen       = 255
+	nobody           = 65534
+	cachedReaders    = 1024
+)
+
+func (s *NfsServer) nfsProcs() map[uint32]rpcHandler {
+	return map[uint32]rpcHandler{
+		nfsProcNull:        nullProc,
+		nfsProcGetattr:     s.getattr,
+		nfsProcSetattr:     s.setattr,
+		nfsProcLookup:      s.lookup,
+		nfsProcAccess:      s.access,
+		nfsProcReadlink:    s.readlink,
+		nfsProcRead:        s.read,
+		nfsProcWrite:       s.write,
+		nfsProcCreate:      s.create,
+		nfsProcMkdir:       s.mkdir,
+		nfsProcSymlink:     s.symlink,
+		nfsProcMknod:       s.mknod,
+		nfsProcRemove:      s.remove,
+		nfsProcRmdir:       s.rmdir,
+		nfsProcRename:      s.rename,
+		nfsProcLink:        s.link,
+		nfsProcReaddir:     s.readdir,
+		nfsProcReaddirplus: s.readdirplus,
+		nfsProcFsstat:      s.fsstat,
+		nfsProcFsinfo:      s.fsinfo,
+		nfsProcPathconf:    s.pathconf,
+		nfsProcCommit:      s.commit,
+	}
+}
+
+// readHandle reads a file handle argument, and resolves it to the path
+func (s *NfsServer) readHandle(call *rpcCall) (util.FullPath, uint32) {
+	fh := call.args.opaque(maxHandleSize)
+	if call.args.err != nil {
+		return "", nfs3ErrBadHandle
+	}
+	return s.handles.toPath(fh)
+}
+
+// getEntry reads the entry of a file handle, which is stale if the entry is gone
+func (s *NfsServer) getEntry(call *rpcCall, fullpath util.FullPath) (*filer_pb.Entry, uint32) {
+	entry, err := s.lookupEntry(call, fullpath)
+	if err != nil {
+		glog.V(1).Infof("nfs read %s: %v", fullpath, err)
+		return nil, toNfsStatus(err)
+	}
+	if entry == nil {
+		return nil, nfs3ErrStale
+	}
+	return entry, nfs3Ok
+}
+
+// identity is the caller as a filer identity, for checking the permissions locally
+func (s *NfsServer) identity(call *rpcCall) *filer.Identity {
+	return s.credIdentity(call.cred)
+}
+
+// credIdentity maps the credentials sent by the client to the uid and gids on the filer.
+// Callers without AUTH_UNIX credentials are nobody, and so is root if it is squashed.
+func (s *NfsServer) credIdentity(cred *authUnixCred) *filer.Identity {
+	if cred == nil {
+		return &filer.Identity{Uid: nobody, Gids: []uint32{nobody}}
+	}
+	squash := func(id uint32) uint32 {
+		if id == 0 && s.option.RootSquash {
+			return nobody
+		}
+		return id
+	}
+	uid, gid := s.option.UidGidMapper.LocalToFiler(squash(cred.uid), squash(cred.gid))
+	identity := &filer.Identity{Uid: uid, Gids: []uint32{gid}}
+	for _, g := range cred.gids {
+		_, mapped := s.option.UidGidMapper.LocalToFiler(0, squash(g))
+		identity.Gids = append(identity.Gids, mapped)
+	}
+	return identity
+}
+
+func (s *NfsServer) updateEntry(call *rpcCall, fullpath util.FullPath, entry *filer_pb.Entry) error {
+	dir, _ := fullpath.DirAndName()
+	return s.asCaller(call).WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(client, &filer_pb.UpdateEntryRequest{
+			Directory:  dir,
+			Entry:      entry,
+			Signatures: []int32{s.signature},
+		})
+	})
+}
+
+func (s *NfsServer) getattr(call *rpcCall, w *xdrWriter) uint32 {
+	fullpath, status := s.readHandle(call)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		entry, status = s.getEntry(call, fullpath)
+	}
+	w.uint32(status)
+	if status == nfs3Ok {
+		s.writeFattr(w, fullpath, entry)
+	}
+	return acceptSuccess
+}
+
+func (s *NfsServer) setattr(call *rpcCall, w *xdrWriter) uint32 {
+	fullpath, status := s.readHandle(call)
+	attrs := readSattr(call.args)
+	if call.args.bool() { // guard on ctime
+		call.args.uint32()
+		call.args.uint32()
+	}
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		if err := s.flushFile(fullpath); err != nil {
+			glog.Errorf("nfs setattr flush %s: %v", fullpath, err)
+			status = toNfsStatus(err)
+		}
+	}
+	if status == nfs3Ok {
+		entry, status = s.getEntry(call, fullpath)
+	}
+	if status == nfs3Ok && attrs.size != nil && entry.IsDirectory {
+		status = nfs3ErrIsDir
+	}
+	if status == nfs3Ok {
+		s.applySattr(entry, attrs)
+		if attrs.size != nil {
+			s.truncate(entry, *attrs.size)
+		}
+		if err := s.updateEntry(call, fullpath, entry); err != nil {
+			glog.V(0).Infof("nfs setattr %s: %v", fullpath, err)
+			status = toNfsStatus(err)
+		}
+	}
+
+	w.uint32(status)
+	if status != nfs3Ok {
+		entry = nil
+	}
+	s.writeWccData(w, fullpath, entry)
+	return acceptSuccess
+}
+
+// truncate changes the file size, dropping or cutting the chunks beyond the new size
+func (s *NfsServer) truncate(entry *filer_pb.Entry, size uint64) {
+	if size < filer.FileSize(entry) {
+		if uint64(len(entry.Content)) > size {
+			entry.Content = entry.Content[:size]
+		}
+		dataChunks, _, err := filer.ResolveChunkManifest(s.lookupFn, entry.Chunks)
+		if err != nil {
+			glog.Errorf("resolve chunk manifest: %v", err)
+			dataChunks = entry.Chunks
+		}
+		var chunks []*filer_pb.FileChunk
+		for _, chunk := range dataChunks {
+			if chunk.Offset >= int64(size) {
+				continue
+			}
+			if chunk.Offset+int64(chunk.Size) > int64(size) {
+				chunk.Size = size - uint64(chunk.Offset)
+			}
+			chunks = append(chunks, chunk)
+		}
+		entry.Chunks = chunks
+	}
+	entry.Attributes.FileSize = size
+	entry.Attributes.Mtime = time.Now().Unix()
+}
+
+func (s *NfsServer) lookup(call *rpcCall, w *xdrWriter) uint32 {
+	dirPath, status := s.readHandle(call)
+	name := call.args.string(maxPathLen)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	var dirEntry, entry *filer_pb.Entry
+	var fullpath util.FullPath
+	if status == nfs3Ok {
+		dirEntry, status = s.getEntry(call, dirPath)
+	}
+	if status == nfs3Ok && !dirEntry.IsDirectory {
+		status = nfs3ErrNotDir
+	}
+	if status == nfs3Ok {
+		switch name {
+		case ".":
+			fullpath, entry = dirPath, dirEntry
+		case "..":
+			fullpath = s.parentOf(dirPath)
+			entry, status = s.getEntry(call, fullpath)
+		default:
+			if status = checkName(name); status == nfs3Ok {
+				fullpath = dirPath.Child(name)
+				var err error
+				if entry, err = s.lookupEntry(call, fullpath); err != nil {
+					status = toNfsStatus(err)
+				} else if entry == nil {
+					status = nfs3ErrNoEnt
+				}
+			}
+		}
+	}
+
+	w.uint32(status)
+	if status == nfs3Ok {
+		w.opaque(s.handles.toHandle(fullpath))
+		s.writePostOpAttr(w, fullpath, entry)
+	}
+	s.writePostOpAttr(w, dirPath, dirEntry)
+	return acceptSuccess
+}
+
+// parentOf returns the parent directory, but not going above the exported directory
+func (s *NfsServer) parentOf(fullpath util.FullPath) util.FullPath {
+	if fullpath == s.root {
+		return fullpath
+	}
+	dir, _ := fullpath.DirAndName()
+	return util.FullPath(dir)
+}
+
+func checkName(name string) uint32 {
+	switch {
+	case name == "" || name == "." || name == "..":
+		return nfs3ErrInval
+	case len(name) > maxNameLen:
+		return nfs3ErrNameTooLong
+	}
+	for i := 0; i < len(name); i++ {
+		if name[i] == '/' || name[i] == 0 {
+			return nfs3ErrInval
+		}
+	}
+	return nfs3Ok
+}
+
+func (s *NfsServer) access(call *rpcCall, w *xdrWriter) uint32 {
+	fullpath, status := s.readHandle(call)
+	requested := call.args.uint32()
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		entry, status = s.getEntry(call, fullpath)
+	}
+	w.uint32(status)
+	s.writePostOpAttr(w, fullpath, entry)
+	if status != nfs3Ok {
+		return acceptSuccess
+	}
+
+	dir, _ := fullpath.DirAndName()
+	fe, identity := filer.FromPbEntry(dir, entry), s.identity(call)
+	var granted uint32
+	if filer.HasPermission(fe, identity, filer.PermissionRead) {
+		granted |= access3Read
+	}
+	if filer.HasPermission(fe, identity, filer.PermissionWrite) {
+		granted |= access3Modify | access3Extend
+		if entry.IsDirectory {
+			granted |= access3Delete
+		}
+	}
+	if filer.HasPermission(fe, identity, filer.PermissionExecute) {
+		if entry.IsDirectory {
+			granted |= access3Lookup
+		} else {
+			granted |= access3Execute
+		}
+	}
+	w.uint32(requested & granted)
+	return acceptSuccess
+}
+
+func (s *NfsServer) readlink(call *rpcCall, w *xdrWriter) uint32 {
+	fullpath, status := s.readHandle(call)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		entry, status = s.getEntry(call, fullpath)
+	}
+	if status == nfs3Ok && os.FileMode(entry.Attributes.GetFileMode())&os.ModeSymlink == 0 {
+		status = nfs3ErrInval
+	}
+	w.uint32(status)
+	s.writePostOpAttr(w, fullpath, entry)
+	if status == nfs3Ok {
+		w.string(entry.Attributes.SymlinkTarget)
+	}
+	return acceptSuccess
+}
+
+func (s *NfsServer) read(call *rpcCall, w *xdrWriter) uint32 {
+	fullpath, status := s.readHandle(call)
+	offset, count := call.args.uint64(), call.args.uint32()
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+	if count > maxReadWriteSize {
+		count = maxReadWriteSize
+	}
+
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		if err := s.flushFile(fullpath); err != nil {
+			glog.Errorf("nfs read flush %s: %v", fullpath, err)
+			status = toNfsStatus(err)
+		}
+	}
+	if status == nfs3Ok {
+		entry, status = s.getEntry(call, fullpath)
+	}
+	if status == nfs3Ok && entry.IsDirectory {
+		status = nfs3ErrIsDir
+	}
+
+	var data []byte
+	var eof bool
+	if status == nfs3Ok {
+		fileSize := filer.FileSize(entry)
+		if offset < fileSize {
+			if uint64(count) > fileSize-offset {
+				count = uint32(fileSize - offset)
+			}
+			data = make([]byte, count)
+			n, err := s.readAt(fullpath, entry, data, int64(offset))
+			if err != nil && err != io.EOF {
+				glog.Errorf("nfs read %s [%d,%d): %v", fullpath, offset, offset+uint64(count), err)
+				status = nfs3ErrIO
+			}
+			data = data[:n]
+		}
+		eof = offset+uint64(len(data)) >= fileSize
+	}
+
+	w.uint32(status)
+	s.writePostOpAttr(w, fullpath, entry)
+	if status == nfs3Ok {
+		w.uint32(uint32(len(data)))
+		w.bool(eof)
+		w.opaque(data)
+	}
+	return acceptSuccess
+}
+
+type cachedReader struct {
+	mtime  int64
+	size   uint64
+	chunks int
+	reader *filer.ChunkReadAt
+}
+
+// readAt reads the file content, reusing the reader while the file is not changed
+func (s *NfsServer) readAt(fullpath util.FullPath, entry *filer_pb.Entry, p []byte, offset int64) (int, error) {
+	if len(entry.Content) > 0 {
+		if offset >= int64(len(entry.Content)) {
+			return 0, io.EOF
+		}
+		return copy(p, entry.Content[offset:]), nil
+	}
+
+	fileSize := filer.FileSize(entry)
+	key := string(fullpath)
+	var reader *filer.ChunkReadAt
+	if item := s.readers.Get(key); item != nil {
+		cached := item.Value().(*cachedReader)
+		if cached.mtime == entry.Attributes.Mtime && cached.size == fileSize && cached.chunks == len(entry.Chunks) {
+			reader = cached.reader
+		}
+	}
+	if reader == nil {
+		visibles, err := filer.NonOverlappingVisibleIntervals(s.lookupFn, entry.Chunks)
+		if err != nil {
+			return 0, err
+		}
+		chunkViews := filer.ViewFromVisibleIntervals(visibles, 0, math.MaxInt64)
+		reader = filer.NewChunkReaderAtFromClient(s.lookupFn, chunkViews, s.chunkCache, int64(fileSize))
+		s.readers.Set(key, &cachedReader{
+			mtime:  entry.Attributes.Mtime,
+			size:   fileSize,
+			chunks: len(entry.Chunks),
+			reader: reader,
+		}, time.Minute)
+	}
+	return reader.ReadAt(p, offset)
+}
+
+func newReaderCache() *ccache.Cache {
+	return ccache.New(ccache.Configure().MaxSize(cachedReaders).ItemsToPrune(cachedReaders / 16))
+}
+
+func (s *NfsServer) write(call *rpcCall, w *xdrWriter) uint32 {
+	fullpath, status := s.readHandle(call)
+	offset := call.args.uint64()
+	call.args.uint32() // count, the same as the data length
+	stable := call.args.uint32()
+	data := call.args.opaque(maxReadWriteSize)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		entry, status = s.getEntry(call, fullpath)
+	}
+	if status == nfs3Ok && entry.IsDirectory {
+		status = nfs3ErrIsDir
+	}
+	if status == nfs3Ok {
+		if err := s.writeFile(call, fullpath, int64(offset), data, stable != stableUnstable); err != nil {
+			glog.Errorf("nfs write %s [%d,%d): %v", fullpath, offset, offset+uint64(len(data)), err)
+			status = toNfsStatus(err)
+		}
+	}
+	if status == nfs3Ok {
+		if end := offset + uint64(len(data)); end > entry.Attributes.FileSize {
+			entry.Attributes.FileSize = end
+		}
+	}
+
+	w.uint32(status)
+	s.writeWccData(w, fullpath, entry)
+	if status == nfs3Ok {
+		w.uint32(uint32(len(data)))
+		if stable == stableUnstable {
+			w.uint32(stableUnstable)
+		} else {
+			w.uint32(stableFileSync)
+		}
+		w.fixedOpaque(s.writeVerifier[:])
+	}
+	return acceptSuccess
+}
+
+func (s *NfsServer) commit(call *rpcCall, w *xdrWriter) uint32 {
+	fullpath, status := s.readHandle(call)
+	call.args.uint64() // offset
+	call.args.uint32() // count
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	if status == nfs3Ok {
+		if err := s.flushFile(fullpath); err != nil {
+			glog.Errorf("nfs commit %s: %v", fullpath, err)
+			status = toNfsStatus(err)
+		}
+	}
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		entry, status = s.getEntry(call, fullpath)
+	}
+
+	w.uint32(status)
+	s.writeWccData(w, fullpath, entry)
+	if status == nfs3Ok {
+		w.fixedOpaque(s.writeVerifier[:])
+	}
+	return acceptSuccess
+}
+
+func (s *NfsServer) fsstat(call *rpcCall, w *xdrWriter) uint32 {
+	fullpath, status := s.readHandle(call)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		entry, status = s.getEntry(call, fullpath)
+	}
+	var totalBytes, usedBytes, maxFiles, usedFiles uint64
+	if status == nfs3Ok {
+		var err error
+		if totalBytes, usedBytes, maxFiles, usedFiles, err = s.statistics(context.Background()); err != nil {
+			glog.V(0).Infof("nfs fsstat: %v", err)
+			status = nfs3ErrIO
+		}
+	}
+
+	w.uint32(status)
+	s.writePostOpAttr(w, fullpath, entry)
+	if status == nfs3Ok {
+		w.uint64(totalBytes)
+		w.uint64(totalBytes - usedBytes)
+		w.uint64(totalBytes - usedBytes)
+		w.uint64(maxFiles)
+		w.uint64(maxFiles - usedFiles)
+		w.uint64(maxFiles - usedFiles)
+		w.uint32(0) // invarsec
+	}
+	return acceptSuccess
+}
+
+func (s *NfsServer) fsinfo(call *rpcCall, w *xdrWriter) uint32 {
+	fullpath, status := s.readHandle(call)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		entry, status = s.getEntry(call, fullpath)
+	}
+
+	w.uint32(status)
+	s.writePostOpAttr(w, fullpath, entry)
+	if status == nfs3Ok {
+		w.uint32(maxReadWriteSize) // rtmax
+		w.uint32(maxReadWriteSize) // rtpref
+		w.uint32(defaultBlockSize) // rtmult
+		w.uint32(maxReadWriteSize) // wtmax
+		w.uint32(maxReadWriteSize) // wtpref
+		w.uint32(defaultBlockSize) // wtmult
+		w.uint32(64 * 1024)        // dtpref
+		w.uint64(math.MaxInt64)    // maxfilesize
+		w.uint32(1)                // time delta
+		w.uint32(0)
+		w.uint32(fsf3Symlink | fsf3Homogeneous | fsf3CanSetTime)
+	}
+	return acceptSuccess
+}
+
+func (s *NfsServer) pathconf(call *rpcCall, w *xdrWriter) uint32 {
+	fullpath, status := s.readHandle(call)
+	if call.args.err != nil {
+		return acceptGarbageArgs
+	}
+
+	var entry *filer_pb.Entry
+	if status == nfs3Ok {
+		entry, status = s.getEntry(call, fullpath)
+	}
+
+	w.uint32(status)
+	s.writePostOpAttr(w, fullpath, entry)
+	if status == nfs3Ok {
+		w.uint32(1)          // linkmax
+		w.uint32(maxNameLen) // name_max
+		w.bool(true)         // no_trunc
+		w.bool(true)         // chown_restricted
+		w.bool(false)        // case_insensitive
+		w.bool(true)         // case_preserving
+	}
+	return acceptSuccess
+}
@@ -0,0 +1,268 @@
+package nfs
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/karlseguin/ccache/v2"
+	"google.golang.org/grpc"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/filesys/meta_cache"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/util/chunk_cache"
+	"github.com/chrislusf/seaweedfs/weed/wdclient"
+)
+
+type NfsServerOption struct {
+	Filer            string
+	FilerGrpcAddress string
+	FilerRootPath    string
+	GrpcDialOption   grpc.DialOption
+	Collection       string
+	Replication      string
+	DiskType         string
+	ChunkSizeLimit   int64
+	Cipher           bool
+	CacheDir         string
+	CacheSizeMB      int64
+	UidGidMapper     *meta_cache.UidGidMapper
+	// RootSquash maps the client root to nobody
+	RootSquash bool
+	// AllowedClients are the ip addresses or cidr ranges allowed to connect, any client if empty
+	AllowedClients []string
+}
+
+// NfsServer serves a filer directory over nfs v3 and the mount v3 protocol, on the same tcp port
+type NfsServer struct {
+	option     *NfsServerOption
+	root       util.FullPath
+	fsid       uint64
+	chunkCache *chunk_cache.TieredChunkCache
+	signature  int32
+	// writeVerifier changes on restart, so clients resend uncommitted writes
+	writeVerifier [8]byte
+	handles       *handleMap
+	cookies       *cookieCache
+	lookupFn      wdclient.LookupFileIdFunctionType
+	readers       *ccache.Cache
+
+	dirtyFilesLock sync.Mutex
+	dirtyFiles     map[util.FullPath]*dirtyFile
+
+	allowedClients []*net.IPNet
+}
+
+func NewNfsServer(option *NfsServerOption) (*NfsServer, error) {
+
+	root := util.FullPath(path.Clean("/" + option.FilerRootPath))
+
+	allowedClients, err := parseAllowedClients(option.AllowedClients)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheUniqueId := util.Md5String([]byte("nfs" + option.FilerGrpcAddress + string(root) + util.Version()))[0:8]
+	cacheDir := path.Join(option.CacheDir, cacheUniqueId)
+	os.MkdirAll(cacheDir, os.FileMode(0755))
+
+	s := &NfsServer{
+		option:     option,
+		root:       root,
+		fsid:       root.AsInode(),
+		chunkCache: chunk_cache.NewTieredChunkCache(256, cacheDir, option.CacheSizeMB, 1024*1024),
+		signature:  util.RandomInt32(),
+		cookies:    newCookieCache(),
+		readers:    newReaderCache(),
+		dirtyFiles: make(map[util.FullPath]*dirtyFile),
+
+		allowedClients: allowedClients,
+	}
+	s.handles = newHandleMap(s)
+	s.lookupFn = filer.LookupFn(s)
+	binary.BigEndian.PutUint64(s.writeVerifier[:], uint64(time.Now().UnixNano()))
+
+	entry, err := filer_pb.GetEntry(s, root)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %v", root, err)
+	}
+	if entry == nil || !entry.IsDirectory {
+		return nil, fmt.Errorf("%s is not a directory on the filer", root)
+	}
+
+	go s.loopFlushingIdleFiles()
+
+	return s, nil
+}
+
+// Serve answers the mount and nfs requests on the listener until it fails
+func (s *NfsServer) Serve(listener net.Listener) error {
+	rpc := &rpcServer{isAllowed: s.isAllowedClient}
+	rpc.register(mountProgram, mountVersion, s.mountProcs())
+	rpc.register(nfsProgram, nfsVersion, s.nfsProcs())
+	return rpc.serve(listener)
+}
+
+var _ = filer_pb.FilerClient(&NfsServer{})
+
+// WithFilerClient acts as the nfs server itself, to read the exported directory, the file handles and the statistics
+func (s *NfsServer) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
+	return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
+		return fn(filer_pb.NewServiceFilerClient(filer_pb.NewSeaweedFilerClient(grpcConnection)))
+	}, s.option.FilerGrpcAddress, s.option.GrpcDialOption)
+}
+
+// withFilerClient acts as the caller of the nfs request, so the filer checks the permissions against it
+func (s *NfsServer) withFilerClient(cred *authUnixCred, fn func(filer_pb.SeaweedFilerClient) error) error {
+
+	identity := s.credIdentity(cred)
+	encoded := security.GenProcessIdentityJwt(identity.Uid, identity.Gids)
+
+	return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
+		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		if encoded != "" {
+			client = filer_pb.NewIdentityFilerClient(client, encoded)
+		}
+		return fn(client)
+	}, s.option.FilerGrpcAddress, s.option.GrpcDialOption)
+
+}
+
+func (s *NfsServer) AdjustedUrl(location *filer_pb.Location) string {
+	return location.Url
+}
+
+// callerClient is a filer client acting as the caller of one nfs request
+type callerClient struct {
+	s    *NfsServer
+	cred *authUnixCred
+}
+
+func (c *callerClient) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
+	return c.s.withFilerClient(c.cred, fn)
+}
+
+func (c *callerClient) AdjustedUrl(location *filer_pb.Location) string {
+	return location.Url
+}
+
+func (s *NfsServer) asCaller(call *rpcCall) *callerClient {
+	return &callerClient{s: s, cred: call.cred}
+}
+
+// parseAllowedClients parses the ip addresses and cidr ranges allowed to connect
+func parseAllowedClients(clients []string) (allowed []*net.IPNet, err error) {
+	for _, client := range clients {
+		if client = strings.TrimSpace(client); client == "" {
+			continue
+		}
+		if !strings.Contains(client, "/") {
+			ip := net.ParseIP(client)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid allowed client %s", client)
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			allowed = append(allowed, &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)})
+			continue
+		}
+		_, cidr, parseErr := net.ParseCIDR(client)
+		if parseErr != nil {
+			return nil, fmt.Errorf("invalid allowed client %s: %v", client, parseErr)
+		}
+		allowed = append(allowed, cidr)
+	}
+	return
+}
+
+// isAllowedClient checks the remote address of a connection against the allowed clients
+func (s *NfsServer) isAllowedClient(addr net.Addr) bool {
+	if len(s.allowedClients) == 0 {
+		return true
+	}
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, allowed := range s.allowedClients {
+		if allowed.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnderRoot checks the path is the exported directory or under it
+func (s *NfsServer) isUnderRoot(fullpath util.FullPath) bool {
+	return fullpath == s.root || s.root == "/" || strings.HasPrefix(string(fullpath), string(s.root)+"/")
+}
+
+// lookupEntry reads the entry as the caller, and returns nil if not found
+func (s *NfsServer) lookupEntry(call *rpcCall, fullpath util.FullPath) (*filer_pb.Entry, error) {
+	entry, err := filer_pb.GetEntry(s.asCaller(call), fullpath)
+	if err != nil || entry == nil {
+		return entry, err
+	}
+	// include the writes not flushed yet
+	if df := s.getDirtyFile(fullpath); df != nil {
+		if size := df.size(); size > entry.Attributes.FileSize {
+			entry.Attributes.FileSize = size
+		}
+	}
+	return entry, nil
+}
+
+// statistics reports the file system size, or the quota on the exported directory if any
+func (s *NfsServer) statistics(ctx context.Context) (totalBytes, usedBytes, maxFiles, usedFiles uint64, err error) {
+	err = s.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.Statistics(ctx, &filer_pb.StatisticsRequest{
+			Collection:  s.option.Collection,
+			Replication: s.option.Replication,
+			DiskType:    s.option.DiskType,
+		})
+		if err != nil {
+			return err
+		}
+		totalBytes, usedBytes = resp.TotalSize, resp.UsedSize
+		maxFiles, usedFiles = uint64(math.MaxInt64), resp.FileCount
+
+		quotaResp, err := client.GetQuota(ctx, &filer_pb.GetQuotaRequest{Directory: string(s.root)})
+		if err != nil {
+			glog.V(1).Infof("reading quota of %s: %v", s.root, err)
+			return nil
+		}
+		if quota := quotaResp.Quota; quota != nil {
+			if quota.MaxBytes > 0 {
+				totalBytes, usedBytes = uint64(quota.MaxBytes), uint64(min(quota.UsedBytes, quota.MaxBytes))
+			}
+			if quota.MaxInodes > 0 {
+				maxFiles, usedFiles = uint64(quota.MaxInodes), uint64(min(quota.UsedInodes, quota.MaxInodes))
+			}
+		}
+		return nil
+	})
+	if usedBytes > totalBytes {
+		usedBytes = totalBytes
+	}
+	return
+}
+
+func min(x, y int64) int64 {
+	if x < y {
+		return x
+	}
+	return y
+}
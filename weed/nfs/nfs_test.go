@@ -0,0 +1,177 @@
+package nfs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/filesys/meta_cache"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestXdrRoundTrip(t *testing.T) {
+	w := &xdrWriter{}
+	w.uint32(7)
+	w.uint64(1 << 40)
+	w.bool(true)
+	w.string("abcde")
+	w.opaque([]byte{1, 2, 3, 4})
+	if w.len() != 4+8+4+4+8+4+4 {
+		t.Fatalf("unexpected length %d", w.len())
+	}
+
+	r := newXdrReader(w.buf)
+	if r.uint32() != 7 || r.uint64() != 1<<40 || !r.bool() || r.string(10) != "abcde" || !bytes.Equal(r.opaque(4), []byte{1, 2, 3, 4}) {
+		t.Fatalf("unexpected values")
+	}
+	if r.err != nil || len(r.remaining()) != 0 {
+		t.Fatalf("unexpected reader state: %v", r.err)
+	}
+	r.uint32()
+	if r.err == nil {
+		t.Fatalf("expected short buffer")
+	}
+
+	r = newXdrReader(w.buf[16:])
+	if r.string(3); r.err == nil {
+		t.Fatalf("expected too long string")
+	}
+}
+
+func TestRpcNullCall(t *testing.T) {
+	rpc := &rpcServer{}
+	rpc.register(nfsProgram, nfsVersion, map[uint32]rpcHandler{nfsProcNull: nullProc})
+
+	call := func(prog, vers, proc uint32) *xdrReader {
+		w := &xdrWriter{}
+		w.uint32(42) // xid
+		w.uint32(msgCall)
+		w.uint32(rpcVersion)
+		w.uint32(prog)
+		w.uint32(vers)
+		w.uint32(proc)
+		w.uint32(authUnix)
+		cred := &xdrWriter{}
+		cred.uint32(0)
+		cred.string("client")
+		cred.uint32(1000)
+		cred.uint32(100)
+		cred.uint32(1)
+		cred.uint32(10)
+		w.opaque(cred.buf)
+		w.uint32(authNone)
+		w.opaque(nil)
+
+		reply := rpc.handle(w.buf)
+		if marker := binary.BigEndian.Uint32(reply); marker != uint32(len(reply)-4)|lastFragment {
+			t.Fatalf("unexpected record marker %x", marker)
+		}
+		r := newXdrReader(reply[4:])
+		if xid, msgType, stat := r.uint32(), r.uint32(), r.uint32(); xid != 42 || msgType != msgReply || stat != replyAccepted {
+			t.Fatalf("unexpected reply header %d %d %d", xid, msgType, stat)
+		}
+		r.uint32()
+		r.opaque(400)
+		return r
+	}
+
+	if r := call(nfsProgram, nfsVersion, nfsProcNull); r.uint32() != acceptSuccess || len(r.remaining()) != 0 {
+		t.Fatalf("null call failed")
+	}
+	if r := call(nfsProgram, nfsVersion, 99); r.uint32() != acceptProcUnavail {
+		t.Fatalf("expected proc unavailable")
+	}
+	if r := call(nfsProgram, 4, nfsProcNull); r.uint32() != acceptProgMismatch || r.uint32() != nfsVersion || r.uint32() != nfsVersion {
+		t.Fatalf("expected prog mismatch")
+	}
+	if r := call(mountProgram, mountVersion, 0); r.uint32() != acceptProgUnavail {
+		t.Fatalf("expected prog unavailable")
+	}
+}
+
+func TestInlineHandle(t *testing.T) {
+	s := &NfsServer{root: "/exports"}
+	s.handles = newHandleMap(s)
+
+	for _, fullpath := range []util.FullPath{"/exports", "/exports/a", "/exports/a/b.txt"} {
+		fh := s.handles.toHandle(fullpath)
+		if p, status := s.handles.toPath(fh); status != nfs3Ok || p != fullpath {
+			t.Fatalf("handle of %s resolved to %s, status %d", fullpath, p, status)
+		}
+	}
+	if _, status := s.handles.toPath([]byte{2, 0}); status != nfs3ErrBadHandle {
+		t.Fatalf("expected bad handle, got %d", status)
+	}
+	for _, relative := range []string{"..", "../etc/passwd", "a/../../etc", "./a", "a//b", "a/", "/a"} {
+		fh := append([]byte{handleVersion, handleInline}, relative...)
+		if p, status := s.handles.toPath(fh); status != nfs3ErrBadHandle {
+			t.Fatalf("handle of %s resolved to %s, status %d", relative, p, status)
+		}
+	}
+}
+
+func TestCredIdentity(t *testing.T) {
+	mapper, _ := meta_cache.NewUidGidMapper("", "")
+	s := &NfsServer{option: &NfsServerOption{UidGidMapper: mapper, RootSquash: true}}
+
+	if identity := s.credIdentity(nil); identity.Uid != nobody || identity.Gid() != nobody {
+		t.Errorf("auth none should be nobody: %+v", identity)
+	}
+	root := &authUnixCred{uid: 0, gid: 0, gids: []uint32{0, 10}}
+	if identity := s.credIdentity(root); identity.Uid != nobody || identity.Gid() != nobody || identity.InGroup(0) || !identity.InGroup(10) {
+		t.Errorf("root should be squashed: %+v", identity)
+	}
+	user := &authUnixCred{uid: 1000, gid: 100}
+	if identity := s.credIdentity(user); identity.Uid != 1000 || identity.Gid() != 100 {
+		t.Errorf("unexpected identity %+v", identity)
+	}
+
+	s.option.RootSquash = false
+	if identity := s.credIdentity(root); identity.Uid != 0 || identity.Gid() != 0 {
+		t.Errorf("root should not be squashed: %+v", identity)
+	}
+}
+
+func TestAllowedClients(t *testing.T) {
+	if _, err := parseAllowedClients([]string{"10.0.0.300"}); err == nil {
+		t.Fatalf("invalid address should fail")
+	}
+	allowed, err := parseAllowedClients([]string{"192.168.1.5", " 10.0.0.0/8", "", "::1"})
+	if err != nil {
+		t.Fatalf("parse allowed clients: %v", err)
+	}
+	s := &NfsServer{allowedClients: allowed}
+	for addr, expected := range map[string]bool{
+		"192.168.1.5": true,
+		"192.168.1.6": false,
+		"10.1.2.3":    true,
+		"11.1.2.3":    false,
+		"::1":         true,
+	} {
+		if actual := s.isAllowedClient(&net.TCPAddr{IP: net.ParseIP(addr), Port: 800}); actual != expected {
+			t.Errorf("client %s: expected %v, actual %v", addr, expected, actual)
+		}
+	}
+	if !s.isAllowedClient(&net.TCPAddr{IP: net.IPv4(192, 168, 1, 5).To4()}) {
+		t.Errorf("4 bytes ipv4 address should be allowed")
+	}
+	if !(&NfsServer{}).isAllowedClient(&net.TCPAddr{IP: net.ParseIP("1.2.3.4")}) {
+		t.Errorf("any client should be allowed by default")
+	}
+}
+
+func TestDirtyFileIntervals(t *testing.T) {
+	df := &dirtyFile{}
+	df.addInterval(100, 200)
+	df.addInterval(0, 50)
+	df.addInterval(50, 100)
+	df.addInterval(300, 400)
+	df.addInterval(150, 250)
+	if len(df.written) != 2 || df.written[0] != (writtenInterval{0, 250}) || df.written[1] != (writtenInterval{300, 400}) {
+		t.Fatalf("unexpected intervals %+v", df.written)
+	}
+	if df.size() != 400 {
+		t.Fatalf("unexpected size %d", df.size())
+	}
+}
@@ -0,0 +1,309 @@
+package nfs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const (
+	// unstable writes are flushed to the filer on COMMIT, or after being idle for a while
+	dirtyFileIdleTime = 3 * time.Second
+)
+
+type writtenInterval struct {
+	start, stop int64
+}
+
+// dirtyFile buffers the unstable writes to one chunk size aligned window of a file.
+// Writing outside of the window flushes the window first.
+// Clients send the writes in parallel, so the writes to a window may arrive in any order.
+type dirtyFile struct {
+	sync.Mutex
+	fullpath    util.FullPath
+	cred        *authUnixCred // the last writer, to flush as it
+	windowStart int64
+	data        []byte
+	written     []writtenInterval
+	lastWrite   time.Time
+	removed     bool
+}
+
+// size returns the end of the buffered writes
+func (df *dirtyFile) size() uint64 {
+	df.Lock()
+	defer df.Unlock()
+	var size int64
+	for _, t := range df.written {
+		if t.stop > size {
+			size = t.stop
+		}
+	}
+	return uint64(size)
+}
+
+func (df *dirtyFile) addInterval(start, stop int64) {
+	df.written = append(df.written, writtenInterval{start, stop})
+	sort.Slice(df.written, func(i, j int) bool {
+		return df.written[i].start < df.written[j].start
+	})
+	merged := df.written[:1]
+	for _, t := range df.written[1:] {
+		last := &merged[len(merged)-1]
+		if t.start <= last.stop {
+			if t.stop > last.stop {
+				last.stop = t.stop
+			}
+			continue
+		}
+		merged = append(merged, t)
+	}
+	df.written = merged
+}
+
+func (s *NfsServer) getDirtyFile(fullpath util.FullPath) *dirtyFile {
+	s.dirtyFilesLock.Lock()
+	defer s.dirtyFilesLock.Unlock()
+	return s.dirtyFiles[fullpath]
+}
+
+// lockDirtyFile returns the locked dirty file of the path, creating it if needed
+func (s *NfsServer) lockDirtyFile(fullpath util.FullPath) *dirtyFile {
+	for {
+		s.dirtyFilesLock.Lock()
+		df, found := s.dirtyFiles[fullpath]
+		if !found {
+			df = &dirtyFile{fullpath: fullpath}
+			s.dirtyFiles[fullpath] = df
+		}
+		s.dirtyFilesLock.Unlock()
+
+		df.Lock()
+		if !df.removed {
+			return df
+		}
+		df.Unlock()
+	}
+}
+
+// removeDirtyFileLocked stops tracking the locked dirty file
+func (s *NfsServer) removeDirtyFileLocked(df *dirtyFile) {
+	df.removed = true
+	s.dirtyFilesLock.Lock()
+	if s.dirtyFiles[df.fullpath] == df {
+		delete(s.dirtyFiles, df.fullpath)
+	}
+	s.dirtyFilesLock.Unlock()
+}
+
+// writeFile buffers the data, and flushes it to the filer if stable
+func (s *NfsServer) writeFile(call *rpcCall, fullpath util.FullPath, offset int64, data []byte, stable bool) error {
+	chunkSize := s.option.ChunkSizeLimit
+
+	df := s.lockDirtyFile(fullpath)
+	defer df.Unlock()
+
+	df.cred, df.lastWrite = call.cred, time.Now()
+	for len(data) > 0 {
+		windowStart := offset / chunkSize * chunkSize
+		if len(df.written) > 0 && windowStart != df.windowStart {
+			if err := s.flushLocked(df); err != nil {
+				return err
+			}
+		}
+		if df.data == nil {
+			df.data = make([]byte, chunkSize)
+		}
+		df.windowStart = windowStart
+		n := copy(df.data[offset-windowStart:], data)
+		df.addInterval(offset, offset+int64(n))
+		offset, data = offset+int64(n), data[n:]
+	}
+
+	if stable {
+		return s.flushLocked(df)
+	}
+	return nil
+}
+
+// flushFile saves the buffered writes of the path, if any
+func (s *NfsServer) flushFile(fullpath util.FullPath) error {
+	df := s.getDirtyFile(fullpath)
+	if df == nil {
+		return nil
+	}
+	df.Lock()
+	defer df.Unlock()
+	if df.removed {
+		return nil
+	}
+	err := s.flushLocked(df)
+	if err == nil {
+		s.removeDirtyFileLocked(df)
+	}
+	return err
+}
+
+// dropFile discards the buffered writes of a removed file
+func (s *NfsServer) dropFile(fullpath util.FullPath) {
+	df := s.getDirtyFile(fullpath)
+	if df == nil {
+		return
+	}
+	df.Lock()
+	s.removeDirtyFileLocked(df)
+	df.Unlock()
+}
+
+func (s *NfsServer) loopFlushingIdleFiles() {
+	for {
+		time.Sleep(dirtyFileIdleTime)
+
+		s.dirtyFilesLock.Lock()
+		var dirtyFiles []*dirtyFile
+		for _, df := range s.dirtyFiles {
+			dirtyFiles = append(dirtyFiles, df)
+		}
+		s.dirtyFilesLock.Unlock()
+
+		for _, df := range dirtyFiles {
+			df.Lock()
+			if !df.removed && time.Since(df.lastWrite) > dirtyFileIdleTime {
+				if err := s.flushLocked(df); err != nil {
+					glog.Errorf("flush nfs writes to %s: %v", df.fullpath, err)
+				} else {
+					s.removeDirtyFileLocked(df)
+				}
+			}
+			df.Unlock()
+		}
+	}
+}
+
+// flushLocked uploads the buffered writes as chunks, and adds them to the entry
+func (s *NfsServer) flushLocked(df *dirtyFile) error {
+	if len(df.written) == 0 {
+		return nil
+	}
+
+	saveFunc := s.saveDataAsChunk(df.fullpath)
+	var chunks []*filer_pb.FileChunk
+	var size uint64
+	for _, t := range df.written {
+		chunk, _, _, err := saveFunc(bytes.NewReader(df.data[t.start-df.windowStart:t.stop-df.windowStart]), df.fullpath.Name(), t.start)
+		if err != nil {
+			return err
+		}
+		chunks = append(chunks, chunk)
+		size = uint64(t.stop)
+	}
+
+	// read the content as the nfs server, since the filer leaves it out for callers who can only write the file,
+	// and save the entry as the caller
+	client := &callerClient{s: s, cred: df.cred}
+	entry, err := filer_pb.GetEntry(s, df.fullpath)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return filer_pb.ErrNotFound
+	}
+	if entry.Attributes == nil {
+		entry.Attributes = &filer_pb.FuseAttributes{}
+	}
+
+	// keep the inlined content as a chunk, before adding the new chunks over it
+	if len(entry.Content) > 0 {
+		chunk, _, _, err := saveFunc(bytes.NewReader(entry.Content), df.fullpath.Name(), 0)
+		if err != nil {
+			return err
+		}
+		chunk.Mtime = 0
+		entry.Chunks = append(entry.Chunks, chunk)
+		entry.Content = nil
+	}
+
+	entry.Chunks = append(entry.Chunks, chunks...)
+	if manifestedChunks, err := filer.MaybeManifestize(saveFunc, entry.Chunks); err != nil {
+		// not good, but should be ok
+		glog.V(0).Infof("file %s flush MaybeManifestize: %v", df.fullpath, err)
+	} else {
+		entry.Chunks = manifestedChunks
+	}
+	if size > entry.Attributes.FileSize {
+		entry.Attributes.FileSize = size
+	}
+	entry.Attributes.Mtime = time.Now().Unix()
+
+	dir, _ := df.fullpath.DirAndName()
+	err = client.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(client, &filer_pb.UpdateEntryRequest{
+			Directory:  dir,
+			Entry:      entry,
+			Signatures: []int32{s.signature},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	df.written = df.written[:0]
+	return nil
+}
+
+func (s *NfsServer) saveDataAsChunk(fullpath util.FullPath) filer.SaveDataAsChunkFunctionType {
+	return func(reader io.Reader, name string, offset int64) (chunk *filer_pb.FileChunk, collection, replication string, err error) {
+
+		var fileId, host string
+		var auth security.EncodedJwt
+
+		if err = s.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+
+			request := &filer_pb.AssignVolumeRequest{
+				Count:       1,
+				Replication: s.option.Replication,
+				Collection:  s.option.Collection,
+				DiskType:    s.option.DiskType,
+				Path:        string(fullpath),
+			}
+
+			resp, err := client.AssignVolume(context.Background(), request)
+			if err != nil {
+				glog.V(0).Infof("assign volume failure %v: %v", request, err)
+				return err
+			}
+			if resp.Error != "" {
+				return fmt.Errorf("assign volume failure %v: %v", request, resp.Error)
+			}
+
+			fileId, host, auth = resp.FileId, resp.Url, security.EncodedJwt(resp.Auth)
+			collection, replication = resp.Collection, resp.Replication
+
+			return nil
+		}); err != nil {
+			return nil, "", "", fmt.Errorf("filerGrpcAddress assign volume: %v", err)
+		}
+
+		fileUrl := fmt.Sprintf("http://%s/%s", host, fileId)
+		uploadResult, err, _ := operation.Upload(fileUrl, name, s.option.Cipher, reader, false, "", nil, auth)
+		if err != nil {
+			glog.V(0).Infof("upload data %v to %s: %v", name, fileUrl, err)
+			return nil, "", "", fmt.Errorf("upload data: %v", err)
+		}
+		if uploadResult.Error != "" {
+			glog.V(0).Infof("upload failure %v to %s: %v", name, fileUrl, uploadResult.Error)
+			return nil, "", "", fmt.Errorf("upload result: %v", uploadResult.Error)
+		}
+		return uploadResult.ToPbFileChunk(fileId, offset), collection, replication, nil
+	}
+}
@@ -0,0 +1,268 @@
+package nfs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+// onc rpc v2 over tcp, as in RFC 5531
+
+const (
+	rpcVersion = 2
+
+	msgCall  = 0
+	msgReply = 1
+
+	replyAccepted = 0
+	replyDenied   = 1
+
+	acceptSuccess      = 0
+	acceptProgUnavail  = 1
+	acceptProgMismatch = 2
+	acceptProcUnavail  = 3
+	acceptGarbageArgs  = 4
+	acceptSystemErr    = 5
+
+	rejectRpcMismatch = 0
+
+	authNone = 0
+	authUnix = 1
+
+	lastFragment = 1 << 31
+	// large enough for the biggest write request
+	maxRecordSize = maxReadWriteSize + 64*1024
+	// requests served in parallel on one connection
+	maxInflightCalls = 32
+)
+
+type authUnixCred struct {
+	machineName string
+	uid         uint32
+	gid         uint32
+	gids        []uint32
+}
+
+type rpcCall struct {
+	xid  uint32
+	prog uint32
+	vers uint32
+	proc uint32
+	cred *authUnixCred // nil if the caller did not send AUTH_UNIX credentials
+	args *xdrReader
+}
+
+// rpcHandler writes the results of the call, and returns the accept status
+type rpcHandler func(call *rpcCall, w *xdrWriter) uint32
+
+type rpcProgram struct {
+	vers  uint32
+	procs map[uint32]rpcHandler
+}
+
+func parseCall(record []byte) (*rpcCall, error) {
+	r := newXdrReader(record)
+	call := &rpcCall{
+		xid: r.uint32(),
+	}
+	if msgType := r.uint32(); r.err == nil && msgType != msgCall {
+		return nil, fmt.Errorf("unexpected message type %d", msgType)
+	}
+	if v := r.uint32(); r.err == nil && v != rpcVersion {
+		return call, fmt.Errorf("unsupported rpc version %d", v)
+	}
+	call.prog, call.vers, call.proc = r.uint32(), r.uint32(), r.uint32()
+
+	flavor, body := r.uint32(), r.opaque(400)
+	r.uint32()
+	r.opaque(400) // verifier
+	if r.err != nil {
+		return nil, r.err
+	}
+	if flavor == authUnix {
+		call.cred = parseAuthUnix(body)
+	}
+	call.args = newXdrReader(r.remaining())
+	return call, nil
+}
+
+func parseAuthUnix(body []byte) *authUnixCred {
+	r := newXdrReader(body)
+	r.uint32() // stamp
+	cred := &authUnixCred{
+		machineName: r.string(255),
+		uid:         r.uint32(),
+		gid:         r.uint32(),
+	}
+	n := r.uint32()
+	for i := uint32(0); i < n && i < 16; i++ {
+		cred.gids = append(cred.gids, r.uint32())
+	}
+	if r.err != nil {
+		return nil
+	}
+	return cred
+}
+
+func writeAcceptedReply(w *xdrWriter, xid, stat uint32) {
+	w.uint32(xid)
+	w.uint32(msgReply)
+	w.uint32(replyAccepted)
+	w.uint32(authNone) // verifier
+	w.uint32(0)
+	w.uint32(stat)
+}
+
+type rpcServer struct {
+	programs map[uint32][]rpcProgram
+	// isAllowed checks the client address of a new connection, any client is allowed if nil
+	isAllowed func(addr net.Addr) bool
+}
+
+func (s *rpcServer) register(prog, vers uint32, procs map[uint32]rpcHandler) {
+	if s.programs == nil {
+		s.programs = make(map[uint32][]rpcProgram)
+	}
+	s.programs[prog] = append(s.programs[prog], rpcProgram{vers: vers, procs: procs})
+}
+
+func (s *rpcServer) serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+func (s *rpcServer) serveConn(conn net.Conn) {
+	defer conn.Close()
+	if s.isAllowed != nil && !s.isAllowed(conn.RemoteAddr()) {
+		glog.V(0).Infof("nfs client %s is not allowed", conn.RemoteAddr())
+		return
+	}
+	glog.V(1).Infof("nfs client %s connected", conn.RemoteAddr())
+
+	reader := bufio.NewReaderSize(conn, 256*1024)
+	var writeLock sync.Mutex
+	inflight := make(chan struct{}, maxInflightCalls)
+	for {
+		record, err := readRecord(reader)
+		if err != nil {
+			if err != io.EOF {
+				glog.V(0).Infof("nfs client %s: %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+		inflight <- struct{}{}
+		go func() {
+			defer func() { <-inflight }()
+			reply := s.handle(record)
+			if reply == nil {
+				return
+			}
+			writeLock.Lock()
+			defer writeLock.Unlock()
+			if _, err := conn.Write(reply); err != nil {
+				glog.V(0).Infof("reply to nfs client %s: %v", conn.RemoteAddr(), err)
+				conn.Close()
+			}
+		}()
+	}
+}
+
+// readRecord reads all fragments of one record
+func readRecord(reader io.Reader) ([]byte, error) {
+	var record []byte
+	header := make([]byte, 4)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return nil, err
+		}
+		h := binary.BigEndian.Uint32(header)
+		size := int(h &^ lastFragment)
+		if len(record)+size > maxRecordSize {
+			return nil, fmt.Errorf("record size %d exceeds %d", len(record)+size, maxRecordSize)
+		}
+		fragment := make([]byte, size)
+		if _, err := io.ReadFull(reader, fragment); err != nil {
+			return nil, err
+		}
+		record = append(record, fragment...)
+		if h&lastFragment != 0 {
+			return record, nil
+		}
+	}
+}
+
+// handle returns the reply record including the record marker, or nil if there should be no reply
+func (s *rpcServer) handle(record []byte) []byte {
+	w := &xdrWriter{buf: make([]byte, 4, 256)}
+
+	call, err := parseCall(record)
+	if err != nil {
+		if call == nil {
+			glog.V(1).Infof("drop rpc message: %v", err)
+			return nil
+		}
+		w.uint32(call.xid)
+		w.uint32(msgReply)
+		w.uint32(replyDenied)
+		w.uint32(rejectRpcMismatch)
+		w.uint32(rpcVersion)
+		w.uint32(rpcVersion)
+		return finishRecord(w)
+	}
+
+	programs, found := s.programs[call.prog]
+	if !found {
+		writeAcceptedReply(w, call.xid, acceptProgUnavail)
+		return finishRecord(w)
+	}
+	var program *rpcProgram
+	low, high := programs[0].vers, programs[0].vers
+	for i, p := range programs {
+		if p.vers == call.vers {
+			program = &programs[i]
+		}
+		if p.vers < low {
+			low = p.vers
+		}
+		if p.vers > high {
+			high = p.vers
+		}
+	}
+	if program == nil {
+		writeAcceptedReply(w, call.xid, acceptProgMismatch)
+		w.uint32(low)
+		w.uint32(high)
+		return finishRecord(w)
+	}
+	handler, found := program.procs[call.proc]
+	if !found {
+		writeAcceptedReply(w, call.xid, acceptProcUnavail)
+		return finishRecord(w)
+	}
+
+	results := &xdrWriter{}
+	stat := handler(call, results)
+	if stat == acceptSuccess && call.args.err != nil {
+		stat = acceptGarbageArgs
+	}
+	writeAcceptedReply(w, call.xid, stat)
+	if stat == acceptSuccess {
+		w.buf = append(w.buf, results.buf...)
+	}
+	return finishRecord(w)
+}
+
+func finishRecord(w *xdrWriter) []byte {
+	binary.BigEndian.PutUint32(w.buf, uint32(len(w.buf)-4)|lastFragment)
+	return w.buf
+}
@@ -0,0 +1,124 @@
+package nfs
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// xdr encoding as in RFC 4506, only the types used by the rpc, mount and nfs v3 protocols
+
+var errXdrShort = errors.New("xdr: short buffer")
+
+type xdrReader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func newXdrReader(buf []byte) *xdrReader {
+	return &xdrReader{buf: buf}
+}
+
+func (r *xdrReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || r.pos+n > len(r.buf) {
+		r.err = errXdrShort
+		return nil
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *xdrReader) uint32() uint32 {
+	b := r.next(4)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint32(b)
+}
+
+func (r *xdrReader) uint64() uint64 {
+	b := r.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.BigEndian.Uint64(b)
+}
+
+func (r *xdrReader) bool() bool {
+	return r.uint32() != 0
+}
+
+// fixedOpaque reads n bytes, padded to a multiple of 4
+func (r *xdrReader) fixedOpaque(n int) []byte {
+	b := r.next(n)
+	r.next(padding(n))
+	return b
+}
+
+// opaque reads variable length bytes of at most max bytes
+func (r *xdrReader) opaque(max int) []byte {
+	n := int(r.uint32())
+	if r.err == nil && n > max {
+		r.err = errors.New("xdr: opaque too long")
+		return nil
+	}
+	return r.fixedOpaque(n)
+}
+
+func (r *xdrReader) string(max int) string {
+	return string(r.opaque(max))
+}
+
+func (r *xdrReader) remaining() []byte {
+	if r.err != nil {
+		return nil
+	}
+	return r.buf[r.pos:]
+}
+
+type xdrWriter struct {
+	buf []byte
+}
+
+func (w *xdrWriter) uint32(v uint32) {
+	w.buf = append(w.buf, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func (w *xdrWriter) uint64(v uint64) {
+	w.uint32(uint32(v >> 32))
+	w.uint32(uint32(v))
+}
+
+func (w *xdrWriter) bool(v bool) {
+	if v {
+		w.uint32(1)
+	} else {
+		w.uint32(0)
+	}
+}
+
+func (w *xdrWriter) fixedOpaque(b []byte) {
+	w.buf = append(w.buf, b...)
+	w.buf = append(w.buf, make([]byte, padding(len(b)))...)
+}
+
+func (w *xdrWriter) opaque(b []byte) {
+	w.uint32(uint32(len(b)))
+	w.fixedOpaque(b)
+}
+
+func (w *xdrWriter) string(s string) {
+	w.opaque([]byte(s))
+}
+
+func (w *xdrWriter) len() int {
+	return len(w.buf)
+}
+
+func padding(n int) int {
+	return (4 - n%4) % 4
+}
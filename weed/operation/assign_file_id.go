@@ -114,6 +114,7 @@ type StorageOption struct {
 	TtlSeconds        int32
 	Fsync             bool
 	VolumeGrowthCount uint32
+	Dedup             bool
 }
 
 func (so *StorageOption) TtlString() string {
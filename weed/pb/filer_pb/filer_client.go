@@ -3,7 +3,6 @@ package filer_pb
 import (
 	"context"
 	"errors"
-	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -115,7 +114,7 @@ func doSeaweedList(client SeaweedFilerClient, fullDirPath util.FullPath, prefix
 	defer cancel()
 	stream, err := client.ListEntries(ctx, request)
 	if err != nil {
-		return fmt.Errorf("list %s: %v", fullDirPath, err)
+		return WrapError(err, "list %s", fullDirPath)
 	}
 
 	var prevEntry *Entry
@@ -161,7 +160,7 @@ func Exists(filerClient FilerClient, parentDirectoryPath string, entryName strin
 				return nil
 			}
 			glog.V(0).Infof("exists entry %v: %v", request, err)
-			return fmt.Errorf("exists entry %s/%s: %v", parentDirectoryPath, entryName, err)
+			return WrapError(err, "exists entry %s/%s", parentDirectoryPath, entryName)
 		}
 
 		exists = resp.Entry.IsDirectory == isDirectory
@@ -199,7 +198,7 @@ func Mkdir(filerClient FilerClient, parentDirectoryPath string, dirName string,
 		glog.V(1).Infof("mkdir: %v", request)
 		if err := CreateEntry(client, request); err != nil {
 			glog.V(0).Infof("mkdir %v: %v", request, err)
-			return fmt.Errorf("mkdir %s/%s: %v", parentDirectoryPath, dirName, err)
+			return WrapError(err, "mkdir %s/%s", parentDirectoryPath, dirName)
 		}
 
 		return nil
@@ -230,7 +229,7 @@ func MkFile(filerClient FilerClient, parentDirectoryPath string, fileName string
 		glog.V(1).Infof("create file: %s/%s", parentDirectoryPath, fileName)
 		if err := CreateEntry(client, request); err != nil {
 			glog.V(0).Infof("create file %v:%v", request, err)
-			return fmt.Errorf("create file %s/%s: %v", parentDirectoryPath, fileName, err)
+			return WrapError(err, "create file %s/%s", parentDirectoryPath, fileName)
 		}
 
 		return nil
@@ -0,0 +1,153 @@
+package filer_pb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/chrislusf/seaweedfs/weed/security"
+)
+
+// NewIdentityFilerClient sends the signed caller identity with every request to the filer
+func NewIdentityFilerClient(client SeaweedFilerClient, identity security.EncodedJwt) SeaweedFilerClient {
+	return &identityFilerClient{
+		SeaweedFilerClient: client,
+		identity:           identity,
+	}
+}
+
+// NewServiceFilerClient sends the requests as a trusted service, which acts as root on the filer.
+// It is meant for administrative callers, and needs the filer identity signing key of this process.
+func NewServiceFilerClient(client SeaweedFilerClient) SeaweedFilerClient {
+	encoded := security.GenProcessIdentityJwt(0, nil)
+	if encoded == "" {
+		return client
+	}
+	return NewIdentityFilerClient(client, encoded)
+}
+
+// WithServiceIdentity lets the function send its requests as a trusted service, see NewServiceFilerClient
+func WithServiceIdentity(fn func(SeaweedFilerClient) error) func(SeaweedFilerClient) error {
+	return func(client SeaweedFilerClient) error {
+		return fn(NewServiceFilerClient(client))
+	}
+}
+
+// identityFilerClient attaches the signed caller identity to all requests,
+// so no request falls back to the permissions of another caller.
+type identityFilerClient struct {
+	SeaweedFilerClient
+	identity security.EncodedJwt
+}
+
+func (c *identityFilerClient) LookupDirectoryEntry(ctx context.Context, in *LookupDirectoryEntryRequest, opts ...grpc.CallOption) (*LookupDirectoryEntryResponse, error) {
+	return c.SeaweedFilerClient.LookupDirectoryEntry(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (SeaweedFiler_ListEntriesClient, error) {
+	return c.SeaweedFilerClient.ListEntries(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) SearchEntries(ctx context.Context, in *SearchEntriesRequest, opts ...grpc.CallOption) (SeaweedFiler_SearchEntriesClient, error) {
+	return c.SeaweedFilerClient.SearchEntries(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) CreateEntry(ctx context.Context, in *CreateEntryRequest, opts ...grpc.CallOption) (*CreateEntryResponse, error) {
+	return c.SeaweedFilerClient.CreateEntry(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) UpdateEntry(ctx context.Context, in *UpdateEntryRequest, opts ...grpc.CallOption) (*UpdateEntryResponse, error) {
+	return c.SeaweedFilerClient.UpdateEntry(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) AppendToEntry(ctx context.Context, in *AppendToEntryRequest, opts ...grpc.CallOption) (*AppendToEntryResponse, error) {
+	return c.SeaweedFilerClient.AppendToEntry(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) DeleteEntry(ctx context.Context, in *DeleteEntryRequest, opts ...grpc.CallOption) (*DeleteEntryResponse, error) {
+	return c.SeaweedFilerClient.DeleteEntry(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) AtomicRenameEntry(ctx context.Context, in *AtomicRenameEntryRequest, opts ...grpc.CallOption) (*AtomicRenameEntryResponse, error) {
+	return c.SeaweedFilerClient.AtomicRenameEntry(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) AssignVolume(ctx context.Context, in *AssignVolumeRequest, opts ...grpc.CallOption) (*AssignVolumeResponse, error) {
+	return c.SeaweedFilerClient.AssignVolume(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) LookupVolume(ctx context.Context, in *LookupVolumeRequest, opts ...grpc.CallOption) (*LookupVolumeResponse, error) {
+	return c.SeaweedFilerClient.LookupVolume(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) CollectionList(ctx context.Context, in *CollectionListRequest, opts ...grpc.CallOption) (*CollectionListResponse, error) {
+	return c.SeaweedFilerClient.CollectionList(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) DeleteCollection(ctx context.Context, in *DeleteCollectionRequest, opts ...grpc.CallOption) (*DeleteCollectionResponse, error) {
+	return c.SeaweedFilerClient.DeleteCollection(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) Statistics(ctx context.Context, in *StatisticsRequest, opts ...grpc.CallOption) (*StatisticsResponse, error) {
+	return c.SeaweedFilerClient.Statistics(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) GetFilerConfiguration(ctx context.Context, in *GetFilerConfigurationRequest, opts ...grpc.CallOption) (*GetFilerConfigurationResponse, error) {
+	return c.SeaweedFilerClient.GetFilerConfiguration(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) SubscribeMetadata(ctx context.Context, in *SubscribeMetadataRequest, opts ...grpc.CallOption) (SeaweedFiler_SubscribeMetadataClient, error) {
+	return c.SeaweedFilerClient.SubscribeMetadata(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) SubscribeLocalMetadata(ctx context.Context, in *SubscribeMetadataRequest, opts ...grpc.CallOption) (SeaweedFiler_SubscribeLocalMetadataClient, error) {
+	return c.SeaweedFilerClient.SubscribeLocalMetadata(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) KeepConnected(ctx context.Context, opts ...grpc.CallOption) (SeaweedFiler_KeepConnectedClient, error) {
+	return c.SeaweedFilerClient.KeepConnected(security.WithIdentityJwt(ctx, c.identity), opts...)
+}
+
+func (c *identityFilerClient) LocateBroker(ctx context.Context, in *LocateBrokerRequest, opts ...grpc.CallOption) (*LocateBrokerResponse, error) {
+	return c.SeaweedFilerClient.LocateBroker(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) KvGet(ctx context.Context, in *KvGetRequest, opts ...grpc.CallOption) (*KvGetResponse, error) {
+	return c.SeaweedFilerClient.KvGet(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) KvPut(ctx context.Context, in *KvPutRequest, opts ...grpc.CallOption) (*KvPutResponse, error) {
+	return c.SeaweedFilerClient.KvPut(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) AcquireLock(ctx context.Context, in *AcquireLockRequest, opts ...grpc.CallOption) (*AcquireLockResponse, error) {
+	return c.SeaweedFilerClient.AcquireLock(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) ReleaseLock(ctx context.Context, in *ReleaseLockRequest, opts ...grpc.CallOption) (*ReleaseLockResponse, error) {
+	return c.SeaweedFilerClient.ReleaseLock(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) QueryLock(ctx context.Context, in *QueryLockRequest, opts ...grpc.CallOption) (*QueryLockResponse, error) {
+	return c.SeaweedFilerClient.QueryLock(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) AcquireLease(ctx context.Context, in *AcquireLeaseRequest, opts ...grpc.CallOption) (*AcquireLeaseResponse, error) {
+	return c.SeaweedFilerClient.AcquireLease(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) SetQuota(ctx context.Context, in *SetQuotaRequest, opts ...grpc.CallOption) (*SetQuotaResponse, error) {
+	return c.SeaweedFilerClient.SetQuota(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) GetQuota(ctx context.Context, in *GetQuotaRequest, opts ...grpc.CallOption) (*GetQuotaResponse, error) {
+	return c.SeaweedFilerClient.GetQuota(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) ListQuotas(ctx context.Context, in *ListQuotasRequest, opts ...grpc.CallOption) (*ListQuotasResponse, error) {
+	return c.SeaweedFilerClient.ListQuotas(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
+
+func (c *identityFilerClient) CopyFileRange(ctx context.Context, in *CopyFileRangeRequest, opts ...grpc.CallOption) (*CopyFileRangeResponse, error) {
+	return c.SeaweedFilerClient.CopyFileRange(security.WithIdentityJwt(ctx, c.identity), in, opts...)
+}
@@ -0,0 +1,56 @@
+package filer_pb
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/chrislusf/seaweedfs/weed/security"
+)
+
+// identityRecordingConn records the identity sent with each request, without sending it
+type identityRecordingConn struct {
+	identities map[string]string
+}
+
+func (c *identityRecordingConn) record(ctx context.Context, method string) error {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	values := md.Get(security.IdentityMetadataKey)
+	if len(values) > 0 {
+		c.identities[method] = values[0]
+	}
+	return fmt.Errorf("not sent")
+}
+
+func (c *identityRecordingConn) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	return c.record(ctx, method)
+}
+
+func (c *identityRecordingConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, c.record(ctx, method)
+}
+
+func TestIdentityFilerClientSignsAllRequests(t *testing.T) {
+	conn := &identityRecordingConn{identities: make(map[string]string)}
+	client := NewIdentityFilerClient(NewSeaweedFilerClient(conn), "signed")
+
+	clientValue := reflect.ValueOf(client)
+	clientType := reflect.TypeOf((*SeaweedFilerClient)(nil)).Elem()
+	for i := 0; i < clientType.NumMethod(); i++ {
+		method := clientType.Method(i)
+		args := []reflect.Value{reflect.ValueOf(context.Background())}
+		if method.Type.NumIn() > 2 {
+			// the request, followed by the variadic call options
+			args = append(args, reflect.New(method.Type.In(1).Elem()))
+		}
+		clientValue.MethodByName(method.Name).Call(args)
+
+		if identity := conn.identities["/filer_pb.SeaweedFiler/"+method.Name]; identity != "signed" {
+			t.Errorf("%s sent identity %q", method.Name, identity)
+		}
+	}
+}
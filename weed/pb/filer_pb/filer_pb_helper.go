@@ -10,6 +10,8 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/storage/needle"
 	"github.com/golang/protobuf/proto"
 	"github.com/viant/ptrie"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func ToFileIdObject(fileIdStr string) (*FileId, error) {
@@ -89,7 +91,7 @@ func CreateEntry(client SeaweedFilerClient, request *CreateEntryRequest) error {
 	resp, err := client.CreateEntry(context.Background(), request)
 	if err != nil {
 		glog.V(1).Infof("create entry %s/%s %v: %v", request.Directory, request.Entry.Name, request.OExcl, err)
-		return fmt.Errorf("CreateEntry: %v", err)
+		return WrapError(err, "CreateEntry")
 	}
 	if resp.Error != "" {
 		glog.V(1).Infof("create entry %s/%s %v: %v", request.Directory, request.Entry.Name, request.OExcl, resp.Error)
@@ -102,7 +104,7 @@ func UpdateEntry(client SeaweedFilerClient, request *UpdateEntryRequest) error {
 	_, err := client.UpdateEntry(context.Background(), request)
 	if err != nil {
 		glog.V(1).Infof("update entry %s/%s :%v", request.Directory, request.Entry.Name, err)
-		return fmt.Errorf("UpdateEntry: %v", err)
+		return WrapError(err, "UpdateEntry")
 	}
 	return nil
 }
@@ -114,7 +116,7 @@ func LookupEntry(client SeaweedFilerClient, request *LookupDirectoryEntryRequest
 			return nil, ErrNotFound
 		}
 		glog.V(3).Infof("read %s/%v: %v", request.Directory, request.Name, err)
-		return nil, fmt.Errorf("LookupEntry1: %v", err)
+		return nil, WrapError(err, "LookupEntry1")
 	}
 	if resp.Entry == nil {
 		return nil, ErrNotFound
@@ -124,6 +126,29 @@ func LookupEntry(client SeaweedFilerClient, request *LookupDirectoryEntryRequest
 
 var ErrNotFound = errors.New("filer: no entry is found in filer store")
 
+var ErrPermissionDenied = errors.New("filer: permission denied")
+
+// IsPermissionDenied checks the grpc status code, which the filer sets when a permission check fails
+func IsPermissionDenied(err error) bool {
+	return err == ErrPermissionDenied || status.Code(err) == codes.PermissionDenied
+}
+
+// WrapError adds context to an error from the filer, keeping its grpc status code
+func WrapError(err error, format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+	if s, ok := status.FromError(err); ok && s.Code() != codes.OK && s.Code() != codes.Unknown {
+		return status.Errorf(s.Code(), "%s: %s", message, s.Message())
+	}
+	return fmt.Errorf("%s: %v", message, err)
+}
+
+var ErrQuotaExceeded = errors.New("filer: directory quota exceeded")
+
+// IsQuotaExceeded checks the grpc status code, which the filer sets when a directory quota is exceeded
+func IsQuotaExceeded(err error) bool {
+	return err != nil && (err == ErrQuotaExceeded || status.Code(err) == codes.ResourceExhausted || strings.Contains(err.Error(), ErrQuotaExceeded.Error()))
+}
+
 func IsCreate(event *SubscribeMetadataResponse) bool {
 	return event.EventNotification.NewEntry != nil && event.EventNotification.OldEntry == nil
 }
@@ -1,9 +1,12 @@
 package filer_pb
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestFileIdSize(t *testing.T) {
@@ -15,3 +18,19 @@ func TestFileIdSize(t *testing.T) {
 	println(len(fileIdStr))
 	println(len(bytes))
 }
+
+func TestIsPermissionDenied(t *testing.T) {
+	denied := status.Error(codes.PermissionDenied, "delete /a: filer: permission denied")
+	if !IsPermissionDenied(denied) {
+		t.Errorf("status code should be permission denied")
+	}
+	if !IsPermissionDenied(WrapError(WrapError(denied, "CreateEntry"), "mkdir %s", "/a")) {
+		t.Errorf("wrapped error should keep the status code")
+	}
+	if IsPermissionDenied(fmt.Errorf("file name: filer: permission denied")) {
+		t.Errorf("error message should not be parsed")
+	}
+	if IsPermissionDenied(nil) {
+		t.Errorf("nil error")
+	}
+}
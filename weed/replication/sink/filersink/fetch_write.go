@@ -124,7 +124,7 @@ var _ = filer_pb.FilerClient(&FilerSink{})
 func (fs *FilerSink) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
 
 	return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
-		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		client := filer_pb.NewServiceFilerClient(filer_pb.NewSeaweedFilerClient(grpcConnection))
 		return fn(client)
 	}, fs.grpcAddress, fs.grpcDialOption)
 
@@ -45,7 +45,7 @@ var _ = filer_pb.FilerClient(&S3ApiServer{})
 func (s3a *S3ApiServer) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
 
 	return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
-		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		client := filer_pb.NewServiceFilerClient(filer_pb.NewSeaweedFilerClient(grpcConnection))
 		return fn(client)
 	}, s3a.option.FilerGrpcAddress, s3a.option.GrpcDialOption)
 
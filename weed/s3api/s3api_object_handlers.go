@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/chrislusf/seaweedfs/weed/s3api/s3err"
+	"github.com/chrislusf/seaweedfs/weed/security"
 
 	"github.com/gorilla/mux"
 
@@ -290,6 +291,8 @@ func (s3a *S3ApiServer) proxyToFiler(w http.ResponseWriter, r *http.Request, des
 		}
 	}
 
+	security.SetServiceIdentity(proxyReq)
+
 	resp, postErr := client.Do(proxyReq)
 
 	if postErr != nil {
@@ -339,6 +342,8 @@ func (s3a *S3ApiServer) putToFiler(r *http.Request, uploadUrl string, dataReader
 		}
 	}
 
+	security.SetServiceIdentity(proxyReq)
+
 	resp, postErr := client.Do(proxyReq)
 
 	if postErr != nil {
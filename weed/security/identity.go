@@ -0,0 +1,131 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	jwt "github.com/dgrijalva/jwt-go"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// IdentityMetadataKey carries the signed caller identity in grpc metadata
+	IdentityMetadataKey = "seaweedfs-identity"
+	// IdentityHeader carries the signed caller identity in http requests
+	IdentityHeader = "Seaweed-Identity"
+)
+
+var (
+	identitySigningOnce     sync.Once
+	identitySigningKey      SigningKey
+	identityExpiresAfterSec int
+)
+
+type SeaweedIdentityClaims struct {
+	Uid  uint32   `json:"uid"`
+	Gids []uint32 `json:"gids,omitempty"`
+	jwt.StandardClaims
+}
+
+func GenIdentityJwt(signingKey SigningKey, expiresAfterSec int, uid uint32, gids []uint32) EncodedJwt {
+	if len(signingKey) == 0 {
+		return ""
+	}
+
+	claims := SeaweedIdentityClaims{
+		uid,
+		gids,
+		jwt.StandardClaims{},
+	}
+	if expiresAfterSec > 0 {
+		claims.ExpiresAt = time.Now().Add(time.Second * time.Duration(expiresAfterSec)).Unix()
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	encoded, e := t.SignedString([]byte(signingKey))
+	if e != nil {
+		glog.V(0).Infof("Failed to sign identity claims %+v: %v", t.Claims, e)
+		return ""
+	}
+	return EncodedJwt(encoded)
+}
+
+func DecodeIdentityJwt(signingKey SigningKey, tokenString EncodedJwt) (*SeaweedIdentityClaims, error) {
+	// check exp, nbf
+	token, err := jwt.ParseWithClaims(string(tokenString), &SeaweedIdentityClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unknown token method")
+		}
+		return []byte(signingKey), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	claims, ok := token.Claims.(*SeaweedIdentityClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid identity token")
+	}
+	return claims, nil
+}
+
+// WithIdentityJwt attaches the signed identity to an outgoing grpc context
+func WithIdentityJwt(ctx context.Context, encoded EncodedJwt) context.Context {
+	if encoded == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, IdentityMetadataKey, string(encoded))
+}
+
+// GetIdentityJwt reads the signed identity from an incoming grpc context
+func GetIdentityJwt(ctx context.Context) EncodedJwt {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(IdentityMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return EncodedJwt(values[0])
+}
+
+// GetIdentityJwtFromRequest reads the signed identity from an http request
+func GetIdentityJwtFromRequest(r *http.Request) EncodedJwt {
+	return EncodedJwt(r.Header.Get(IdentityHeader))
+}
+
+// LoadIdentitySigningKey reads the [jwt.filer_identity] section of security.toml.
+// An empty key means the filer does not enforce permissions.
+func LoadIdentitySigningKey(config util.Configuration) (SigningKey, int) {
+	key := config.GetString("jwt.filer_identity.key")
+	expiresAfterSec := config.GetInt("jwt.filer_identity.expires_after_seconds")
+	if expiresAfterSec <= 0 {
+		expiresAfterSec = 60
+	}
+	return SigningKey(key), expiresAfterSec
+}
+
+// IdentitySigningKey returns the identity signing key of this process, loaded once from security.toml
+func IdentitySigningKey() (SigningKey, int) {
+	identitySigningOnce.Do(func() {
+		identitySigningKey, identityExpiresAfterSec = LoadIdentitySigningKey(util.GetViper())
+	})
+	return identitySigningKey, identityExpiresAfterSec
+}
+
+// GenProcessIdentityJwt signs an identity with the signing key of this process
+func GenProcessIdentityJwt(uid uint32, gids []uint32) EncodedJwt {
+	key, expiresAfterSec := IdentitySigningKey()
+	return GenIdentityJwt(key, expiresAfterSec, uid, gids)
+}
+
+// SetServiceIdentity marks an http request to the filer as coming from a trusted service, which acts as root
+func SetServiceIdentity(r *http.Request) {
+	if encoded := GenProcessIdentityJwt(0, nil); encoded != "" {
+		r.Header.Set(IdentityHeader, string(encoded))
+	}
+}
@@ -21,6 +21,14 @@ func (fs *FilerServer) LookupDirectoryEntry(ctx context.Context, req *filer_pb.L
 
 	glog.V(4).Infof("LookupDirectoryEntry %s", filepath.Join(req.Directory, req.Name))
 
+	identity, err := fs.identityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err = fs.checkLookup(ctx, identity, util.JoinPath(req.Directory, req.Name)); err != nil {
+		return nil, err
+	}
+
 	entry, err := fs.filer.FindEntry(ctx, util.JoinPath(req.Directory, req.Name))
 	if err == filer_pb.ErrNotFound {
 		return &filer_pb.LookupDirectoryEntryResponse{}, err
@@ -31,7 +39,7 @@ func (fs *FilerServer) LookupDirectoryEntry(ctx context.Context, req *filer_pb.L
 	}
 
 	return &filer_pb.LookupDirectoryEntryResponse{
-		Entry: &filer_pb.Entry{
+		Entry: withoutUnreadableContent(identity, entry, &filer_pb.Entry{
 			Name:            req.Name,
 			IsDirectory:     entry.IsDirectory(),
 			Attributes:      filer.EntryAttributeToPb(entry),
@@ -40,7 +48,7 @@ func (fs *FilerServer) LookupDirectoryEntry(ctx context.Context, req *filer_pb.L
 			HardLinkId:      entry.HardLinkId,
 			HardLinkCounter: entry.HardLinkCounter,
 			Content:         entry.Content,
-		},
+		}),
 	}, nil
 }
 
@@ -48,6 +56,14 @@ func (fs *FilerServer) ListEntries(req *filer_pb.ListEntriesRequest, stream file
 
 	glog.V(4).Infof("ListEntries %v", req)
 
+	identity, err := fs.identityFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+	if err = fs.checkReadDirectory(stream.Context(), util.FullPath(req.Directory)); err != nil {
+		return err
+	}
+
 	limit := int(req.Limit)
 	if limit == 0 {
 		limit = fs.option.DirListingLimit
@@ -66,7 +82,7 @@ func (fs *FilerServer) ListEntries(req *filer_pb.ListEntriesRequest, stream file
 		lastFileName, listErr = fs.filer.StreamListDirectoryEntries(stream.Context(), util.FullPath(req.Directory), lastFileName, includeLastFile, int64(paginationLimit), req.Prefix, "", func(entry *filer.Entry) bool {
 			hasEntries = true
 			if err = stream.Send(&filer_pb.ListEntriesResponse{
-				Entry: &filer_pb.Entry{
+				Entry: withoutUnreadableContent(identity, entry, &filer_pb.Entry{
 					Name:            entry.Name(),
 					IsDirectory:     entry.IsDirectory(),
 					Chunks:          entry.Chunks,
@@ -75,7 +91,7 @@ func (fs *FilerServer) ListEntries(req *filer_pb.ListEntriesRequest, stream file
 					HardLinkId:      entry.HardLinkId,
 					HardLinkCounter: entry.HardLinkCounter,
 					Content:         entry.Content,
-				},
+				}),
 			}); err != nil {
 				return false
 			}
@@ -104,6 +120,39 @@ func (fs *FilerServer) ListEntries(req *filer_pb.ListEntriesRequest, stream file
 	return nil
 }
 
+func (fs *FilerServer) SearchEntries(req *filer_pb.SearchEntriesRequest, stream filer_pb.SeaweedFiler_SearchEntriesServer) (err error) {
+
+	glog.V(4).Infof("SearchEntries %v", req)
+
+	identity, err := fs.identityFromContext(stream.Context())
+	if err != nil {
+		return err
+	}
+	if err = fs.checkReadDirectory(stream.Context(), util.FullPath(req.Directory)); err != nil {
+		return err
+	}
+
+	limit := int64(req.Limit)
+	filter := filer.NewSearchFilter(req)
+
+	searchErr := fs.filer.SearchEntries(stream.Context(), util.FullPath(req.Directory), filter, func(entry *filer.Entry) bool {
+		dir, _ := entry.FullPath.DirAndName()
+		if err = stream.Send(&filer_pb.SearchEntriesResponse{
+			Directory: dir,
+			Entry:     withoutUnreadableContent(identity, entry, entry.ToProtoEntry()),
+		}); err != nil {
+			return false
+		}
+		limit--
+		return limit != 0
+	})
+
+	if searchErr != nil {
+		return searchErr
+	}
+	return err
+}
+
 func (fs *FilerServer) LookupVolume(ctx context.Context, req *filer_pb.LookupVolumeRequest) (*filer_pb.LookupVolumeResponse, error) {
 
 	resp := &filer_pb.LookupVolumeResponse{
@@ -156,12 +205,17 @@ func (fs *FilerServer) CreateEntry(ctx context.Context, req *filer_pb.CreateEntr
 
 	resp = &filer_pb.CreateEntryResponse{}
 
+	identity, err := fs.identityFromContext(ctx)
+	if err != nil {
+		return resp, err
+	}
+
 	chunks, garbage, err2 := fs.cleanupChunks(util.Join(req.Directory, req.Entry.Name), nil, req.Entry)
 	if err2 != nil {
 		return &filer_pb.CreateEntryResponse{}, fmt.Errorf("CreateEntry cleanupChunks %s %s: %v", req.Directory, req.Entry.Name, err2)
 	}
 
-	createErr := fs.filer.CreateEntry(ctx, &filer.Entry{
+	newEntry := &filer.Entry{
 		FullPath:        util.JoinPath(req.Directory, req.Entry.Name),
 		Attr:            filer.PbToEntryAttribute(req.Entry.Attributes),
 		Chunks:          chunks,
@@ -169,7 +223,16 @@ func (fs *FilerServer) CreateEntry(ctx context.Context, req *filer_pb.CreateEntr
 		HardLinkId:      filer.HardLinkId(req.Entry.HardLinkId),
 		HardLinkCounter: req.Entry.HardLinkCounter,
 		Content:         req.Entry.Content,
-	}, req.OExcl, req.IsFromOtherCluster, req.Signatures)
+	}
+
+	if err = fs.checkCreate(ctx, identity, newEntry); err != nil {
+		return resp, err
+	}
+	if err = fs.filer.CheckQuota(ctx, newEntry); err != nil {
+		return resp, quotaError(err)
+	}
+
+	createErr := fs.filer.CreateEntry(ctx, newEntry, req.OExcl, req.IsFromOtherCluster, req.Signatures)
 
 	if createErr == nil {
 		fs.filer.DeleteChunks(garbage)
@@ -185,6 +248,11 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 
 	glog.V(4).Infof("UpdateEntry %v", req)
 
+	identity, err := fs.identityFromContext(ctx)
+	if err != nil {
+		return &filer_pb.UpdateEntryResponse{}, err
+	}
+
 	fullpath := util.Join(req.Directory, req.Entry.Name)
 	entry, err := fs.filer.FindEntry(ctx, util.FullPath(fullpath))
 	if err != nil {
@@ -215,6 +283,9 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 		if req.Entry.Attributes.Mtime != 0 {
 			newEntry.Attr.Mtime = time.Unix(req.Entry.Attributes.Mtime, 0)
 		}
+		if req.Entry.Attributes.Atime != 0 {
+			newEntry.Attr.Atime = time.Unix(req.Entry.Attributes.Atime, 0)
+		}
 		if req.Entry.Attributes.FileMode != 0 {
 			newEntry.Attr.Mode = os.FileMode(req.Entry.Attributes.FileMode)
 		}
@@ -230,6 +301,13 @@ func (fs *FilerServer) UpdateEntry(ctx context.Context, req *filer_pb.UpdateEntr
 		return &filer_pb.UpdateEntryResponse{}, err
 	}
 
+	if err = fs.checkUpdate(ctx, identity, entry, newEntry); err != nil {
+		return &filer_pb.UpdateEntryResponse{}, err
+	}
+	if err = fs.filer.CheckQuotaChange(entry, newEntry); err != nil {
+		return &filer_pb.UpdateEntryResponse{}, quotaError(err)
+	}
+
 	if err = fs.filer.UpdateEntry(ctx, entry, newEntry); err == nil {
 		fs.filer.DeleteChunks(garbage)
 
@@ -283,6 +361,11 @@ func (fs *FilerServer) AppendToEntry(ctx context.Context, req *filer_pb.AppendTo
 
 	glog.V(4).Infof("AppendToEntry %v", req)
 
+	identity, err := fs.identityFromContext(ctx)
+	if err != nil {
+		return &filer_pb.AppendToEntryResponse{}, err
+	}
+
 	fullpath := util.NewFullPath(req.Directory, req.EntryName)
 	var offset int64 = 0
 	entry, err := fs.filer.FindEntry(ctx, fullpath)
@@ -297,7 +380,16 @@ func (fs *FilerServer) AppendToEntry(ctx context.Context, req *filer_pb.AppendTo
 				Gid:    OS_GID,
 			},
 		}
+		if identity != nil {
+			entry.Uid, entry.Gid = identity.Uid, identity.Gid()
+		}
+		if err = fs.checkCreate(ctx, identity, entry); err != nil {
+			return &filer_pb.AppendToEntryResponse{}, err
+		}
 	} else {
+		if identity != nil && identity.Uid != entry.Uid && !filer.HasPermission(entry, identity, filer.PermissionWrite) {
+			return &filer_pb.AppendToEntryResponse{}, permissionDenied("append", fullpath)
+		}
 		offset = int64(filer.TotalSize(entry.Chunks))
 	}
 
@@ -314,6 +406,10 @@ func (fs *FilerServer) AppendToEntry(ctx context.Context, req *filer_pb.AppendTo
 		glog.V(0).Infof("MaybeManifestize: %v", err)
 	}
 
+	if err = fs.filer.CheckQuota(ctx, entry); err != nil {
+		return &filer_pb.AppendToEntryResponse{}, quotaError(err)
+	}
+
 	err = fs.filer.CreateEntry(context.Background(), entry, false, false, nil)
 
 	return &filer_pb.AppendToEntryResponse{}, err
@@ -323,6 +419,14 @@ func (fs *FilerServer) DeleteEntry(ctx context.Context, req *filer_pb.DeleteEntr
 
 	glog.V(4).Infof("DeleteEntry %v", req)
 
+	identity, err := fs.identityFromContext(ctx)
+	if err == nil {
+		err = fs.checkDelete(ctx, identity, util.JoinPath(req.Directory, req.Name), req.IsRecursive)
+	}
+	if err != nil {
+		return &filer_pb.DeleteEntryResponse{}, err
+	}
+
 	err = fs.filer.DeleteEntryMetaAndData(ctx, util.JoinPath(req.Directory, req.Name), req.IsRecursive, req.IgnoreRecursiveError, req.IsDeleteData, req.IsFromOtherCluster, req.Signatures)
 	resp = &filer_pb.DeleteEntryResponse{}
 	if err != nil && err != filer_pb.ErrNotFound {
@@ -384,6 +488,10 @@ func (fs *FilerServer) DeleteCollection(ctx context.Context, req *filer_pb.Delet
 
 	glog.V(4).Infof("DeleteCollection %v", req)
 
+	if err = fs.checkAdmin(ctx, "delete collection "+req.GetCollection()); err != nil {
+		return nil, err
+	}
+
 	err = fs.filer.MasterClient.WithClient(func(client master_pb.SeaweedClient) error {
 		_, err := client.CollectionDelete(context.Background(), &master_pb.CollectionDeleteRequest{
 			Name: req.GetCollection(),
@@ -450,6 +558,10 @@ func (fs *FilerServer) KeepConnected(stream filer_pb.SeaweedFiler_KeepConnectedS
 		return err
 	}
 
+	if req.LockClientId != "" {
+		return fs.keepLockClientConnected(stream, req)
+	}
+
 	clientName := fmt.Sprintf("%s:%d", req.Name, req.GrpcPort)
 	m := make(map[string]bool)
 	for _, tp := range req.Resources {
@@ -0,0 +1,164 @@
+package weed_server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/util/chunk_cache"
+)
+
+// CopyFileRange copies a byte range between two files by adding the chunks of the source file to the destination file.
+// Only the parts not aligned to the source chunks are read and written again, by the filer.
+func (fs *FilerServer) CopyFileRange(ctx context.Context, req *filer_pb.CopyFileRangeRequest) (*filer_pb.CopyFileRangeResponse, error) {
+
+	glog.V(3).Infof("CopyFileRange %v", req)
+
+	if req.SrcOffset < 0 || req.DstOffset < 0 || req.Length < 0 {
+		return nil, fmt.Errorf("invalid range to copy: %v", req)
+	}
+
+	identity, err := fs.identityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	srcPath, dstPath := util.NewFullPath(req.SrcDirectory, req.SrcName), util.NewFullPath(req.DstDirectory, req.DstName)
+	srcEntry, err := fs.filer.FindEntry(ctx, srcPath)
+	if err != nil {
+		return nil, fmt.Errorf("find %s: %v", srcPath, err)
+	}
+	dstEntry, err := fs.filer.FindEntry(ctx, dstPath)
+	if err != nil {
+		return nil, fmt.Errorf("find %s: %v", dstPath, err)
+	}
+	if srcEntry.IsDirectory() || dstEntry.IsDirectory() {
+		return nil, fmt.Errorf("copy range between %s and %s: is a directory", srcPath, dstPath)
+	}
+	if len(srcEntry.Content) > 0 || len(dstEntry.Content) > 0 {
+		return nil, fmt.Errorf("copy range between %s and %s: inline content is not supported", srcPath, dstPath)
+	}
+	if err = fs.checkRead(ctx, identity, srcEntry); err != nil {
+		return nil, err
+	}
+	if identity != nil && !identity.IsRoot() && !filer.HasPermission(dstEntry, identity, filer.PermissionWrite) {
+		return nil, permissionDenied("write", dstPath)
+	}
+
+	srcSize := int64(srcEntry.Size())
+	length := req.Length
+	if req.SrcOffset >= srcSize {
+		length = 0
+	} else if length > srcSize-req.SrcOffset {
+		length = srcSize - req.SrcOffset
+	}
+	if length == 0 {
+		return &filer_pb.CopyFileRangeResponse{Entry: dstEntry.ToProtoEntry()}, nil
+	}
+	if srcPath == dstPath && req.SrcOffset < req.DstOffset+length && req.DstOffset < req.SrcOffset+length {
+		return nil, fmt.Errorf("copy range of %s: overlapping ranges", srcPath)
+	}
+
+	lookupFn := fs.filer.MasterClient.GetLookupFileIdFunction()
+	srcVisibles, err := filer.NonOverlappingVisibleIntervals(lookupFn, srcEntry.Chunks)
+	if err != nil {
+		return nil, fmt.Errorf("resolve chunks of %s: %v", srcPath, err)
+	}
+	dstVisibles, err := filer.NonOverlappingVisibleIntervals(lookupFn, dstEntry.Chunks)
+	if err != nil {
+		return nil, fmt.Errorf("resolve chunks of %s: %v", dstPath, err)
+	}
+
+	shared, toCopy := filer.PlanCopyFileRange(srcVisibles, dstVisibles, req.SrcOffset, req.DstOffset, length, time.Now().UnixNano())
+
+	copied, err := fs.copyChunkRanges(dstEntry, srcVisibles, srcSize, toCopy)
+	if err != nil {
+		return nil, fmt.Errorf("copy range of %s to %s: %v", srcPath, dstPath, err)
+	}
+
+	newEntry := &filer.Entry{
+		FullPath:        dstEntry.FullPath,
+		Attr:            dstEntry.Attr,
+		Extended:        dstEntry.Extended,
+		Chunks:          append(append(append([]*filer_pb.FileChunk{}, dstEntry.Chunks...), shared...), copied...),
+		HardLinkId:      dstEntry.HardLinkId,
+		HardLinkCounter: dstEntry.HardLinkCounter,
+	}
+	newEntry.Mtime = time.Now()
+	if end := uint64(req.DstOffset + length); end > newEntry.FileSize {
+		newEntry.FileSize = end
+	}
+	if newEntry.FileSize < filer.TotalSize(dstEntry.Chunks) {
+		newEntry.FileSize = filer.TotalSize(dstEntry.Chunks)
+	}
+
+	if err = fs.filer.CheckQuotaChange(dstEntry, newEntry); err != nil {
+		fs.filer.DeleteChunks(copied)
+		return nil, quotaError(err)
+	}
+	if err = fs.filer.DedupShareChunks(ctx, srcEntry.Collection, shared); err != nil {
+		fs.filer.DeleteChunks(copied)
+		return nil, fmt.Errorf("share chunks of %s: %v", srcPath, err)
+	}
+	if err = fs.filer.UpdateEntry(ctx, dstEntry, newEntry); err != nil {
+		fs.filer.DeleteChunks(append(shared, copied...))
+		return nil, fmt.Errorf("update %s: %v", dstPath, err)
+	}
+	fs.filer.NotifyUpdateEvent(ctx, dstEntry, newEntry, false, false, req.Signatures)
+
+	glog.V(2).Infof("copied %s [%d,%d) to %s at %d: %d chunks shared, %d parts copied",
+		srcPath, req.SrcOffset, req.SrcOffset+length, dstPath, req.DstOffset, len(shared), len(toCopy))
+
+	return &filer_pb.CopyFileRangeResponse{
+		Copied: length,
+		Entry:  newEntry.ToProtoEntry(),
+	}, nil
+}
+
+// copyChunkRanges reads the source parts that can not share chunks, and writes them as new chunks of the destination
+func (fs *FilerServer) copyChunkRanges(dstEntry *filer.Entry, srcVisibles []filer.VisibleInterval, srcSize int64, toCopy []filer.CopyRange) (chunks []*filer_pb.FileChunk, err error) {
+	if len(toCopy) == 0 {
+		return nil, nil
+	}
+
+	// each part is read once, a nil cache reads the chunks directly
+	var noCache *chunk_cache.TieredChunkCache
+	chunkViews := filer.ViewFromVisibleIntervals(srcVisibles, 0, math.MaxInt64)
+	reader := filer.NewChunkReaderAtFromClient(fs.filer.MasterClient.GetLookupFileIdFunction(), chunkViews, noCache, srcSize)
+	defer reader.Close()
+
+	so := fs.detectStorageOption(string(dstEntry.FullPath), dstEntry.Collection, dstEntry.Replication, dstEntry.TtlSec, dstEntry.DiskType, "", "")
+	saveFn := fs.saveAsChunk(so)
+	partSize := int64(fs.option.MaxMB) * 1024 * 1024
+	if partSize <= 0 {
+		partSize = 4 * 1024 * 1024
+	}
+
+	for _, r := range toCopy {
+		for offset := int64(0); offset < r.Size; offset += partSize {
+			size := r.Size - offset
+			if size > partSize {
+				size = partSize
+			}
+			data := make([]byte, size)
+			if _, err = reader.ReadAt(data, r.SrcOffset+offset); err != nil && err != io.EOF {
+				fs.filer.DeleteChunks(chunks)
+				return nil, err
+			}
+			chunk, _, _, saveErr := saveFn(bytes.NewReader(data), dstEntry.Name(), r.DstOffset+offset)
+			if saveErr != nil {
+				fs.filer.DeleteChunks(chunks)
+				return nil, saveErr
+			}
+			chunks = append(chunks, chunk)
+		}
+	}
+	return chunks, nil
+}
@@ -8,6 +8,10 @@ import (
 
 func (fs *FilerServer) KvGet(ctx context.Context, req *filer_pb.KvGetRequest) (*filer_pb.KvGetResponse, error) {
 
+	if err := fs.checkAdmin(ctx, "kv get"); err != nil {
+		return nil, err
+	}
+
 	value, err := fs.filer.Store.KvGet(ctx, req.Key)
 	if err == filer.ErrKvNotFound {
 		return &filer_pb.KvGetResponse{}, nil
@@ -26,6 +30,10 @@ func (fs *FilerServer) KvGet(ctx context.Context, req *filer_pb.KvGetRequest) (*
 // KvPut sets the key~value. if empty value, delete the kv entry
 func (fs *FilerServer) KvPut(ctx context.Context, req *filer_pb.KvPutRequest) (*filer_pb.KvPutResponse, error) {
 
+	if err := fs.checkAdmin(ctx, "kv put"); err != nil {
+		return nil, err
+	}
+
 	if len(req.Value) == 0 {
 		if err := fs.filer.Store.KvDelete(ctx, req.Key); err != nil {
 			return &filer_pb.KvPutResponse{Error: err.Error()}, nil
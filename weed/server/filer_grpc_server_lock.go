@@ -0,0 +1,290 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// LockLeaseDuration is how long the locks of a client are kept without hearing from it.
+// Connected clients renew the lease with the KeepConnected heartbeats.
+const LockLeaseDuration = 30 * time.Second
+
+// lockOwnerOf returns the filer keeping the advisory locks for all filers, which is the first of the sorted peers,
+// or "" if it is this filer. The filers sharing a store list the same peers, and agree on the owner.
+// The other filers forward the lock requests, and relay the lease heartbeats of their clients, to the owner.
+func lockOwnerOf(self string, peers []string) string {
+	owner := self
+	for _, peer := range peers {
+		if peer < owner {
+			owner = peer
+		}
+	}
+	if owner == self {
+		return ""
+	}
+	return owner
+}
+
+func (fs *FilerServer) withLockOwner(fn func(client filer_pb.SeaweedFilerClient) error) error {
+	return pb.WithFilerClient(fs.lockOwner, fs.grpcDialOption, fn)
+}
+
+func (fs *FilerServer) AcquireLock(ctx context.Context, req *filer_pb.AcquireLockRequest) (resp *filer_pb.AcquireLockResponse, err error) {
+
+	if fs.lockOwner != "" {
+		err = fs.withLockOwner(func(client filer_pb.SeaweedFilerClient) error {
+			resp, err = client.AcquireLock(ctx, req)
+			return err
+		})
+		return
+	}
+
+	lock, err := toFileLock(req.Lock)
+	if err != nil {
+		return &filer_pb.AcquireLockResponse{Error: err.Error()}, nil
+	}
+	path := util.FullPath(req.Path)
+
+	fs.lockManager.RenewLease(lock.ClientId)
+
+	if !req.Wait {
+		if conflict := fs.lockManager.TryLock(path, lock); conflict != nil {
+			glog.V(3).Infof("lock %s %+v conflicts with %+v", path, lock, conflict)
+			return &filer_pb.AcquireLockResponse{Conflict: toPbFileLock(conflict)}, nil
+		}
+		return &filer_pb.AcquireLockResponse{}, nil
+	}
+
+	if err := fs.lockManager.Lock(ctx, path, lock); err != nil {
+		return &filer_pb.AcquireLockResponse{Error: fmt.Sprintf("wait for lock %s: %v", path, err)}, nil
+	}
+	return &filer_pb.AcquireLockResponse{}, nil
+}
+
+func (fs *FilerServer) ReleaseLock(ctx context.Context, req *filer_pb.ReleaseLockRequest) (resp *filer_pb.ReleaseLockResponse, err error) {
+
+	if fs.lockOwner != "" {
+		err = fs.withLockOwner(func(client filer_pb.SeaweedFilerClient) error {
+			resp, err = client.ReleaseLock(ctx, req)
+			return err
+		})
+		return
+	}
+
+	lock, err := toFileLock(req.Lock)
+	if err != nil {
+		return nil, err
+	}
+	path := util.FullPath(req.Path)
+
+	if req.AllRanges {
+		fs.lockManager.UnlockOwner(path, lock)
+	} else {
+		fs.lockManager.Unlock(path, lock)
+	}
+
+	return &filer_pb.ReleaseLockResponse{}, nil
+}
+
+func (fs *FilerServer) QueryLock(ctx context.Context, req *filer_pb.QueryLockRequest) (resp *filer_pb.QueryLockResponse, err error) {
+
+	if fs.lockOwner != "" {
+		err = fs.withLockOwner(func(client filer_pb.SeaweedFilerClient) error {
+			resp, err = client.QueryLock(ctx, req)
+			return err
+		})
+		return
+	}
+
+	lock, err := toFileLock(req.Lock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filer_pb.QueryLockResponse{
+		Conflict: toPbFileLock(fs.lockManager.Query(util.FullPath(req.Path), lock)),
+	}, nil
+}
+
+// keepLockClientConnected renews the lease of the locks on every heartbeat, and pushes the revoked leases to the client.
+// All locks and leases of the client are released once the client disconnects.
+func (fs *FilerServer) keepLockClientConnected(stream filer_pb.SeaweedFiler_KeepConnectedServer, req *filer_pb.KeepConnectedRequest) error {
+
+	clientId := req.LockClientId
+	fs.lockManager.RenewLease(clientId)
+	revocations := fs.filer.LeaseManager.Connect(clientId, req.Signature)
+	glog.V(0).Infof("+ lock client %v", clientId)
+
+	defer func() {
+		fs.filer.LeaseManager.Disconnect(clientId)
+		fs.lockManager.ReleaseClient(clientId)
+		glog.V(0).Infof("- lock client %v", clientId)
+	}()
+
+	renewals := make(chan struct{}, 1)
+	if fs.lockOwner != "" {
+		ctx, cancel := context.WithCancel(stream.Context())
+		defer cancel()
+		go fs.relayLockLease(ctx, clientId, renewals)
+	}
+
+	heartbeats := make(chan struct{}, 1)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			fs.lockManager.RenewLease(clientId)
+			for _, path := range req.ReleasedLeases {
+				fs.filer.LeaseManager.Release(clientId, util.FullPath(path))
+			}
+			for _, ch := range []chan struct{}{heartbeats, renewals} {
+				select {
+				case ch <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+
+	if err := stream.Send(&filer_pb.KeepConnectedResponse{}); err != nil {
+		return err
+	}
+	for {
+		var resp *filer_pb.KeepConnectedResponse
+		select {
+		case <-heartbeats:
+			resp = &filer_pb.KeepConnectedResponse{}
+		case path := <-revocations:
+			resp = &filer_pb.KeepConnectedResponse{RevokedLeases: []string{string(path)}}
+		case err := <-recvErr:
+			glog.V(0).Infof("recv lock client %v: %v", clientId, err)
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			glog.V(0).Infof("send lock client %v: %v", clientId, err)
+			return err
+		}
+	}
+
+}
+
+// relayLockLease renews the lease of the client on the lock owner on every heartbeat of the client.
+// The owner releases the locks of the client once the relay stops, after the client disconnects from this filer.
+func (fs *FilerServer) relayLockLease(ctx context.Context, clientId string, renewals <-chan struct{}) {
+	request := &filer_pb.KeepConnectedRequest{
+		Name:         clientId,
+		LockClientId: clientId,
+	}
+	for {
+		err := fs.withLockOwner(func(client filer_pb.SeaweedFilerClient) error {
+			stream, err := client.KeepConnected(ctx)
+			if err != nil {
+				return err
+			}
+			if err = stream.Send(request); err != nil {
+				return err
+			}
+			recvErr := make(chan error, 1)
+			go func() {
+				for {
+					if _, err := stream.Recv(); err != nil {
+						recvErr <- err
+						return
+					}
+				}
+			}()
+			for {
+				select {
+				case <-ctx.Done():
+					return nil
+				case err := <-recvErr:
+					return err
+				case <-renewals:
+					if err := stream.Send(request); err != nil {
+						return err
+					}
+				}
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		glog.V(0).Infof("relay lock client %v to %s: %v", clientId, fs.lockOwner, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(1733 * time.Millisecond):
+		}
+	}
+}
+
+func (fs *FilerServer) AcquireLease(ctx context.Context, req *filer_pb.AcquireLeaseRequest) (*filer_pb.AcquireLeaseResponse, error) {
+
+	resp := &filer_pb.AcquireLeaseResponse{}
+	path := util.NewFullPath(req.Directory, req.Name)
+
+	if err := fs.filer.LeaseManager.Acquire(ctx, path, req.ClientId, req.IsWrite); err != nil {
+		glog.V(1).Infof("lease %s to %s: %v", path, req.ClientId, err)
+	} else {
+		resp.Granted = true
+	}
+
+	// the entry is read after the lease is granted, so the client caches nothing older
+	lookupResp, err := fs.LookupDirectoryEntry(ctx, &filer_pb.LookupDirectoryEntryRequest{
+		Directory: req.Directory,
+		Name:      req.Name,
+	})
+	if err != nil {
+		if resp.Granted {
+			fs.filer.LeaseManager.Release(req.ClientId, path)
+		}
+		return nil, err
+	}
+	resp.Entry = lookupResp.Entry
+
+	return resp, nil
+}
+
+func toFileLock(lock *filer_pb.FileLock) (*filer.FileLock, error) {
+	if lock == nil || lock.ClientId == "" {
+		return nil, fmt.Errorf("missing lock owner")
+	}
+	if lock.Start > lock.End {
+		return nil, fmt.Errorf("invalid lock range [%d,%d]", lock.Start, lock.End)
+	}
+	return &filer.FileLock{
+		ClientId: lock.ClientId,
+		Owner:    lock.Owner,
+		Pid:      lock.Pid,
+		Start:    lock.Start,
+		End:      lock.End,
+		IsWrite:  lock.IsWrite,
+		IsFlock:  lock.IsFlock,
+	}, nil
+}
+
+func toPbFileLock(lock *filer.FileLock) *filer_pb.FileLock {
+	if lock == nil {
+		return nil
+	}
+	return &filer_pb.FileLock{
+		ClientId: lock.ClientId,
+		Owner:    lock.Owner,
+		Pid:      lock.Pid,
+		Start:    lock.Start,
+		End:      lock.End,
+		IsWrite:  lock.IsWrite,
+		IsFlock:  lock.IsFlock,
+	}
+}
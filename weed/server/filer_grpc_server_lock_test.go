@@ -0,0 +1,22 @@
+package weed_server
+
+import "testing"
+
+func TestLockOwnerOf(t *testing.T) {
+	tests := []struct {
+		self  string
+		peers []string
+		owner string
+	}{
+		{"localhost:8888", nil, ""},
+		{"10.0.0.1:8888", []string{"10.0.0.1:8888", "10.0.0.2:8888"}, ""},
+		{"10.0.0.2:8888", []string{"10.0.0.1:8888", "10.0.0.2:8888"}, "10.0.0.1:8888"},
+		{"10.0.0.2:8888", []string{"10.0.0.3:8888", "10.0.0.1:8888"}, "10.0.0.1:8888"},
+		{"10.0.0.1:8888", []string{"10.0.0.2:8888", "10.0.0.3:8888"}, ""},
+	}
+	for _, tt := range tests {
+		if owner := lockOwnerOf(tt.self, tt.peers); owner != tt.owner {
+			t.Errorf("lockOwnerOf(%s, %v) = %q, want %q", tt.self, tt.peers, owner, tt.owner)
+		}
+	}
+}
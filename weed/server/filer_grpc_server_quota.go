@@ -0,0 +1,66 @@
+package weed_server
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// quotaError returns an exceeded quota to grpc clients with the ResourceExhausted status code,
+// the same way for all calls, so that the clients can report it as EDQUOT.
+func quotaError(err error) error {
+	if filer_pb.IsQuotaExceeded(err) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return err
+}
+
+func (fs *FilerServer) SetQuota(ctx context.Context, req *filer_pb.SetQuotaRequest) (*filer_pb.SetQuotaResponse, error) {
+
+	dir := util.FullPath(req.Directory)
+
+	identity, err := fs.identityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if identity != nil && !identity.IsRoot() {
+		return nil, permissionDenied("set quota", dir)
+	}
+
+	if req.Remove {
+		if err := fs.filer.RemoveQuota(dir); err != nil {
+			return nil, err
+		}
+		glog.V(0).Infof("removed quota on %s", dir)
+		return &filer_pb.SetQuotaResponse{}, nil
+	}
+
+	quota, err := fs.filer.SetQuota(ctx, dir, req.MaxBytes, req.MaxInodes)
+	if err != nil {
+		return nil, err
+	}
+	glog.V(0).Infof("set quota on %s: %+v", dir, quota)
+
+	return &filer_pb.SetQuotaResponse{
+		Quota: quota.ToPb(),
+	}, nil
+}
+
+func (fs *FilerServer) GetQuota(ctx context.Context, req *filer_pb.GetQuotaRequest) (*filer_pb.GetQuotaResponse, error) {
+	return &filer_pb.GetQuotaResponse{
+		Quota: fs.filer.FindQuota(util.FullPath(req.Directory)).ToPb(),
+	}, nil
+}
+
+func (fs *FilerServer) ListQuotas(ctx context.Context, req *filer_pb.ListQuotasRequest) (*filer_pb.ListQuotasResponse, error) {
+	resp := &filer_pb.ListQuotasResponse{}
+	for _, quota := range fs.filer.ListQuotas() {
+		resp.Quotas = append(resp.Quotas, quota.ToPb())
+	}
+	return resp, nil
+}
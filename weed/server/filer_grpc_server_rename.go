@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/chrislusf/seaweedfs/weed/filer"
 	"github.com/chrislusf/seaweedfs/weed/glog"
@@ -22,7 +24,18 @@ func (fs *FilerServer) AtomicRenameEntry(ctx context.Context, req *filer_pb.Atom
 		return nil, err
 	}
 
-	ctx, err := fs.filer.BeginTransaction(ctx)
+	identity, err := fs.identityFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Exchange {
+		return &filer_pb.AtomicRenameEntryResponse{}, fs.exchangeEntries(ctx, identity, oldParent, req.OldName, newParent, req.NewName)
+	}
+	if err = fs.checkRename(ctx, identity, oldParent.Child(req.OldName), newParent.Child(req.NewName)); err != nil {
+		return nil, err
+	}
+
+	ctx, err = fs.filer.BeginTransaction(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -32,6 +45,10 @@ func (fs *FilerServer) AtomicRenameEntry(ctx context.Context, req *filer_pb.Atom
 		fs.filer.RollbackTransaction(ctx)
 		return nil, fmt.Errorf("%s/%s not found: %v", req.OldDirectory, req.OldName, err)
 	}
+	if err = fs.filer.CheckQuotaForMove(ctx, oldEntry, newParent.Child(req.NewName)); err != nil {
+		fs.filer.RollbackTransaction(ctx)
+		return nil, quotaError(err)
+	}
 
 	var events MoveEvents
 	moveErr := fs.moveEntry(ctx, oldParent, oldEntry, newParent, req.NewName, &events)
@@ -48,6 +65,71 @@ func (fs *FilerServer) AtomicRenameEntry(ctx context.Context, req *filer_pb.Atom
 	return &filer_pb.AtomicRenameEntryResponse{}, nil
 }
 
+// exchangeEntries atomically swaps two existing entries, as renameat2(2) with RENAME_EXCHANGE,
+// by moving the old entry to a temporary name in its directory first.
+func (fs *FilerServer) exchangeEntries(ctx context.Context, identity *filer.Identity, oldParent util.FullPath, oldName string, newParent util.FullPath, newName string) error {
+
+	oldPath, newPath := oldParent.Child(oldName), newParent.Child(newName)
+	if oldPath == newPath {
+		return nil
+	}
+	if strings.HasPrefix(string(newPath), string(oldPath)+"/") || strings.HasPrefix(string(oldPath), string(newPath)+"/") {
+		return fmt.Errorf("exchange %s and %s: one contains the other", oldPath, newPath)
+	}
+	if err := fs.checkRename(ctx, identity, oldPath, newPath); err != nil {
+		return err
+	}
+	if err := fs.checkRename(ctx, identity, newPath, oldPath); err != nil {
+		return err
+	}
+
+	ctx, err := fs.filer.BeginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	oldEntry, err := fs.filer.FindEntry(ctx, oldPath)
+	if err != nil {
+		fs.filer.RollbackTransaction(ctx)
+		return fmt.Errorf("%s not found: %v", oldPath, err)
+	}
+	newEntry, err := fs.filer.FindEntry(ctx, newPath)
+	if err != nil {
+		fs.filer.RollbackTransaction(ctx)
+		return fmt.Errorf("%s not found: %v", newPath, err)
+	}
+	if err = fs.filer.CheckQuotaForMove(ctx, oldEntry, newPath); err == nil {
+		err = fs.filer.CheckQuotaForMove(ctx, newEntry, oldPath)
+	}
+	if err != nil {
+		fs.filer.RollbackTransaction(ctx)
+		return quotaError(err)
+	}
+
+	var events MoveEvents
+	tempName := fmt.Sprintf(".%s.exchange.%d", oldName, time.Now().UnixNano())
+	moveErr := fs.moveEntry(ctx, oldParent, oldEntry, oldParent, tempName, &events)
+	if moveErr == nil {
+		moveErr = fs.moveEntry(ctx, newParent, newEntry, oldParent, oldName, &events)
+	}
+	if moveErr == nil {
+		var tempEntry *filer.Entry
+		if tempEntry, moveErr = fs.filer.FindEntry(ctx, oldParent.Child(tempName)); moveErr == nil {
+			moveErr = fs.moveEntry(ctx, oldParent, tempEntry, newParent, newName, &events)
+		}
+	}
+	if moveErr != nil {
+		fs.filer.RollbackTransaction(ctx)
+		return fmt.Errorf("exchange %s and %s: %v", oldPath, newPath, moveErr)
+	}
+	if commitError := fs.filer.CommitTransaction(ctx); commitError != nil {
+		fs.filer.RollbackTransaction(ctx)
+		return fmt.Errorf("exchange %s and %s commit error: %v", oldPath, newPath, commitError)
+	}
+
+	return nil
+}
+
 func (fs *FilerServer) moveEntry(ctx context.Context, oldParent util.FullPath, entry *filer.Entry, newParent util.FullPath, newName string, events *MoveEvents) error {
 
 	if err := fs.moveSelfEntry(ctx, oldParent, entry, newParent, newName, events, func() error {
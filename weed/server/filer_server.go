@@ -30,6 +30,7 @@ import (
 	_ "github.com/chrislusf/seaweedfs/weed/filer/mongodb"
 	_ "github.com/chrislusf/seaweedfs/weed/filer/mysql"
 	_ "github.com/chrislusf/seaweedfs/weed/filer/mysql2"
+	_ "github.com/chrislusf/seaweedfs/weed/filer/pebble"
 	_ "github.com/chrislusf/seaweedfs/weed/filer/postgres"
 	_ "github.com/chrislusf/seaweedfs/weed/filer/postgres2"
 	_ "github.com/chrislusf/seaweedfs/weed/filer/redis"
@@ -66,6 +67,7 @@ type FilerOption struct {
 type FilerServer struct {
 	option         *FilerOption
 	secret         security.SigningKey
+	identityKey    security.SigningKey
 	filer          *filer.Filer
 	grpcDialOption grpc.DialOption
 
@@ -79,6 +81,10 @@ type FilerServer struct {
 
 	brokers     map[string]map[string]bool
 	brokersLock sync.Mutex
+
+	// advisory locks held by mounts, kept by the lock owner filer
+	lockManager *filer.LockManager
+	lockOwner   string
 }
 
 func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption) (fs *FilerServer, err error) {
@@ -87,6 +93,7 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 		option:         option,
 		grpcDialOption: security.LoadClientTLS(util.GetViper(), "grpc.filer"),
 		brokers:        make(map[string]map[string]bool),
+		lockManager:    filer.NewLockManager(LockLeaseDuration),
 	}
 	fs.listenersCond = sync.NewCond(&fs.listenersLock)
 
@@ -118,6 +125,11 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 	}
 	util.LoadConfiguration("notification", false)
 
+	fs.identityKey, _ = security.LoadIdentitySigningKey(v)
+	if len(fs.identityKey) > 0 {
+		glog.V(0).Infof("enforcing permissions of signed client identities")
+	}
+
 	fs.option.recursiveDelete = v.GetBool("filer.options.recursive_delete")
 	v.SetDefault("filer.options.buckets_folder", "/buckets")
 	fs.filer.DirBucketsPath = v.GetString("filer.options.buckets_folder")
@@ -139,6 +151,11 @@ func NewFilerServer(defaultMux, readonlyMux *http.ServeMux, option *FilerOption)
 
 	fs.filer.AggregateFromPeers(fmt.Sprintf("%s:%d", option.Host, option.Port), option.Filers)
 
+	fs.lockOwner = lockOwnerOf(fmt.Sprintf("%s:%d", option.Host, option.Port), option.Filers)
+	if fs.lockOwner != "" {
+		glog.V(0).Infof("advisory locks are kept by filer %s", fs.lockOwner)
+	}
+
 	fs.filer.LoadBuckets()
 
 	fs.filer.LoadFilerConf()
@@ -47,6 +47,16 @@ func (fs *FilerServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	identity, err := fs.identityFromRequest(r)
+	if err == nil {
+		err = fs.checkRead(context.Background(), identity, entry)
+	}
+	if err != nil {
+		glog.V(1).Infof("read %s: %v", path, err)
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
 	if entry.IsDirectory() {
 		if fs.option.DisableDirListing {
 			w.WriteHeader(http.StatusMethodNotAllowed)
@@ -56,6 +56,16 @@ func (fs *FilerServer) PostHandler(w http.ResponseWriter, r *http.Request) {
 
 	ctx := context.Background()
 
+	identity, err := fs.identityFromRequest(r)
+	if err == nil {
+		err = fs.checkWrite(ctx, identity, util.FullPath(r.URL.Path))
+	}
+	if err != nil {
+		writeJsonError(w, r, http.StatusForbidden, err)
+		util.CloseRequest(r)
+		return
+	}
+
 	query := r.URL.Query()
 	so := fs.detectStorageOption0(r.RequestURI,
 		query.Get("collection"),
@@ -91,7 +101,16 @@ func (fs *FilerServer) DeleteHandler(w http.ResponseWriter, r *http.Request) {
 		objectPath = objectPath[0 : len(objectPath)-1]
 	}
 
-	err := fs.filer.DeleteEntryMetaAndData(context.Background(), util.FullPath(objectPath), isRecursive, ignoreRecursiveError, !skipChunkDeletion, false, nil)
+	identity, err := fs.identityFromRequest(r)
+	if err == nil {
+		err = fs.checkDelete(context.Background(), identity, util.FullPath(objectPath), isRecursive)
+	}
+	if err != nil {
+		writeJsonError(w, r, http.StatusForbidden, err)
+		return
+	}
+
+	err = fs.filer.DeleteEntryMetaAndData(context.Background(), util.FullPath(objectPath), isRecursive, ignoreRecursiveError, !skipChunkDeletion, false, nil)
 	if err != nil {
 		glog.V(1).Infoln("deleting", objectPath, ":", err.Error())
 		httpStatus := http.StatusInternalServerError
@@ -138,6 +157,7 @@ func (fs *FilerServer) detectStorageOption(requestURI, qCollection, qReplication
 		DiskType:          util.Nvl(diskType, rule.DiskType),
 		Fsync:             fsync || rule.Fsync,
 		VolumeGrowthCount: rule.VolumeGrowthCount,
+		Dedup:             rule.Dedup,
 	}
 }
 
@@ -59,6 +59,8 @@ func (fs *FilerServer) autoChunk(ctx context.Context, w http.ResponseWriter, r *
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "read input:") {
 			writeJsonError(w, r, 499, err)
+		} else if filer_pb.IsQuotaExceeded(err) {
+			writeJsonError(w, r, http.StatusInsufficientStorage, err)
 		} else {
 			writeJsonError(w, r, http.StatusInternalServerError, err)
 		}
@@ -148,6 +150,10 @@ func (fs *FilerServer) saveMetaData(ctx context.Context, r *http.Request, fileNa
 
 	var entry *filer.Entry
 	var mergedChunks []*filer_pb.FileChunk
+	var replacedEntry *filer.Entry
+	if so.Dedup && !isAppend(r) {
+		replacedEntry, _ = fs.filer.FindEntry(ctx, util.FullPath(path))
+	}
 	// when it is an append
 	if isAppend(r) {
 		existingEntry, findErr := fs.filer.FindEntry(ctx, util.FullPath(path))
@@ -175,14 +181,15 @@ func (fs *FilerServer) saveMetaData(ctx context.Context, r *http.Request, fileNa
 	} else {
 		glog.V(4).Infoln("saving", path)
 		mergedChunks = fileChunks
+		uid, gid := fs.requestOwner(r)
 		entry = &filer.Entry{
 			FullPath: util.FullPath(path),
 			Attr: filer.Attr{
 				Mtime:       time.Now(),
 				Crtime:      time.Now(),
 				Mode:        os.FileMode(mode),
-				Uid:         OS_UID,
-				Gid:         OS_GID,
+				Uid:         uid,
+				Gid:         gid,
 				Replication: so.Replication,
 				Collection:  so.Collection,
 				TtlSec:      so.TtlSeconds,
@@ -220,11 +227,17 @@ func (fs *FilerServer) saveMetaData(ctx context.Context, r *http.Request, fileNa
 		}
 	}
 
-	if dbErr := fs.filer.CreateEntry(ctx, entry, false, false, nil); dbErr != nil {
+	dbErr := fs.filer.CheckQuota(ctx, entry)
+	if dbErr == nil {
+		dbErr = fs.filer.CreateEntry(ctx, entry, false, false, nil)
+	}
+	if dbErr != nil {
 		fs.filer.DeleteChunks(fileChunks)
 		replyerr = dbErr
 		filerResult.Error = dbErr.Error()
 		glog.V(0).Infof("failing to write %s to filer server : %v", path, dbErr)
+	} else if replacedEntry != nil {
+		fs.filer.DedupReleaseReplaced(replacedEntry, entry)
 	}
 	return filerResult, replyerr
 }
@@ -252,6 +265,25 @@ func (fs *FilerServer) uploadReaderToChunks(w http.ResponseWriter, r *http.Reque
 				break
 			}
 		}
+
+		var dedupHash []byte
+		if so.Dedup && so.TtlSeconds == 0 && len(data) > 0 {
+			dedupHash = filer.DedupHash(data)
+			existingChunk, dedupErr := fs.filer.DedupAcquireChunk(r.Context(), so.Collection, dedupHash, chunkOffset)
+			if dedupErr != nil {
+				glog.V(0).Infof("dedup lookup %s chunk %d: %v", fileName, len(fileChunks)+1, dedupErr)
+			}
+			if existingChunk != nil {
+				fileChunks = append(fileChunks, existingChunk)
+				glog.V(4).Infof("reused %s chunk %d from %s [%d,%d)", fileName, len(fileChunks), existingChunk.GetFileIdString(), chunkOffset, chunkOffset+int64(len(data)))
+				chunkOffset = chunkOffset + int64(len(data))
+				if int64(len(data)) < int64(chunkSize) {
+					break
+				}
+				continue
+			}
+		}
+
 		dataReader := util.NewBytesReader(data)
 
 		// retry to assign a different file id
@@ -286,6 +318,12 @@ func (fs *FilerServer) uploadReaderToChunks(w http.ResponseWriter, r *http.Reque
 		// Save to chunk manifest structure
 		fileChunks = append(fileChunks, uploadResult.ToPbFileChunk(fileId, chunkOffset))
 
+		if dedupHash != nil {
+			if dedupErr := fs.filer.DedupRegisterChunk(r.Context(), so.Collection, dedupHash, fileChunks[len(fileChunks)-1]); dedupErr != nil {
+				glog.V(0).Infof("dedup register %s chunk %s: %v", fileName, fileId, dedupErr)
+			}
+		}
+
 		glog.V(4).Infof("uploaded %s chunk %d to %s [%d,%d)", fileName, len(fileChunks), fileId, chunkOffset, chunkOffset+int64(uploadResult.Size))
 
 		// reset variables for the next chunk
@@ -357,14 +395,15 @@ func (fs *FilerServer) mkdir(ctx context.Context, w http.ResponseWriter, r *http
 	}
 
 	glog.V(4).Infoln("mkdir", path)
+	uid, gid := fs.requestOwner(r)
 	entry := &filer.Entry{
 		FullPath: util.FullPath(path),
 		Attr: filer.Attr{
 			Mtime:  time.Now(),
 			Crtime: time.Now(),
 			Mode:   os.FileMode(mode) | os.ModeDir,
-			Uid:    OS_UID,
-			Gid:    OS_GID,
+			Uid:    uid,
+			Gid:    gid,
 		},
 	}
 
@@ -372,7 +411,11 @@ func (fs *FilerServer) mkdir(ctx context.Context, w http.ResponseWriter, r *http
 		Name: util.FullPath(path).Name(),
 	}
 
-	if dbErr := fs.filer.CreateEntry(ctx, entry, false, false, nil); dbErr != nil {
+	dbErr := fs.filer.CheckQuota(ctx, entry)
+	if dbErr == nil {
+		dbErr = fs.filer.CreateEntry(ctx, entry, false, false, nil)
+	}
+	if dbErr != nil {
 		replyerr = dbErr
 		filerResult.Error = dbErr.Error()
 		glog.V(0).Infof("failing to create dir %s on filer server : %v", path, dbErr)
@@ -57,14 +57,15 @@ func (fs *FilerServer) encrypt(ctx context.Context, w http.ResponseWriter, r *ht
 		}
 	}
 
+	uid, gid := fs.requestOwner(r)
 	entry := &filer.Entry{
 		FullPath: util.FullPath(path),
 		Attr: filer.Attr{
 			Mtime:       time.Now(),
 			Crtime:      time.Now(),
 			Mode:        0660,
-			Uid:         OS_UID,
-			Gid:         OS_GID,
+			Uid:         uid,
+			Gid:         gid,
 			Replication: so.Replication,
 			Collection:  so.Collection,
 			TtlSec:      so.TtlSeconds,
@@ -80,7 +81,11 @@ func (fs *FilerServer) encrypt(ctx context.Context, w http.ResponseWriter, r *ht
 		Size: int64(pu.OriginalDataSize),
 	}
 
-	if dbErr := fs.filer.CreateEntry(ctx, entry, false, false, nil); dbErr != nil {
+	dbErr := fs.filer.CheckQuota(ctx, entry)
+	if dbErr == nil {
+		dbErr = fs.filer.CreateEntry(ctx, entry, false, false, nil)
+	}
+	if dbErr != nil {
 		fs.filer.DeleteChunks(entry.Chunks)
 		err = dbErr
 		filerResult.Error = dbErr.Error()
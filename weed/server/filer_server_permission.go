@@ -0,0 +1,350 @@
+package weed_server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// identityFromContext returns the verified caller of a grpc request,
+// or nil if the filer is not configured to enforce permissions.
+func (fs *FilerServer) identityFromContext(ctx context.Context) (*filer.Identity, error) {
+	if len(fs.identityKey) == 0 {
+		return nil, nil
+	}
+	return fs.decodeIdentity(security.GetIdentityJwt(ctx))
+}
+
+// identityFromRequest returns the verified caller of a http request,
+// or nil if the filer is not configured to enforce permissions.
+func (fs *FilerServer) identityFromRequest(r *http.Request) (*filer.Identity, error) {
+	if len(fs.identityKey) == 0 {
+		return nil, nil
+	}
+	return fs.decodeIdentity(security.GetIdentityJwtFromRequest(r))
+}
+
+func (fs *FilerServer) decodeIdentity(encoded security.EncodedJwt) (*filer.Identity, error) {
+	if encoded == "" {
+		return nil, &permissionError{fmt.Sprintf("%v: missing identity", filer_pb.ErrPermissionDenied)}
+	}
+	claims, err := security.DecodeIdentityJwt(fs.identityKey, encoded)
+	if err != nil {
+		return nil, &permissionError{fmt.Sprintf("%v: %v", filer_pb.ErrPermissionDenied, err)}
+	}
+	return &filer.Identity{
+		Uid:  claims.Uid,
+		Gids: claims.Gids,
+	}, nil
+}
+
+// permissionError is returned to grpc clients with the PermissionDenied status code
+type permissionError struct {
+	message string
+}
+
+func (e *permissionError) Error() string {
+	return e.message
+}
+
+func (e *permissionError) GRPCStatus() *status.Status {
+	return status.New(codes.PermissionDenied, e.message)
+}
+
+func permissionDenied(op string, fullpath util.FullPath) error {
+	return &permissionError{fmt.Sprintf("%s %s: %v", op, fullpath, filer_pb.ErrPermissionDenied)}
+}
+
+// findExistingAncestor returns the closest existing directory for a path,
+// which is the directory whose permissions govern creating the path.
+func (fs *FilerServer) findExistingAncestor(ctx context.Context, fullpath util.FullPath) (*filer.Entry, error) {
+	dir, _ := fullpath.DirAndName()
+	for {
+		entry, err := fs.filer.FindEntry(ctx, util.FullPath(dir))
+		if err == nil {
+			return entry, nil
+		}
+		if err != filer_pb.ErrNotFound {
+			return nil, err
+		}
+		if dir == "/" {
+			return nil, err
+		}
+		dir, _ = util.FullPath(dir).DirAndName()
+	}
+}
+
+// checkLookup requires search permission on the parent directory.
+// Only the direct parent is checked, the ancestors are expected to be walked by the client one by one.
+func (fs *FilerServer) checkLookup(ctx context.Context, identity *filer.Identity, fullpath util.FullPath) error {
+	if identity == nil || identity.IsRoot() || fullpath == "/" {
+		return nil
+	}
+	dir, _ := fullpath.DirAndName()
+	dirEntry, err := fs.filer.FindEntry(ctx, util.FullPath(dir))
+	if err != nil {
+		return nil
+	}
+	if !filer.HasPermission(dirEntry, identity, filer.PermissionExecute) {
+		return permissionDenied("lookup", fullpath)
+	}
+	return nil
+}
+
+func (fs *FilerServer) checkRead(ctx context.Context, identity *filer.Identity, entry *filer.Entry) error {
+	if identity == nil {
+		return nil
+	}
+	if err := fs.checkLookup(ctx, identity, entry.FullPath); err != nil {
+		return err
+	}
+	if !filer.HasPermission(entry, identity, filer.PermissionRead) {
+		return permissionDenied("read", entry.FullPath)
+	}
+	return nil
+}
+
+// withoutUnreadableContent leaves out the chunks and the content of a file the caller cannot read,
+// so that looking up or listing the file shows its attributes and size, but not its data.
+func withoutUnreadableContent(identity *filer.Identity, entry *filer.Entry, pbEntry *filer_pb.Entry) *filer_pb.Entry {
+	if entry.IsDirectory() || filer.HasPermission(entry, identity, filer.PermissionRead) {
+		return pbEntry
+	}
+	pbEntry.Attributes.FileSize = filer.FileSize(pbEntry)
+	pbEntry.Chunks = nil
+	pbEntry.Content = nil
+	return pbEntry
+}
+
+// checkAdmin requires a root or trusted service identity for the calls on the filer internals,
+// e.g., the kv records of quotas, dedup, locks and nfs handles, and deleting collections.
+func (fs *FilerServer) checkAdmin(ctx context.Context, op string) error {
+	identity, err := fs.identityFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if identity != nil && !identity.IsRoot() {
+		return &permissionError{fmt.Sprintf("%s: %v", op, filer_pb.ErrPermissionDenied)}
+	}
+	return nil
+}
+
+// canReadEvent checks the caller may read the entries changed by a metadata event
+func canReadEvent(identity *filer.Identity, dirPath string, eventNotification *filer_pb.EventNotification) bool {
+	if identity == nil || identity.IsRoot() {
+		return true
+	}
+	if eventNotification.OldEntry != nil && !filer.HasPermission(filer.FromPbEntry(dirPath, eventNotification.OldEntry), identity, filer.PermissionRead) {
+		return false
+	}
+	if eventNotification.NewEntry != nil {
+		newDir := dirPath
+		if eventNotification.NewParentPath != "" {
+			newDir = eventNotification.NewParentPath
+		}
+		if !filer.HasPermission(filer.FromPbEntry(newDir, eventNotification.NewEntry), identity, filer.PermissionRead) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkReadDirectory requires read permission on a directory to list it
+func (fs *FilerServer) checkReadDirectory(ctx context.Context, dir util.FullPath) error {
+	identity, err := fs.identityFromContext(ctx)
+	if err != nil || identity == nil {
+		return err
+	}
+	dirEntry, err := fs.filer.FindEntry(ctx, dir)
+	if err != nil {
+		// let the listing report the missing directory
+		return nil
+	}
+	return fs.checkRead(ctx, identity, dirEntry)
+}
+
+// checkCreate verifies the caller may create the entry, or overwrite it if it already exists.
+func (fs *FilerServer) checkCreate(ctx context.Context, identity *filer.Identity, newEntry *filer.Entry) error {
+	if identity == nil {
+		return nil
+	}
+	existing, err := fs.filer.FindEntry(ctx, newEntry.FullPath)
+	if err == nil {
+		// the mount saves written files with CreateEntry
+		return fs.checkUpdate(ctx, identity, existing, newEntry)
+	}
+	dirEntry, err := fs.findExistingAncestor(ctx, newEntry.FullPath)
+	if err != nil {
+		return err
+	}
+	if !filer.CanModifyDirectory(dirEntry, identity) {
+		return permissionDenied("create", newEntry.FullPath)
+	}
+	if identity.IsRoot() {
+		return nil
+	}
+	if newEntry.Uid != identity.Uid {
+		return permissionDenied("create as another user", newEntry.FullPath)
+	}
+	if newEntry.Gid != dirEntry.Gid && !identity.InGroup(newEntry.Gid) {
+		return permissionDenied("create in another group", newEntry.FullPath)
+	}
+	return nil
+}
+
+// checkWrite verifies the caller may write the file at the path, creating it if it does not exist.
+// Writing to an existing directory creates a child in it.
+func (fs *FilerServer) checkWrite(ctx context.Context, identity *filer.Identity, fullpath util.FullPath) error {
+	if identity == nil || identity.IsRoot() {
+		return nil
+	}
+	if existing, err := fs.filer.FindEntry(ctx, fullpath); err == nil {
+		if existing.IsDirectory() {
+			if !filer.CanModifyDirectory(existing, identity) {
+				return permissionDenied("write into", fullpath)
+			}
+			return nil
+		}
+		if !filer.HasPermission(existing, identity, filer.PermissionWrite) {
+			return permissionDenied("write", fullpath)
+		}
+		return nil
+	}
+	dirEntry, err := fs.findExistingAncestor(ctx, fullpath)
+	if err != nil {
+		return err
+	}
+	if !filer.CanModifyDirectory(dirEntry, identity) {
+		return permissionDenied("create", fullpath)
+	}
+	return nil
+}
+
+// requestOwner is the owner of entries created by a http request.
+// Unsigned requests and trusted services create entries as the filer process.
+func (fs *FilerServer) requestOwner(r *http.Request) (uid, gid uint32) {
+	identity, err := fs.identityFromRequest(r)
+	if err != nil || identity == nil || identity.IsRoot() {
+		return OS_UID, OS_GID
+	}
+	return identity.Uid, identity.Gid()
+}
+
+// checkUpdate verifies ownership and mode changes, and write permission for content changes.
+// The owner may always change the content, since files opened for writing can be saved after a chmod,
+// and the owner could grant itself write permission anyway.
+func (fs *FilerServer) checkUpdate(ctx context.Context, identity *filer.Identity, oldEntry, newEntry *filer.Entry) error {
+	if identity == nil || identity.IsRoot() {
+		return nil
+	}
+	if !filer.CanChangeAttributes(oldEntry, newEntry, identity) {
+		return permissionDenied("change attributes", newEntry.FullPath)
+	}
+	isOwner := identity.Uid == oldEntry.Uid
+	canWrite := filer.HasPermission(oldEntry, identity, filer.PermissionWrite)
+	if !filer.EqualContent(oldEntry, newEntry) && !isOwner && !canWrite {
+		return permissionDenied("write", newEntry.FullPath)
+	}
+	if !oldEntry.Mtime.Equal(newEntry.Mtime) && !isOwner && !canWrite {
+		return permissionDenied("set times", newEntry.FullPath)
+	}
+	return nil
+}
+
+// checkDelete verifies the caller may remove the entry from its parent directory, honoring the sticky bit.
+// Deleting a directory recursively also removes every entry under it, which is checked the same way.
+func (fs *FilerServer) checkDelete(ctx context.Context, identity *filer.Identity, fullpath util.FullPath, isRecursive bool) error {
+	if identity == nil || identity.IsRoot() {
+		return nil
+	}
+	entry, err := fs.filer.FindEntry(ctx, fullpath)
+	if err != nil {
+		// let the delete report the missing entry
+		return nil
+	}
+	dir, _ := fullpath.DirAndName()
+	dirEntry, err := fs.filer.FindEntry(ctx, util.FullPath(dir))
+	if err != nil {
+		return err
+	}
+	if !filer.CanRemoveFromDirectory(dirEntry, entry, identity) {
+		return permissionDenied("delete", fullpath)
+	}
+	if isRecursive && entry.IsDirectory() {
+		return fs.checkDeleteChildren(ctx, identity, entry)
+	}
+	return nil
+}
+
+// checkDeleteChildren requires write and search permission on the directory and all directories under it,
+// and that the sticky bit allows removing each entry.
+func (fs *FilerServer) checkDeleteChildren(ctx context.Context, identity *filer.Identity, dirEntry *filer.Entry) error {
+	if !filer.CanModifyDirectory(dirEntry, identity) {
+		return permissionDenied("delete in", dirEntry.FullPath)
+	}
+	lastFileName := ""
+	for {
+		var subDirs []*filer.Entry
+		var count int
+		var denied error
+		var err error
+		lastFileName, err = fs.filer.StreamListDirectoryEntries(ctx, dirEntry.FullPath, lastFileName, false, filer.PaginationSize, "", "", func(entry *filer.Entry) bool {
+			count++
+			if !filer.CanRemoveFromDirectory(dirEntry, entry, identity) {
+				denied = permissionDenied("delete", entry.FullPath)
+				return false
+			}
+			if entry.IsDirectory() {
+				subDirs = append(subDirs, entry)
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if denied != nil {
+			return denied
+		}
+		for _, subDir := range subDirs {
+			if err = fs.checkDeleteChildren(ctx, identity, subDir); err != nil {
+				return err
+			}
+		}
+		if count < filer.PaginationSize {
+			return nil
+		}
+	}
+}
+
+// checkRename verifies the caller may remove the entry from the old directory,
+// add it to the new directory, and replace the target if it exists.
+func (fs *FilerServer) checkRename(ctx context.Context, identity *filer.Identity, oldPath, newPath util.FullPath) error {
+	if identity == nil || identity.IsRoot() {
+		return nil
+	}
+	if err := fs.checkDelete(ctx, identity, oldPath, false); err != nil {
+		return err
+	}
+	newDir, _ := newPath.DirAndName()
+	newDirEntry, err := fs.filer.FindEntry(ctx, util.FullPath(newDir))
+	if err != nil {
+		return err
+	}
+	if !filer.CanModifyDirectory(newDirEntry, identity) {
+		return permissionDenied("rename into", newPath)
+	}
+	if target, err := fs.filer.FindEntry(ctx, newPath); err == nil {
+		if !filer.CanRemoveFromDirectory(newDirEntry, target, identity) {
+			return permissionDenied("replace", newPath)
+		}
+	}
+	return nil
+}
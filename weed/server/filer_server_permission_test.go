@@ -0,0 +1,161 @@
+package weed_server
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/filer/leveldb"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestCheckRecursiveDelete(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "seaweedfs_filer_permission_test")
+	defer os.RemoveAll(dir)
+
+	config := viper.New()
+	config.Set("dir", dir)
+	store := &leveldb.LevelDBStore{}
+	if err := store.Initialize(config, ""); err != nil {
+		t.Fatalf("initialize store: %v", err)
+	}
+	defer store.Shutdown()
+	testFiler := filer.NewFiler(nil, nil, "", 0, "", "", "", nil)
+	testFiler.SetStore(store)
+	fs := &FilerServer{filer: testFiler}
+
+	ctx := context.Background()
+	for _, e := range []struct {
+		path string
+		mode os.FileMode
+		uid  uint32
+	}{
+		{"/home", os.ModeDir | 0755, 0},
+		{"/home/alice", os.ModeDir | 0755, 1000},
+		{"/home/alice/b", os.ModeDir | 0755, 1000},
+		{"/home/alice/b/c.txt", 0644, 1000},
+		{"/home/alice/docs", os.ModeDir | 0555, 1000},
+		{"/home/alice/docs/a.txt", 0644, 1000},
+		{"/home/alice/shared", os.ModeDir | 0777, 1000},
+		{"/home/alice/shared/sticky", os.ModeDir | os.ModeSticky | 0777, 2000},
+		{"/home/alice/shared/sticky/bob.txt", 0644, 2000},
+	} {
+		if err := testFiler.CreateEntry(ctx, &filer.Entry{
+			FullPath: util.FullPath(e.path),
+			Attr:     filer.Attr{Mode: e.mode, Uid: e.uid, Gid: e.uid, Mtime: time.Now(), Crtime: time.Now()},
+		}, false, false, nil); err != nil {
+			t.Fatalf("create %s: %v", e.path, err)
+		}
+	}
+
+	alice := &filer.Identity{Uid: 1000, Gids: []uint32{1000}}
+	tests := []struct {
+		path        string
+		isRecursive bool
+		allowed     bool
+	}{
+		{"/home/alice/b", true, true},
+		{"/home/alice/docs", false, true},
+		// the directory is not writable, so its children can not be removed
+		{"/home/alice/docs", true, false},
+		// the sticky bit protects the file of another user
+		{"/home/alice/shared", true, false},
+		{"/home/alice", true, false},
+	}
+	for _, tt := range tests {
+		err := fs.checkDelete(ctx, alice, util.FullPath(tt.path), tt.isRecursive)
+		if tt.allowed && err != nil {
+			t.Errorf("delete %s recursive:%v: %v", tt.path, tt.isRecursive, err)
+		}
+		if !tt.allowed && !filer_pb.IsPermissionDenied(err) {
+			t.Errorf("delete %s recursive:%v should be denied: %v", tt.path, tt.isRecursive, err)
+		}
+	}
+
+	root := &filer.Identity{Uid: 0}
+	if err := fs.checkDelete(ctx, root, "/home/alice", true); err != nil {
+		t.Errorf("root delete: %v", err)
+	}
+}
+
+func TestWithoutUnreadableContent(t *testing.T) {
+	alice := &filer.Identity{Uid: 1000, Gids: []uint32{1000}}
+	bob := &filer.Identity{Uid: 2000, Gids: []uint32{2000}}
+	entry := &filer.Entry{
+		FullPath: "/home/alice/secret.txt",
+		Attr:     filer.Attr{Mode: 0600, Uid: 1000, Gid: 1000, FileSize: 0},
+		Chunks:   []*filer_pb.FileChunk{{FileId: "3,01637037d6", Size: 11}},
+	}
+
+	pbEntry := withoutUnreadableContent(alice, entry, entry.ToProtoEntry())
+	if len(pbEntry.Chunks) != 1 {
+		t.Errorf("owner should see the chunks: %+v", pbEntry)
+	}
+
+	pbEntry = withoutUnreadableContent(bob, entry, entry.ToProtoEntry())
+	if len(pbEntry.Chunks) != 0 || len(pbEntry.Content) != 0 {
+		t.Errorf("other users should not see the chunks: %+v", pbEntry)
+	}
+	if pbEntry.Attributes.FileSize != 11 {
+		t.Errorf("file size %d, want 11", pbEntry.Attributes.FileSize)
+	}
+
+	if pbEntry = withoutUnreadableContent(nil, entry, entry.ToProtoEntry()); len(pbEntry.Chunks) != 1 {
+		t.Errorf("no enforcement should keep the chunks: %+v", pbEntry)
+	}
+}
+
+func TestCanReadEvent(t *testing.T) {
+	bob := &filer.Identity{Uid: 2000, Gids: []uint32{2000}}
+	public := &filer_pb.Entry{Name: "public.txt", Attributes: &filer_pb.FuseAttributes{FileMode: 0644, Uid: 1000, Gid: 1000}}
+	private := &filer_pb.Entry{Name: "private.txt", Attributes: &filer_pb.FuseAttributes{FileMode: 0600, Uid: 1000, Gid: 1000}}
+
+	tests := []struct {
+		event   *filer_pb.EventNotification
+		allowed bool
+	}{
+		{&filer_pb.EventNotification{NewEntry: public}, true},
+		{&filer_pb.EventNotification{NewEntry: private}, false},
+		{&filer_pb.EventNotification{OldEntry: private}, false},
+		{&filer_pb.EventNotification{OldEntry: public, NewEntry: private, NewParentPath: "/tmp"}, false},
+	}
+	for i, tt := range tests {
+		if allowed := canReadEvent(bob, "/home/alice", tt.event); allowed != tt.allowed {
+			t.Errorf("event %d: allowed %v, want %v", i, allowed, tt.allowed)
+		}
+		if !canReadEvent(&filer.Identity{Uid: 0}, "/home/alice", tt.event) {
+			t.Errorf("event %d: root should read all events", i)
+		}
+	}
+}
+
+func TestCheckAdmin(t *testing.T) {
+	key := security.SigningKey("secret")
+	fs := &FilerServer{identityKey: key}
+
+	callerContext := func(uid uint32) context.Context {
+		encoded := security.GenIdentityJwt(key, 60, uid, []uint32{uid})
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(security.IdentityMetadataKey, string(encoded)))
+	}
+
+	if err := fs.checkAdmin(callerContext(0), "kv put"); err != nil {
+		t.Errorf("service identity: %v", err)
+	}
+	if err := fs.checkAdmin(callerContext(1000), "kv put"); !filer_pb.IsPermissionDenied(err) {
+		t.Errorf("user identity should be denied: %v", err)
+	}
+	if err := fs.checkAdmin(context.Background(), "kv put"); !filer_pb.IsPermissionDenied(err) {
+		t.Errorf("unsigned caller should be denied: %v", err)
+	}
+	if err := (&FilerServer{}).checkAdmin(context.Background(), "kv put"); err != nil {
+		t.Errorf("no enforcement: %v", err)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"github.com/chrislusf/seaweedfs/weed/storage/backend"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/stats"
 	"github.com/chrislusf/seaweedfs/weed/storage/needle"
 	"github.com/chrislusf/seaweedfs/weed/topology"
 )
@@ -144,6 +146,12 @@ func (ms *MasterServer) SendHeartbeat(stream master_pb.Seaweed_SendHeartbeatServ
 			}
 
 		}
+		for _, corruptNeedle := range heartbeat.CorruptNeedles {
+			glog.Warningf("volume server %s found corrupted needle %d,%x ec:%v repaired:%v: %s", dn.Url(),
+				corruptNeedle.VolumeId, corruptNeedle.NeedleId, corruptNeedle.IsEcVolume, corruptNeedle.Repaired, corruptNeedle.Error)
+			stats.MasterCorruptNeedleCounter.WithLabelValues(corruptNeedle.Collection, strconv.FormatBool(corruptNeedle.Repaired)).Inc()
+		}
+
 		if len(message.NewVids) > 0 || len(message.DeletedVids) > 0 {
 			ms.clientChansLock.RLock()
 			for host, ch := range ms.clientChans {
@@ -310,6 +318,8 @@ func (ms *MasterServer) GetMasterConfiguration(ctx context.Context, req *master_
 		StorageBackends:        backend.ToPbStorageBackends(),
 		DefaultReplication:     ms.option.DefaultReplicaPlacement,
 		Leader:                 leader,
+		DefaultEcScheme:        ms.defaultEcScheme,
+		CollectionEcSchemes:    ms.collectionEcSchemes,
 	}
 
 	return resp, nil
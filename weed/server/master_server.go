@@ -20,6 +20,7 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/security"
 	"github.com/chrislusf/seaweedfs/weed/sequence"
 	"github.com/chrislusf/seaweedfs/weed/shell"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
 	"github.com/chrislusf/seaweedfs/weed/topology"
 	"github.com/chrislusf/seaweedfs/weed/util"
 	"github.com/chrislusf/seaweedfs/weed/wdclient"
@@ -66,6 +67,9 @@ type MasterServer struct {
 	MasterClient *wdclient.MasterClient
 
 	adminLocks *AdminLocks
+
+	defaultEcScheme     string
+	collectionEcSchemes map[string]string
 }
 
 func NewMasterServer(r *mux.Router, option *MasterOption, peers []string) *MasterServer {
@@ -97,6 +101,7 @@ func NewMasterServer(r *mux.Router, option *MasterOption, peers []string) *Maste
 		adminLocks:      NewAdminLocks(),
 	}
 	ms.boundedLeaderChan = make(chan int, 16)
+	ms.loadEcSchemes(v)
 
 	seq := ms.createSequencer(option)
 	if nil == seq {
@@ -131,6 +136,7 @@ func NewMasterServer(r *mux.Router, option *MasterOption, peers []string) *Maste
 	ms.Topo.StartRefreshWritableVolumes(ms.grpcDialOption, ms.option.GarbageThreshold, ms.preallocateSize)
 
 	ms.startAdminScripts()
+	ms.startTieringPolicy()
 
 	return ms
 }
@@ -282,3 +288,18 @@ func (ms *MasterServer) createSequencer(option *MasterOption) sequence.Sequencer
 	}
 	return seq
 }
+
+// loadEcSchemes reads the erasure coding schemes used by ec.encode, by default and for each collection
+func (ms *MasterServer) loadEcSchemes(v *util.ViperProxy) {
+	v.SetDefault("master.erasure_coding.default", erasure_coding.DefaultEcScheme.String())
+	ms.defaultEcScheme = v.GetString("master.erasure_coding.default")
+	if _, err := erasure_coding.ParseEcScheme(ms.defaultEcScheme); err != nil {
+		glog.Fatalf("master.erasure_coding.default: %v", err)
+	}
+	ms.collectionEcSchemes = v.GetStringMapString("master.erasure_coding.collections")
+	for collection, scheme := range ms.collectionEcSchemes {
+		if _, err := erasure_coding.ParseEcScheme(scheme); err != nil {
+			glog.Fatalf("master.erasure_coding.collections.%s: %v", collection, err)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package weed_server
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/security"
+	"github.com/chrislusf/seaweedfs/weed/shell"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// startTieringPolicy periodically moves the volumes following the rules in [master.tiering], when this master is the leader
+func (ms *MasterServer) startTieringPolicy() {
+
+	v := util.GetViper()
+	if !v.GetBool("master.tiering.enabled") {
+		return
+	}
+
+	policy, err := shell.LoadTieringPolicy(v, "master.tiering.")
+	if err != nil {
+		glog.Errorf("tiering policy: %v", err)
+		return
+	}
+	if len(policy.Rules) == 0 {
+		glog.V(0).Infof("tiering policy: no rules in master.tiering.rules")
+		return
+	}
+
+	v.SetDefault("master.tiering.sleep_minutes", 60)
+	sleepMinutes := v.GetInt("master.tiering.sleep_minutes")
+	dryRun := v.GetBool("master.tiering.dry_run")
+
+	masterAddress := fmt.Sprintf("%s:%d", ms.option.Host, ms.option.Port)
+
+	var shellOptions shell.ShellOptions
+	shellOptions.GrpcDialOption = security.LoadClientTLS(v, "grpc.master")
+	shellOptions.Masters = &masterAddress
+	shellOptions.Directory = "/"
+
+	commandEnv := shell.NewCommandEnv(shellOptions)
+
+	reg, _ := regexp.Compile(`\S+`)
+
+	go commandEnv.MasterClient.KeepConnectedToMaster()
+
+	go func() {
+		commandEnv.MasterClient.WaitUntilConnected()
+
+		c := time.Tick(time.Duration(sleepMinutes) * time.Minute)
+		for range c {
+			if !ms.Topo.IsLeader() {
+				continue
+			}
+			var report bytes.Buffer
+			if !dryRun {
+				processEachCmd(reg, "lock", commandEnv)
+			}
+			if err := policy.Apply(commandEnv, &report, dryRun); err != nil {
+				glog.V(0).Infof("tiering policy: %v", err)
+			}
+			if !dryRun {
+				processEachCmd(reg, "unlock", commandEnv)
+			}
+			glog.V(0).Infof("tiering policy dry_run:%v\n%s", dryRun, report.String())
+		}
+	}()
+}
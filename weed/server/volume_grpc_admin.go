@@ -8,6 +8,7 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/chrislusf/seaweedfs/weed/stats"
+	"github.com/chrislusf/seaweedfs/weed/storage"
 	"github.com/chrislusf/seaweedfs/weed/storage/needle"
 	"github.com/chrislusf/seaweedfs/weed/storage/super_block"
 	"github.com/chrislusf/seaweedfs/weed/storage/types"
@@ -137,6 +138,78 @@ func (vs *VolumeServer) VolumeConfigure(ctx context.Context, req *volume_server_
 
 }
 
+func (vs *VolumeServer) VolumeConfigureCompression(ctx context.Context, req *volume_server_pb.VolumeConfigureCompressionRequest) (*volume_server_pb.VolumeConfigureCompressionResponse, error) {
+
+	resp := &volume_server_pb.VolumeConfigureCompressionResponse{}
+
+	// unmount
+	if err := vs.store.UnmountVolume(needle.VolumeId(req.VolumeId)); err != nil {
+		glog.Errorf("volume configure compression unmount %d: %v", req.VolumeId, err)
+		resp.Error = fmt.Sprintf("volume configure compression unmount %d: %v", req.VolumeId, err)
+		return resp, nil
+	}
+
+	// modify the volume info file and the dictionary file
+	configureErr := vs.store.ConfigureVolumeCompression(needle.VolumeId(req.VolumeId), req.Compression, req.Dictionary)
+	if configureErr != nil {
+		glog.Errorf("volume configure compression %d: %v", req.VolumeId, configureErr)
+		resp.Error = fmt.Sprintf("volume configure compression %d: %v", req.VolumeId, configureErr)
+	}
+
+	// mount, even if the configuration failed
+	if err := vs.store.MountVolume(needle.VolumeId(req.VolumeId)); err != nil {
+		glog.Errorf("volume configure compression mount %d: %v", req.VolumeId, err)
+		resp.Error = fmt.Sprintf("volume configure compression mount %d: %v", req.VolumeId, err)
+		return resp, nil
+	}
+
+	return resp, nil
+
+}
+
+func (vs *VolumeServer) VolumeConfigureEncryption(ctx context.Context, req *volume_server_pb.VolumeConfigureEncryptionRequest) (*volume_server_pb.VolumeConfigureEncryptionResponse, error) {
+
+	resp := &volume_server_pb.VolumeConfigureEncryptionResponse{}
+
+	// unmount
+	if err := vs.store.UnmountVolume(needle.VolumeId(req.VolumeId)); err != nil {
+		glog.Errorf("volume configure encryption unmount %d: %v", req.VolumeId, err)
+		resp.Error = fmt.Sprintf("volume configure encryption unmount %d: %v", req.VolumeId, err)
+		return resp, nil
+	}
+
+	// modify the volume info file
+	configureErr := vs.store.ConfigureVolumeEncryption(needle.VolumeId(req.VolumeId), req.Encryption, req.RotateKey)
+	if configureErr != nil {
+		glog.Errorf("volume configure encryption %d: %v", req.VolumeId, configureErr)
+		resp.Error = fmt.Sprintf("volume configure encryption %d: %v", req.VolumeId, configureErr)
+	}
+
+	// mount, even if the configuration failed
+	if err := vs.store.MountVolume(needle.VolumeId(req.VolumeId)); err != nil {
+		glog.Errorf("volume configure encryption mount %d: %v", req.VolumeId, err)
+		resp.Error = fmt.Sprintf("volume configure encryption mount %d: %v", req.VolumeId, err)
+		return resp, nil
+	}
+
+	return resp, nil
+
+}
+
+func (vs *VolumeServer) VolumeTrainCompressionDictionary(ctx context.Context, req *volume_server_pb.VolumeTrainCompressionDictionaryRequest) (*volume_server_pb.VolumeTrainCompressionDictionaryResponse, error) {
+
+	dictionary, sampleCount, err := vs.store.BuildVolumeCompressionDictionary(needle.VolumeId(req.VolumeId), int(req.MaxSize))
+	if err != nil {
+		return nil, fmt.Errorf("build compression dictionary for volume %d: %v", req.VolumeId, err)
+	}
+
+	return &volume_server_pb.VolumeTrainCompressionDictionaryResponse{
+		Dictionary:  dictionary,
+		SampleCount: uint32(sampleCount),
+	}, nil
+
+}
+
 func (vs *VolumeServer) VolumeMarkReadonly(ctx context.Context, req *volume_server_pb.VolumeMarkReadonlyRequest) (*volume_server_pb.VolumeMarkReadonlyResponse, error) {
 
 	resp := &volume_server_pb.VolumeMarkReadonlyResponse{}
@@ -193,6 +266,8 @@ func (vs *VolumeServer) VolumeServerStatus(ctx context.Context, req *volume_serv
 
 	resp.MemoryStatus = stats.MemStat()
 
+	resp.ScrubStatus = vs.store.ScrubStatus()
+
 	return resp, nil
 
 }
@@ -235,6 +310,10 @@ func (vs *VolumeServer) VolumeNeedleStatus(ctx context.Context, req *volume_serv
 	if count < 0 {
 		return nil, fmt.Errorf("needle not found %d", n.Id)
 	}
+	// report the checksum of the data as written by the client, not of the volume compression
+	if err = storage.UncompressNeedleData(n); err != nil {
+		return nil, err
+	}
 
 	resp.NeedleId = uint64(n.Id)
 	resp.Cookie = uint32(n.Cookie)
@@ -247,3 +326,46 @@ func (vs *VolumeServer) VolumeNeedleStatus(ctx context.Context, req *volume_serv
 	return resp, nil
 
 }
+
+func (vs *VolumeServer) VolumeNeedleVersions(ctx context.Context, req *volume_server_pb.VolumeNeedleVersionsRequest) (*volume_server_pb.VolumeNeedleVersionsResponse, error) {
+
+	versions, err := vs.store.ReadVolumeNeedleVersions(needle.VolumeId(req.VolumeId), types.NeedleId(req.NeedleId))
+	if err != nil {
+		return nil, fmt.Errorf("read needle versions %d,%x: %v", req.VolumeId, req.NeedleId, err)
+	}
+
+	resp := &volume_server_pb.VolumeNeedleVersionsResponse{}
+	for i, n := range versions {
+		if n == nil {
+			resp.Versions = append(resp.Versions, &volume_server_pb.VolumeNeedleVersionsResponse_NeedleVersion{
+				Version:   uint32(i),
+				IsDeleted: true,
+			})
+			continue
+		}
+		resp.Versions = append(resp.Versions, &volume_server_pb.VolumeNeedleVersionsResponse_NeedleVersion{
+			Version:      uint32(i),
+			Cookie:       uint32(n.Cookie),
+			Size:         uint32(n.Size),
+			LastModified: n.LastModified,
+			AppendAtNs:   n.AppendAtNs,
+			Crc:          n.Checksum.Value(),
+		})
+	}
+	return resp, nil
+
+}
+
+func (vs *VolumeServer) ReadNeedleBlob(ctx context.Context, req *volume_server_pb.ReadNeedleBlobRequest) (*volume_server_pb.ReadNeedleBlobResponse, error) {
+
+	blob, version, err := vs.store.ReadVolumeNeedleBlob(needle.VolumeId(req.VolumeId), types.NeedleId(req.NeedleId))
+	if err != nil {
+		return nil, fmt.Errorf("read needle blob %d,%x: %v", req.VolumeId, req.NeedleId, err)
+	}
+
+	return &volume_server_pb.ReadNeedleBlobResponse{
+		NeedleBlob: blob,
+		Version:    uint32(version),
+	}, nil
+
+}
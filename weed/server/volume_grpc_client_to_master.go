@@ -178,6 +178,17 @@ func (vs *VolumeServer) doHeartbeat(masterNode, masterGrpcAddress string, grpcDi
 				glog.V(0).Infof("Volume Server Failed to update to master %s: %v", masterNode, err)
 				return "", err
 			}
+		case corruptNeedle := <-vs.store.CorruptNeedlesChan:
+			deltaBeat := &master_pb.Heartbeat{
+				CorruptNeedles: []*master_pb.CorruptNeedleMessage{
+					corruptNeedle,
+				},
+			}
+			glog.V(1).Infof("volume server %s:%d reports corrupted needle %d,%x", vs.store.Ip, vs.store.Port, corruptNeedle.VolumeId, corruptNeedle.NeedleId)
+			if err = stream.Send(deltaBeat); err != nil {
+				glog.V(0).Infof("Volume Server Failed to update to master %s: %v", masterNode, err)
+				return "", err
+			}
 		case volumeMessage := <-vs.store.DeletedVolumesChan:
 			deltaBeat := &master_pb.Heartbeat{
 				DeletedVolumes: []*master_pb.VolumeShortInformationMessage{
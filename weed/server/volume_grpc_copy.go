@@ -82,6 +82,15 @@ func (vs *VolumeServer) VolumeCopy(ctx context.Context, req *volume_server_pb.Vo
 			return err
 		}
 
+		if err := vs.doCopyFile(client, false, req.Collection, req.VolumeId, volFileInfoResp.CompactionRevision, volFileInfoResp.DatFileSize, dataBaseFileName, ".zdict", false, true); err != nil {
+			return err
+		}
+
+		// the kept needle versions
+		if err := vs.doCopyFile(client, false, req.Collection, req.VolumeId, volFileInfoResp.CompactionRevision, volFileInfoResp.DatFileSize, indexBaseFileName, ".vdx", false, true); err != nil {
+			return err
+		}
+
 		os.Remove(dataBaseFileName + ".note")
 
 		return nil
@@ -102,6 +111,8 @@ func (vs *VolumeServer) VolumeCopy(ctx context.Context, req *volume_server_pb.Vo
 			os.Remove(idxFileName)
 			os.Remove(datFileName)
 			os.Remove(dataBaseFileName + ".vif")
+			os.Remove(dataBaseFileName + ".zdict")
+			os.Remove(indexBaseFileName + ".vdx")
 		}
 	}()
 
@@ -255,7 +266,7 @@ func (vs *VolumeServer) CopyFile(req *volume_server_pb.CopyFileRequest, stream v
 
 	file, err := os.Open(fileName)
 	if err != nil {
-		if req.IgnoreSourceFileNotFound && err == os.ErrNotExist {
+		if req.IgnoreSourceFileNotFound && os.IsNotExist(err) {
 			return nil
 		}
 		return err
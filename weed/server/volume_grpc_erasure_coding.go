@@ -25,16 +25,16 @@ import (
 
 Steps to apply erasure coding to .dat .idx files
 0. ensure the volume is readonly
-1. client call VolumeEcShardsGenerate to generate the .ecx and .ec00 ~ .ec13 files
+1. client call VolumeEcShardsGenerate to generate the .ecx and .ec00 ~ .ec13 files, for the default 10+4 scheme
 2. client ask master for possible servers to hold the ec files
 3. client call VolumeEcShardsCopy on above target servers to copy ec files from the source server
-4. target servers report the new ec files to the master
-5.   master stores vid -> [14]*DataNode
-6. client checks master. If all 14 slices are ready, delete the original .idx, .idx files
+4. target servers report the new ec files, with the scheme recorded in .vif file, to the master
+5.   master stores vid -> [data+parity]*DataNode
+6. client checks master. If all shards are ready, delete the original .idx, .idx files
 
 */
 
-// VolumeEcShardsGenerate generates the .ecx and .ec00 ~ .ec13 files
+// VolumeEcShardsGenerate generates the .ecx and .ec00 ~ .ecNN files, according to the requested scheme
 func (vs *VolumeServer) VolumeEcShardsGenerate(ctx context.Context, req *volume_server_pb.VolumeEcShardsGenerateRequest) (*volume_server_pb.VolumeEcShardsGenerateResponse, error) {
 
 	glog.V(0).Infof("VolumeEcShardsGenerate: %v", req)
@@ -49,8 +49,13 @@ func (vs *VolumeServer) VolumeEcShardsGenerate(ctx context.Context, req *volume_
 		return nil, fmt.Errorf("existing collection:%v unexpected input: %v", v.Collection, req.Collection)
 	}
 
-	// write .ec00 ~ .ec13 files
-	if err := erasure_coding.WriteEcFiles(baseFileName); err != nil {
+	scheme := erasure_coding.ToEcScheme(req.DataShards, req.ParityShards)
+	if _, err := erasure_coding.NewEcScheme(scheme.DataShards, scheme.ParityShards); err != nil {
+		return nil, err
+	}
+
+	// write .ec00 ~ .ecNN files
+	if err := erasure_coding.WriteEcFiles(baseFileName, scheme); err != nil {
 		return nil, fmt.Errorf("WriteEcFiles %s: %v", baseFileName, err)
 	}
 
@@ -60,14 +65,21 @@ func (vs *VolumeServer) VolumeEcShardsGenerate(ctx context.Context, req *volume_
 	}
 
 	// write .vif files
-	if err := pb.SaveVolumeInfo(baseFileName+".vif", &volume_server_pb.VolumeInfo{Version: uint32(v.Version())}); err != nil {
+	if err := pb.SaveVolumeInfo(baseFileName+".vif", &volume_server_pb.VolumeInfo{
+		Version:        uint32(v.Version()),
+		EcDataShards:   uint32(scheme.DataShards),
+		EcParityShards: uint32(scheme.ParityShards),
+		Compression:    v.Compression(),
+		Encryption:     v.Encryption(),
+		EncryptionKeys: v.EncryptionKeys(),
+	}); err != nil {
 		return nil, fmt.Errorf("WriteEcFiles %s: %v", baseFileName, err)
 	}
 
 	return &volume_server_pb.VolumeEcShardsGenerateResponse{}, nil
 }
 
-// VolumeEcShardsRebuild generates the any of the missing .ec00 ~ .ec13 files
+// VolumeEcShardsRebuild generates the any of the missing .ec00 ~ .ecNN files
 func (vs *VolumeServer) VolumeEcShardsRebuild(ctx context.Context, req *volume_server_pb.VolumeEcShardsRebuildRequest) (*volume_server_pb.VolumeEcShardsRebuildResponse, error) {
 
 	glog.V(0).Infof("VolumeEcShardsRebuild: %v", req)
@@ -78,9 +90,14 @@ func (vs *VolumeServer) VolumeEcShardsRebuild(ctx context.Context, req *volume_s
 
 	for _, location := range vs.store.Locations {
 		if util.FileExists(path.Join(location.IdxDirectory, baseFileName+".ecx")) {
-			// write .ec00 ~ .ec13 files
+			// write .ec00 ~ .ecNN files
 			dataBaseFileName := path.Join(location.Directory, baseFileName)
-			if generatedShardIds, err := erasure_coding.RebuildEcFiles(dataBaseFileName); err != nil {
+			volumeInfo, _, err := pb.MaybeLoadVolumeInfo(dataBaseFileName + ".vif")
+			if err != nil {
+				return nil, fmt.Errorf("load volume info %s: %v", dataBaseFileName, err)
+			}
+			scheme := erasure_coding.ToEcScheme(volumeInfo.EcDataShards, volumeInfo.EcParityShards)
+			if generatedShardIds, err := erasure_coding.RebuildEcFiles(dataBaseFileName, scheme); err != nil {
 				return nil, fmt.Errorf("RebuildEcFiles %s: %v", dataBaseFileName, err)
 			} else {
 				rebuiltShardIds = generatedShardIds
@@ -128,7 +145,6 @@ func (vs *VolumeServer) VolumeEcShardsCopy(ctx context.Context, req *volume_serv
 			if err := vs.doCopyFile(client, true, req.Collection, req.VolumeId, math.MaxUint32, math.MaxInt64, indexBaseFileName, ".ecx", false, false); err != nil {
 				return err
 			}
-			return nil
 		}
 
 		if req.CopyEcjFile {
@@ -143,6 +159,10 @@ func (vs *VolumeServer) VolumeEcShardsCopy(ctx context.Context, req *volume_serv
 			if err := vs.doCopyFile(client, true, req.Collection, req.VolumeId, math.MaxUint32, math.MaxInt64, dataBaseFileName, ".vif", false, true); err != nil {
 				return err
 			}
+			// copy the zstd dictionary if the needles are compressed with it
+			if err := vs.doCopyFile(client, true, req.Collection, req.VolumeId, math.MaxUint32, math.MaxInt64, dataBaseFileName, ".zdict", false, true); err != nil {
+				return err
+			}
 		}
 
 		return nil
@@ -221,6 +241,7 @@ func (vs *VolumeServer) VolumeEcShardsDelete(ctx context.Context, req *volume_se
 	if !hasIdxFile {
 		// .vif is used for ec volumes and normal volumes
 		os.Remove(dataBaseFilename + ".vif")
+		os.Remove(dataBaseFilename + ".zdict")
 	}
 
 	return &volume_server_pb.VolumeEcShardsDeleteResponse{}, nil
@@ -386,8 +407,8 @@ func (vs *VolumeServer) VolumeEcShardsToVolume(ctx context.Context, req *volume_
 		return nil, fmt.Errorf("FindDatFileSize %s: %v", dataBaseFileName, err)
 	}
 
-	// write .dat file from .ec00 ~ .ec09 files
-	if err := erasure_coding.WriteDatFile(dataBaseFileName, datFileSize); err != nil {
+	// write .dat file from the data shards
+	if err := erasure_coding.WriteDatFile(dataBaseFileName, datFileSize, v.Scheme); err != nil {
 		return nil, fmt.Errorf("WriteEcFiles %s: %v", dataBaseFileName, err)
 	}
 
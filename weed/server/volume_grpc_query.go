@@ -5,6 +5,7 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/operation"
 	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/chrislusf/seaweedfs/weed/query/json"
+	"github.com/chrislusf/seaweedfs/weed/storage"
 	"github.com/chrislusf/seaweedfs/weed/storage/needle"
 	"github.com/tidwall/gjson"
 )
@@ -34,6 +35,11 @@ func (vs *VolumeServer) Query(req *volume_server_pb.QueryRequest, stream volume_
 			return err
 		}
 
+		if err := storage.DecodeNeedleData(n); err != nil {
+			glog.V(0).Infof("volume query failed to decode fid %s: %v", fid, err)
+			return err
+		}
+
 		if req.InputSerialization.CsvInput != nil {
 
 		}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"github.com/chrislusf/seaweedfs/weed/storage/types"
 	"net/http"
+	"time"
 
 	"google.golang.org/grpc"
 
 	"github.com/chrislusf/seaweedfs/weed/stats"
 	"github.com/chrislusf/seaweedfs/weed/util"
+	"github.com/chrislusf/seaweedfs/weed/util/read_cache"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
 	"github.com/chrislusf/seaweedfs/weed/security"
@@ -48,6 +50,10 @@ func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 	readRedirect bool,
 	compactionMBPerSecond int,
 	fileSizeLimitMB int,
+	scrubMBPerSecond int,
+	scrubIntervalHours int,
+	readCacheDir string,
+	readCacheSizeMB int,
 ) *VolumeServer {
 
 	v := util.GetViper()
@@ -78,6 +84,13 @@ func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 	vs.checkWithMaster()
 
 	vs.store = storage.NewStore(vs.grpcDialOption, port, ip, publicUrl, folders, maxCounts, minFreeSpacePercents, idxFolder, vs.needleMapKind, diskTypes)
+	if readCacheDir != "" {
+		readCache, err := read_cache.NewReadCache(util.ResolvePath(readCacheDir), int64(readCacheSizeMB)*1024*1024)
+		if err != nil {
+			glog.Fatalf("read cache: %v", err)
+		}
+		vs.store.SetReadCache(readCache)
+	}
 	vs.guard = security.NewGuard(whiteList, signingKey, expiresAfterSec, readSigningKey, readExpiresAfterSec)
 
 	handleStaticResources(adminMux)
@@ -99,6 +112,7 @@ func NewVolumeServer(adminMux, publicMux *http.ServeMux, ip string,
 	}
 
 	go vs.heartbeat()
+	vs.store.StartScrubbing(int64(scrubMBPerSecond)*1024*1024, time.Duration(scrubIntervalHours)*time.Hour)
 	go stats.LoopPushingMetric("volumeServer", fmt.Sprintf("%s:%d", ip, port), vs.metricsAddress, vs.metricsIntervalSec)
 
 	return vs
@@ -72,6 +72,9 @@ func (vs *VolumeServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 			if c := r.FormValue("collection"); c != "" {
 				arg.Set("collection", c)
 			}
+			if version := r.FormValue("version"); version != "" {
+				arg.Set("version", version)
+			}
 			u.RawQuery = arg.Encode()
 			http.Redirect(w, r, u.String(), http.StatusMovedPermanently)
 
@@ -86,10 +89,20 @@ func (vs *VolumeServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 	readOption := &storage.ReadOption{
 		ReadDeleted: r.FormValue("readDeleted") == "true",
 	}
+	if version := r.FormValue("version"); version != "" {
+		if readOption.Version, err = strconv.Atoi(version); err != nil || readOption.Version < 0 {
+			glog.V(2).Infof("read %s with invalid version %s", r.URL.Path, version)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
 
 	var count int
 	if hasVolume {
 		count, err = vs.store.ReadVolumeNeedle(volumeId, n, readOption)
+	} else if hasEcVolume && readOption.Version > 0 {
+		// erasure coded volumes do not keep needle versions
+		err = storage.ErrorNotFound
 	} else if hasEcVolume {
 		count, err = vs.store.ReadEcShardNeedle(volumeId, n)
 	}
@@ -154,13 +167,20 @@ func (vs *VolumeServer) GetOrHeadHandler(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	if n.IsCompressed() {
+	if n.IsZstdCompressed() {
+		// compressed with a dictionary can only be decompressed here
+		if _, _, _, shouldResize := shouldResizeImages(ext, r); !shouldResize && strings.Contains(r.Header.Get("Accept-Encoding"), "zstd") && util.ZstdDictionaryId(n.Data) == 0 {
+			w.Header().Set("Content-Encoding", "zstd")
+		} else if err = storage.UncompressNeedleData(n); err != nil {
+			glog.V(0).Infoln("unzstd error:", err, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	} else if n.IsCompressed() {
 		if _, _, _, shouldResize := shouldResizeImages(ext, r); shouldResize {
 			if n.Data, err = util.DecompressData(n.Data); err != nil {
 				glog.V(0).Infoln("ungzip error:", err, r.URL.Path)
 			}
-			// } else if strings.Contains(r.Header.Get("Accept-Encoding"), "zstd") && util.IsZstdContent(n.Data) {
-			//	w.Header().Set("Content-Encoding", "zstd")
 		} else if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") && util.IsGzippedContent(n.Data) {
 			w.Header().Set("Content-Encoding", "gzip")
 		} else {
@@ -0,0 +1,320 @@
+package weed_server
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/net/webdav"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const webDavLocksDirectory = "/etc/webdav/locks"
+
+// webDavLock is one exclusive write lock, stored as a json file named by the hash of the locked path
+type webDavLock struct {
+	Token     string        `json:"token"`
+	Root      string        `json:"root"`
+	OwnerXML  string        `json:"ownerXml,omitempty"`
+	ZeroDepth bool          `json:"zeroDepth,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	// unix time in nanoseconds, 0 means never expire
+	Expiry int64 `json:"expiry,omitempty"`
+}
+
+func (l *webDavLock) expired(now time.Time) bool {
+	return l.Expiry != 0 && l.Expiry <= now.UnixNano()
+}
+
+// covers tells whether the lock protects the path
+func (l *webDavLock) covers(fullpath string) bool {
+	if l.Root == fullpath {
+		return true
+	}
+	return !l.ZeroDepth && isDescendantPath(l.Root, fullpath)
+}
+
+// conflicts tells whether a new lock on the path can not be created because of this lock
+func (l *webDavLock) conflicts(fullpath string, zeroDepth bool) bool {
+	return l.covers(fullpath) || !zeroDepth && isDescendantPath(fullpath, l.Root)
+}
+
+func isDescendantPath(dir, fullpath string) bool {
+	return dir == "/" && fullpath != "/" || strings.HasPrefix(fullpath, dir+"/")
+}
+
+// webDavRequestLocks holds the locks the webdav handler takes for the duration of one request,
+// when the client does not hold a lock. They are too short lived to be stored in the filer.
+type webDavRequestLocks struct {
+	sync.Mutex
+	locks map[string]*webDavLock
+}
+
+func newWebDavRequestLocks() *webDavRequestLocks {
+	return &webDavRequestLocks{
+		locks: make(map[string]*webDavLock),
+	}
+}
+
+// filerLockSystem keeps the webdav locks in the filer, so all webdav servers on the same filer share them.
+// The lock names are relative to the root directory of the user.
+type filerLockSystem struct {
+	client       filer_pb.FilerClient
+	root         util.FullPath
+	requestLocks *webDavRequestLocks
+}
+
+var _ = webdav.LockSystem(&filerLockSystem{})
+
+func newFilerLockSystem(client filer_pb.FilerClient, root util.FullPath, requestLocks *webDavRequestLocks) *filerLockSystem {
+	return &filerLockSystem{
+		client:       client,
+		root:         root,
+		requestLocks: requestLocks,
+	}
+}
+
+func (ls *filerLockSystem) fullpath(name string) string {
+	name = path.Clean("/" + name)
+	if name == "/" {
+		return string(ls.root)
+	}
+	return string(ls.root.Child(name[1:]))
+}
+
+func (ls *filerLockSystem) relativePath(fullpath string) (string, bool) {
+	switch {
+	case ls.root == "/":
+		return fullpath, true
+	case fullpath == string(ls.root):
+		return "/", true
+	case isDescendantPath(string(ls.root), fullpath):
+		return strings.TrimPrefix(fullpath, string(ls.root)), true
+	}
+	return "", false
+}
+
+// isRequestLock tells the locks taken by the webdav handler while serving a request without an If header
+func isRequestLock(details webdav.LockDetails) bool {
+	return details.Duration < 0 && details.ZeroDepth && details.OwnerXML == ""
+}
+
+func (ls *filerLockSystem) Confirm(now time.Time, name0, name1 string, conditions ...webdav.Condition) (func(), error) {
+
+	glog.V(4).Infof("webdav lock confirm %s %s %+v", name0, name1, conditions)
+
+	if len(conditions) == 0 {
+		return nil, webdav.ErrConfirmationFailed
+	}
+
+	locks, err := ls.listLocks(now)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{name0, name1} {
+		if name == "" {
+			continue
+		}
+		if ls.lookup(locks, ls.fullpath(name), conditions) == nil {
+			return nil, webdav.ErrConfirmationFailed
+		}
+	}
+
+	// a lock can not be held across the webdav servers, the filer serializes the operations anyway
+	return func() {}, nil
+}
+
+func (ls *filerLockSystem) lookup(locks []*webDavLock, fullpath string, conditions []webdav.Condition) *webDavLock {
+	for _, c := range conditions {
+		for _, l := range locks {
+			if l.Token == c.Token && l.covers(fullpath) {
+				return l
+			}
+		}
+	}
+	return nil
+}
+
+func (ls *filerLockSystem) Create(now time.Time, details webdav.LockDetails) (string, error) {
+
+	lock := &webDavLock{
+		Token:     "opaquelocktoken:" + uuid.New().String(),
+		Root:      ls.fullpath(details.Root),
+		OwnerXML:  details.OwnerXML,
+		ZeroDepth: details.ZeroDepth,
+		Duration:  details.Duration,
+	}
+	if details.Duration >= 0 {
+		lock.Expiry = now.Add(details.Duration).UnixNano()
+	}
+
+	locks, err := ls.listLocks(now)
+	if err != nil {
+		return "", err
+	}
+
+	ls.requestLocks.Lock()
+	defer ls.requestLocks.Unlock()
+
+	for _, l := range locks {
+		if l.conflicts(lock.Root, lock.ZeroDepth) {
+			return "", webdav.ErrLocked
+		}
+	}
+	for _, l := range ls.requestLocks.locks {
+		if l.conflicts(lock.Root, lock.ZeroDepth) {
+			return "", webdav.ErrLocked
+		}
+	}
+
+	if isRequestLock(details) {
+		ls.requestLocks.locks[lock.Token] = lock
+		return lock.Token, nil
+	}
+
+	if err := ls.saveLock(lock, true); err != nil {
+		if strings.Contains(err.Error(), "EEXIST") {
+			return "", webdav.ErrLocked
+		}
+		return "", err
+	}
+
+	glog.V(2).Infof("webdav lock %s on %s", lock.Token, lock.Root)
+	return lock.Token, nil
+}
+
+func (ls *filerLockSystem) Refresh(now time.Time, token string, duration time.Duration) (webdav.LockDetails, error) {
+
+	lock, err := ls.findLock(now, token)
+	if err != nil {
+		return webdav.LockDetails{}, err
+	}
+
+	lock.Duration = duration
+	lock.Expiry = 0
+	if duration >= 0 {
+		lock.Expiry = now.Add(duration).UnixNano()
+	}
+	if err = ls.saveLock(lock, false); err != nil {
+		return webdav.LockDetails{}, err
+	}
+
+	root, _ := ls.relativePath(lock.Root)
+	return webdav.LockDetails{
+		Root:      root,
+		Duration:  lock.Duration,
+		OwnerXML:  lock.OwnerXML,
+		ZeroDepth: lock.ZeroDepth,
+	}, nil
+}
+
+func (ls *filerLockSystem) Unlock(now time.Time, token string) error {
+
+	ls.requestLocks.Lock()
+	if _, found := ls.requestLocks.locks[token]; found {
+		delete(ls.requestLocks.locks, token)
+		ls.requestLocks.Unlock()
+		return nil
+	}
+	ls.requestLocks.Unlock()
+
+	lock, err := ls.findLock(now, token)
+	if err != nil {
+		return err
+	}
+
+	glog.V(2).Infof("webdav unlock %s on %s", lock.Token, lock.Root)
+	return ls.deleteLock(lock)
+}
+
+// findLock looks up a stored lock under the root directory by its token
+func (ls *filerLockSystem) findLock(now time.Time, token string) (*webDavLock, error) {
+	locks, err := ls.listLocks(now)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range locks {
+		if l.Token != token {
+			continue
+		}
+		if _, ok := ls.relativePath(l.Root); !ok {
+			break
+		}
+		return l, nil
+	}
+	return nil, webdav.ErrNoSuchLock
+}
+
+// listLocks reads the stored locks, and removes the expired ones
+func (ls *filerLockSystem) listLocks(now time.Time) (locks []*webDavLock, err error) {
+	var expired []*webDavLock
+	err = filer_pb.ReadDirAllEntries(ls.client, webDavLocksDirectory, "", func(entry *filer_pb.Entry, isLast bool) error {
+		lock := &webDavLock{}
+		if err := json.Unmarshal(entry.Content, lock); err != nil {
+			glog.V(0).Infof("webdav lock %s/%s: %v", webDavLocksDirectory, entry.Name, err)
+			return nil
+		}
+		if lock.expired(now) {
+			expired = append(expired, lock)
+			return nil
+		}
+		locks = append(locks, lock)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list webdav locks: %v", err)
+	}
+	for _, lock := range expired {
+		if err := ls.deleteLock(lock); err != nil {
+			glog.V(1).Infof("remove expired webdav lock on %s: %v", lock.Root, err)
+		}
+	}
+	return locks, nil
+}
+
+func lockEntryName(root string) string {
+	return util.Md5String([]byte(root))
+}
+
+func (ls *filerLockSystem) saveLock(lock *webDavLock, exclusive bool) error {
+	data, err := json.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	entry := &filer_pb.Entry{
+		Name:    lockEntryName(lock.Root),
+		Content: data,
+		Attributes: &filer_pb.FuseAttributes{
+			Mtime:    now,
+			Crtime:   now,
+			FileMode: uint32(0600),
+			FileSize: uint64(len(data)),
+		},
+	}
+	return ls.client.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		if exclusive {
+			return filer_pb.CreateEntry(client, &filer_pb.CreateEntryRequest{
+				Directory: webDavLocksDirectory,
+				Entry:     entry,
+				OExcl:     true,
+			})
+		}
+		return filer_pb.UpdateEntry(client, &filer_pb.UpdateEntryRequest{
+			Directory: webDavLocksDirectory,
+			Entry:     entry,
+		})
+	})
+}
+
+func (ls *filerLockSystem) deleteLock(lock *webDavLock) error {
+	return filer_pb.Remove(ls.client, webDavLocksDirectory, lockEntryName(lock.Root), true, false, false, false, nil)
+}
@@ -0,0 +1,49 @@
+package weed_server
+
+import (
+	"testing"
+)
+
+func TestWebDavLockConflicts(t *testing.T) {
+	depthInfinity := &webDavLock{Root: "/a/b"}
+	zeroDepth := &webDavLock{Root: "/a/b", ZeroDepth: true}
+
+	tests := []struct {
+		lock      *webDavLock
+		path      string
+		zeroDepth bool
+		expected  bool
+	}{
+		{depthInfinity, "/a/b", true, true},
+		{depthInfinity, "/a/b/c", true, true},
+		{depthInfinity, "/a/bc", true, false},
+		{depthInfinity, "/a", true, false},
+		{depthInfinity, "/a", false, true},
+		{depthInfinity, "/", false, true},
+		{zeroDepth, "/a/b", true, true},
+		{zeroDepth, "/a/b/c", true, false},
+		{zeroDepth, "/a", false, true},
+		{&webDavLock{Root: "/"}, "/x", true, true},
+	}
+	for _, tt := range tests {
+		if actual := tt.lock.conflicts(tt.path, tt.zeroDepth); actual != tt.expected {
+			t.Errorf("lock %+v on %s zeroDepth:%v: expected %v, actual %v", tt.lock, tt.path, tt.zeroDepth, tt.expected, actual)
+		}
+	}
+}
+
+func TestWebDavLockPaths(t *testing.T) {
+	ls := newFilerLockSystem(nil, "/home/alice", newWebDavRequestLocks())
+	if p := ls.fullpath("/docs/../a.txt"); p != "/home/alice/a.txt" {
+		t.Errorf("unexpected full path %s", p)
+	}
+	if p := ls.fullpath("/"); p != "/home/alice" {
+		t.Errorf("unexpected root path %s", p)
+	}
+	if p, ok := ls.relativePath("/home/alice/a.txt"); !ok || p != "/a.txt" {
+		t.Errorf("unexpected relative path %s %v", p, ok)
+	}
+	if _, ok := ls.relativePath("/home/alice2/a.txt"); ok {
+		t.Errorf("path outside of the root")
+	}
+}
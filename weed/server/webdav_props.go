@@ -0,0 +1,212 @@
+package weed_server
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/webdav"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// the dead properties are stored in the entry extended attributes, keyed by the property name in clark notation
+const webDavPropertyPrefix = "webdav.prop:"
+
+var (
+	quotaAvailableBytes = xml.Name{Space: "DAV:", Local: "quota-available-bytes"}
+	quotaUsedBytes      = xml.Name{Space: "DAV:", Local: "quota-used-bytes"}
+)
+
+var _ = webdav.DeadPropsHolder(&WebDavFile{})
+
+func webDavPropertyKey(name xml.Name) string {
+	return webDavPropertyPrefix + "{" + name.Space + "}" + name.Local
+}
+
+func isQuotaProperty(name xml.Name) bool {
+	return name == quotaAvailableBytes || name == quotaUsedBytes
+}
+
+func (f *WebDavFile) fullpath() util.FullPath {
+	if name := strings.TrimSuffix(f.name, "/"); name != "" {
+		return util.FullPath(name)
+	}
+	return "/"
+}
+
+func (f *WebDavFile) DeadProps() (map[xml.Name]webdav.Property, error) {
+
+	entry, err := filer_pb.GetEntry(f.fs, f.fullpath())
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	props := make(map[xml.Name]webdav.Property)
+	for key, value := range entry.Extended {
+		if !strings.HasPrefix(key, webDavPropertyPrefix) {
+			continue
+		}
+		var prop webdav.Property
+		if err := json.Unmarshal(value, &prop); err != nil {
+			glog.V(1).Infof("webdav property %s of %s: %v", key, f.name, err)
+			continue
+		}
+		props[prop.XMLName] = prop
+	}
+
+	if entry.IsDirectory {
+		if available, used, found := f.fs.quota(f.fullpath()); found {
+			props[quotaAvailableBytes] = webdav.Property{XMLName: quotaAvailableBytes, InnerXML: []byte(strconv.FormatInt(available, 10))}
+			props[quotaUsedBytes] = webdav.Property{XMLName: quotaUsedBytes, InnerXML: []byte(strconv.FormatInt(used, 10))}
+		}
+	}
+
+	return props, nil
+}
+
+func (f *WebDavFile) Patch(patches []webdav.Proppatch) ([]webdav.Propstat, error) {
+
+	glog.V(2).Infof("WebDavFile.Patch %v", f.name)
+
+	var names []webdav.Property
+	protected := false
+	for _, patch := range patches {
+		for _, p := range patch.Props {
+			names = append(names, webdav.Property{XMLName: p.XMLName})
+			protected = protected || isQuotaProperty(p.XMLName)
+		}
+	}
+
+	// the quota properties are computed, and all patches fail together
+	if protected {
+		pstatForbidden := webdav.Propstat{
+			Status:   http.StatusForbidden,
+			XMLError: `<D:cannot-modify-protected-property xmlns:D="DAV:"/>`,
+		}
+		pstatFailedDep := webdav.Propstat{
+			Status: webdav.StatusFailedDependency,
+		}
+		for _, name := range names {
+			if isQuotaProperty(name.XMLName) {
+				pstatForbidden.Props = append(pstatForbidden.Props, name)
+			} else {
+				pstatFailedDep.Props = append(pstatFailedDep.Props, name)
+			}
+		}
+		if len(pstatFailedDep.Props) == 0 {
+			return []webdav.Propstat{pstatForbidden}, nil
+		}
+		return []webdav.Propstat{pstatForbidden, pstatFailedDep}, nil
+	}
+
+	fullpath := f.fullpath()
+	entry, err := filer_pb.GetEntry(f.fs, fullpath)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, os.ErrNotExist
+	}
+
+	if entry.Extended == nil {
+		entry.Extended = make(map[string][]byte)
+	}
+	for _, patch := range patches {
+		for _, p := range patch.Props {
+			key := webDavPropertyKey(p.XMLName)
+			if patch.Remove {
+				delete(entry.Extended, key)
+				continue
+			}
+			data, err := json.Marshal(p)
+			if err != nil {
+				return nil, err
+			}
+			entry.Extended[key] = data
+		}
+	}
+
+	dir, _ := fullpath.DirAndName()
+	err = f.fs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		return filer_pb.UpdateEntry(client, &filer_pb.UpdateEntryRequest{
+			Directory:  dir,
+			Entry:      entry,
+			Signatures: []int32{f.fs.signature},
+		})
+	})
+	if filer_pb.IsPermissionDenied(err) {
+		return []webdav.Propstat{{Status: http.StatusForbidden, Props: names}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []webdav.Propstat{{Status: http.StatusOK, Props: names}}, nil
+}
+
+// quota reports the byte quota covering the directory, or the free space if there is no quota
+func (fs *WebDavFileSystem) quota(dir util.FullPath) (available, used int64, found bool) {
+
+	err := fs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.GetQuota(context.Background(), &filer_pb.GetQuotaRequest{
+			Directory: string(dir),
+		})
+		if err != nil {
+			return err
+		}
+		if quota := resp.Quota; quota != nil && quota.MaxBytes > 0 {
+			available, used, found = quota.MaxBytes-quota.UsedBytes, quota.UsedBytes, true
+		}
+		return nil
+	})
+	if err != nil {
+		glog.V(1).Infof("reading quota of %s: %v", dir, err)
+		return
+	}
+	if found {
+		if available < 0 {
+			available = 0
+		}
+		return
+	}
+
+	fs.statsLock.Lock()
+	defer fs.statsLock.Unlock()
+	if fs.stats.lastChecked < time.Now().Unix()-20 {
+		err = fs.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+			resp, err := client.Statistics(context.Background(), &filer_pb.StatisticsRequest{
+				Collection:  fs.option.Collection,
+				Replication: fs.option.Replication,
+				DiskType:    fs.option.DiskType,
+			})
+			if err != nil {
+				return err
+			}
+			fs.stats.totalSize, fs.stats.usedSize = resp.TotalSize, resp.UsedSize
+			fs.stats.lastChecked = time.Now().Unix()
+			return nil
+		})
+		if err != nil {
+			glog.V(1).Infof("reading filer stats: %v", err)
+			return
+		}
+	}
+
+	used = int64(fs.stats.usedSize)
+	available = int64(fs.stats.totalSize) - used
+	if available < 0 {
+		available = 0
+	}
+	return available, used, true
+}
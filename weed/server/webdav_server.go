@@ -3,14 +3,19 @@ package weed_server
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"fmt"
 	"io"
 	"math"
+	"net/http"
 	"os"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/chrislusf/seaweedfs/weed/ftpd"
 	"github.com/chrislusf/seaweedfs/weed/util/buffered_writer"
 	"golang.org/x/net/webdav"
 	"google.golang.org/grpc"
@@ -40,6 +45,8 @@ type WebDavOption struct {
 	Cipher           bool
 	CacheDir         string
 	CacheSizeMB      int64
+	// if set, the users log in with basic auth, and each user is confined to the home directory
+	Users *ftpd.FtpUsers
 }
 
 type WebDavServer struct {
@@ -47,25 +54,161 @@ type WebDavServer struct {
 	secret         security.SigningKey
 	filer          *filer.Filer
 	grpcDialOption grpc.DialOption
-	Handler        *webdav.Handler
+	chunkCache     *chunk_cache.TieredChunkCache
+	requestLocks   *webDavRequestLocks
+	userHandlers   map[string]*webDavUserHandler
+	userLock       sync.Mutex
+	Handler        http.Handler
 }
 
-func NewWebDavServer(option *WebDavOption) (ws *WebDavServer, err error) {
+type webDavUserHandler struct {
+	*webdav.Handler
+	user *ftpd.FtpUser
+	// the hash of the checked password, to skip checking the bcrypt hash again
+	passwordHash [sha256.Size]byte
+}
 
-	fs, _ := NewWebDavFileSystem(option)
+func NewWebDavServer(option *WebDavOption) (ws *WebDavServer, err error) {
 
 	ws = &WebDavServer{
 		option:         option,
 		grpcDialOption: security.LoadClientTLS(util.GetViper(), "grpc.filer"),
-		Handler: &webdav.Handler{
-			FileSystem: fs,
-			LockSystem: webdav.NewMemLS(),
-		},
+		chunkCache:     newWebDavChunkCache(option),
+		requestLocks:   newWebDavRequestLocks(),
+		userHandlers:   make(map[string]*webDavUserHandler),
+	}
+
+	if option.Users == nil {
+		ws.Handler = ws.newHandler(option, "/")
+	} else {
+		ws.Handler = http.HandlerFunc(ws.serveUser)
 	}
 
 	return ws, nil
 }
 
+var _ = filer_pb.FilerClient(&WebDavServer{})
+
+// WithFilerClient acts as the webdav server itself, to share the locks and to create the home directories
+func (ws *WebDavServer) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
+	return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
+		return fn(filer_pb.NewServiceFilerClient(filer_pb.NewSeaweedFilerClient(grpcConnection)))
+	}, ws.option.FilerGrpcAddress, ws.option.GrpcDialOption)
+}
+
+func (ws *WebDavServer) AdjustedUrl(location *filer_pb.Location) string {
+	return location.Url
+}
+
+func (ws *WebDavServer) newHandler(option *WebDavOption, root util.FullPath) *webdav.Handler {
+	return &webdav.Handler{
+		FileSystem: newWebDavFileSystem(option, root, ws.chunkCache),
+		LockSystem: newFilerLockSystem(ws, root, ws.requestLocks),
+		Logger: func(r *http.Request, err error) {
+			if err != nil {
+				glog.V(1).Infof("webdav %s %s: %v", r.Method, r.URL.Path, err)
+			}
+		},
+	}
+}
+
+// serveUser authenticates the user, and serves the request within the home directory of the user
+func (ws *WebDavServer) serveUser(w http.ResponseWriter, r *http.Request) {
+
+	username, password, ok := r.BasicAuth()
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Basic realm="SeaweedFS WebDAV"`)
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	handler, user, err := ws.userHandler(username, password)
+	if err != nil {
+		glog.V(0).Infof("webdav user %s from %s: %v", username, r.RemoteAddr, err)
+		w.Header().Set("WWW-Authenticate", `Basic realm="SeaweedFS WebDAV"`)
+		http.Error(w, "authentication failed", http.StatusUnauthorized)
+		return
+	}
+	if handler == nil {
+		http.Error(w, "home directory unavailable", http.StatusInternalServerError)
+		return
+	}
+
+	if user.ReadOnly && isWebDavWriteMethod(r.Method) {
+		http.Error(w, "read only user", http.StatusForbidden)
+		return
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+func isWebDavWriteMethod(method string) bool {
+	switch method {
+	case "PUT", "DELETE", "MKCOL", "COPY", "MOVE", "PROPPATCH", "LOCK", "UNLOCK", "POST", "PATCH":
+		return true
+	}
+	return false
+}
+
+func (ws *WebDavServer) userHandler(username, password string) (*webDavUserHandler, *ftpd.FtpUser, error) {
+
+	passwordHash := sha256.Sum256([]byte(password))
+
+	ws.userLock.Lock()
+	handler, found := ws.userHandlers[username]
+	ws.userLock.Unlock()
+	if found && subtle.ConstantTimeCompare(handler.passwordHash[:], passwordHash[:]) == 1 {
+		return handler, handler.user, nil
+	}
+
+	user, err := ws.option.Users.AuthenticatePassword(username, password)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	home := util.FullPath(user.HomeDir)
+	if err := ws.ensureHomeDirectory(user, home); err != nil {
+		glog.Errorf("webdav user %s: %v", username, err)
+		return nil, user, nil
+	}
+
+	// the files are created and checked as the user
+	userOption := *ws.option
+	userOption.Uid, userOption.Gid = user.Uid, user.Gid
+
+	handler = &webDavUserHandler{
+		Handler:      ws.newHandler(&userOption, home),
+		user:         user,
+		passwordHash: passwordHash,
+	}
+	ws.userLock.Lock()
+	ws.userHandlers[username] = handler
+	ws.userLock.Unlock()
+
+	return handler, user, nil
+}
+
+func (ws *WebDavServer) ensureHomeDirectory(user *ftpd.FtpUser, home util.FullPath) error {
+	entry, err := filer_pb.GetEntry(ws, home)
+	if err != nil {
+		return fmt.Errorf("read home directory %s: %v", home, err)
+	}
+	if entry != nil {
+		if !entry.IsDirectory {
+			return fmt.Errorf("home %s is not a directory", home)
+		}
+		return nil
+	}
+	dir, name := home.DirAndName()
+	if err = filer_pb.Mkdir(ws, dir, name, func(entry *filer_pb.Entry) {
+		entry.Attributes.FileMode = uint32(0700 | os.ModeDir)
+		entry.Attributes.Uid, entry.Attributes.Gid = user.Uid, user.Gid
+	}); err != nil {
+		return fmt.Errorf("create home directory %s: %v", home, err)
+	}
+	return nil
+}
+
 // adapted from https://github.com/mattn/davfs/blob/master/plugin/mysql/mysql.go
 
 type WebDavFileSystem struct {
@@ -75,6 +218,16 @@ type WebDavFileSystem struct {
 	grpcDialOption grpc.DialOption
 	chunkCache     *chunk_cache.TieredChunkCache
 	signature      int32
+	// the filer directory seen as "/" by the clients
+	root      util.FullPath
+	stats     webDavStats
+	statsLock sync.Mutex
+}
+
+type webDavStats struct {
+	totalSize   uint64
+	usedSize    uint64
+	lastChecked int64 // unix time in seconds
 }
 
 type FileInfo struct {
@@ -106,29 +259,83 @@ type WebDavFile struct {
 }
 
 func NewWebDavFileSystem(option *WebDavOption) (webdav.FileSystem, error) {
+	return newWebDavFileSystem(option, "/", newWebDavChunkCache(option)), nil
+}
 
+func newWebDavChunkCache(option *WebDavOption) *chunk_cache.TieredChunkCache {
 	cacheUniqueId := util.Md5String([]byte("webdav" + option.FilerGrpcAddress + util.Version()))[0:8]
 	cacheDir := path.Join(option.CacheDir, cacheUniqueId)
 
 	os.MkdirAll(cacheDir, os.FileMode(0755))
-	chunkCache := chunk_cache.NewTieredChunkCache(256, cacheDir, option.CacheSizeMB, 1024*1024)
+	return chunk_cache.NewTieredChunkCache(256, cacheDir, option.CacheSizeMB, 1024*1024)
+}
+
+func newWebDavFileSystem(option *WebDavOption, root util.FullPath, chunkCache *chunk_cache.TieredChunkCache) *WebDavFileSystem {
 	return &WebDavFileSystem{
 		option:     option,
 		chunkCache: chunkCache,
 		signature:  util.RandomInt32(),
-	}, nil
+		root:       root,
+	}
+}
+
+// fullpath maps the cleaned path in the request to the path on the filer, keeping the trailing slash
+func (fs *WebDavFileSystem) fullpath(name string) string {
+	if fs.root == "/" {
+		return name
+	}
+	return string(fs.root) + name
+}
+
+func (fs *WebDavFileSystem) isRoot(fullFilePath string) bool {
+	return fullFilePath == string(fs.root) || fullFilePath == string(fs.root)+"/"
 }
 
 var _ = filer_pb.FilerClient(&WebDavFileSystem{})
 
 func (fs *WebDavFileSystem) WithFilerClient(fn func(filer_pb.SeaweedFilerClient) error) error {
 
+	// act as the configured uid and gid, so the filer checks the permissions and acls against them
+	encoded := security.GenProcessIdentityJwt(fs.option.Uid, []uint32{fs.option.Gid})
+
 	return pb.WithCachedGrpcClient(func(grpcConnection *grpc.ClientConn) error {
 		client := filer_pb.NewSeaweedFilerClient(grpcConnection)
+		if encoded != "" {
+			client = filer_pb.NewIdentityFilerClient(client, encoded)
+		}
 		return fn(client)
 	}, fs.option.FilerGrpcAddress, fs.option.GrpcDialOption)
 
 }
+
+// checkPermission checks reading or writing an existing entry, including its acl.
+// File content is read from the volume servers directly, so the filer can not check it.
+func (fs *WebDavFileSystem) checkPermission(fullFilePath string, flag int) error {
+	if key, _ := security.IdentitySigningKey(); len(key) == 0 {
+		return nil
+	}
+	fullpath := util.FullPath(strings.TrimSuffix(fullFilePath, "/"))
+	if fullpath == "" {
+		return nil
+	}
+	entry, err := filer_pb.GetEntry(fs, fullpath)
+	if err != nil || entry == nil {
+		return nil
+	}
+	dir, _ := fullpath.DirAndName()
+	want := uint32(filer.PermissionRead)
+	switch flag & (os.O_RDONLY | os.O_WRONLY | os.O_RDWR) {
+	case os.O_WRONLY:
+		want = filer.PermissionWrite
+	case os.O_RDWR:
+		want = filer.PermissionRead | filer.PermissionWrite
+	}
+	identity := &filer.Identity{Uid: fs.option.Uid, Gids: []uint32{fs.option.Gid}}
+	if !filer.HasPermission(filer.FromPbEntry(dir, entry), identity, want) {
+		return os.ErrPermission
+	}
+	return nil
+}
 func (fs *WebDavFileSystem) AdjustedUrl(location *filer_pb.Location) string {
 	return location.Url
 }
@@ -157,6 +364,7 @@ func (fs *WebDavFileSystem) Mkdir(ctx context.Context, fullDirPath string, perm
 	if fullDirPath, err = clearName(fullDirPath); err != nil {
 		return err
 	}
+	fullDirPath = fs.fullpath(fullDirPath)
 
 	_, err = fs.stat(ctx, fullDirPath)
 	if err == nil {
@@ -183,7 +391,7 @@ func (fs *WebDavFileSystem) Mkdir(ctx context.Context, fullDirPath string, perm
 
 		glog.V(1).Infof("mkdir: %v", request)
 		if err := filer_pb.CreateEntry(client, request); err != nil {
-			return fmt.Errorf("mkdir %s/%s: %v", dir, name, err)
+			return filer_pb.WrapError(err, "mkdir %s/%s", dir, name)
 		}
 
 		return nil
@@ -198,6 +406,7 @@ func (fs *WebDavFileSystem) OpenFile(ctx context.Context, fullFilePath string, f
 	if fullFilePath, err = clearName(fullFilePath); err != nil {
 		return nil, err
 	}
+	fullFilePath = fs.fullpath(fullFilePath)
 
 	if flag&os.O_CREATE != 0 {
 		// file should not have / suffix.
@@ -232,7 +441,7 @@ func (fs *WebDavFileSystem) OpenFile(ctx context.Context, fullFilePath string, f
 				},
 				Signatures: []int32{fs.signature},
 			}); err != nil {
-				return fmt.Errorf("create %s: %v", fullFilePath, err)
+				return filer_pb.WrapError(err, "create %s", fullFilePath)
 			}
 			return nil
 		})
@@ -254,6 +463,9 @@ func (fs *WebDavFileSystem) OpenFile(ctx context.Context, fullFilePath string, f
 	if !strings.HasSuffix(fullFilePath, "/") && fi.IsDir() {
 		fullFilePath += "/"
 	}
+	if err = fs.checkPermission(fullFilePath, flag); err != nil {
+		return nil, err
+	}
 
 	return &WebDavFile{
 		fs:          fs,
@@ -280,6 +492,15 @@ func (fs *WebDavFileSystem) RemoveAll(ctx context.Context, name string) error {
 
 	glog.V(2).Infof("WebDavFileSystem.RemoveAll %v", name)
 
+	var err error
+	if name, err = clearName(name); err != nil {
+		return err
+	}
+	name = fs.fullpath(name)
+	if fs.isRoot(name) {
+		return os.ErrPermission
+	}
+
 	return fs.removeAll(ctx, name)
 }
 
@@ -294,6 +515,10 @@ func (fs *WebDavFileSystem) Rename(ctx context.Context, oldName, newName string)
 	if newName, err = clearName(newName); err != nil {
 		return err
 	}
+	oldName, newName = fs.fullpath(oldName), fs.fullpath(newName)
+	if fs.isRoot(oldName) || fs.isRoot(newName) {
+		return os.ErrPermission
+	}
 
 	of, err := fs.stat(ctx, oldName)
 	if err != nil {
@@ -327,7 +552,7 @@ func (fs *WebDavFileSystem) Rename(ctx context.Context, oldName, newName string)
 
 		_, err := client.AtomicRenameEntry(ctx, request)
 		if err != nil {
-			return fmt.Errorf("renaming %s/%s => %s/%s: %v", oldDir, oldBaseName, newDir, newBaseName, err)
+			return filer_pb.WrapError(err, "renaming %s/%s => %s/%s", oldDir, oldBaseName, newDir, newBaseName)
 		}
 
 		return nil
@@ -342,6 +567,9 @@ func (fs *WebDavFileSystem) stat(ctx context.Context, fullFilePath string) (os.F
 	}
 
 	fullpath := util.FullPath(fullFilePath)
+	if fullFilePath != "/" {
+		fullpath = util.FullPath(strings.TrimSuffix(fullFilePath, "/"))
+	}
 
 	var fi FileInfo
 	entry, err := filer_pb.GetEntry(fs, fullpath)
@@ -357,7 +585,7 @@ func (fs *WebDavFileSystem) stat(ctx context.Context, fullFilePath string) (os.F
 	fi.modifiledTime = time.Unix(entry.Attributes.Mtime, 0)
 	fi.isDirectory = entry.IsDirectory
 
-	if fi.name == "/" {
+	if fs.isRoot(fi.name) {
 		fi.modifiledTime = time.Now()
 		fi.isDirectory = true
 	}
@@ -368,7 +596,12 @@ func (fs *WebDavFileSystem) Stat(ctx context.Context, name string) (os.FileInfo,
 
 	glog.V(2).Infof("WebDavFileSystem.Stat %v", name)
 
-	return fs.stat(ctx, name)
+	var err error
+	if name, err = clearName(name); err != nil {
+		return nil, err
+	}
+
+	return fs.stat(ctx, fs.fullpath(name))
 }
 
 func (f *WebDavFile) saveDataAsChunk(reader io.Reader, name string, offset int64) (chunk *filer_pb.FileChunk, collection, replication string, err error) {
@@ -474,7 +707,7 @@ func (f *WebDavFile) Write(buf []byte) (int, error) {
 				}
 
 				if _, err := client.UpdateEntry(ctx, request); err != nil {
-					return fmt.Errorf("update %s: %v", f.name, err)
+					return filer_pb.WrapError(err, "update %s", f.name)
 				}
 
 				return nil
@@ -44,7 +44,8 @@ func moveMountedShardToEcNode(commandEnv *CommandEnv, existingLocation *EcNode,
 
 	}
 
-	destinationEcNode.addEcVolumeShards(vid, collection, copiedShardIds)
+	scheme, _ := existingLocation.findEcVolumeScheme(vid)
+	destinationEcNode.addEcVolumeShards(vid, collection, scheme, copiedShardIds)
 	existingLocation.deleteEcVolumeShards(vid, copiedShardIds)
 
 	return nil
@@ -296,7 +297,29 @@ func findEcVolumeShards(ecNode *EcNode, vid needle.VolumeId) erasure_coding.Shar
 	return 0
 }
 
-func (ecNode *EcNode) addEcVolumeShards(vid needle.VolumeId, collection string, shardIds []uint32) *EcNode {
+// findEcVolumeScheme returns the erasure coding scheme of the ec volume if the node has any of its shards
+func (ecNode *EcNode) findEcVolumeScheme(vid needle.VolumeId) (scheme erasure_coding.EcScheme, found bool) {
+	for _, diskInfo := range ecNode.info.DiskInfos {
+		for _, shardInfo := range diskInfo.EcShardInfos {
+			if needle.VolumeId(shardInfo.Id) == vid {
+				return erasure_coding.ToEcScheme(shardInfo.DataShards, shardInfo.ParityShards), true
+			}
+		}
+	}
+	return erasure_coding.DefaultEcScheme, false
+}
+
+// findEcVolumeSchemeOnNodes returns the erasure coding scheme of the ec volume on any of the nodes
+func findEcVolumeSchemeOnNodes(ecNodes []*EcNode, vid needle.VolumeId) erasure_coding.EcScheme {
+	for _, ecNode := range ecNodes {
+		if scheme, found := ecNode.findEcVolumeScheme(vid); found {
+			return scheme
+		}
+	}
+	return erasure_coding.DefaultEcScheme
+}
+
+func (ecNode *EcNode) addEcVolumeShards(vid needle.VolumeId, collection string, scheme erasure_coding.EcScheme, shardIds []uint32) *EcNode {
 
 	foundVolume := false
 	diskInfo, found := ecNode.info.DiskInfos[string(types.HardDriveType)]
@@ -327,10 +350,12 @@ func (ecNode *EcNode) addEcVolumeShards(vid needle.VolumeId, collection string,
 			newShardBits = newShardBits.AddShardId(erasure_coding.ShardId(shardId))
 		}
 		diskInfo.EcShardInfos = append(diskInfo.EcShardInfos, &master_pb.VolumeEcShardInformationMessage{
-			Id:          uint32(vid),
-			Collection:  collection,
-			EcIndexBits: uint32(newShardBits),
-			DiskType:    string(types.HardDriveType),
+			Id:           uint32(vid),
+			Collection:   collection,
+			EcIndexBits:  uint32(newShardBits),
+			DiskType:     string(types.HardDriveType),
+			DataShards:   uint32(scheme.DataShards),
+			ParityShards: uint32(scheme.ParityShards),
 		})
 		ecNode.freeEcSlot -= len(shardIds)
 	}
@@ -106,16 +106,16 @@ func rebuildEcVolumes(commandEnv *CommandEnv, allEcNodes []*EcNode, collection s
 
 	for vid, locations := range ecShardMap {
 		shardCount := locations.shardCount()
-		if shardCount == erasure_coding.TotalShardsCount {
+		if shardCount == locations.scheme.TotalShards() {
 			continue
 		}
-		if shardCount < erasure_coding.DataShardsCount {
+		if shardCount < locations.scheme.DataShards {
 			return fmt.Errorf("ec volume %d is unrepairable with %d shards\n", vid, shardCount)
 		}
 
 		sortEcNodesByFreeslotsDecending(allEcNodes)
 
-		if allEcNodes[0].freeEcSlot < erasure_coding.TotalShardsCount {
+		if allEcNodes[0].freeEcSlot < locations.scheme.TotalShards() {
 			return fmt.Errorf("disk space is not enough")
 		}
 
@@ -127,7 +127,7 @@ func rebuildEcVolumes(commandEnv *CommandEnv, allEcNodes []*EcNode, collection s
 	return nil
 }
 
-func rebuildOneEcVolume(commandEnv *CommandEnv, rebuilder *EcNode, collection string, volumeId needle.VolumeId, locations EcShardLocations, writer io.Writer, applyChanges bool) error {
+func rebuildOneEcVolume(commandEnv *CommandEnv, rebuilder *EcNode, collection string, volumeId needle.VolumeId, locations *EcShardLocations, writer io.Writer, applyChanges bool) error {
 
 	fmt.Printf("rebuildOneEcVolume %s %d\n", collection, volumeId)
 
@@ -164,7 +164,7 @@ func rebuildOneEcVolume(commandEnv *CommandEnv, rebuilder *EcNode, collection st
 		return err
 	}
 
-	rebuilder.addEcVolumeShards(volumeId, collection, generatedShardIds)
+	rebuilder.addEcVolumeShards(volumeId, collection, locations.scheme, generatedShardIds)
 
 	return nil
 }
@@ -184,7 +184,7 @@ func generateMissingShards(grpcDialOption grpc.DialOption, collection string, vo
 	return
 }
 
-func prepareDataToRecover(commandEnv *CommandEnv, rebuilder *EcNode, collection string, volumeId needle.VolumeId, locations EcShardLocations, writer io.Writer, applyBalancing bool) (copiedShardIds []uint32, localShardIds []uint32, err error) {
+func prepareDataToRecover(commandEnv *CommandEnv, rebuilder *EcNode, collection string, volumeId needle.VolumeId, locations *EcShardLocations, writer io.Writer, applyBalancing bool) (copiedShardIds []uint32, localShardIds []uint32, err error) {
 
 	needEcxFile := true
 	var localShardBits erasure_coding.ShardBits
@@ -197,7 +197,7 @@ func prepareDataToRecover(commandEnv *CommandEnv, rebuilder *EcNode, collection
 		}
 	}
 
-	for shardId, ecNodes := range locations {
+	for shardId, ecNodes := range locations.locations {
 
 		if len(ecNodes) == 0 {
 			fmt.Fprintf(writer, "missing shard %d.%d\n", volumeId, shardId)
@@ -237,7 +237,7 @@ func prepareDataToRecover(commandEnv *CommandEnv, rebuilder *EcNode, collection
 
 	}
 
-	if len(copiedShardIds)+len(localShardIds) >= erasure_coding.DataShardsCount {
+	if len(copiedShardIds)+len(localShardIds) >= locations.scheme.DataShards {
 		return copiedShardIds, localShardIds, nil
 	}
 
@@ -245,8 +245,11 @@ func prepareDataToRecover(commandEnv *CommandEnv, rebuilder *EcNode, collection
 
 }
 
-type EcShardMap map[needle.VolumeId]EcShardLocations
-type EcShardLocations [][]*EcNode
+type EcShardMap map[needle.VolumeId]*EcShardLocations
+type EcShardLocations struct {
+	scheme    erasure_coding.EcScheme
+	locations [][]*EcNode // indexed by the shard id
+}
 
 func (ecShardMap EcShardMap) registerEcNode(ecNode *EcNode, collection string) {
 	for _, diskInfo := range ecNode.info.DiskInfos {
@@ -254,19 +257,25 @@ func (ecShardMap EcShardMap) registerEcNode(ecNode *EcNode, collection string) {
 			if shardInfo.Collection == collection {
 				existing, found := ecShardMap[needle.VolumeId(shardInfo.Id)]
 				if !found {
-					existing = make([][]*EcNode, erasure_coding.TotalShardsCount)
+					scheme := erasure_coding.ToEcScheme(shardInfo.DataShards, shardInfo.ParityShards)
+					existing = &EcShardLocations{
+						scheme:    scheme,
+						locations: make([][]*EcNode, scheme.TotalShards()),
+					}
 					ecShardMap[needle.VolumeId(shardInfo.Id)] = existing
 				}
 				for _, shardId := range erasure_coding.ShardBits(shardInfo.EcIndexBits).ShardIds() {
-					existing[shardId] = append(existing[shardId], ecNode)
+					if int(shardId) < len(existing.locations) {
+						existing.locations[shardId] = append(existing.locations[shardId], ecNode)
+					}
 				}
 			}
 		}
 	}
 }
 
-func (ecShardLocations EcShardLocations) shardCount() (count int) {
-	for _, locations := range ecShardLocations {
+func (ecShardLocations *EcShardLocations) shardCount() (count int) {
+	for _, locations := range ecShardLocations.locations {
 		if len(locations) > 0 {
 			count++
 		}
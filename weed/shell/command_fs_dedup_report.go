@@ -0,0 +1,80 @@
+package shell
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsDedupReport{})
+}
+
+type commandFsDedupReport struct {
+}
+
+func (c *commandFsDedupReport) Name() string {
+	return "fs.dedup.report"
+}
+
+func (c *commandFsDedupReport) Help() string {
+	return `show space saved by chunk deduplication for each collection
+
+	fs.dedup.report
+
+	Deduplication is enabled per location, e.g. "fs.configure -locationPrefix=/layers/ -collection=layers -dedup -apply".
+	Each filer saves its changes to the statistics every minute, so recent writes may not be counted yet.
+`
+}
+
+func (c *commandFsDedupReport) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	var value []byte
+	if err = commandEnv.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.KvGet(context.Background(), &filer_pb.KvGetRequest{Key: []byte(filer.DedupStatsKey)})
+		if err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("kv get: %s", resp.Error)
+		}
+		value = resp.Value
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	stats, err := filer.ReadDedupStats(value, nil)
+	if err != nil {
+		return fmt.Errorf("read dedup stats: %v", err)
+	}
+
+	var collections []string
+	for collection := range stats {
+		collections = append(collections, collection)
+	}
+	sort.Strings(collections)
+
+	var totalSaved int64
+	for _, collection := range collections {
+		s := stats[collection]
+		name := collection
+		if name == "" {
+			name = "(default)"
+		}
+		fmt.Fprintf(writer, "collection:%s\tunique chunks:%d (%s)\treferenced chunks:%d (%s)\tsaved:%s\n",
+			name,
+			s.UniqueChunks, util.BytesToHumanReadable(uint64(s.UniqueBytes)),
+			s.ReferencedChunks, util.BytesToHumanReadable(uint64(s.ReferencedBytes)),
+			util.BytesToHumanReadable(uint64(s.SavedBytes())))
+		totalSaved += s.SavedBytes()
+	}
+	fmt.Fprintf(writer, "total saved:%s\n", util.BytesToHumanReadable(uint64(totalSaved)))
+
+	return nil
+}
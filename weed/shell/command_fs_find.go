@@ -0,0 +1,142 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/chrislusf/seaweedfs/weed/filer"
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsFind{})
+}
+
+type commandFsFind struct {
+}
+
+func (c *commandFsFind) Name() string {
+	return "fs.find"
+}
+
+func (c *commandFsFind) Help() string {
+	return `recursively search files by name, size, modification time, mime type, owner and extended attributes
+
+	fs.find -name "*.jpg" /dir
+	fs.find -minSize 100MiB -olderThan 720h /dir
+	fs.find -mime image/ -uid 1000 -l /dir
+	fs.find -xattr X-Amz-Tagging-project=ml /dir
+
+	The search runs on the filer. Filers backed by elastic search evaluate it in the index.
+`
+}
+
+func (c *commandFsFind) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	findCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	namePattern := findCommand.String("name", "", "file name glob pattern, e.g. \"*.jpg\"")
+	entryType := findCommand.String("type", "f", "f: files only, d: directories only, a: both")
+	minSize := findCommand.String("minSize", "", "minimum file size, e.g. 10MiB")
+	maxSize := findCommand.String("maxSize", "", "maximum file size, e.g. 1GiB")
+	newerThan := findCommand.Duration("newerThan", 0, "modified within this duration, e.g. 24h")
+	olderThan := findCommand.Duration("olderThan", 0, "not modified within this duration, e.g. 720h")
+	mimePrefix := findCommand.String("mime", "", "mime type prefix, e.g. image/")
+	uid := findCommand.Int("uid", -1, "owner uid")
+	gid := findCommand.Int("gid", -1, "owner gid")
+	xattr := findCommand.String("xattr", "", "extended attribute, as key or key=value")
+	maxDepth := findCommand.Int("maxDepth", 0, "maximum directory depth, 0 means unlimited")
+	limit := findCommand.Int("limit", 0, "maximum number of results, 0 means unlimited")
+	isLongFormat := findCommand.Bool("l", false, "show mode, size and modification time")
+	if err = findCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	path, err := commandEnv.parseUrl(findInputDirectory(findCommand.Args()))
+	if err != nil {
+		return err
+	}
+	if err = commandEnv.checkDirectory(path); err != nil {
+		return err
+	}
+
+	req := &filer_pb.SearchEntriesRequest{
+		Directory:          path,
+		NamePattern:        *namePattern,
+		MimePrefix:         *mimePrefix,
+		IncludeDirectories: *entryType != "f",
+		DirectoriesOnly:    *entryType == "d",
+		MaxDepth:           int32(*maxDepth),
+		Limit:              uint32(*limit),
+	}
+	if *minSize != "" {
+		if req.MinSize, err = humanize.ParseBytes(*minSize); err != nil {
+			return fmt.Errorf("parse minSize %s: %v", *minSize, err)
+		}
+	}
+	if *maxSize != "" {
+		if req.MaxSize, err = humanize.ParseBytes(*maxSize); err != nil {
+			return fmt.Errorf("parse maxSize %s: %v", *maxSize, err)
+		}
+	}
+	now := time.Now()
+	if *newerThan > 0 {
+		req.MtimeAfter = now.Add(-*newerThan).Unix()
+	}
+	if *olderThan > 0 {
+		req.MtimeBefore = now.Add(-*olderThan).Unix()
+	}
+	if *uid >= 0 {
+		req.Uids = []uint32{uint32(*uid)}
+	}
+	if *gid >= 0 {
+		req.Gids = []uint32{uint32(*gid)}
+	}
+	if *xattr != "" {
+		key, value := *xattr, ""
+		if sepIndex := strings.Index(*xattr, "="); sepIndex > 0 {
+			key, value = (*xattr)[:sepIndex], (*xattr)[sepIndex+1:]
+		}
+		req.Extended = map[string][]byte{key: []byte(value)}
+	}
+
+	var count int
+	err = commandEnv.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		stream, err := client.SearchEntries(context.Background(), req)
+		if err != nil {
+			return err
+		}
+		for {
+			resp, recvErr := stream.Recv()
+			if recvErr == io.EOF {
+				return nil
+			}
+			if recvErr != nil {
+				return recvErr
+			}
+			entry := resp.Entry
+			count++
+			fullPath := util.NewFullPath(resp.Directory, entry.Name)
+			if *isLongFormat {
+				fmt.Fprintf(writer, "%s %10d %s %s\n",
+					os.FileMode(entry.Attributes.FileMode), filer.FileSize(entry),
+					time.Unix(entry.Attributes.Mtime, 0).Format("2006-01-02 15:04:05"), fullPath)
+			} else {
+				fmt.Fprintf(writer, "%s\n", fullPath)
+			}
+		}
+	})
+
+	if *isLongFormat && err == nil {
+		fmt.Fprintf(writer, "total %d\n", count)
+	}
+
+	return err
+}
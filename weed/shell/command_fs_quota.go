@@ -0,0 +1,114 @@
+package shell
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/filer_pb"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandFsQuota{})
+}
+
+type commandFsQuota struct {
+}
+
+func (c *commandFsQuota) Name() string {
+	return "fs.quota"
+}
+
+func (c *commandFsQuota) Help() string {
+	return `set, remove, or list byte and inode quotas on directories
+
+	# limit all files and directories under /home/alice to 10GB and 100000 inodes
+	fs.quota set -dir=/home/alice -maxMB=10240 -maxInodes=100000
+
+	# remove the quota
+	fs.quota remove -dir=/home/alice
+
+	# list all quotas and their usage
+	fs.quota list
+
+	Writes through the filer are rejected once a quota covering the written path is exceeded.
+	Quotas can be nested, and a quota stays with the directory path, even if the directory is renamed.
+	A mount of a directory with a quota reports the quota as the file system size.
+`
+}
+
+func (c *commandFsQuota) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if len(args) == 0 {
+		return fmt.Errorf("need an action: set, remove, or list")
+	}
+	action := args[0]
+
+	fsQuotaCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	dir := fsQuotaCommand.String("dir", "", "the directory")
+	maxMB := fsQuotaCommand.Int64("maxMB", 0, "max size in MB of all files under the directory, 0 means unlimited")
+	maxInodes := fsQuotaCommand.Int64("maxInodes", 0, "max number of files and directories under the directory, 0 means unlimited")
+	if err = fsQuotaCommand.Parse(args[1:]); err != nil {
+		return nil
+	}
+
+	switch action {
+	case "list":
+		return commandEnv.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+			resp, err := client.ListQuotas(context.Background(), &filer_pb.ListQuotasRequest{})
+			if err != nil {
+				return err
+			}
+			for _, quota := range resp.Quotas {
+				printQuota(writer, quota)
+			}
+			return nil
+		})
+	case "set", "remove":
+	default:
+		return fmt.Errorf("unknown action %s, should be set, remove, or list", action)
+	}
+
+	if *dir == "" {
+		return fmt.Errorf("need a directory")
+	}
+	path, err := commandEnv.parseUrl(*dir)
+	if err != nil {
+		return err
+	}
+	if path != "/" {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	return commandEnv.WithFilerClient(func(client filer_pb.SeaweedFilerClient) error {
+		resp, err := client.SetQuota(context.Background(), &filer_pb.SetQuotaRequest{
+			Directory: path,
+			MaxBytes:  *maxMB * 1024 * 1024,
+			MaxInodes: *maxInodes,
+			Remove:    action == "remove",
+		})
+		if err != nil {
+			return err
+		}
+		if resp.Quota != nil {
+			printQuota(writer, resp.Quota)
+		}
+		return nil
+	})
+
+}
+
+func printQuota(writer io.Writer, quota *filer_pb.DirectoryQuota) {
+	maxBytes, maxInodes := "unlimited", "unlimited"
+	if quota.MaxBytes > 0 {
+		maxBytes = util.BytesToHumanReadable(uint64(quota.MaxBytes))
+	}
+	if quota.MaxInodes > 0 {
+		maxInodes = fmt.Sprintf("%d", quota.MaxInodes)
+	}
+	fmt.Fprintf(writer, "%s\tbytes:%s/%s\tinodes:%d/%s\n",
+		quota.Directory, util.BytesToHumanReadable(uint64(quota.UsedBytes)), maxBytes, quota.UsedInodes, maxInodes)
+}
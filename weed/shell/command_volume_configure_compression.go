@@ -0,0 +1,197 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+)
+
+func init() {
+	Commands = append(Commands, &commandVolumeConfigureCompression{})
+}
+
+type commandVolumeConfigureCompression struct {
+}
+
+func (c *commandVolumeConfigureCompression) Name() string {
+	return "volume.configure.compression"
+}
+
+func (c *commandVolumeConfigureCompression) Help() string {
+	return `compress needle data of volumes with zstd
+
+	volume.configure.compression -collection=<collection name> [-compression=zstd|none] [-dictionarySize=0] [-recompress]
+	volume.configure.compression -volumeId=<volume id> [-compression=zstd|none] [-recompress]
+
+	This command makes the volume servers compress new writes with zstd, if the data can be compressed by more than 10%.
+	Data already compressed by the clients, and chunk manifests, are kept as is.
+
+	With -dictionarySize, a raw dictionary is built from the beginning of sampled files in the largest volume
+	of the collection, and shared by all volumes of the collection. It is not a trained zstd dictionary. It helps a lot for many small files of the same kind.
+	The dictionary is saved as a .zdict file next to the .vif file, and can not be changed once the volume has one.
+	Readers asking for "Accept-Encoding: zstd" get the compressed bytes as is, unless a dictionary is used.
+
+	New volumes of the collection follow the existing volumes of the same collection on the same volume server.
+
+	With -recompress, the volumes are vacuumed right away, which also compresses the existing data.
+	Otherwise, the existing data is compressed by the next vacuum.
+
+`
+}
+
+func (c *commandVolumeConfigureCompression) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if err = commandEnv.confirmIsLocked(); err != nil {
+		return
+	}
+
+	configureCompressionCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	volumeIdInt := configureCompressionCommand.Int("volumeId", 0, "the volume id")
+	collection := configureCompressionCommand.String("collection", "", "the collection name, for all its volumes")
+	compression := configureCompressionCommand.String("compression", storage.CompressionZstd, "zstd, or none to stop compressing new writes")
+	dictionarySize := configureCompressionCommand.Int("dictionarySize", 0, "build a dictionary of this size in bytes, e.g. 112640. 0 means no dictionary")
+	recompress := configureCompressionCommand.Bool("recompress", false, "vacuum the volumes to compress the existing data")
+	if err = configureCompressionCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *compression == "none" {
+		*compression = ""
+	}
+	if *compression != "" && *compression != storage.CompressionZstd {
+		return fmt.Errorf("unsupported compression %s", *compression)
+	}
+	if *volumeIdInt == 0 && *collection == "" {
+		return fmt.Errorf("need -collection or -volumeId")
+	}
+
+	// collect topology information
+	topologyInfo, _, err := collectTopologyInfo(commandEnv)
+	if err != nil {
+		return err
+	}
+
+	// find all volumes to configure, with their locations
+	volumeLocations := make(map[needle.VolumeId][]location)
+	volumeSizes := make(map[needle.VolumeId]uint64)
+	eachDataNode(topologyInfo, func(dc string, rack RackId, dn *master_pb.DataNodeInfo) {
+		loc := newLocation(dc, string(rack), dn)
+		for _, diskInfo := range dn.DiskInfos {
+			for _, v := range diskInfo.VolumeInfos {
+				vid := needle.VolumeId(v.Id)
+				if *volumeIdInt != 0 && vid != needle.VolumeId(*volumeIdInt) {
+					continue
+				}
+				if *volumeIdInt == 0 && v.Collection != *collection {
+					continue
+				}
+				volumeLocations[vid] = append(volumeLocations[vid], loc)
+				volumeSizes[vid] = v.Size
+			}
+		}
+	})
+	if len(volumeLocations) == 0 {
+		return fmt.Errorf("no volume found")
+	}
+	var vids []needle.VolumeId
+	for vid := range volumeLocations {
+		vids = append(vids, vid)
+	}
+	sort.Slice(vids, func(i, j int) bool {
+		return volumeSizes[vids[i]] > volumeSizes[vids[j]]
+	})
+
+	var dictionary []byte
+	if *compression != "" && *dictionarySize > 0 {
+		largest := vids[0]
+		err = operation.WithVolumeServerClient(volumeLocations[largest][0].dataNode.Id, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			resp, trainErr := volumeServerClient.VolumeTrainCompressionDictionary(context.Background(), &volume_server_pb.VolumeTrainCompressionDictionaryRequest{
+				VolumeId: uint32(largest),
+				MaxSize:  uint32(*dictionarySize),
+			})
+			if trainErr != nil {
+				return trainErr
+			}
+			dictionary = resp.Dictionary
+			fmt.Fprintf(writer, "built dictionary of %d bytes from %d files in volume %d\n", len(dictionary), resp.SampleCount, largest)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, vid := range vids {
+		for _, dst := range volumeLocations[vid] {
+			err = operation.WithVolumeServerClient(dst.dataNode.Id, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+				resp, configureErr := volumeServerClient.VolumeConfigureCompression(context.Background(), &volume_server_pb.VolumeConfigureCompressionRequest{
+					VolumeId:    uint32(vid),
+					Compression: *compression,
+					Dictionary:  dictionary,
+				})
+				if configureErr != nil {
+					return configureErr
+				}
+				if resp.Error != "" {
+					return errors.New(resp.Error)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("configure volume %d on %s: %v", vid, dst.dataNode.Id, err)
+			}
+		}
+		fmt.Fprintf(writer, "configured volume %d on %d servers\n", vid, len(volumeLocations[vid]))
+
+		if *recompress && *compression != "" {
+			if err = recompressVolume(commandEnv, vid, volumeLocations[vid]); err != nil {
+				return fmt.Errorf("recompress volume %d: %v", vid, err)
+			}
+			fmt.Fprintf(writer, "recompressed volume %d\n", vid)
+		}
+	}
+
+	return nil
+}
+
+// recompressVolume vacuums all replicas of the volume, which rewrites the needles with the current compression and encryption
+func recompressVolume(commandEnv *CommandEnv, vid needle.VolumeId, locations []location) (err error) {
+	for _, loc := range locations {
+		err = operation.WithVolumeServerClient(loc.dataNode.Id, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			_, compactErr := volumeServerClient.VacuumVolumeCompact(context.Background(), &volume_server_pb.VacuumVolumeCompactRequest{
+				VolumeId: uint32(vid),
+			})
+			return compactErr
+		})
+		if err != nil {
+			break
+		}
+	}
+	for _, loc := range locations {
+		finishErr := operation.WithVolumeServerClient(loc.dataNode.Id, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+			if err != nil {
+				_, cleanupErr := volumeServerClient.VacuumVolumeCleanup(context.Background(), &volume_server_pb.VacuumVolumeCleanupRequest{
+					VolumeId: uint32(vid),
+				})
+				return cleanupErr
+			}
+			_, commitErr := volumeServerClient.VacuumVolumeCommit(context.Background(), &volume_server_pb.VacuumVolumeCommitRequest{
+				VolumeId: uint32(vid),
+			})
+			return commitErr
+		})
+		if err == nil && finishErr != nil {
+			return finishErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,146 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+)
+
+func init() {
+	Commands = append(Commands, &commandVolumeConfigureEncryption{})
+}
+
+type commandVolumeConfigureEncryption struct {
+}
+
+func (c *commandVolumeConfigureEncryption) Name() string {
+	return "volume.configure.encryption"
+}
+
+func (c *commandVolumeConfigureEncryption) Help() string {
+	return `encrypt needle data of volumes at rest with AES-256-GCM
+
+	volume.configure.encryption -collection=<collection name> [-encryption=aes256gcm|none] [-rotateKey] [-reencrypt]
+	volume.configure.encryption -volumeId=<volume id> [-encryption=aes256gcm|none] [-rotateKey] [-reencrypt]
+
+	This command makes the volume servers encrypt new writes with a key of each volume.
+	The volume keys are saved in the .vif files, wrapped by the master key of the volume servers,
+	which is loaded from the file set by "weed volume -encryption.keyFile". All replicas of a volume
+	get the same keys, so all volume servers holding the volume need the same master key.
+	Reads are decrypted transparently by the volume servers.
+
+	New volumes of the collection follow the existing volumes of the same collection on the same volume server.
+
+	With -rotateKey, a new key is added to the volumes, and used for new writes.
+	The older keys are kept until the next vacuum, which re-encrypts all needles with the new key.
+
+	With -reencrypt, the volumes are vacuumed right away, which encrypts the existing data with the current key,
+	or decrypts it if the encryption is set to none. Otherwise, the existing data is changed by the next vacuum.
+
+`
+}
+
+func (c *commandVolumeConfigureEncryption) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	if err = commandEnv.confirmIsLocked(); err != nil {
+		return
+	}
+
+	configureEncryptionCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	volumeIdInt := configureEncryptionCommand.Int("volumeId", 0, "the volume id")
+	collection := configureEncryptionCommand.String("collection", "", "the collection name, for all its volumes")
+	encryption := configureEncryptionCommand.String("encryption", storage.EncryptionAes256Gcm, "aes256gcm, or none to stop encrypting new writes")
+	rotateKey := configureEncryptionCommand.Bool("rotateKey", false, "add a new key for new writes")
+	reencrypt := configureEncryptionCommand.Bool("reencrypt", false, "vacuum the volumes to re-encrypt the existing data")
+	if err = configureEncryptionCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *encryption == "none" {
+		*encryption = ""
+	}
+	if *encryption != "" && *encryption != storage.EncryptionAes256Gcm {
+		return fmt.Errorf("unsupported encryption %s", *encryption)
+	}
+	if *rotateKey && *encryption == "" {
+		return fmt.Errorf("-rotateKey needs -encryption=%s", storage.EncryptionAes256Gcm)
+	}
+	if *volumeIdInt == 0 && *collection == "" {
+		return fmt.Errorf("need -collection or -volumeId")
+	}
+
+	// collect topology information
+	topologyInfo, _, err := collectTopologyInfo(commandEnv)
+	if err != nil {
+		return err
+	}
+
+	// find all volumes to configure, with their locations
+	volumeLocations := make(map[needle.VolumeId][]location)
+	eachDataNode(topologyInfo, func(dc string, rack RackId, dn *master_pb.DataNodeInfo) {
+		loc := newLocation(dc, string(rack), dn)
+		for _, diskInfo := range dn.DiskInfos {
+			for _, v := range diskInfo.VolumeInfos {
+				vid := needle.VolumeId(v.Id)
+				if *volumeIdInt != 0 && vid != needle.VolumeId(*volumeIdInt) {
+					continue
+				}
+				if *volumeIdInt == 0 && v.Collection != *collection {
+					continue
+				}
+				volumeLocations[vid] = append(volumeLocations[vid], loc)
+			}
+		}
+	})
+	if len(volumeLocations) == 0 {
+		return fmt.Errorf("no volume found")
+	}
+	var vids []needle.VolumeId
+	for vid := range volumeLocations {
+		vids = append(vids, vid)
+	}
+	sort.Slice(vids, func(i, j int) bool {
+		return vids[i] < vids[j]
+	})
+
+	for _, vid := range vids {
+		for _, dst := range volumeLocations[vid] {
+			err = operation.WithVolumeServerClient(dst.dataNode.Id, commandEnv.option.GrpcDialOption, func(volumeServerClient volume_server_pb.VolumeServerClient) error {
+				resp, configureErr := volumeServerClient.VolumeConfigureEncryption(context.Background(), &volume_server_pb.VolumeConfigureEncryptionRequest{
+					VolumeId:   uint32(vid),
+					Encryption: *encryption,
+					RotateKey:  *rotateKey,
+				})
+				if configureErr != nil {
+					return configureErr
+				}
+				if resp.Error != "" {
+					return errors.New(resp.Error)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("configure volume %d on %s: %v", vid, dst.dataNode.Id, err)
+			}
+		}
+		fmt.Fprintf(writer, "configured volume %d on %d servers\n", vid, len(volumeLocations[vid]))
+
+		if *reencrypt {
+			if err = recompressVolume(commandEnv, vid, volumeLocations[vid]); err != nil {
+				return fmt.Errorf("re-encrypt volume %d: %v", vid, err)
+			}
+			fmt.Fprintf(writer, "re-encrypted volume %d\n", vid)
+		}
+	}
+
+	return nil
+}
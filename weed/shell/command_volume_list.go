@@ -118,7 +118,7 @@ func writeDiskInfo(writer io.Writer, t *master_pb.DiskInfo) statistics {
 		s = s.plus(writeVolumeInformationMessage(writer, vi))
 	}
 	for _, ecShardInfo := range t.EcShardInfos {
-		fmt.Fprintf(writer, "          ec volume id:%v collection:%v shards:%v\n", ecShardInfo.Id, ecShardInfo.Collection, erasure_coding.ShardBits(ecShardInfo.EcIndexBits).ShardIds())
+		fmt.Fprintf(writer, "          ec volume id:%v collection:%v scheme:%v shards:%v\n", ecShardInfo.Id, ecShardInfo.Collection, erasure_coding.ToEcScheme(ecShardInfo.DataShards, ecShardInfo.ParityShards), erasure_coding.ShardBits(ecShardInfo.EcIndexBits).ShardIds())
 	}
 	fmt.Fprintf(writer, "        Disk %s %+v \n", diskType, s)
 	return s
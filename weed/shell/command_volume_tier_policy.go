@@ -0,0 +1,439 @@
+package shell
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func init() {
+	Commands = append(Commands, &commandVolumeTierPolicy{})
+}
+
+type commandVolumeTierPolicy struct {
+}
+
+func (c *commandVolumeTierPolicy) Name() string {
+	return "volume.tier.policy"
+}
+
+func (c *commandVolumeTierPolicy) Help() string {
+	return `move volumes between disk types and remote tiers, following the rules in master.toml
+
+	volume.tier.policy [-force]
+
+	The rules are defined in the [master.tiering] section of master.toml, which is also run
+	periodically by the master if enabled. See "weed scaffold -config=master".
+
+	Each rule selects volumes by collection name pattern, source disk type or remote tier,
+	time since the last write, fullness, and read frequency, and either
+	* moves the volumes to another disk type, with "to_disk_type", or
+	* uploads the volumes to a remote tier, with "dest", or
+	* downloads the remote volumes back to local disks, with "from_remote = true".
+
+	The read frequency is the number of reads in the recent hour, reported by the volume servers.
+	Volumes loaded less than 10 minutes ago are not moved by rules with read frequency.
+	The rules are checked in the order of their names. Only the first matching rule applies to a volume.
+
+	Without -force, only the planned moves are printed.
+
+`
+}
+
+func (c *commandVolumeTierPolicy) Do(args []string, commandEnv *CommandEnv, writer io.Writer) (err error) {
+
+	tierCommand := flag.NewFlagSet(c.Name(), flag.ContinueOnError)
+	applyChange := tierCommand.Bool("force", false, "actually apply the changes")
+	if err = tierCommand.Parse(args); err != nil {
+		return nil
+	}
+
+	if *applyChange {
+		if err = commandEnv.confirmIsLocked(); err != nil {
+			return
+		}
+	}
+
+	if !util.LoadConfiguration("master", false) {
+		return fmt.Errorf("master.toml is not found")
+	}
+	policy, err := LoadTieringPolicy(util.GetViper(), "master.tiering.")
+	if err != nil {
+		return err
+	}
+
+	return policy.Apply(commandEnv, writer, !*applyChange)
+}
+
+// TieringRule selects volumes to move to another disk type, or between local disks and a remote tier
+type TieringRule struct {
+	Name            string
+	Collection      string // collection name pattern, "*" for all collections
+	FromDiskType    types.DiskType
+	FromRemote      bool
+	ToDiskType      types.DiskType
+	Dest            string // remote storage backend name, e.g. s3.default
+	QuietFor        time.Duration
+	MinFullPercent  float64
+	MinReadsPerHour float64 // 0 to ignore
+	MaxReadsPerHour float64 // negative to ignore
+}
+
+// TieringPolicy moves volumes following the rules, with at most Concurrency volumes moving at the same time
+type TieringPolicy struct {
+	Rules       []*TieringRule
+	Concurrency int
+}
+
+const minReadCountingSeconds = 600
+
+func LoadTieringPolicy(config *util.ViperProxy, prefix string) (*TieringPolicy, error) {
+	config.SetDefault(prefix+"concurrency", 2)
+	policy := &TieringPolicy{
+		Concurrency: config.GetInt(prefix + "concurrency"),
+	}
+	if policy.Concurrency <= 0 {
+		policy.Concurrency = 1
+	}
+
+	var names []string
+	for name := range config.GetStringMap(prefix + "rules") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rulePrefix := prefix + "rules." + name + "."
+		rule := &TieringRule{
+			Name:            name,
+			Collection:      config.GetString(rulePrefix + "collection"),
+			FromDiskType:    types.ToDiskType(config.GetString(rulePrefix + "from_disk_type")),
+			FromRemote:      config.GetBool(rulePrefix + "from_remote"),
+			ToDiskType:      types.ToDiskType(config.GetString(rulePrefix + "to_disk_type")),
+			Dest:            config.GetString(rulePrefix + "dest"),
+			QuietFor:        config.GetDuration(rulePrefix + "quiet_for"),
+			MinFullPercent:  config.GetFloat64(rulePrefix + "min_full_percent"),
+			MinReadsPerHour: config.GetFloat64(rulePrefix + "min_reads_per_hour"),
+			MaxReadsPerHour: -1,
+		}
+		if config.GetString(rulePrefix+"max_reads_per_hour") != "" {
+			rule.MaxReadsPerHour = config.GetFloat64(rulePrefix + "max_reads_per_hour")
+		}
+		if rule.Collection == "" {
+			rule.Collection = "*"
+		}
+		if _, err := filepath.Match(rule.Collection, ""); err != nil {
+			return nil, fmt.Errorf("tiering rule %s: bad collection pattern %s: %v", name, rule.Collection, err)
+		}
+		actions := 0
+		if rule.FromRemote {
+			actions++
+		}
+		if config.GetString(rulePrefix+"to_disk_type") != "" {
+			actions++
+		}
+		if rule.Dest != "" {
+			actions++
+		}
+		if actions != 1 {
+			return nil, fmt.Errorf("tiering rule %s: need exactly one of to_disk_type, dest, or from_remote", name)
+		}
+		if !rule.FromRemote && rule.Dest == "" && rule.FromDiskType == rule.ToDiskType {
+			return nil, fmt.Errorf("tiering rule %s: source disk type %s is the same as target disk type", name, rule.FromDiskType.ReadableString())
+		}
+		policy.Rules = append(policy.Rules, rule)
+	}
+
+	return policy, nil
+}
+
+// tieringVolume is a volume with all its replicas
+type tieringVolume struct {
+	id               needle.VolumeId
+	collection       string
+	size             uint64
+	modifiedAtSecond int64
+	readsPerHour     float64
+	readsKnown       bool
+	remote           bool
+	replicas         []*tieringReplica
+}
+
+type tieringReplica struct {
+	location location
+	diskType types.DiskType
+}
+
+type tieringMove struct {
+	rule   *TieringRule
+	volume *tieringVolume
+	// for moves between disk types
+	sources []location
+	targets []location
+}
+
+func (m *tieringMove) String() string {
+	var action string
+	switch {
+	case m.rule.FromRemote:
+		action = "download from remote tier"
+	case m.rule.Dest != "":
+		action = "upload to " + m.rule.Dest
+	default:
+		action = "move to disk type " + m.rule.ToDiskType.ReadableString()
+		for i := range m.sources {
+			action += fmt.Sprintf(", %s => %s", m.sources[i].dataNode.Id, m.targets[i].dataNode.Id)
+		}
+	}
+	readsPerHour := "unknown"
+	if m.volume.readsKnown {
+		readsPerHour = fmt.Sprintf("%.1f", m.volume.readsPerHour)
+	}
+	return fmt.Sprintf("rule %s: volume %d collection:%q size:%d modified:%s reads/hour:%s: %s",
+		m.rule.Name, m.volume.id, m.volume.collection, m.volume.size,
+		time.Unix(m.volume.modifiedAtSecond, 0).Format(time.RFC3339), readsPerHour, action)
+}
+
+// Apply plans the moves following the rules, and prints them. If not dryRun, the moves are executed.
+func (p *TieringPolicy) Apply(commandEnv *CommandEnv, writer io.Writer, dryRun bool) error {
+
+	topologyInfo, volumeSizeLimitMb, err := collectTopologyInfo(commandEnv)
+	if err != nil {
+		return err
+	}
+
+	moves := p.plan(topologyInfo, volumeSizeLimitMb, time.Now())
+	if len(moves) == 0 {
+		fmt.Fprintf(writer, "tiering policy: no volumes to move\n")
+		return nil
+	}
+	for _, move := range moves {
+		fmt.Fprintf(writer, "tiering policy: %s\n", move)
+	}
+	if dryRun {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	var writerLock sync.Mutex
+	var firstErr error
+	sem := make(chan struct{}, p.Concurrency)
+	for _, move := range moves {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(move *tieringMove) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			moveErr := move.execute(commandEnv, &lockedWriter{w: writer, lock: &writerLock})
+			writerLock.Lock()
+			defer writerLock.Unlock()
+			if moveErr != nil {
+				fmt.Fprintf(writer, "tiering policy: volume %d: %v\n", move.volume.id, moveErr)
+				if firstErr == nil {
+					firstErr = moveErr
+				}
+				return
+			}
+			fmt.Fprintf(writer, "tiering policy: volume %d moved by rule %s\n", move.volume.id, move.rule.Name)
+		}(move)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+func (p *TieringPolicy) plan(topologyInfo *master_pb.TopologyInfo, volumeSizeLimitMb uint64, now time.Time) (moves []*tieringMove) {
+
+	volumes := make(map[needle.VolumeId]*tieringVolume)
+	var allLocations []location
+	eachDataNode(topologyInfo, func(dc string, rack RackId, dn *master_pb.DataNodeInfo) {
+		loc := newLocation(dc, string(rack), dn)
+		allLocations = append(allLocations, loc)
+		for _, diskInfo := range dn.DiskInfos {
+			for _, v := range diskInfo.VolumeInfos {
+				vid := needle.VolumeId(v.Id)
+				tv, found := volumes[vid]
+				if !found {
+					tv = &tieringVolume{
+						id:         vid,
+						collection: v.Collection,
+						readsKnown: true,
+					}
+					volumes[vid] = tv
+				}
+				if v.Size > tv.size {
+					tv.size = v.Size
+				}
+				if v.ModifiedAtSecond > tv.modifiedAtSecond {
+					tv.modifiedAtSecond = v.ModifiedAtSecond
+				}
+				if v.RemoteStorageName != "" {
+					tv.remote = true
+				}
+				if v.RecentReadSeconds < minReadCountingSeconds {
+					tv.readsKnown = false
+				} else {
+					tv.readsPerHour += float64(v.RecentReadCount) * 3600 / float64(v.RecentReadSeconds)
+				}
+				tv.replicas = append(tv.replicas, &tieringReplica{
+					location: loc,
+					diskType: types.ToDiskType(v.DiskType),
+				})
+			}
+		}
+	})
+
+	var vids []needle.VolumeId
+	for vid := range volumes {
+		vids = append(vids, vid)
+	}
+	sort.Slice(vids, func(i, j int) bool {
+		return vids[i] < vids[j]
+	})
+
+	// free volume slots of each disk type on each server, reduced as the moves are planned
+	freeSlots := make(map[string]map[types.DiskType]int)
+	for _, loc := range allLocations {
+		freeSlots[loc.dataNode.Id] = make(map[types.DiskType]int)
+		for diskType, diskInfo := range loc.dataNode.DiskInfos {
+			freeSlots[loc.dataNode.Id][types.ToDiskType(diskType)] = int(diskInfo.MaxVolumeCount - diskInfo.VolumeCount)
+		}
+	}
+
+	for _, vid := range vids {
+		tv := volumes[vid]
+		for _, rule := range p.Rules {
+			if !rule.matches(tv, volumeSizeLimitMb, now) {
+				continue
+			}
+			move := &tieringMove{rule: rule, volume: tv}
+			if rule.FromRemote || rule.Dest != "" || planDiskTypeMove(move, allLocations, freeSlots) {
+				moves = append(moves, move)
+			}
+			break
+		}
+	}
+
+	return
+}
+
+func (rule *TieringRule) matches(tv *tieringVolume, volumeSizeLimitMb uint64, now time.Time) bool {
+	if matched, _ := filepath.Match(rule.Collection, tv.collection); !matched {
+		return false
+	}
+	if tv.remote != rule.FromRemote {
+		return false
+	}
+	if !rule.FromRemote {
+		hasSourceReplica := false
+		for _, r := range tv.replicas {
+			if r.diskType == rule.FromDiskType {
+				hasSourceReplica = true
+			}
+		}
+		if !hasSourceReplica {
+			return false
+		}
+	}
+	if tv.modifiedAtSecond+int64(rule.QuietFor/time.Second) > now.Unix() {
+		return false
+	}
+	if rule.MinFullPercent > 0 && float64(tv.size) < rule.MinFullPercent/100*float64(volumeSizeLimitMb)*1024*1024 {
+		return false
+	}
+	if rule.MinReadsPerHour > 0 || rule.MaxReadsPerHour >= 0 {
+		if !tv.readsKnown {
+			return false
+		}
+		if rule.MinReadsPerHour > 0 && tv.readsPerHour < rule.MinReadsPerHour {
+			return false
+		}
+		if rule.MaxReadsPerHour >= 0 && tv.readsPerHour > rule.MaxReadsPerHour {
+			return false
+		}
+	}
+	return true
+}
+
+// planDiskTypeMove picks one target server for each replica on the source disk type,
+// preferring servers in the same rack, then in the same data center
+func planDiskTypeMove(move *tieringMove, allLocations []location, freeSlots map[string]map[types.DiskType]int) bool {
+	toDiskType := move.rule.ToDiskType
+	used := make(map[string]bool)
+	for _, r := range move.volume.replicas {
+		used[r.location.dataNode.Id] = true
+	}
+	var sources, targets []location
+	for _, r := range move.volume.replicas {
+		if r.diskType != move.rule.FromDiskType {
+			continue
+		}
+		var best *location
+		bestScore := -1
+		for i, loc := range allLocations {
+			if used[loc.dataNode.Id] || freeSlots[loc.dataNode.Id][toDiskType] <= 0 {
+				continue
+			}
+			score := 0
+			if loc.dc == r.location.dc {
+				score++
+				if loc.rack == r.location.rack {
+					score++
+				}
+			}
+			if score > bestScore || score == bestScore && freeSlots[loc.dataNode.Id][toDiskType] > freeSlots[best.dataNode.Id][toDiskType] {
+				best, bestScore = &allLocations[i], score
+			}
+		}
+		if best == nil {
+			return false
+		}
+		used[best.dataNode.Id] = true
+		sources = append(sources, r.location)
+		targets = append(targets, *best)
+	}
+	for i := range targets {
+		freeSlots[targets[i].dataNode.Id][toDiskType]--
+		freeSlots[sources[i].dataNode.Id][move.rule.FromDiskType]++
+	}
+	move.sources, move.targets = sources, targets
+	return true
+}
+
+func (m *tieringMove) execute(commandEnv *CommandEnv, writer io.Writer) error {
+	switch {
+	case m.rule.FromRemote:
+		return doVolumeTierDownload(commandEnv, writer, m.volume.collection, m.volume.id)
+	case m.rule.Dest != "":
+		return doVolumeTierUpload(commandEnv, writer, m.volume.collection, m.volume.id, m.rule.Dest, false)
+	}
+	for i := range m.sources {
+		fmt.Fprintf(writer, "moving volume %d from %s to %s with disk type %s ...\n", m.volume.id, m.sources[i].dataNode.Id, m.targets[i].dataNode.Id, m.rule.ToDiskType.ReadableString())
+		if err := LiveMoveVolume(commandEnv.option.GrpcDialOption, m.volume.id, m.sources[i].dataNode.Id, m.targets[i].dataNode.Id, 5*time.Second, m.rule.ToDiskType.ReadableString()); err != nil {
+			return fmt.Errorf("move volume %d %s => %s : %v", m.volume.id, m.sources[i].dataNode.Id, m.targets[i].dataNode.Id, err)
+		}
+	}
+	return nil
+}
+
+type lockedWriter struct {
+	w    io.Writer
+	lock *sync.Mutex
+}
+
+func (lw *lockedWriter) Write(p []byte) (int, error) {
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+	return lw.w.Write(p)
+}
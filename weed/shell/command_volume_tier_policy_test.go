@@ -0,0 +1,84 @@
+package shell
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+func TestTieringPolicyPlan(t *testing.T) {
+
+	now := time.Now()
+	old := now.Add(-48 * time.Hour).Unix()
+
+	topologyInfo := &master_pb.TopologyInfo{
+		DataCenterInfos: []*master_pb.DataCenterInfo{{
+			Id: "dc1",
+			RackInfos: []*master_pb.RackInfo{{
+				Id: "r1",
+				DataNodeInfos: []*master_pb.DataNodeInfo{
+					{
+						Id: "dn1",
+						DiskInfos: map[string]*master_pb.DiskInfo{
+							"ssd": {MaxVolumeCount: 10, VolumeCount: 3, VolumeInfos: []*master_pb.VolumeInformationMessage{
+								// cold and full
+								{Id: 1, Size: 1 << 20, ModifiedAtSecond: old, DiskType: "ssd", RecentReadCount: 1, RecentReadSeconds: 3600},
+								// still hot
+								{Id: 2, Size: 1 << 20, ModifiedAtSecond: old, DiskType: "ssd", RecentReadCount: 1000, RecentReadSeconds: 3600},
+								// reads are not known yet
+								{Id: 3, Size: 1 << 20, ModifiedAtSecond: old, DiskType: "ssd", RecentReadCount: 0, RecentReadSeconds: 60},
+							}},
+						},
+					},
+					{
+						Id: "dn2",
+						DiskInfos: map[string]*master_pb.DiskInfo{
+							"": {MaxVolumeCount: 10, VolumeCount: 2, VolumeInfos: []*master_pb.VolumeInformationMessage{
+								// archived by collection
+								{Id: 4, Collection: "logs_2020", Size: 1 << 20, ModifiedAtSecond: old, RecentReadCount: 0, RecentReadSeconds: 3600},
+								// recently written
+								{Id: 5, Collection: "logs_2021", Size: 1 << 20, ModifiedAtSecond: now.Unix(), RecentReadCount: 0, RecentReadSeconds: 3600},
+							}},
+						},
+					},
+				},
+			}},
+		}},
+	}
+
+	policy := &TieringPolicy{
+		Concurrency: 1,
+		Rules: []*TieringRule{
+			{
+				Name:            "cold",
+				Collection:      "*",
+				FromDiskType:    types.SsdType,
+				ToDiskType:      types.HardDriveType,
+				QuietFor:        24 * time.Hour,
+				MinFullPercent:  90,
+				MaxReadsPerHour: 10,
+			},
+			{
+				Name:            "archive",
+				Collection:      "logs_*",
+				FromDiskType:    types.HardDriveType,
+				Dest:            "s3.default",
+				QuietFor:        24 * time.Hour,
+				MaxReadsPerHour: -1,
+			},
+		},
+	}
+
+	moves := policy.plan(topologyInfo, 1, now)
+	if len(moves) != 2 {
+		t.Fatalf("expected 2 moves, got %v", moves)
+	}
+	if moves[0].volume.id != 1 || moves[0].rule.Name != "cold" || len(moves[0].targets) != 1 || moves[0].targets[0].dataNode.Id != "dn2" {
+		t.Errorf("unexpected move %v", moves[0])
+	}
+	if moves[1].volume.id != 4 || moves[1].rule.Name != "archive" {
+		t.Errorf("unexpected move %v", moves[1])
+	}
+}
@@ -34,9 +34,11 @@ func (c *commandVolumeTierUpload) Help() string {
 	e.g.:
 	volume.tier.upload -volumeId=7 -dest=s3
 	volume.tier.upload -volumeId=7 -dest=s3.default
+	volume.tier.upload -volumeId=7 -dest=local.default
 
 	The <storage_backend> is defined in master.toml.
 	For example, "s3.default" in [storage.backend.s3.default]
+	The supported backend types are s3, local (a local or mounted network directory), azure, and gcs.
 
 	This command will move the dat file of a volume to a remote tier.
 
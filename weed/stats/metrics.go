@@ -52,6 +52,46 @@ var (
 			Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 24),
 		}, []string{"store", "type"})
 
+	FilerStoreSizeGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "filerStore",
+			Name:      "size_bytes",
+			Help:      "Size of the embedded filer store files.",
+		}, []string{"store", "type"})
+
+	FilerStoreCompactionCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "filerStore",
+			Name:      "compaction_total",
+			Help:      "Counter of embedded filer store compactions.",
+		}, []string{"store", "type"})
+
+	FilerLeaseRevocationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "filer",
+			Name:      "lease_revocation_total",
+			Help:      "Counter of revoked client leases.",
+		}, []string{"type"})
+
+	MountCacheInvalidationCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "mount",
+			Name:      "cache_invalidation_total",
+			Help:      "Counter of mount cache invalidations.",
+		}, []string{"type"})
+
+	MountReadAheadCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "mount",
+			Name:      "read_ahead_total",
+			Help:      "Counter of mount read-ahead prefetches, and chunk reads hitting or missing the prefetched chunks.",
+		}, []string{"type"})
+
 	VolumeServerRequestCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "SeaweedFS",
@@ -109,6 +149,30 @@ var (
 			Help:      "Resource usage",
 		}, []string{"name", "type"})
 
+	MasterCorruptNeedleCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "master",
+			Name:      "corrupt_needle_total",
+			Help:      "Counter of corrupted needles reported by volume server scrubbing.",
+		}, []string{"collection", "repaired"})
+
+	VolumeServerScrubCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "volumeServer",
+			Name:      "scrub_total",
+			Help:      "Counter of scrubbed needles and bytes, and corrupted and repaired needles.",
+		}, []string{"type"})
+
+	VolumeServerReadCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "SeaweedFS",
+			Subsystem: "volumeServer",
+			Name:      "read_cache_total",
+			Help:      "Counter of needle reads hitting or missing the read cache, per volume.",
+		}, []string{"collection", "volume", "type"})
+
 	S3RequestCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "SeaweedFS",
@@ -132,8 +196,15 @@ func init() {
 	Gather.MustRegister(FilerRequestHistogram)
 	Gather.MustRegister(FilerStoreCounter)
 	Gather.MustRegister(FilerStoreHistogram)
+	Gather.MustRegister(FilerStoreSizeGauge)
+	Gather.MustRegister(FilerStoreCompactionCounter)
+	Gather.MustRegister(FilerLeaseRevocationCounter)
+	Gather.MustRegister(MountCacheInvalidationCounter)
+	Gather.MustRegister(MountReadAheadCounter)
 	Gather.MustRegister(prometheus.NewGoCollector())
 
+	Gather.MustRegister(MasterCorruptNeedleCounter)
+
 	Gather.MustRegister(VolumeServerRequestCounter)
 	Gather.MustRegister(VolumeServerRequestHistogram)
 	Gather.MustRegister(VolumeServerVolumeCounter)
@@ -141,6 +212,8 @@ func init() {
 	Gather.MustRegister(VolumeServerReadOnlyVolumeGauge)
 	Gather.MustRegister(VolumeServerDiskSizeGauge)
 	Gather.MustRegister(VolumeServerResourceGauge)
+	Gather.MustRegister(VolumeServerScrubCounter)
+	Gather.MustRegister(VolumeServerReadCacheCounter)
 
 	Gather.MustRegister(S3RequestCounter)
 	Gather.MustRegister(S3RequestHistogram)
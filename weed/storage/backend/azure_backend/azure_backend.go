@@ -0,0 +1,215 @@
+package azure_backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/google/uuid"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage/backend"
+)
+
+func init() {
+	backend.BackendStorageFactories["azure"] = &AzureBackendFactory{}
+}
+
+type AzureBackendFactory struct {
+}
+
+func (factory *AzureBackendFactory) StorageType() backend.StorageType {
+	return backend.StorageType("azure")
+}
+func (factory *AzureBackendFactory) BuildStorage(configuration backend.StringProperties, configPrefix string, id string) (backend.BackendStorage, error) {
+	return newAzureBackendStorage(configuration, configPrefix, id)
+}
+
+type AzureBackendStorage struct {
+	id           string
+	accountName  string
+	accountKey   string
+	container    string
+	containerURL azblob.ContainerURL
+}
+
+func newAzureBackendStorage(configuration backend.StringProperties, configPrefix string, id string) (s *AzureBackendStorage, err error) {
+	s = &AzureBackendStorage{}
+	s.id = id
+	s.accountName = configuration.GetString(configPrefix + "account_name")
+	s.accountKey = configuration.GetString(configPrefix + "account_key")
+	s.container = configuration.GetString(configPrefix + "container")
+
+	credential, err := azblob.NewSharedKeyCredential(s.accountName, s.accountKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Azure credential with account name:%s: %v", s.accountName, err)
+	}
+	p := azblob.NewPipeline(credential, azblob.PipelineOptions{})
+	u, err := url.Parse(fmt.Sprintf("https://%s.blob.core.windows.net", s.accountName))
+	if err != nil {
+		return nil, fmt.Errorf("parse Azure url for account name:%s: %v", s.accountName, err)
+	}
+	s.containerURL = azblob.NewServiceURL(*u, p).NewContainerURL(s.container)
+
+	glog.V(0).Infof("created backend storage azure.%s for container %s", s.id, s.container)
+	return
+}
+
+func (s *AzureBackendStorage) ToProperties() map[string]string {
+	m := make(map[string]string)
+	m["account_name"] = s.accountName
+	m["account_key"] = s.accountKey
+	m["container"] = s.container
+	return m
+}
+
+func (s *AzureBackendStorage) blobURL(key string) azblob.BlockBlobURL {
+	return s.containerURL.NewBlockBlobURL(strings.TrimPrefix(key, "/"))
+}
+
+func (s *AzureBackendStorage) NewStorageFile(key string, tierInfo *volume_server_pb.VolumeInfo) backend.BackendStorageFile {
+	f := &AzureBackendStorageFile{
+		backendStorage: s,
+		key:            key,
+		tierInfo:       tierInfo,
+	}
+	return f
+}
+
+func (s *AzureBackendStorage) CopyFile(f *os.File, attributes map[string]string, fn func(progressed int64, percentage float32) error) (key string, size int64, err error) {
+	randomUuid, _ := uuid.NewRandom()
+	key = randomUuid.String()
+
+	glog.V(1).Infof("copying dat file of %s to remote azure.%s as %s", f.Name(), s.id, key)
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to stat file %q, %v", f.Name(), err)
+	}
+	size = info.Size()
+
+	_, err = azblob.UploadFileToBlockBlob(context.Background(), f, s.blobURL(key), azblob.UploadToBlockBlobOptions{
+		BlockSize:   64 * 1024 * 1024,
+		Parallelism: 5,
+		Metadata:    attributes,
+		Progress: func(bytesTransferred int64) {
+			if fn != nil && size > 0 {
+				fn(bytesTransferred, float32(bytesTransferred*100)/float32(size))
+			}
+		},
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to upload file %s to azure.%s: %v", f.Name(), s.id, err)
+	}
+
+	return
+}
+
+func (s *AzureBackendStorage) DownloadFile(fileName string, key string, fn func(progressed int64, percentage float32) error) (size int64, err error) {
+
+	glog.V(1).Infof("download dat file of %s from remote azure.%s as %s", fileName, s.id, key)
+
+	blobURL := s.blobURL(key)
+	props, err := blobURL.GetProperties(context.Background(), azblob.BlobAccessConditions{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get properties of azure.%s %s: %v", s.id, key, err)
+	}
+	size = props.ContentLength()
+
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file %q, %v", fileName, err)
+	}
+	defer f.Close()
+
+	err = azblob.DownloadBlobToFile(context.Background(), blobURL.BlobURL, 0, size, f, azblob.DownloadFromBlobOptions{
+		BlockSize:   64 * 1024 * 1024,
+		Parallelism: 5,
+		Progress: func(bytesTransferred int64) {
+			if fn != nil && size > 0 {
+				fn(bytesTransferred, float32(bytesTransferred*100)/float32(size))
+			}
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to download azure.%s %s: %v", s.id, key, err)
+	}
+
+	return
+}
+
+func (s *AzureBackendStorage) DeleteFile(key string) (err error) {
+
+	glog.V(1).Infof("delete dat file %s from remote", key)
+
+	_, err = s.blobURL(key).Delete(context.Background(), azblob.DeleteSnapshotsOptionInclude, azblob.BlobAccessConditions{})
+
+	return
+}
+
+type AzureBackendStorageFile struct {
+	backendStorage *AzureBackendStorage
+	key            string
+	tierInfo       *volume_server_pb.VolumeInfo
+}
+
+func (azureBackendStorageFile AzureBackendStorageFile) ReadAt(p []byte, off int64) (n int, err error) {
+
+	resp, err := azureBackendStorageFile.backendStorage.blobURL(azureBackendStorageFile.key).Download(
+		context.Background(), off, int64(len(p)), azblob.BlobAccessConditions{}, false)
+	if err != nil {
+		return 0, fmt.Errorf("download azure.%s %s [%d,%d): %v", azureBackendStorageFile.backendStorage.id, azureBackendStorageFile.key, off, off+int64(len(p)), err)
+	}
+	body := resp.Body(azblob.RetryReaderOptions{MaxRetryRequests: 3})
+	defer body.Close()
+
+	glog.V(4).Infof("read azure.%s %s [%d,%d)", azureBackendStorageFile.backendStorage.id, azureBackendStorageFile.key, off, off+int64(len(p)))
+
+	n, err = io.ReadFull(body, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+
+	return
+}
+
+func (azureBackendStorageFile AzureBackendStorageFile) WriteAt(p []byte, off int64) (n int, err error) {
+	panic("not implemented")
+}
+
+func (azureBackendStorageFile AzureBackendStorageFile) Truncate(off int64) error {
+	panic("not implemented")
+}
+
+func (azureBackendStorageFile AzureBackendStorageFile) Close() error {
+	return nil
+}
+
+func (azureBackendStorageFile AzureBackendStorageFile) GetStat() (datSize int64, modTime time.Time, err error) {
+
+	files := azureBackendStorageFile.tierInfo.GetFiles()
+
+	if len(files) == 0 {
+		err = fmt.Errorf("remote file info not found")
+		return
+	}
+
+	datSize = int64(files[0].FileSize)
+	modTime = time.Unix(int64(files[0].ModifiedTime), 0)
+
+	return
+}
+
+func (azureBackendStorageFile AzureBackendStorageFile) Name() string {
+	return azureBackendStorageFile.key
+}
+
+func (azureBackendStorageFile AzureBackendStorageFile) Sync() error {
+	return nil
+}
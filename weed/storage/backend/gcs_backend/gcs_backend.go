@@ -0,0 +1,201 @@
+package gcs_backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/google/uuid"
+	"google.golang.org/api/option"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage/backend"
+)
+
+func init() {
+	backend.BackendStorageFactories["gcs"] = &GcsBackendFactory{}
+}
+
+type GcsBackendFactory struct {
+}
+
+func (factory *GcsBackendFactory) StorageType() backend.StorageType {
+	return backend.StorageType("gcs")
+}
+func (factory *GcsBackendFactory) BuildStorage(configuration backend.StringProperties, configPrefix string, id string) (backend.BackendStorage, error) {
+	return newGcsBackendStorage(configuration, configPrefix, id)
+}
+
+type GcsBackendStorage struct {
+	id                           string
+	googleApplicationCredentials string
+	bucket                       string
+	client                       *storage.Client
+}
+
+func newGcsBackendStorage(configuration backend.StringProperties, configPrefix string, id string) (s *GcsBackendStorage, err error) {
+	s = &GcsBackendStorage{}
+	s.id = id
+	s.googleApplicationCredentials = configuration.GetString(configPrefix + "google_application_credentials")
+	s.bucket = configuration.GetString(configPrefix + "bucket")
+
+	// without the credentials file, fall back to GOOGLE_APPLICATION_CREDENTIALS or the default service account
+	var opts []option.ClientOption
+	if s.googleApplicationCredentials != "" {
+		opts = append(opts, option.WithCredentialsFile(s.googleApplicationCredentials))
+	}
+	s.client, err = storage.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcs client: %v", err)
+	}
+
+	glog.V(0).Infof("created backend storage gcs.%s for bucket %s", s.id, s.bucket)
+	return
+}
+
+func (s *GcsBackendStorage) ToProperties() map[string]string {
+	m := make(map[string]string)
+	m["google_application_credentials"] = s.googleApplicationCredentials
+	m["bucket"] = s.bucket
+	return m
+}
+
+func (s *GcsBackendStorage) object(key string) *storage.ObjectHandle {
+	return s.client.Bucket(s.bucket).Object(strings.TrimPrefix(key, "/"))
+}
+
+func (s *GcsBackendStorage) NewStorageFile(key string, tierInfo *volume_server_pb.VolumeInfo) backend.BackendStorageFile {
+	f := &GcsBackendStorageFile{
+		backendStorage: s,
+		key:            key,
+		tierInfo:       tierInfo,
+	}
+	return f
+}
+
+func (s *GcsBackendStorage) CopyFile(f *os.File, attributes map[string]string, fn func(progressed int64, percentage float32) error) (key string, size int64, err error) {
+	randomUuid, _ := uuid.NewRandom()
+	key = randomUuid.String()
+
+	glog.V(1).Infof("copying dat file of %s to remote gcs.%s as %s", f.Name(), s.id, key)
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to stat file %q, %v", f.Name(), err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wc := s.object(key).NewWriter(ctx)
+	wc.Metadata = attributes
+	size, err = io.Copy(wc, backend.NewProgressedReader(io.NewSectionReader(f, 0, info.Size()), info.Size(), fn))
+	if err != nil {
+		// cancelling the context aborts the upload
+		cancel()
+		wc.Close()
+		return "", 0, fmt.Errorf("failed to upload file %s to gcs.%s: %v", f.Name(), s.id, err)
+	}
+	if err = wc.Close(); err != nil {
+		return "", 0, fmt.Errorf("failed to upload file %s to gcs.%s: %v", f.Name(), s.id, err)
+	}
+
+	return
+}
+
+func (s *GcsBackendStorage) DownloadFile(fileName string, key string, fn func(progressed int64, percentage float32) error) (size int64, err error) {
+
+	glog.V(1).Infof("download dat file of %s from remote gcs.%s as %s", fileName, s.id, key)
+
+	rc, err := s.object(key).NewReader(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("failed to read gcs.%s %s: %v", s.id, key, err)
+	}
+	defer rc.Close()
+
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file %q, %v", fileName, err)
+	}
+	defer f.Close()
+
+	size, err = io.Copy(f, backend.NewProgressedReader(rc, rc.Attrs.Size, fn))
+	if err != nil {
+		return 0, fmt.Errorf("failed to download gcs.%s %s: %v", s.id, key, err)
+	}
+
+	return
+}
+
+func (s *GcsBackendStorage) DeleteFile(key string) (err error) {
+
+	glog.V(1).Infof("delete dat file %s from remote", key)
+
+	err = s.object(key).Delete(context.Background())
+
+	return
+}
+
+type GcsBackendStorageFile struct {
+	backendStorage *GcsBackendStorage
+	key            string
+	tierInfo       *volume_server_pb.VolumeInfo
+}
+
+func (gcsBackendStorageFile GcsBackendStorageFile) ReadAt(p []byte, off int64) (n int, err error) {
+
+	rc, err := gcsBackendStorageFile.backendStorage.object(gcsBackendStorageFile.key).NewRangeReader(context.Background(), off, int64(len(p)))
+	if err != nil {
+		return 0, fmt.Errorf("read gcs.%s %s [%d,%d): %v", gcsBackendStorageFile.backendStorage.id, gcsBackendStorageFile.key, off, off+int64(len(p)), err)
+	}
+	defer rc.Close()
+
+	glog.V(4).Infof("read gcs.%s %s [%d,%d)", gcsBackendStorageFile.backendStorage.id, gcsBackendStorageFile.key, off, off+int64(len(p)))
+
+	n, err = io.ReadFull(rc, p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+
+	return
+}
+
+func (gcsBackendStorageFile GcsBackendStorageFile) WriteAt(p []byte, off int64) (n int, err error) {
+	panic("not implemented")
+}
+
+func (gcsBackendStorageFile GcsBackendStorageFile) Truncate(off int64) error {
+	panic("not implemented")
+}
+
+func (gcsBackendStorageFile GcsBackendStorageFile) Close() error {
+	return nil
+}
+
+func (gcsBackendStorageFile GcsBackendStorageFile) GetStat() (datSize int64, modTime time.Time, err error) {
+
+	files := gcsBackendStorageFile.tierInfo.GetFiles()
+
+	if len(files) == 0 {
+		err = fmt.Errorf("remote file info not found")
+		return
+	}
+
+	datSize = int64(files[0].FileSize)
+	modTime = time.Unix(int64(files[0].ModifiedTime), 0)
+
+	return
+}
+
+func (gcsBackendStorageFile GcsBackendStorageFile) Name() string {
+	return gcsBackendStorageFile.key
+}
+
+func (gcsBackendStorageFile GcsBackendStorageFile) Sync() error {
+	return nil
+}
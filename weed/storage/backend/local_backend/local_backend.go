@@ -0,0 +1,191 @@
+package local_backend
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage/backend"
+)
+
+func init() {
+	backend.BackendStorageFactories["local"] = &LocalBackendFactory{}
+}
+
+// LocalBackendFactory builds backends storing the volume files in a local directory,
+// usually a mounted network file system or cheap large disks.
+type LocalBackendFactory struct {
+}
+
+func (factory *LocalBackendFactory) StorageType() backend.StorageType {
+	return backend.StorageType("local")
+}
+func (factory *LocalBackendFactory) BuildStorage(configuration backend.StringProperties, configPrefix string, id string) (backend.BackendStorage, error) {
+	return newLocalBackendStorage(configuration, configPrefix, id)
+}
+
+type LocalBackendStorage struct {
+	id        string
+	directory string
+}
+
+func newLocalBackendStorage(configuration backend.StringProperties, configPrefix string, id string) (s *LocalBackendStorage, err error) {
+	s = &LocalBackendStorage{}
+	s.id = id
+	s.directory = configuration.GetString(configPrefix + "directory")
+	if s.directory == "" {
+		return nil, fmt.Errorf("local.%s: empty directory", id)
+	}
+
+	glog.V(0).Infof("created backend storage local.%s for directory %s", s.id, s.directory)
+	return
+}
+
+func (s *LocalBackendStorage) ToProperties() map[string]string {
+	m := make(map[string]string)
+	m["directory"] = s.directory
+	return m
+}
+
+func (s *LocalBackendStorage) fileName(key string) string {
+	return filepath.Join(s.directory, strings.TrimPrefix(key, "/"))
+}
+
+func (s *LocalBackendStorage) NewStorageFile(key string, tierInfo *volume_server_pb.VolumeInfo) backend.BackendStorageFile {
+	f := &LocalBackendStorageFile{
+		backendStorage: s,
+		key:            key,
+		tierInfo:       tierInfo,
+	}
+	f.file, f.openErr = os.Open(s.fileName(key))
+	if f.openErr != nil {
+		glog.Errorf("open local.%s %s: %v", s.id, key, f.openErr)
+	}
+	return f
+}
+
+// CopyFile copies the file into the directory. The attributes are not kept.
+func (s *LocalBackendStorage) CopyFile(f *os.File, attributes map[string]string, fn func(progressed int64, percentage float32) error) (key string, size int64, err error) {
+	randomUuid, _ := uuid.NewRandom()
+	key = randomUuid.String()
+
+	glog.V(1).Infof("copying dat file of %s to remote local.%s as %s", f.Name(), s.id, key)
+
+	size, err = copyLocalFile(f.Name(), s.fileName(key), fn)
+
+	return
+}
+
+func (s *LocalBackendStorage) DownloadFile(fileName string, key string, fn func(progressed int64, percentage float32) error) (size int64, err error) {
+
+	glog.V(1).Infof("download dat file of %s from remote local.%s as %s", fileName, s.id, key)
+
+	size, err = copyLocalFile(s.fileName(key), fileName, fn)
+
+	return
+}
+
+func (s *LocalBackendStorage) DeleteFile(key string) (err error) {
+
+	glog.V(1).Infof("delete dat file %s from remote", key)
+
+	err = os.Remove(s.fileName(key))
+
+	return
+}
+
+func copyLocalFile(srcFileName, dstFileName string, fn func(progressed int64, percentage float32) error) (size int64, err error) {
+	src, err := os.Open(srcFileName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file %q, %v", srcFileName, err)
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat file %q, %v", srcFileName, err)
+	}
+
+	dst, err := os.OpenFile(dstFileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file %q, %v", dstFileName, err)
+	}
+
+	size, err = io.Copy(dst, backend.NewProgressedReader(src, info.Size(), fn))
+	if err == nil {
+		err = dst.Sync()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dstFileName)
+		return 0, fmt.Errorf("failed to copy %s to %s: %v", srcFileName, dstFileName, err)
+	}
+
+	return
+}
+
+type LocalBackendStorageFile struct {
+	backendStorage *LocalBackendStorage
+	key            string
+	tierInfo       *volume_server_pb.VolumeInfo
+	file           *os.File
+	openErr        error
+}
+
+func (localBackendStorageFile *LocalBackendStorageFile) ReadAt(p []byte, off int64) (n int, err error) {
+	if localBackendStorageFile.openErr != nil {
+		return 0, localBackendStorageFile.openErr
+	}
+	n, err = localBackendStorageFile.file.ReadAt(p, off)
+	if err == io.EOF && n == len(p) {
+		err = nil
+	}
+	return
+}
+
+func (localBackendStorageFile *LocalBackendStorageFile) WriteAt(p []byte, off int64) (n int, err error) {
+	return 0, fmt.Errorf("local.%s %s is read only", localBackendStorageFile.backendStorage.id, localBackendStorageFile.key)
+}
+
+func (localBackendStorageFile *LocalBackendStorageFile) Truncate(off int64) error {
+	return fmt.Errorf("local.%s %s is read only", localBackendStorageFile.backendStorage.id, localBackendStorageFile.key)
+}
+
+func (localBackendStorageFile *LocalBackendStorageFile) Close() error {
+	if localBackendStorageFile.file == nil {
+		return nil
+	}
+	return localBackendStorageFile.file.Close()
+}
+
+func (localBackendStorageFile *LocalBackendStorageFile) GetStat() (datSize int64, modTime time.Time, err error) {
+
+	files := localBackendStorageFile.tierInfo.GetFiles()
+
+	if len(files) == 0 {
+		err = fmt.Errorf("remote file info not found")
+		return
+	}
+
+	datSize = int64(files[0].FileSize)
+	modTime = time.Unix(int64(files[0].ModifiedTime), 0)
+
+	return
+}
+
+func (localBackendStorageFile *LocalBackendStorageFile) Name() string {
+	return localBackendStorageFile.key
+}
+
+func (localBackendStorageFile *LocalBackendStorageFile) Sync() error {
+	return nil
+}
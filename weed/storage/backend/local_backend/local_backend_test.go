@@ -0,0 +1,94 @@
+package local_backend
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+)
+
+type testProperties map[string]string
+
+func (p testProperties) GetString(key string) string {
+	return p[key]
+}
+
+func TestLocalBackendRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	remoteDir := filepath.Join(dir, "remote")
+	if err := os.Mkdir(remoteDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	storage, err := newLocalBackendStorage(testProperties{"backend.local.directory": remoteDir}, "backend.local.", "default")
+	if err != nil {
+		t.Fatalf("create storage: %v", err)
+	}
+
+	content := make([]byte, 100*1024)
+	for i := range content {
+		content[i] = byte(i * 7)
+	}
+	datFileName := filepath.Join(dir, "1.dat")
+	if err = ioutil.WriteFile(datFileName, content, 0644); err != nil {
+		t.Fatalf("write dat file: %v", err)
+	}
+	datFile, err := os.Open(datFileName)
+	if err != nil {
+		t.Fatalf("open dat file: %v", err)
+	}
+	defer datFile.Close()
+
+	var lastProgressed int64
+	key, size, err := storage.CopyFile(datFile, nil, func(progressed int64, percentage float32) error {
+		lastProgressed = progressed
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("copy file: %v", err)
+	}
+	if size != int64(len(content)) || lastProgressed != size {
+		t.Fatalf("copied %d bytes, progressed %d, expected %d", size, lastProgressed, len(content))
+	}
+
+	// ranged reads, including one ending at the end of the file
+	f := storage.NewStorageFile(key, &volume_server_pb.VolumeInfo{
+		Files: []*volume_server_pb.RemoteFile{{Key: key, FileSize: uint64(size)}},
+	})
+	for _, r := range []struct{ offset, length int64 }{{0, 10}, {4096, 8192}, {size - 100, 100}} {
+		p := make([]byte, r.length)
+		n, err := f.ReadAt(p, r.offset)
+		if err != nil || int64(n) != r.length {
+			t.Fatalf("read %d bytes at %d: %d %v", r.length, r.offset, n, err)
+		}
+		if !bytes.Equal(p, content[r.offset:r.offset+r.length]) {
+			t.Fatalf("unexpected content at %d", r.offset)
+		}
+	}
+	if datSize, _, err := f.GetStat(); err != nil || datSize != size {
+		t.Fatalf("stat: %d %v", datSize, err)
+	}
+	if _, err = f.WriteAt([]byte("x"), 0); err == nil {
+		t.Fatalf("remote file should be read only")
+	}
+	f.Close()
+
+	downloadedFileName := filepath.Join(dir, "2.dat")
+	if size, err = storage.DownloadFile(downloadedFileName, key, nil); err != nil || size != int64(len(content)) {
+		t.Fatalf("download file: %d %v", size, err)
+	}
+	downloaded, err := ioutil.ReadFile(downloadedFileName)
+	if err != nil || !bytes.Equal(downloaded, content) {
+		t.Fatalf("downloaded content differs: %v", err)
+	}
+
+	if err = storage.DeleteFile(key); err != nil {
+		t.Fatalf("delete file: %v", err)
+	}
+	if _, err = os.Stat(storage.fileName(key)); !os.IsNotExist(err) {
+		t.Fatalf("remote file still exists: %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package backend
+
+import (
+	"io"
+)
+
+// ProgressedReader reports the progress of reading size bytes, when uploading or downloading a file
+type ProgressedReader struct {
+	r    io.Reader
+	size int64
+	read int64
+	fn   func(progressed int64, percentage float32) error
+}
+
+func NewProgressedReader(r io.Reader, size int64, fn func(progressed int64, percentage float32) error) *ProgressedReader {
+	return &ProgressedReader{r: r, size: size, fn: fn}
+}
+
+func (pr *ProgressedReader) Read(p []byte) (n int, err error) {
+	n, err = pr.r.Read(p)
+	pr.read += int64(n)
+	if pr.fn != nil && n > 0 {
+		percentage := float32(100)
+		if pr.size > 0 {
+			percentage = float32(pr.read*100) / float32(pr.size)
+		}
+		if fnErr := pr.fn(pr.read, percentage); fnErr != nil {
+			return n, fnErr
+		}
+	}
+	return
+}
@@ -0,0 +1,69 @@
+package erasure_coding
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxShardCount is the most shards a volume can be erasure coded into, limited by the bits of ShardBits
+const MaxShardCount = 32
+
+// EcScheme is the number of data shards and parity shards a volume is erasure coded into.
+// Any ParityShards of the shards can be lost, and the volume can still be read.
+type EcScheme struct {
+	DataShards   int
+	ParityShards int
+}
+
+// DefaultEcScheme is used when the scheme is not specified, and for the volumes encoded before the schemes are recorded
+var DefaultEcScheme = EcScheme{DataShards: DataShardsCount, ParityShards: ParityShardsCount}
+
+func NewEcScheme(dataShards, parityShards int) (EcScheme, error) {
+	scheme := EcScheme{DataShards: dataShards, ParityShards: parityShards}
+	if dataShards <= 0 || parityShards <= 0 {
+		return scheme, fmt.Errorf("invalid erasure coding scheme %s: needs at least one data shard and one parity shard", scheme)
+	}
+	if scheme.TotalShards() > MaxShardCount {
+		return scheme, fmt.Errorf("invalid erasure coding scheme %s: more than %d shards", scheme, MaxShardCount)
+	}
+	return scheme, nil
+}
+
+// ParseEcScheme parses the scheme written as "<data shards>+<parity shards>", e.g. "10+4"
+func ParseEcScheme(s string) (EcScheme, error) {
+	parts := strings.Split(strings.TrimSpace(s), "+")
+	if len(parts) != 2 {
+		return EcScheme{}, fmt.Errorf("invalid erasure coding scheme %q, expecting <data shards>+<parity shards>", s)
+	}
+	dataShards, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return EcScheme{}, fmt.Errorf("invalid data shards in erasure coding scheme %q: %v", s, err)
+	}
+	parityShards, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return EcScheme{}, fmt.Errorf("invalid parity shards in erasure coding scheme %q: %v", s, err)
+	}
+	return NewEcScheme(dataShards, parityShards)
+}
+
+// ToEcScheme converts the scheme in the protobuf messages, where zero means the default scheme
+func ToEcScheme(dataShards, parityShards uint32) EcScheme {
+	if dataShards == 0 || parityShards == 0 {
+		return DefaultEcScheme
+	}
+	return EcScheme{DataShards: int(dataShards), ParityShards: int(parityShards)}
+}
+
+func (s EcScheme) TotalShards() int {
+	return s.DataShards + s.ParityShards
+}
+
+// IsParityShard tells whether the shard holds parity data, instead of the volume data
+func (s EcScheme) IsParityShard(id ShardId) bool {
+	return int(id) >= s.DataShards
+}
+
+func (s EcScheme) String() string {
+	return fmt.Sprintf("%d+%d", s.DataShards, s.ParityShards)
+}
@@ -0,0 +1,97 @@
+package erasure_coding
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+func TestParseEcScheme(t *testing.T) {
+	for input, expected := range map[string]EcScheme{
+		"10+4":   DefaultEcScheme,
+		"4+2":    {DataShards: 4, ParityShards: 2},
+		" 12+4 ": {DataShards: 12, ParityShards: 4},
+	} {
+		scheme, err := ParseEcScheme(input)
+		if err != nil {
+			t.Errorf("parse %q: %v", input, err)
+		}
+		if scheme != expected {
+			t.Errorf("parse %q: %v, expected %v", input, scheme, expected)
+		}
+	}
+
+	for _, input := range []string{"", "10", "10.4", "a+4", "0+4", "4+0", "30+3"} {
+		if _, err := ParseEcScheme(input); err == nil {
+			t.Errorf("parse %q: expecting an error", input)
+		}
+	}
+
+	if ToEcScheme(0, 0) != DefaultEcScheme {
+		t.Errorf("unspecified scheme should be the default")
+	}
+}
+
+func TestEncodingRebuildingWithScheme(t *testing.T) {
+	scheme := EcScheme{DataShards: 4, ParityShards: 2}
+
+	dir, err := ioutil.TempDir("", "ec_scheme")
+	if err != nil {
+		t.Fatalf("create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	baseFileName := filepath.Join(dir, "1")
+
+	datData := make([]byte, 2*int64(scheme.DataShards)*largeBlockSize+12345)
+	rand.Read(datData)
+	if err := ioutil.WriteFile(baseFileName+".dat", datData, 0644); err != nil {
+		t.Fatalf("write dat file: %v", err)
+	}
+
+	if err := generateEcFiles(baseFileName, 50, largeBlockSize, smallBlockSize, scheme); err != nil {
+		t.Fatalf("generateEcFiles: %v", err)
+	}
+	if _, err := os.Stat(baseFileName + ToExt(scheme.TotalShards())); !os.IsNotExist(err) {
+		t.Fatalf("more shards than the scheme: %v", err)
+	}
+
+	// lose one data shard and one parity shard
+	for _, shardId := range []int{1, 5} {
+		os.Remove(baseFileName + ToExt(shardId))
+	}
+	generated, err := generateMissingEcFiles(baseFileName, 50, largeBlockSize, smallBlockSize, scheme)
+	if err != nil {
+		t.Fatalf("generateMissingEcFiles: %v", err)
+	}
+	if len(generated) != 2 || generated[0] != 1 || generated[1] != 5 {
+		t.Fatalf("rebuilt shards %v", generated)
+	}
+
+	ecFiles, err := openEcFiles(baseFileName, true, scheme)
+	if err != nil {
+		t.Fatalf("openEcFiles: %v", err)
+	}
+	defer closeEcFiles(ecFiles)
+
+	for i := 0; i < 100; i++ {
+		offset := rand.Int63n(int64(len(datData)))
+		size := rand.Int63n(int64(len(datData))-offset) + 1
+		var ecData []byte
+		for _, interval := range LocateData(largeBlockSize, smallBlockSize, scheme.DataShards, int64(len(datData)), offset, types.Size(size)) {
+			shardId, shardOffset := interval.ToShardIdAndOffset(largeBlockSize, smallBlockSize, scheme.DataShards)
+			data := make([]byte, interval.Size)
+			if err := readFromFile(ecFiles[shardId], data, shardOffset); err != nil {
+				t.Fatalf("read shard %d: %v", shardId, err)
+			}
+			ecData = append(ecData, data...)
+		}
+		if !bytes.Equal(ecData, datData[offset:offset+size]) {
+			t.Fatalf("unexpected data read at %d size %d", offset, size)
+		}
+	}
+}
@@ -95,3 +95,20 @@ func (shard *EcVolumeShard) ReadAt(buf []byte, offset int64) (int, error) {
 	return shard.ecdFile.ReadAt(buf, offset)
 
 }
+
+// WriteAt overwrites part of the shard file, to repair the corrupted data in place
+func (shard *EcVolumeShard) WriteAt(buf []byte, offset int64) (int, error) {
+
+	file, err := os.OpenFile(shard.FileName()+ToExt(int(shard.ShardId)), os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	n, err := file.WriteAt(buf, offset)
+	if err != nil {
+		return n, err
+	}
+	return n, file.Sync()
+
+}
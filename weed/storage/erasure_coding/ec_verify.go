@@ -0,0 +1,38 @@
+package erasure_coding
+
+import (
+	"fmt"
+
+	"github.com/klauspost/reedsolomon"
+)
+
+// LocateCorruptShard finds the one shard of a stripe not matching the others, which all have the same size.
+// It returns -1 if the stripe is consistent, or an error if no single shard explains the mismatch.
+// With only one parity shard, any shard can be rebuilt from the others, so a mismatch can not be located.
+func LocateCorruptShard(enc reedsolomon.Encoder, shards [][]byte) (int, error) {
+	if ok, err := enc.Verify(shards); err != nil {
+		return -1, err
+	} else if ok {
+		return -1, nil
+	}
+	corrupted := -1
+	candidate := make([][]byte, len(shards))
+	for i := range shards {
+		copy(candidate, shards)
+		candidate[i] = nil
+		if err := enc.Reconstruct(candidate); err != nil {
+			return -1, err
+		}
+		if ok, _ := enc.Verify(candidate); !ok {
+			continue
+		}
+		if corrupted >= 0 {
+			return -1, fmt.Errorf("either shard %d or shard %d is corrupted", corrupted, i)
+		}
+		corrupted = i
+	}
+	if corrupted < 0 {
+		return -1, fmt.Errorf("more than one shard is corrupted")
+	}
+	return corrupted, nil
+}
@@ -0,0 +1,46 @@
+package erasure_coding
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/klauspost/reedsolomon"
+)
+
+func TestLocateCorruptShard(t *testing.T) {
+	scheme := EcScheme{DataShards: 4, ParityShards: 2}
+	enc, err := reedsolomon.New(scheme.DataShards, scheme.ParityShards)
+	if err != nil {
+		t.Fatalf("create encoder: %v", err)
+	}
+	shards := make([][]byte, scheme.TotalShards())
+	for i := range shards {
+		shards[i] = make([]byte, 1000)
+		if i < scheme.DataShards {
+			rand.Read(shards[i])
+		}
+	}
+	if err = enc.Encode(shards); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	if corrupted, err := LocateCorruptShard(enc, shards); corrupted != -1 || err != nil {
+		t.Fatalf("consistent stripe: %d %v", corrupted, err)
+	}
+
+	// any one corrupted shard is located, data or parity
+	for i := range shards {
+		shards[i][123] ^= 0xff
+		if corrupted, err := LocateCorruptShard(enc, shards); corrupted != i || err != nil {
+			t.Fatalf("corrupted shard %d: located %d %v", i, corrupted, err)
+		}
+		shards[i][123] ^= 0xff
+	}
+
+	// two corrupted shards can not be located with two parity shards
+	shards[1][5] ^= 0xff
+	shards[4][7] ^= 0xff
+	if _, err := LocateCorruptShard(enc, shards); err == nil {
+		t.Fatalf("two corrupted shards should not be located")
+	}
+}
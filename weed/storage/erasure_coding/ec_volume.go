@@ -3,6 +3,7 @@ package erasure_coding
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"os"
 	"sort"
@@ -15,6 +16,7 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/storage/idx"
 	"github.com/chrislusf/seaweedfs/weed/storage/needle"
 	"github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util"
 )
 
 var (
@@ -34,9 +36,11 @@ type EcVolume struct {
 	ShardLocationsRefreshTime time.Time
 	ShardLocationsLock        sync.RWMutex
 	Version                   needle.Version
+	Scheme                    EcScheme
 	ecjFile                   *os.File
 	ecjFileAccessLock         sync.Mutex
 	diskType                  types.DiskType
+	EncryptionKeys            []*volume_server_pb.VolumeEncryptionKey // wrapped keys of the encrypted needles
 }
 
 func NewEcVolume(diskType types.DiskType, dir string, dirIdx string, collection string, vid needle.VolumeId) (ev *EcVolume, err error) {
@@ -63,12 +67,22 @@ func NewEcVolume(diskType types.DiskType, dir string, dirIdx string, collection
 
 	// read volume info
 	ev.Version = needle.Version3
-	if volumeInfo, found, _ := pb.MaybeLoadVolumeInfo(dataBaseFileName + ".vif"); found {
+	ev.Scheme = DefaultEcScheme
+	if volumeInfo, _, err := pb.MaybeLoadVolumeInfo(dataBaseFileName + ".vif"); err == nil && volumeInfo.Version != 0 {
 		ev.Version = needle.Version(volumeInfo.Version)
+		ev.Scheme = ToEcScheme(volumeInfo.EcDataShards, volumeInfo.EcParityShards)
+		ev.EncryptionKeys = volumeInfo.EncryptionKeys
 	} else {
 		pb.SaveVolumeInfo(dataBaseFileName+".vif", &volume_server_pb.VolumeInfo{Version: uint32(ev.Version)})
 	}
 
+	// register the zstd dictionary of the compressed needles
+	if dict, readErr := ioutil.ReadFile(dataBaseFileName + ".zdict"); readErr == nil && len(dict) > 0 {
+		if _, err = util.RegisterZstdDictionary(dict); err != nil {
+			return nil, fmt.Errorf("ec volume %d: %v", vid, err)
+		}
+	}
+
 	ev.ShardLocations = make(map[ShardId][]string)
 
 	return
@@ -140,6 +154,7 @@ func (ev *EcVolume) Destroy() {
 	os.Remove(ev.FileName(".ecx"))
 	os.Remove(ev.FileName(".ecj"))
 	os.Remove(ev.FileName(".vif"))
+	os.Remove(ev.FileName(".zdict"))
 }
 
 func (ev *EcVolume) FileName(ext string) string {
@@ -147,7 +162,7 @@ func (ev *EcVolume) FileName(ext string) string {
 	case ".ecx", ".ecj":
 		return ev.IndexBaseFileName() + ext
 	}
-	// .vif
+	// .vif, .zdict
 	return ev.DataBaseFileName() + ext
 }
 
@@ -190,9 +205,11 @@ func (ev *EcVolume) ToVolumeEcShardInformationMessage() (messages []*master_pb.V
 	for _, s := range ev.Shards {
 		if s.VolumeId != prevVolumeId {
 			m = &master_pb.VolumeEcShardInformationMessage{
-				Id:         uint32(s.VolumeId),
-				Collection: s.Collection,
-				DiskType:   string(ev.diskType),
+				Id:           uint32(s.VolumeId),
+				Collection:   s.Collection,
+				DiskType:     string(ev.diskType),
+				DataShards:   uint32(ev.Scheme.DataShards),
+				ParityShards: uint32(ev.Scheme.ParityShards),
 			}
 			messages = append(messages, m)
 		}
@@ -210,14 +227,22 @@ func (ev *EcVolume) LocateEcShardNeedle(needleId types.NeedleId, version needle.
 		return types.Offset{}, 0, nil, fmt.Errorf("FindNeedleFromEcx: %v", err)
 	}
 
-	shard := ev.Shards[0]
-
-	// calculate the locations in the ec shards
-	intervals = LocateData(ErasureCodingLargeBlockSize, ErasureCodingSmallBlockSize, DataShardsCount*shard.ecdFileSize, offset.ToActualOffset(), types.Size(needle.GetActualSize(size, version)))
+	intervals = ev.LocateNeedleIntervals(offset, size, version)
 
 	return
 }
 
+// LocateNeedleIntervals calculates the locations in the ec shards for the needle in the .ecx file
+func (ev *EcVolume) LocateNeedleIntervals(offset types.Offset, size types.Size, version needle.Version) []Interval {
+	shard := ev.Shards[0]
+	return LocateData(ErasureCodingLargeBlockSize, ErasureCodingSmallBlockSize, ev.Scheme.DataShards, int64(ev.Scheme.DataShards)*shard.ecdFileSize, offset.ToActualOffset(), types.Size(needle.GetActualSize(size, version)))
+}
+
+// WalkIndex visits all entries in the .ecx file, including the deleted ones, and stops if processNeedleFn returns io.EOF
+func (ev *EcVolume) WalkIndex(processNeedleFn func(key types.NeedleId, offset types.Offset, size types.Size) error) error {
+	return iterateEcxFile(ev.IndexBaseFileName(), processNeedleFn)
+}
+
 func (ev *EcVolume) FindNeedleFromEcx(needleId types.NeedleId) (offset types.Offset, size types.Size, err error) {
 	return SearchNeedleFromSortedIndex(ev.ecxFile, ev.ecxFileSize, needleId, nil)
 }
@@ -13,18 +13,20 @@ import (
 )
 
 const (
-	FlagIsCompressed        = 0x01
+	FlagIsCompressed        = 0x01 // gzip, or zstd by the volume compression, told apart by the data
 	FlagHasName             = 0x02
 	FlagHasMime             = 0x04
 	FlagHasLastModifiedDate = 0x08
 	FlagHasTtl              = 0x10
 	FlagHasPairs            = 0x20
+	FlagIsEncrypted         = 0x40
 	FlagIsChunkManifest     = 0x80
 	LastModifiedBytesLength = 5
 	TtlBytesLength          = 2
 )
 
 var ErrorSizeMismatch = errors.New("size mismatch")
+var ErrorCRC = errors.New("CRC error! Data On Disk Corrupted")
 
 func (n *Needle) DiskSize(version Version) int64 {
 	return GetActualSize(n.Size, version)
@@ -198,7 +200,7 @@ func (n *Needle) ReadBytes(bytes []byte, offset int64, size Size, version Versio
 		checksum := util.BytesToUint32(bytes[NeedleHeaderSize+size : NeedleHeaderSize+size+NeedleChecksumSize])
 		newChecksum := NewCRC(n.Data)
 		if checksum != newChecksum.Value() {
-			return errors.New("CRC error! Data On Disk Corrupted")
+			return ErrorCRC
 		}
 		n.Checksum = newChecksum
 	}
@@ -364,6 +366,17 @@ func (n *Needle) IsCompressed() bool {
 func (n *Needle) SetIsCompressed() {
 	n.Flags = n.Flags | FlagIsCompressed
 }
+
+// IsZstdCompressed checks the data compressed by the volume compression, which is zstd instead of gzip
+func (n *Needle) IsZstdCompressed() bool {
+	return n.IsCompressed() && !n.IsEncrypted() && util.IsZstdContent(n.Data)
+}
+func (n *Needle) IsEncrypted() bool {
+	return n.Flags&FlagIsEncrypted > 0
+}
+func (n *Needle) SetIsEncrypted() {
+	n.Flags = n.Flags | FlagIsEncrypted
+}
 func (n *Needle) HasName() bool {
 	return n.Flags&FlagHasName > 0
 }
@@ -0,0 +1,385 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/storage/idx"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle_map"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+/*
+The .smx file keeps the needle map as index entries sorted by needle id, after a fixed size header:
+
+	magic(4) mergedIndexOffset(8) lastMergedIndexEntry(16) FileCounter(4) DeletionCounter(4)
+	FileByteCounter(8) DeletionByteCounter(8) MaximumFileKey(8) crc(4)
+
+The file is memory mapped and searched in place, so the memory usage does not grow with the volume size.
+Changes are appended to the .idx file as usual, and kept in a write buffer, which is merged into a new .smx file
+in the background when it is full. On startup, only the .idx entries after mergedIndexOffset are replayed.
+The last merged .idx entry detects .idx files rewritten after the .smx file, e.g. by vacuum.
+*/
+
+const (
+	sortedIndexHeaderSize = 64
+)
+
+var (
+	sortedIndexMagic = []byte("SMX1")
+	// the number of changed needles kept in memory before merging them into the .smx file
+	sortedIndexBufferLimit = 128 * 1024
+)
+
+type SortedIndexNeedleMap struct {
+	baseNeedleMapper
+	dbFileName string
+
+	accessLock sync.RWMutex
+	sorted     []byte                              // the mapped .smx file
+	buffer     map[NeedleId]needle_map.NeedleValue // changes after the last merge
+	merging    map[NeedleId]needle_map.NeedleValue // changes being merged
+	mergeWait  sync.WaitGroup
+}
+
+func NewSortedIndexNeedleMap(dbFileName string, indexFile *os.File) (m *SortedIndexNeedleMap, err error) {
+	m = &SortedIndexNeedleMap{dbFileName: dbFileName, buffer: make(map[NeedleId]needle_map.NeedleValue)}
+	m.indexFile = indexFile
+	stat, err := indexFile.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat file %s: %v", indexFile.Name(), err)
+	}
+	m.indexFileOffset = stat.Size()
+
+	mergedIndexOffset, err := m.openSortedIndex()
+	if err != nil {
+		glog.V(1).Infof("Start to Generate %s from %s: %v", dbFileName, indexFile.Name(), err)
+		mergedIndexOffset = 0
+		if err = m.resetSortedIndex(); err != nil {
+			return nil, err
+		}
+	}
+
+	glog.V(1).Infof("Loading %s from offset %d...", indexFile.Name(), mergedIndexOffset)
+	replayedIndexOffset := mergedIndexOffset
+	err = idx.WalkIndexFile(io.NewSectionReader(indexFile, mergedIndexOffset, stat.Size()-mergedIndexOffset), func(key NeedleId, offset Offset, size Size) error {
+		replayedIndexOffset += NeedleMapEntrySize
+		if !offset.IsZero() && size.IsValid() {
+			m.put(key, offset, size)
+		} else {
+			m.delete(key)
+		}
+		if len(m.buffer) < sortedIndexBufferLimit {
+			return nil
+		}
+		return m.mergeBuffer(replayedIndexOffset)
+	})
+	if err != nil {
+		unmapSortedIndex(m.sorted)
+		return nil, fmt.Errorf("load %s: %v", indexFile.Name(), err)
+	}
+	return m, nil
+}
+
+func (m *SortedIndexNeedleMap) Get(key NeedleId) (element *needle_map.NeedleValue, ok bool) {
+	m.accessLock.RLock()
+	defer m.accessLock.RUnlock()
+	return m.get(key)
+}
+
+func (m *SortedIndexNeedleMap) get(key NeedleId) (element *needle_map.NeedleValue, ok bool) {
+	if nv, found := m.buffer[key]; found {
+		return &nv, true
+	}
+	if nv, found := m.merging[key]; found {
+		return &nv, true
+	}
+	entries := m.sorted[sortedIndexHeaderSize:]
+	entryCount := len(entries) / NeedleMapEntrySize
+	i := sort.Search(entryCount, func(i int) bool {
+		return BytesToNeedleId(entries[i*NeedleMapEntrySize:i*NeedleMapEntrySize+NeedleIdSize]) >= key
+	})
+	if i == entryCount {
+		return nil, false
+	}
+	foundKey, offset, size := idx.IdxFileEntry(entries[i*NeedleMapEntrySize : (i+1)*NeedleMapEntrySize])
+	if foundKey != key {
+		return nil, false
+	}
+	return &needle_map.NeedleValue{Key: key, Offset: offset, Size: size}, true
+}
+
+func (m *SortedIndexNeedleMap) Put(key NeedleId, offset Offset, size Size) error {
+	m.accessLock.Lock()
+	defer m.accessLock.Unlock()
+
+	// write to index file first
+	if err := m.appendToIndexFile(key, offset, size); err != nil {
+		return fmt.Errorf("cannot write to indexfile %s: %v", m.indexFile.Name(), err)
+	}
+	m.put(key, offset, size)
+	m.maybeMergeBuffer()
+	return nil
+}
+
+func (m *SortedIndexNeedleMap) put(key NeedleId, offset Offset, size Size) {
+	var oldSize Size
+	if oldNeedle, ok := m.get(key); ok {
+		oldSize = oldNeedle.Size
+	}
+	m.logPut(key, oldSize, size)
+	m.buffer[key] = needle_map.NeedleValue{Key: key, Offset: offset, Size: size}
+}
+
+func (m *SortedIndexNeedleMap) Delete(key NeedleId, offset Offset) error {
+	m.accessLock.Lock()
+	defer m.accessLock.Unlock()
+
+	if oldNeedle, found := m.get(key); !found || oldNeedle.Size.IsDeleted() {
+		return nil
+	}
+	// write to index file first
+	if err := m.appendToIndexFile(key, offset, TombstoneFileSize); err != nil {
+		return err
+	}
+	m.delete(key)
+	m.maybeMergeBuffer()
+	return nil
+}
+
+func (m *SortedIndexNeedleMap) delete(key NeedleId) {
+	oldNeedle, found := m.get(key)
+	if !found || oldNeedle.Size.IsDeleted() {
+		return
+	}
+	m.logDelete(oldNeedle.Size)
+	m.buffer[key] = needle_map.NeedleValue{Key: key, Offset: oldNeedle.Offset, Size: -oldNeedle.Size}
+}
+
+func (m *SortedIndexNeedleMap) Close() {
+	m.mergeWait.Wait()
+
+	indexFileName := m.indexFile.Name()
+	if err := m.indexFile.Sync(); err != nil {
+		glog.Warningf("sync file %s failed: %v", indexFileName, err)
+	}
+	if err := m.indexFile.Close(); err != nil {
+		glog.Warningf("close index file %s failed: %v", indexFileName, err)
+	}
+
+	m.accessLock.Lock()
+	defer m.accessLock.Unlock()
+	if err := unmapSortedIndex(m.sorted); err != nil {
+		glog.Warningf("unmap %s failed: %v", m.dbFileName, err)
+	}
+	m.sorted = nil
+}
+
+func (m *SortedIndexNeedleMap) Destroy() error {
+	m.Close()
+	os.Remove(m.indexFile.Name())
+	return os.Remove(m.dbFileName)
+}
+
+// maybeMergeBuffer starts merging the write buffer into a new .smx file in the background,
+// if the buffer is full and no other merge is running. It needs the write lock.
+func (m *SortedIndexNeedleMap) maybeMergeBuffer() {
+	if len(m.buffer) < sortedIndexBufferLimit || m.merging != nil {
+		return
+	}
+	m.merging, m.buffer = m.buffer, make(map[NeedleId]needle_map.NeedleValue)
+	sorted, merging, header := m.sorted, m.merging, m.sortedIndexHeader(m.indexFileOffset)
+
+	m.mergeWait.Add(1)
+	go func() {
+		defer m.mergeWait.Done()
+
+		merged, err := m.writeSortedIndex(sorted, merging, header)
+
+		m.accessLock.Lock()
+		defer m.accessLock.Unlock()
+		if err != nil {
+			glog.Errorf("merge %s: %v", m.dbFileName, err)
+			for key, nv := range merging {
+				if _, found := m.buffer[key]; !found {
+					m.buffer[key] = nv
+				}
+			}
+		} else {
+			if err = unmapSortedIndex(m.sorted); err != nil {
+				glog.Warningf("unmap %s failed: %v", m.dbFileName, err)
+			}
+			m.sorted = merged
+		}
+		m.merging = nil
+	}()
+}
+
+// mergeBuffer merges the write buffer into a new .smx file right away, during loading.
+func (m *SortedIndexNeedleMap) mergeBuffer(mergedIndexOffset int64) error {
+	merged, err := m.writeSortedIndex(m.sorted, m.buffer, m.sortedIndexHeader(mergedIndexOffset))
+	if err != nil {
+		return err
+	}
+	if err = unmapSortedIndex(m.sorted); err != nil {
+		glog.Warningf("unmap %s failed: %v", m.dbFileName, err)
+	}
+	m.sorted = merged
+	m.buffer = make(map[NeedleId]needle_map.NeedleValue)
+	return nil
+}
+
+func (m *SortedIndexNeedleMap) resetSortedIndex() (err error) {
+	m.mapMetric = mapMetric{}
+	m.sorted, err = m.writeSortedIndex(nil, nil, m.sortedIndexHeader(0))
+	return
+}
+
+// openSortedIndex maps the existing .smx file, and returns the .idx file offset merged into it
+func (m *SortedIndexNeedleMap) openSortedIndex() (mergedIndexOffset int64, err error) {
+	sorted, err := mapSortedIndex(m.dbFileName)
+	if err != nil {
+		return 0, err
+	}
+	if mergedIndexOffset, err = m.checkSortedIndex(sorted); err != nil {
+		unmapSortedIndex(sorted)
+		return 0, err
+	}
+	m.sorted = sorted
+	return mergedIndexOffset, nil
+}
+
+func (m *SortedIndexNeedleMap) checkSortedIndex(sorted []byte) (mergedIndexOffset int64, err error) {
+	if len(sorted) < sortedIndexHeaderSize || (len(sorted)-sortedIndexHeaderSize)%NeedleMapEntrySize != 0 {
+		return 0, fmt.Errorf("unexpected file size %d", len(sorted))
+	}
+	header := sorted[:sortedIndexHeaderSize]
+	if !bytes.Equal(header[0:4], sortedIndexMagic) {
+		return 0, fmt.Errorf("unknown format %x", header[0:4])
+	}
+	if crc := util.BytesToUint32(header[60:64]); crc != crc32.ChecksumIEEE(header[:60]) {
+		return 0, fmt.Errorf("header crc mismatch")
+	}
+	mergedIndexOffset = int64(util.BytesToUint64(header[4:12]))
+	if mergedIndexOffset%NeedleMapEntrySize != 0 || mergedIndexOffset > m.indexFileOffset {
+		return 0, fmt.Errorf("merged offset %d beyond index file size %d", mergedIndexOffset, m.indexFileOffset)
+	}
+	if lastEntry, readErr := m.readLastMergedIndexEntry(mergedIndexOffset); readErr != nil {
+		return 0, readErr
+	} else if !bytes.Equal(lastEntry, header[12:28]) {
+		return 0, fmt.Errorf("index file changed after offset %d was merged", mergedIndexOffset)
+	}
+	m.mapMetric = mapMetric{
+		FileCounter:         util.BytesToUint32(header[28:32]),
+		DeletionCounter:     util.BytesToUint32(header[32:36]),
+		FileByteCounter:     util.BytesToUint64(header[36:44]),
+		DeletionByteCounter: util.BytesToUint64(header[44:52]),
+		MaximumFileKey:      util.BytesToUint64(header[52:60]),
+	}
+	return mergedIndexOffset, nil
+}
+
+func (m *SortedIndexNeedleMap) sortedIndexHeader(mergedIndexOffset int64) []byte {
+	header := make([]byte, sortedIndexHeaderSize)
+	copy(header[0:4], sortedIndexMagic)
+	util.Uint64toBytes(header[4:12], uint64(mergedIndexOffset))
+	if lastEntry, err := m.readLastMergedIndexEntry(mergedIndexOffset); err == nil {
+		copy(header[12:28], lastEntry)
+	}
+	util.Uint32toBytes(header[28:32], m.FileCounter)
+	util.Uint32toBytes(header[32:36], m.DeletionCounter)
+	util.Uint64toBytes(header[36:44], m.FileByteCounter)
+	util.Uint64toBytes(header[44:52], m.DeletionByteCounter)
+	util.Uint64toBytes(header[52:60], m.MaximumFileKey)
+	util.Uint32toBytes(header[60:64], crc32.ChecksumIEEE(header[:60]))
+	return header
+}
+
+func (m *SortedIndexNeedleMap) readLastMergedIndexEntry(mergedIndexOffset int64) ([]byte, error) {
+	entry := make([]byte, NeedleMapEntrySize)
+	if mergedIndexOffset == 0 {
+		return entry, nil
+	}
+	if _, err := m.indexFile.ReadAt(entry, mergedIndexOffset-NeedleMapEntrySize); err != nil {
+		return nil, fmt.Errorf("read index file %s at %d: %v", m.indexFile.Name(), mergedIndexOffset-NeedleMapEntrySize, err)
+	}
+	return entry, nil
+}
+
+// writeSortedIndex writes the sorted entries with the changes applied into a new .smx file, and maps it.
+// Deleted needles are left out.
+func (m *SortedIndexNeedleMap) writeSortedIndex(sorted []byte, changes map[NeedleId]needle_map.NeedleValue, header []byte) (merged []byte, err error) {
+	// the merged .idx entries must be persisted before the .smx file refers to them
+	if err = m.indexFile.Sync(); err != nil {
+		return nil, fmt.Errorf("sync %s: %v", m.indexFile.Name(), err)
+	}
+
+	var changedKeys []NeedleId
+	for key := range changes {
+		changedKeys = append(changedKeys, key)
+	}
+	sort.Slice(changedKeys, func(i, j int) bool {
+		return changedKeys[i] < changedKeys[j]
+	})
+
+	tmpFileName := m.dbFileName + ".tmp"
+	dst, err := os.OpenFile(tmpFileName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			dst.Close()
+			os.Remove(tmpFileName)
+		}
+	}()
+
+	writer := bufio.NewWriterSize(dst, 1024*1024)
+	writer.Write(header)
+	writeEntry := func(nv needle_map.NeedleValue) {
+		if !nv.Offset.IsZero() && nv.Size.IsValid() {
+			writer.Write(nv.ToBytes())
+		}
+	}
+	var entries []byte
+	if len(sorted) > sortedIndexHeaderSize {
+		entries = sorted[sortedIndexHeaderSize:]
+	}
+	for len(entries) > 0 || len(changedKeys) > 0 {
+		if len(entries) > 0 {
+			key := BytesToNeedleId(entries[:NeedleIdSize])
+			if len(changedKeys) == 0 || key < changedKeys[0] {
+				writer.Write(entries[:NeedleMapEntrySize])
+				entries = entries[NeedleMapEntrySize:]
+				continue
+			}
+			if key == changedKeys[0] {
+				entries = entries[NeedleMapEntrySize:]
+			}
+		}
+		writeEntry(changes[changedKeys[0]])
+		changedKeys = changedKeys[1:]
+	}
+
+	if err = writer.Flush(); err != nil {
+		return nil, err
+	}
+	if err = dst.Sync(); err != nil {
+		return nil, err
+	}
+	if err = dst.Close(); err != nil {
+		return nil, err
+	}
+	if err = os.Rename(tmpFileName, m.dbFileName); err != nil {
+		return nil, err
+	}
+	return mapSortedIndex(m.dbFileName)
+}
@@ -0,0 +1,17 @@
+//go:build windows
+// +build windows
+
+package storage
+
+import (
+	"io/ioutil"
+)
+
+// memory mapping is not implemented on windows, the sorted index is read into memory instead
+func mapSortedIndex(fileName string) ([]byte, error) {
+	return ioutil.ReadFile(fileName)
+}
+
+func unmapSortedIndex(data []byte) error {
+	return nil
+}
@@ -0,0 +1,193 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/syndtr/goleveldb/leveldb/opt"
+
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+func TestSortedIndexNeedleMap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "smx")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	defer setSortedIndexBufferLimit(100)()
+
+	idxFileName, dbFileName := filepath.Join(dir, "1.idx"), filepath.Join(dir, "1.smx")
+	open := func() *SortedIndexNeedleMap {
+		indexFile, err := os.OpenFile(idxFileName, os.O_RDWR|os.O_CREATE, 0644)
+		if err != nil {
+			t.Fatalf("open index file: %v", err)
+		}
+		m, err := NewSortedIndexNeedleMap(dbFileName, indexFile)
+		if err != nil {
+			t.Fatalf("open sorted index: %v", err)
+		}
+		return m
+	}
+
+	m := open()
+	expected := make(map[NeedleId]Size)
+	for i := 1; i <= 1000; i++ {
+		key := NeedleId(i * 7 % 1009)
+		if err = m.Put(key, ToOffset(int64(i*8)), Size(i)); err != nil {
+			t.Fatalf("put %d: %v", key, err)
+		}
+		expected[key] = Size(i)
+		if i%5 == 0 {
+			deleted := NeedleId(i * 3 % 1009)
+			if err = m.Delete(deleted, ToOffset(int64(i*8))); err != nil {
+				t.Fatalf("delete %d: %v", deleted, err)
+			}
+			delete(expected, deleted)
+		}
+	}
+	assertNeedleMap := func(m NeedleMapper) {
+		for key := NeedleId(0); key < 1009; key++ {
+			nv, ok := m.Get(key)
+			size, found := expected[key]
+			if found && (!ok || nv.Size != size) {
+				t.Fatalf("needle %d: %+v %v, expected size %d", key, nv, ok, size)
+			}
+			if !found && ok && !nv.Size.IsDeleted() {
+				t.Fatalf("needle %d: %+v, expected deleted", key, nv)
+			}
+		}
+		if liveCount := m.FileCount() - m.DeletedCount(); liveCount != len(expected) {
+			t.Fatalf("%d live needles, expected %d", liveCount, len(expected))
+		}
+	}
+	assertNeedleMap(m)
+	fileCount, deletedCount, contentSize, deletedSize := m.FileCount(), m.DeletedCount(), m.ContentSize(), m.DeletedSize()
+	m.Close()
+
+	// reopening replays the changes after the last merge, and keeps the metrics
+	m = open()
+	assertNeedleMap(m)
+	if m.FileCount() != fileCount || m.DeletedCount() != deletedCount || m.ContentSize() != contentSize || m.DeletedSize() != deletedSize {
+		t.Fatalf("metrics %d %d %d %d, expected %d %d %d %d",
+			m.FileCount(), m.DeletedCount(), m.ContentSize(), m.DeletedSize(), fileCount, deletedCount, contentSize, deletedSize)
+	}
+	if m.MaxFileKey() != 1008 {
+		t.Fatalf("max file key %d", m.MaxFileKey())
+	}
+	m.Close()
+
+	// a rewritten index file is detected, and the sorted index is generated again
+	if err = os.Truncate(idxFileName, 0); err != nil {
+		t.Fatalf("truncate index file: %v", err)
+	}
+	expected = make(map[NeedleId]Size)
+	m = open()
+	assertNeedleMap(m)
+	if err = m.Put(1, ToOffset(8), 1); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	expected[1] = 1
+	assertNeedleMap(m)
+	m.Close()
+}
+
+func setSortedIndexBufferLimit(limit int) (restore func()) {
+	previous := sortedIndexBufferLimit
+	sortedIndexBufferLimit = limit
+	return func() {
+		sortedIndexBufferLimit = previous
+	}
+}
+
+/*
+
+To compare the needle map kinds:
+
+go test -run XXX -bench NeedleMap -benchmem
+
+*/
+
+func BenchmarkNeedleMapPut(b *testing.B) {
+	benchmarkNeedleMaps(b, func(b *testing.B, open func() NeedleMapper) {
+		m := open()
+		defer m.Close()
+		b.ResetTimer()
+		putBenchmarkNeedles(m, b.N)
+	})
+}
+
+func BenchmarkNeedleMapGet(b *testing.B) {
+	benchmarkNeedleMaps(b, func(b *testing.B, open func() NeedleMapper) {
+		const count = 1000000
+		m := open()
+		defer m.Close()
+		putBenchmarkNeedles(m, count)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			m.Get(NeedleId(i%count*7919 + 1))
+		}
+	})
+}
+
+func BenchmarkNeedleMapLoad(b *testing.B) {
+	benchmarkNeedleMaps(b, func(b *testing.B, open func() NeedleMapper) {
+		const count = 1000000
+		m := open()
+		putBenchmarkNeedles(m, count)
+		m.Close()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			open().Close()
+		}
+	})
+}
+
+func putBenchmarkNeedles(m NeedleMapper, count int) {
+	for i := 0; i < count; i++ {
+		m.Put(NeedleId(i*7919+1), ToOffset(int64(i+1)*8), Size(i%1024+1))
+	}
+}
+
+func benchmarkNeedleMaps(b *testing.B, fn func(b *testing.B, open func() NeedleMapper)) {
+	kinds := []struct {
+		name string
+		open func(dir string, indexFile *os.File) (NeedleMapper, error)
+	}{
+		{"memory", func(dir string, indexFile *os.File) (NeedleMapper, error) {
+			return LoadCompactNeedleMap(indexFile)
+		}},
+		{"leveldb", func(dir string, indexFile *os.File) (NeedleMapper, error) {
+			return NewLevelDbNeedleMap(filepath.Join(dir, "1.ldb"), indexFile, &opt.Options{
+				BlockCacheCapacity:            2 * 1024 * 1024,
+				WriteBuffer:                   1 * 1024 * 1024,
+				CompactionTableSizeMultiplier: 10,
+			})
+		}},
+		{"sortedIndex", func(dir string, indexFile *os.File) (NeedleMapper, error) {
+			return NewSortedIndexNeedleMap(filepath.Join(dir, "1.smx"), indexFile)
+		}},
+	}
+	for _, kind := range kinds {
+		b.Run(kind.name, func(b *testing.B) {
+			dir, err := ioutil.TempDir("", "needle_map")
+			if err != nil {
+				b.Fatalf("temp dir creation: %v", err)
+			}
+			defer os.RemoveAll(dir)
+			fn(b, func() NeedleMapper {
+				indexFile, err := os.OpenFile(filepath.Join(dir, "1.idx"), os.O_RDWR|os.O_CREATE, 0644)
+				if err != nil {
+					b.Fatalf("open index file: %v", err)
+				}
+				m, err := kind.open(dir, indexFile)
+				if err != nil {
+					b.Fatalf("open %s needle map: %v", kind.name, err)
+				}
+				return m
+			})
+		})
+	}
+}
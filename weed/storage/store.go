@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -24,6 +27,7 @@ const (
 
 type ReadOption struct {
 	ReadDeleted bool
+	Version     int // the kept previous version to read, counting back from the latest version
 }
 
 /*
@@ -45,6 +49,8 @@ type Store struct {
 	DeletedVolumesChan  chan master_pb.VolumeShortInformationMessage
 	NewEcShardsChan     chan master_pb.VolumeEcShardInformationMessage
 	DeletedEcShardsChan chan master_pb.VolumeEcShardInformationMessage
+	CorruptNeedlesChan  chan *master_pb.CorruptNeedleMessage
+	scrubber            *scrubber
 }
 
 func (s *Store) String() (str string) {
@@ -67,6 +73,8 @@ func NewStore(grpcDialOption grpc.DialOption, port int, ip, publicUrl string, di
 	s.NewEcShardsChan = make(chan master_pb.VolumeEcShardInformationMessage, 3)
 	s.DeletedEcShardsChan = make(chan master_pb.VolumeEcShardInformationMessage, 3)
 
+	s.CorruptNeedlesChan = make(chan *master_pb.CorruptNeedleMessage, 64)
+
 	return
 }
 func (s *Store) AddVolume(volumeId needle.VolumeId, collection string, needleMapKind NeedleMapKind, replicaPlacement string, ttlString string, preallocate int64, MemoryMapMaxSizeMb uint32, diskType DiskType) error {
@@ -125,6 +133,12 @@ func (s *Store) addVolume(vid needle.VolumeId, collection string, needleMapKind
 		glog.V(0).Infof("In dir %s adds volume:%v collection:%s replicaPlacement:%v ttl:%v",
 			location.Directory, vid, collection, replicaPlacement, ttl)
 		if volume, err := NewVolume(location.Directory, location.IdxDirectory, collection, vid, needleMapKind, replicaPlacement, ttl, preallocate, memoryMapMaxSizeMb); err == nil {
+			if err = s.inheritCompression(volume); err != nil {
+				glog.Warningf("volume %d compression: %v", vid, err)
+			}
+			if err = s.inheritEncryption(volume); err != nil {
+				glog.Warningf("volume %d encryption: %v", vid, err)
+			}
 			location.SetVolume(vid, volume)
 			glog.V(0).Infof("add volume %d", vid)
 			s.NewVolumesChan <- master_pb.VolumeShortInformationMessage{
@@ -299,6 +313,9 @@ func (s *Store) CollectHeartbeat() *master_pb.Heartbeat {
 }
 
 func (s *Store) Close() {
+	if s.scrubber != nil {
+		s.scrubber.stop()
+	}
 	for _, location := range s.Locations {
 		location.Close()
 	}
@@ -310,7 +327,11 @@ func (s *Store) WriteVolumeNeedle(i needle.VolumeId, n *needle.Needle, fsync boo
 			err = fmt.Errorf("volume %d is read only", i)
 			return
 		}
-		_, _, isUnchanged, err = v.writeNeedle2(n, fsync)
+		var stored *needle.Needle
+		if stored, err = v.encryptor.encrypt(v.compressor.compress(n)); err != nil {
+			return
+		}
+		_, _, isUnchanged, err = v.writeNeedle2(stored, fsync)
 		return
 	}
 	glog.V(0).Infoln("volume", i, "not found!")
@@ -330,10 +351,23 @@ func (s *Store) DeleteVolumeNeedle(i needle.VolumeId, n *needle.Needle) (Size, e
 
 func (s *Store) ReadVolumeNeedle(i needle.VolumeId, n *needle.Needle, readOption *ReadOption) (int, error) {
 	if v := s.findVolume(i); v != nil {
-		return v.readNeedle(n, readOption)
+		v.reads.add(time.Now())
+		count, err := v.readNeedle(n, readOption)
+		if err == nil && count > 0 {
+			err = v.encryptor.decrypt(n)
+		}
+		return count, err
 	}
 	return 0, fmt.Errorf("volume %d not found", i)
 }
+
+// ReadVolumeNeedleVersions reads the latest and the kept previous versions of the needle without their data, latest first
+func (s *Store) ReadVolumeNeedleVersions(i needle.VolumeId, needleId NeedleId) ([]*needle.Needle, error) {
+	if v := s.findVolume(i); v != nil {
+		return v.NeedleVersions(needleId)
+	}
+	return nil, fmt.Errorf("volume %d not found", i)
+}
 func (s *Store) GetVolume(i needle.VolumeId) *Volume {
 	return s.findVolume(i)
 }
@@ -461,6 +495,102 @@ func (s *Store) ConfigureVolume(i needle.VolumeId, replication string) error {
 	return fmt.Errorf("volume %d not found on disk", i)
 }
 
+// ConfigureVolumeCompression saves the compression and the optional dictionary of an unmounted volume
+func (s *Store) ConfigureVolumeCompression(i needle.VolumeId, compression string, dictionary []byte) error {
+
+	if compression != "" && compression != CompressionZstd {
+		return fmt.Errorf("unsupported compression %s", compression)
+	}
+
+	for _, location := range s.Locations {
+		fileInfo, found := location.LocateVolume(i)
+		if !found {
+			continue
+		}
+		// load, modify, save
+		baseFileName := strings.TrimSuffix(fileInfo.Name(), filepath.Ext(fileInfo.Name()))
+		vifFile := filepath.Join(location.Directory, baseFileName+".vif")
+		volumeInfo, _, err := pb.MaybeLoadVolumeInfo(vifFile)
+		if err != nil {
+			return fmt.Errorf("volume %d fail to load vif", i)
+		}
+		dictFile := filepath.Join(location.Directory, baseFileName+".zdict")
+		if compression != "" && len(dictionary) > 0 {
+			// the existing dictionary is still needed to read the needles compressed with it
+			if existing, readErr := ioutil.ReadFile(dictFile); readErr == nil && len(existing) > 0 && !bytes.Equal(existing, dictionary) {
+				return fmt.Errorf("volume %d already has a different zstd dictionary", i)
+			}
+			if err = ioutil.WriteFile(dictFile, dictionary, 0644); err != nil {
+				return fmt.Errorf("volume %d fail to save zstd dictionary: %v", i, err)
+			}
+		}
+		volumeInfo.Compression = compression
+		err = pb.SaveVolumeInfo(vifFile, volumeInfo)
+		if err != nil {
+			return fmt.Errorf("volume %d fail to save vif", i)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("volume %d not found on disk", i)
+}
+
+// ConfigureVolumeEncryption saves the encryption of an unmounted volume.
+// A new key is added when turning on encryption for the first time, or when rotating the key.
+func (s *Store) ConfigureVolumeEncryption(i needle.VolumeId, encryption string, rotateKey bool) error {
+
+	if encryption != "" && encryption != EncryptionAes256Gcm {
+		return fmt.Errorf("unsupported encryption %s", encryption)
+	}
+	if rotateKey && encryption == "" {
+		return fmt.Errorf("volume %d: rotating the key needs encryption", i)
+	}
+
+	for _, location := range s.Locations {
+		fileInfo, found := location.LocateVolume(i)
+		if !found {
+			continue
+		}
+		// load, modify, save
+		baseFileName := strings.TrimSuffix(fileInfo.Name(), filepath.Ext(fileInfo.Name()))
+		vifFile := filepath.Join(location.Directory, baseFileName+".vif")
+		volumeInfo, _, err := pb.MaybeLoadVolumeInfo(vifFile)
+		if err != nil {
+			return fmt.Errorf("volume %d fail to load vif", i)
+		}
+		if encryption != "" && (len(volumeInfo.EncryptionKeys) == 0 || rotateKey) {
+			var lastKeyId uint32
+			for _, key := range volumeInfo.EncryptionKeys {
+				if key.Id > lastKeyId {
+					lastKeyId = key.Id
+				}
+			}
+			key, err := newVolumeKey(lastKeyId + 1)
+			if err != nil {
+				return fmt.Errorf("volume %d: %v", i, err)
+			}
+			volumeInfo.EncryptionKeys = append(volumeInfo.EncryptionKeys, key)
+		}
+		volumeInfo.Encryption = encryption
+		err = pb.SaveVolumeInfo(vifFile, volumeInfo)
+		if err != nil {
+			return fmt.Errorf("volume %d fail to save vif", i)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("volume %d not found on disk", i)
+}
+
+// BuildVolumeCompressionDictionary builds a raw zstd dictionary from the needles of the volume
+func (s *Store) BuildVolumeCompressionDictionary(i needle.VolumeId, maxSize int) (dictionary []byte, sampleCount int, err error) {
+	v := s.findVolume(i)
+	if v == nil {
+		return nil, 0, fmt.Errorf("volume %d not found", i)
+	}
+	return v.BuildCompressionDictionary(maxSize)
+}
+
 func (s *Store) SetVolumeSizeLimit(x uint64) {
 	atomic.StoreUint64(&s.volumeSizeLimit, x)
 }
@@ -0,0 +1,595 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/klauspost/reedsolomon"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/operation"
+	"github.com/chrislusf/seaweedfs/weed/pb/master_pb"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/stats"
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+// wait for the volume server to join the cluster before the first scrubbing pass
+var scrubStartDelay = 10 * time.Minute
+
+/*
+ * The scrubber walks all needles of all volumes and ec shards in the background,
+ * verifies the CRC and whether the index matches the data, reports the corrupted needles
+ * to the master, and repairs them from a healthy replica, or from the other ec shards.
+ *
+ * For ec volumes, each needle is checked by the server holding the shard of its first interval,
+ * so the needle is checked once in the cluster. The parity shards are checked by the servers holding them,
+ * stripe by stripe, comparing them with the parity encoded from the data shards. If they differ,
+ * all shards of the stripe are read to locate the corrupted one, which is rebuilt if it is local.
+ */
+type scrubber struct {
+	store     *Store
+	throttler *util.WriteThrottler
+	interval  time.Duration
+	isStopped int32
+
+	sync.Mutex
+	isScrubbing         bool
+	passStartedAt       time.Time
+	lastPassCompletedAt time.Time
+	currentVolumeId     needle.VolumeId
+	scrubbedVolumeCount uint32
+	totalVolumeCount    uint32
+	checkedNeedleCount  uint64
+	checkedByteCount    uint64
+	corruptNeedleCount  uint64
+	repairedNeedleCount uint64
+}
+
+// StartScrubbing starts a scrubbing pass every interval, reading at most bytesPerSecond
+func (s *Store) StartScrubbing(bytesPerSecond int64, interval time.Duration) {
+	if bytesPerSecond <= 0 || interval <= 0 {
+		glog.V(0).Infof("volume scrubbing is disabled")
+		return
+	}
+	s.scrubber = &scrubber{
+		store:     s,
+		throttler: util.NewWriteThrottler(bytesPerSecond),
+		interval:  interval,
+	}
+	glog.V(0).Infof("scrub volumes every %v at %d bytes per second", interval, bytesPerSecond)
+	go s.scrubber.loop()
+}
+
+func (s *Store) ScrubStatus() *volume_server_pb.ScrubStatus {
+	sc := s.scrubber
+	if sc == nil {
+		return &volume_server_pb.ScrubStatus{}
+	}
+	sc.Lock()
+	defer sc.Unlock()
+	status := &volume_server_pb.ScrubStatus{
+		Enabled:             true,
+		IsScrubbing:         sc.isScrubbing,
+		CurrentVolumeId:     uint32(sc.currentVolumeId),
+		ScrubbedVolumeCount: sc.scrubbedVolumeCount,
+		TotalVolumeCount:    sc.totalVolumeCount,
+		CheckedNeedleCount:  sc.checkedNeedleCount,
+		CheckedByteCount:    sc.checkedByteCount,
+		CorruptNeedleCount:  sc.corruptNeedleCount,
+		RepairedNeedleCount: sc.repairedNeedleCount,
+	}
+	if !sc.passStartedAt.IsZero() {
+		status.PassStartedAtSecond = sc.passStartedAt.Unix()
+	}
+	if !sc.lastPassCompletedAt.IsZero() {
+		status.LastPassCompletedAtSecond = sc.lastPassCompletedAt.Unix()
+	}
+	return status
+}
+
+func (sc *scrubber) stop() {
+	atomic.StoreInt32(&sc.isStopped, 1)
+}
+
+func (sc *scrubber) stopped() bool {
+	return atomic.LoadInt32(&sc.isStopped) == 1
+}
+
+func (sc *scrubber) loop() {
+	time.Sleep(scrubStartDelay)
+	for !sc.stopped() {
+		startedAt := time.Now()
+		sc.scrubAll()
+		if wait := startedAt.Add(sc.interval).Sub(time.Now()); wait > 0 {
+			time.Sleep(wait)
+		}
+	}
+}
+
+func (sc *scrubber) scrubAll() {
+	volumes, ecVolumes := sc.store.volumesToScrub()
+
+	sc.Lock()
+	sc.isScrubbing = true
+	sc.passStartedAt = time.Now()
+	sc.scrubbedVolumeCount = 0
+	sc.totalVolumeCount = uint32(len(volumes) + len(ecVolumes))
+	sc.Unlock()
+
+	for _, v := range volumes {
+		if sc.stopped() {
+			break
+		}
+		sc.setCurrentVolume(v.Id)
+		if err := sc.scrubVolume(v); err != nil {
+			glog.V(0).Infof("scrub volume %d: %v", v.Id, err)
+		}
+		sc.volumeScrubbed()
+	}
+	for _, ev := range ecVolumes {
+		if sc.stopped() {
+			break
+		}
+		sc.setCurrentVolume(ev.VolumeId)
+		if err := sc.scrubEcVolume(ev); err != nil {
+			glog.V(0).Infof("scrub ec volume %d: %v", ev.VolumeId, err)
+		}
+		if err := sc.scrubEcParity(ev); err != nil {
+			glog.V(0).Infof("scrub ec volume %d parity: %v", ev.VolumeId, err)
+		}
+		sc.volumeScrubbed()
+	}
+
+	sc.Lock()
+	sc.isScrubbing = false
+	sc.currentVolumeId = 0
+	if !sc.stopped() {
+		sc.lastPassCompletedAt = time.Now()
+	}
+	sc.Unlock()
+}
+
+func (sc *scrubber) setCurrentVolume(vid needle.VolumeId) {
+	sc.Lock()
+	sc.currentVolumeId = vid
+	sc.Unlock()
+}
+
+func (sc *scrubber) volumeScrubbed() {
+	sc.Lock()
+	sc.scrubbedVolumeCount++
+	sc.Unlock()
+}
+
+func (sc *scrubber) checked(size int64) {
+	sc.throttler.MaybeSlowdown(size)
+	sc.Lock()
+	sc.checkedNeedleCount++
+	sc.checkedByteCount += uint64(size)
+	sc.Unlock()
+	stats.VolumeServerScrubCounter.WithLabelValues("needle").Inc()
+	stats.VolumeServerScrubCounter.WithLabelValues("byte").Add(float64(size))
+}
+
+// checkedStripe counts the bytes of a verified ec stripe, which is not a needle
+func (sc *scrubber) checkedStripe(size int64) {
+	sc.throttler.MaybeSlowdown(size)
+	sc.Lock()
+	sc.checkedByteCount += uint64(size)
+	sc.Unlock()
+	stats.VolumeServerScrubCounter.WithLabelValues("byte").Add(float64(size))
+}
+
+func (sc *scrubber) scrubVolume(v *Volume) error {
+	if v.HasRemoteFile() {
+		// the cloud storage keeps its own checksums
+		return nil
+	}
+	entryCount := v.IndexEntryCount()
+	for i := int64(0); i < entryCount && !sc.stopped(); i++ {
+		scrubbed, err := v.ScrubIndexEntry(i)
+		if err != nil {
+			// the volume is closed or compacted
+			return err
+		}
+		if scrubbed == nil {
+			continue
+		}
+		sc.checked(needle.GetActualSize(scrubbed.Size, v.Version()))
+		if scrubbed.Err == nil {
+			continue
+		}
+
+		glog.Errorf("scrub volume %d found corrupted needle %s at offset %d: %v", v.Id, scrubbed.Key, scrubbed.Offset.ToActualOffset(), scrubbed.Err)
+		repairErr := sc.store.repairNeedleFromReplica(v, scrubbed)
+		if repairErr != nil {
+			glog.Errorf("repair volume %d needle %s: %v", v.Id, scrubbed.Key, repairErr)
+		}
+		sc.foundCorruptNeedle(&master_pb.CorruptNeedleMessage{
+			VolumeId:   uint32(v.Id),
+			Collection: v.Collection,
+			NeedleId:   uint64(scrubbed.Key),
+			Error:      scrubbed.Err.Error(),
+			Repaired:   repairErr == nil,
+		})
+	}
+	return nil
+}
+
+func (sc *scrubber) scrubEcVolume(ev *erasure_coding.EcVolume) error {
+	if len(ev.Shards) == 0 {
+		return nil
+	}
+	return ev.WalkIndex(func(key types.NeedleId, offset types.Offset, size types.Size) error {
+		if sc.stopped() {
+			return io.EOF
+		}
+		if offset.IsZero() || size.IsDeleted() {
+			return nil
+		}
+		intervals := ev.LocateNeedleIntervals(offset, size, ev.Version)
+		if len(intervals) == 0 {
+			return nil
+		}
+		firstShardId, _ := intervals[0].ToShardIdAndOffset(erasure_coding.ErasureCodingLargeBlockSize, erasure_coding.ErasureCodingSmallBlockSize, ev.Scheme.DataShards)
+		if _, found := ev.FindEcVolumeShard(firstShardId); !found {
+			return nil
+		}
+
+		data, isDeleted, err := sc.store.readEcShardIntervals(ev.VolumeId, key, ev, intervals)
+		if err != nil {
+			// can not tell whether the needle is corrupted
+			glog.V(1).Infof("scrub ec volume %d needle %s: %v", ev.VolumeId, key, err)
+			return nil
+		}
+		if isDeleted {
+			return nil
+		}
+		sc.checked(int64(len(data)))
+		verifyErr := verifyEcNeedle(ev, key, offset, size, data)
+		if verifyErr == nil {
+			return nil
+		}
+
+		glog.Errorf("scrub ec volume %d found corrupted needle %s: %v", ev.VolumeId, key, verifyErr)
+		repairErr := sc.store.repairEcNeedle(ev, key, offset, size, intervals)
+		if repairErr != nil {
+			glog.Errorf("repair ec volume %d needle %s: %v", ev.VolumeId, key, repairErr)
+		}
+		sc.foundCorruptNeedle(&master_pb.CorruptNeedleMessage{
+			VolumeId:   uint32(ev.VolumeId),
+			Collection: ev.Collection,
+			NeedleId:   uint64(key),
+			IsEcVolume: true,
+			Error:      verifyErr.Error(),
+			Repaired:   repairErr == nil,
+		})
+		return nil
+	})
+}
+
+// scrubEcParity verifies the local parity shards of the ec volume, one stripe of ecScrubStripeSize at a time
+func (sc *scrubber) scrubEcParity(ev *erasure_coding.EcVolume) error {
+	var parityShards []*erasure_coding.EcVolumeShard
+	for _, shard := range ev.Shards {
+		if int(shard.ShardId) >= ev.Scheme.DataShards {
+			parityShards = append(parityShards, shard)
+		}
+	}
+	if len(parityShards) == 0 {
+		return nil
+	}
+	enc, err := reedsolomon.New(ev.Scheme.DataShards, ev.Scheme.ParityShards)
+	if err != nil {
+		return fmt.Errorf("failed to create encoder: %v", err)
+	}
+
+	shardSize := parityShards[0].Size()
+	for offset := int64(0); offset < shardSize && !sc.stopped(); offset += ecScrubStripeSize {
+		size := shardSize - offset
+		if size > ecScrubStripeSize {
+			size = ecScrubStripeSize
+		}
+		verifyErr := sc.store.verifyEcStripe(ev, enc, parityShards, offset, size)
+		if verifyErr == errEcStripeUnreadable {
+			// can not tell whether the stripe is corrupted
+			continue
+		}
+		sc.checkedStripe(size * int64(ev.Scheme.DataShards+len(parityShards)))
+		if verifyErr == nil {
+			continue
+		}
+
+		glog.Errorf("scrub ec volume %d found corrupted stripe at shard offset %d: %v", ev.VolumeId, offset, verifyErr)
+		repairErr := sc.store.repairEcStripe(ev, enc, offset, size)
+		if repairErr != nil {
+			glog.Errorf("repair ec volume %d stripe at shard offset %d: %v", ev.VolumeId, offset, repairErr)
+		}
+		sc.foundCorruptNeedle(&master_pb.CorruptNeedleMessage{
+			VolumeId:   uint32(ev.VolumeId),
+			Collection: ev.Collection,
+			IsEcVolume: true,
+			Error:      fmt.Sprintf("stripe at shard offset %d: %v", offset, verifyErr),
+			Repaired:   repairErr == nil,
+		})
+	}
+	return nil
+}
+
+func (sc *scrubber) foundCorruptNeedle(message *master_pb.CorruptNeedleMessage) {
+	sc.Lock()
+	sc.corruptNeedleCount++
+	if message.Repaired {
+		sc.repairedNeedleCount++
+	}
+	sc.Unlock()
+	stats.VolumeServerScrubCounter.WithLabelValues("corrupt").Inc()
+	if message.Repaired {
+		stats.VolumeServerScrubCounter.WithLabelValues("repaired").Inc()
+	}
+
+	// do not block scrubbing if the master is not connected
+	select {
+	case sc.store.CorruptNeedlesChan <- message:
+	default:
+		glog.V(0).Infof("drop reporting corrupted needle %d,%x to master", message.VolumeId, message.NeedleId)
+	}
+}
+
+func (s *Store) volumesToScrub() (volumes []*Volume, ecVolumes []*erasure_coding.EcVolume) {
+	for _, location := range s.Locations {
+		location.volumesLock.RLock()
+		for _, v := range location.volumes {
+			volumes = append(volumes, v)
+		}
+		location.volumesLock.RUnlock()
+	}
+	sort.Slice(volumes, func(i, j int) bool {
+		return volumes[i].Id < volumes[j].Id
+	})
+	return volumes, s.EcVolumes()
+}
+
+func (s *Store) repairNeedleFromReplica(v *Volume, corrupted *ScrubbedNeedle) error {
+	if !v.NeedToReplicate() {
+		return fmt.Errorf("volume %d has no replica", v.Id)
+	}
+
+	var locations []string
+	err := operation.WithMasterServerClient(s.MasterAddress, s.grpcDialOption, func(masterClient master_pb.SeaweedClient) error {
+		resp, err := masterClient.LookupVolume(context.Background(), &master_pb.LookupVolumeRequest{
+			VolumeIds:  []string{v.Id.String()},
+			Collection: v.Collection,
+		})
+		if err != nil {
+			return err
+		}
+		for _, vidLocations := range resp.VolumeIdLocations {
+			if vidLocations.Error != "" {
+				return errors.New(vidLocations.Error)
+			}
+			for _, location := range vidLocations.Locations {
+				locations = append(locations, location.Url)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("lookup volume %d: %v", v.Id, err)
+	}
+
+	self := fmt.Sprintf("%s:%d", s.Ip, s.Port)
+	err = fmt.Errorf("no other replica of volume %d", v.Id)
+	for _, location := range locations {
+		if location == self {
+			continue
+		}
+		var blob []byte
+		if blob, err = s.readNeedleBlobFromReplica(location, v, corrupted.Key); err != nil {
+			glog.V(0).Infof("read needle %d,%s from %s: %v", v.Id, corrupted.Key, location, err)
+			continue
+		}
+		if err = v.RepairNeedle(corrupted, blob); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (s *Store) readNeedleBlobFromReplica(location string, v *Volume, needleId types.NeedleId) (blob []byte, err error) {
+	err = operation.WithVolumeServerClient(location, s.grpcDialOption, func(client volume_server_pb.VolumeServerClient) error {
+		resp, err := client.ReadNeedleBlob(context.Background(), &volume_server_pb.ReadNeedleBlobRequest{
+			VolumeId: uint32(v.Id),
+			NeedleId: uint64(needleId),
+		})
+		if err != nil {
+			return err
+		}
+		if needle.Version(resp.Version) != v.Version() {
+			return fmt.Errorf("replica has version %d, expecting %d", resp.Version, v.Version())
+		}
+		blob = resp.NeedleBlob
+		return nil
+	})
+	return
+}
+
+// ReadVolumeNeedleBlob reads the verified raw needle, for other replicas to repair their corrupted needle
+func (s *Store) ReadVolumeNeedleBlob(vid needle.VolumeId, needleId types.NeedleId) ([]byte, needle.Version, error) {
+	v := s.findVolume(vid)
+	if v == nil {
+		return nil, 0, fmt.Errorf("volume %d not found", vid)
+	}
+	blob, err := v.ReadNeedleBlob(needleId)
+	return blob, v.Version(), err
+}
+
+func verifyEcNeedle(ev *erasure_coding.EcVolume, key types.NeedleId, offset types.Offset, size types.Size, data []byte) error {
+	n := new(needle.Needle)
+	if err := n.ReadBytes(data, offset.ToActualOffset(), size, ev.Version); err != nil {
+		return err
+	}
+	if n.Id != key {
+		return fmt.Errorf("ec index has needle %s but ec shards have needle %s", key, n.Id)
+	}
+	return nil
+}
+
+// repairEcNeedle reconstructs the intervals on the local shards from the other shards,
+// and overwrites the local intervals that differ, if the reconstructed needle is healthy.
+func (s *Store) repairEcNeedle(ev *erasure_coding.EcVolume, key types.NeedleId, offset types.Offset, size types.Size, intervals []erasure_coding.Interval) error {
+
+	type shardRepair struct {
+		shard  *erasure_coding.EcVolumeShard
+		offset int64
+		data   []byte
+	}
+	var repairs []shardRepair
+	var data []byte
+
+	for _, interval := range intervals {
+		shardId, shardOffset := interval.ToShardIdAndOffset(erasure_coding.ErasureCodingLargeBlockSize, erasure_coding.ErasureCodingSmallBlockSize, ev.Scheme.DataShards)
+		shard, isLocal := ev.FindEcVolumeShard(shardId)
+		if !isLocal {
+			d, _, err := s.readOneEcShardInterval(key, ev, interval)
+			if err != nil {
+				return err
+			}
+			data = append(data, d...)
+			continue
+		}
+
+		recovered := make([]byte, interval.Size)
+		if _, _, err := s.recoverOneRemoteEcShardInterval(key, ev, shardId, recovered, shardOffset); err != nil {
+			return fmt.Errorf("recover ec shard %d.%d: %v", ev.VolumeId, shardId, err)
+		}
+		local := make([]byte, interval.Size)
+		if _, err := shard.ReadAt(local, shardOffset); err != nil && err != io.EOF {
+			return fmt.Errorf("read ec shard %d.%d: %v", ev.VolumeId, shardId, err)
+		}
+		if !bytes.Equal(local, recovered) {
+			repairs = append(repairs, shardRepair{shard: shard, offset: shardOffset, data: recovered})
+		}
+		data = append(data, recovered...)
+	}
+
+	if err := verifyEcNeedle(ev, key, offset, size, data); err != nil {
+		return fmt.Errorf("reconstructed needle is still corrupted: %v", err)
+	}
+	if len(repairs) == 0 {
+		return fmt.Errorf("corrupted on the shards of other volume servers")
+	}
+
+	for _, repair := range repairs {
+		if _, err := repair.shard.WriteAt(repair.data, repair.offset); err != nil {
+			return fmt.Errorf("write ec shard %d.%d: %v", ev.VolumeId, repair.shard.ShardId, err)
+		}
+		glog.V(0).Infof("repaired ec shard %d.%d needle %s at offset %d", ev.VolumeId, repair.shard.ShardId, key, repair.offset)
+	}
+	return nil
+}
+
+// the size of the ec stripes verified at a time, on each shard
+var ecScrubStripeSize = int64(erasure_coding.ErasureCodingSmallBlockSize)
+
+var errEcStripeUnreadable = errors.New("ec stripe is not readable")
+
+// verifyEcStripe encodes the parity from the data shards, and compares it with the local parity shards
+func (s *Store) verifyEcStripe(ev *erasure_coding.EcVolume, enc reedsolomon.Encoder, parityShards []*erasure_coding.EcVolumeShard, offset, size int64) error {
+	shards := make([][]byte, ev.Scheme.TotalShards())
+	for i := range shards {
+		if i < ev.Scheme.DataShards {
+			data, err := s.readEcShardRange(ev, erasure_coding.ShardId(i), offset, size)
+			if err != nil {
+				glog.V(1).Infof("scrub ec volume %d: %v", ev.VolumeId, err)
+				return errEcStripeUnreadable
+			}
+			shards[i] = data
+		} else {
+			shards[i] = make([]byte, size)
+		}
+	}
+	if err := enc.Encode(shards); err != nil {
+		return fmt.Errorf("encode: %v", err)
+	}
+	for _, shard := range parityShards {
+		local := make([]byte, size)
+		if _, err := shard.ReadAt(local, offset); err != nil && err != io.EOF {
+			glog.V(1).Infof("scrub ec shard %d.%d: %v", ev.VolumeId, shard.ShardId, err)
+			return errEcStripeUnreadable
+		}
+		if !bytes.Equal(local, shards[shard.ShardId]) {
+			return fmt.Errorf("ec shard %d.%d does not match the parity of the data shards", ev.VolumeId, shard.ShardId)
+		}
+	}
+	return nil
+}
+
+// repairEcStripe reads all shards of the stripe to locate the corrupted one, and rebuilds it if it is local.
+// A corrupted remote parity shard is repaired by its own server.
+func (s *Store) repairEcStripe(ev *erasure_coding.EcVolume, enc reedsolomon.Encoder, offset, size int64) error {
+	shards := make([][]byte, ev.Scheme.TotalShards())
+	for i := range shards {
+		data, err := s.readEcShardRange(ev, erasure_coding.ShardId(i), offset, size)
+		if err != nil {
+			return err
+		}
+		shards[i] = data
+	}
+	corrupted, err := erasure_coding.LocateCorruptShard(enc, shards)
+	if err != nil {
+		return err
+	}
+	if corrupted < 0 {
+		return nil
+	}
+	shard, isLocal := ev.FindEcVolumeShard(erasure_coding.ShardId(corrupted))
+	if !isLocal {
+		return fmt.Errorf("ec shard %d.%d is corrupted on another volume server", ev.VolumeId, corrupted)
+	}
+	shards[corrupted] = nil
+	if err = enc.Reconstruct(shards); err != nil {
+		return fmt.Errorf("rebuild ec shard %d.%d: %v", ev.VolumeId, corrupted, err)
+	}
+	if _, err = shard.WriteAt(shards[corrupted], offset); err != nil {
+		return fmt.Errorf("write ec shard %d.%d: %v", ev.VolumeId, corrupted, err)
+	}
+	glog.V(0).Infof("repaired ec shard %d.%d at offset %d", ev.VolumeId, corrupted, offset)
+	return nil
+}
+
+// readEcShardRange reads the range of the local or remote shard as is, without recovering it from other shards
+func (s *Store) readEcShardRange(ev *erasure_coding.EcVolume, shardId erasure_coding.ShardId, offset, size int64) ([]byte, error) {
+	data := make([]byte, size)
+	if shard, found := ev.FindEcVolumeShard(shardId); found {
+		if _, err := shard.ReadAt(data, offset); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("read ec shard %d.%d: %v", ev.VolumeId, shardId, err)
+		}
+		return data, nil
+	}
+	if err := s.cachedLookupEcShardLocations(ev); err != nil {
+		return nil, fmt.Errorf("locate ec shard %d.%d: %v", ev.VolumeId, shardId, err)
+	}
+	ev.ShardLocationsLock.RLock()
+	sourceDataNodes := ev.ShardLocations[shardId]
+	ev.ShardLocationsLock.RUnlock()
+	n, _, err := s.readRemoteEcShardInterval(sourceDataNodes, 0, ev.VolumeId, shardId, data, offset)
+	if err != nil {
+		return nil, err
+	}
+	if int64(n) != size {
+		return nil, fmt.Errorf("read ec shard %d.%d: %d bytes, expected %d", ev.VolumeId, shardId, n, size)
+	}
+	return data, nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/stats"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/storage/backend"
 	"github.com/chrislusf/seaweedfs/weed/storage/needle"
 )
 
@@ -18,7 +19,13 @@ func (s *Store) CheckCompactVolume(volumeId needle.VolumeId) (float64, error) {
 func (s *Store) CompactVolume(vid needle.VolumeId, preallocate int64, compactionBytePerSecond int64) error {
 	if v := s.findVolume(vid); v != nil {
 		s := stats.NewDiskStatus(v.dir)
-		if int64(s.Free) < preallocate {
+		liveSize := int64(v.ContentSize() - v.DeletedSize())
+		if int64(s.Free) < liveSize || int64(s.Free) < preallocate {
+			// the kept needle versions are not moved by the compaction in place
+			if _, isDiskFile := v.DataBackend.(*backend.DiskFile); isDiskFile && v.MemoryMapMaxSizeMb == 0 && !v.hasNeedleVersions() {
+				glog.V(0).Infof("free space: %d bytes, not enough to copy %d bytes, compact volume %d in place", s.Free, liveSize, vid)
+				return v.CompactInPlace(compactionBytePerSecond)
+			}
 			return fmt.Errorf("free space: %d bytes, not enough for %d bytes", s.Free, preallocate)
 		}
 		return v.Compact2(preallocate, compactionBytePerSecond)
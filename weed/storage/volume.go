@@ -39,13 +39,20 @@ type Volume struct {
 	lastModifiedTsSeconds uint64 // unix time in seconds
 	lastAppendAtNs        uint64 // unix time in nanoseconds
 
-	lastCompactIndexOffset uint64
-	lastCompactRevision    uint16
+	lastCompactIndexOffset    uint64
+	lastCompactRevision       uint16
+	lastCompactKeyId          uint32
+	lastCompactVersionsOffset int64
 
-	isCompacting bool
+	isCompacting       bool
+	isCompactedInPlace bool // the commit and cleanup steps of vacuum have nothing to do
 
 	volumeInfo *volume_server_pb.VolumeInfo
 	location   *DiskLocation
+	compressor *needleCompressor // nil if not compressing
+	encryptor  *needleEncryptor  // nil if the volume has no encryption keys
+	versions   *needleVersions   // nil if not keeping needle versions
+	reads      readCounter
 
 	lastIoError error
 }
@@ -87,7 +94,7 @@ func (v *Volume) IndexFileName() (fileName string) {
 
 func (v *Volume) FileName(ext string) (fileName string) {
 	switch ext {
-	case ".idx", ".cpx", ".ldb":
+	case ".idx", ".cpx", ".ldb", ".smx", ".vdx", ".cpv":
 		return VolumeFileName(v.dirIdx, v.Collection, int(v.Id)) + ext
 	}
 	// .dat, .cpd, .vif
@@ -183,6 +190,10 @@ func (v *Volume) Close() {
 		v.nm.Close()
 		v.nm = nil
 	}
+	if v.versions != nil {
+		v.versions.close()
+		v.versions = nil
+	}
 	if v.DataBackend != nil {
 		_ = v.DataBackend.Close()
 		v.DataBackend = nil
@@ -269,6 +280,7 @@ func (v *Volume) ToVolumeInformationMessage() (types.NeedleId, *master_pb.Volume
 		DiskType:         string(v.location.DiskType),
 	}
 
+	volumeInfo.RecentReadCount, volumeInfo.RecentReadSeconds = v.reads.recentReads(time.Now())
 	volumeInfo.RemoteStorageName, volumeInfo.RemoteStorageKey = v.RemoteStorageNameKey()
 
 	return maxFileKey, volumeInfo
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const CompressionZstd = "zstd"
+
+// needleCompressor compresses the needle data of volumes configured with compression.
+// The optional dictionary is registered globally by its id, which is recorded in each compressed needle.
+type needleCompressor struct {
+	dictId uint32
+}
+
+func (v *Volume) Compression() string {
+	if v.volumeInfo == nil {
+		return ""
+	}
+	return v.volumeInfo.Compression
+}
+
+func (v *Volume) loadCompression() error {
+	v.compressor = nil
+	// the dictionary is needed to read the compressed needles, even if no longer compressing new writes
+	dictId, err := loadZstdDictionary(v.FileName(".zdict"))
+	if err != nil {
+		return fmt.Errorf("volume %d: %v", v.Id, err)
+	}
+	switch v.Compression() {
+	case "":
+	case CompressionZstd:
+		v.compressor = &needleCompressor{dictId: dictId}
+	default:
+		return fmt.Errorf("volume %d has unknown compression %s", v.Id, v.Compression())
+	}
+	return nil
+}
+
+// loadZstdDictionary registers the dictionary file if it exists and is not empty
+func loadZstdDictionary(dictFileName string) (dictId uint32, err error) {
+	dict, err := ioutil.ReadFile(dictFileName)
+	if os.IsNotExist(err) || err == nil && len(dict) == 0 {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read zstd dictionary %s: %v", dictFileName, err)
+	}
+	return util.RegisterZstdDictionary(dict)
+}
+
+// inheritCompression makes a new volume compress like the other volumes of the same collection on this server
+func (s *Store) inheritCompression(v *Volume) error {
+	var template *Volume
+	for _, location := range s.Locations {
+		location.volumesLock.RLock()
+		for _, other := range location.volumes {
+			if other.Collection == v.Collection && other.Compression() != "" {
+				template = other
+				break
+			}
+		}
+		location.volumesLock.RUnlock()
+		if template != nil {
+			break
+		}
+	}
+	if template == nil {
+		return nil
+	}
+	if dict, err := ioutil.ReadFile(template.FileName(".zdict")); err == nil && len(dict) > 0 {
+		if err = ioutil.WriteFile(v.FileName(".zdict"), dict, 0644); err != nil {
+			return fmt.Errorf("save zstd dictionary of volume %d: %v", v.Id, err)
+		}
+	}
+	v.volumeInfo.Compression = template.Compression()
+	if err := v.SaveVolumeInfo(); err != nil {
+		return fmt.Errorf("save volume info of volume %d: %v", v.Id, err)
+	}
+	return v.loadCompression()
+}
+
+// compress returns a compressed copy of the needle, or the needle itself if it is not worth compressing.
+// The caller's needle is not changed, so the original data can still be sent to the replicas.
+func (c *needleCompressor) compress(n *needle.Needle) *needle.Needle {
+	if c == nil || len(n.Data) == 0 || n.IsCompressed() || n.IsChunkedManifest() {
+		return n
+	}
+	compressed, err := util.ZstdData(n.Data, c.dictId)
+	if err != nil {
+		glog.V(0).Infof("zstd needle %s: %v", n.Id, err)
+		return n
+	}
+	if len(compressed)*10 > len(n.Data)*9 {
+		return n
+	}
+	compressedNeedle := *n
+	compressedNeedle.Data = compressed
+	compressedNeedle.Checksum = needle.NewCRC(compressed)
+	compressedNeedle.SetIsCompressed()
+	return &compressedNeedle
+}
+
+// UncompressNeedleData restores the original data of a zstd compressed needle
+func UncompressNeedleData(n *needle.Needle) error {
+	if !n.IsZstdCompressed() {
+		return nil
+	}
+	data, err := util.UnzstdData(n.Data)
+	if err != nil {
+		return fmt.Errorf("unzstd needle %s: %v", n.Id, err)
+	}
+	n.Data = data
+	n.Flags = n.Flags &^ needle.FlagIsCompressed
+	// the checksum was computed on the compressed data
+	n.Checksum = needle.NewCRC(data)
+	return nil
+}
+
+// DecodeNeedleData restores the data as written by the client, also uncompressing the data gzipped by the client.
+// Needles read from the store are already decrypted.
+func DecodeNeedleData(n *needle.Needle) error {
+	if err := UncompressNeedleData(n); err != nil {
+		return err
+	}
+	if !n.IsCompressed() {
+		return nil
+	}
+	data, err := util.DecompressData(n.Data)
+	if err != nil {
+		return fmt.Errorf("uncompress needle %s: %v", n.Id, err)
+	}
+	n.Data = data
+	n.Flags = n.Flags &^ needle.FlagIsCompressed
+	return nil
+}
+
+// BuildCompressionDictionary samples the live needles evenly across the volume
+// to build a raw zstd dictionary of at most maxSize bytes.
+func (v *Volume) BuildCompressionDictionary(maxSize int) (dict []byte, sampleCount int, err error) {
+	const maxSampleCount = 1024
+	entryCount := v.IndexEntryCount()
+	step := entryCount/maxSampleCount + 1
+	var samples [][]byte
+	for i := int64(0); i < entryCount; i += step {
+		n, err := v.readIndexEntryNeedle(i)
+		if err != nil {
+			return nil, 0, err
+		}
+		if n == nil || len(n.Data) == 0 || n.IsChunkedManifest() || n.IsCompressed() && !n.IsZstdCompressed() {
+			continue
+		}
+		if err = UncompressNeedleData(n); err != nil {
+			continue
+		}
+		samples = append(samples, n.Data)
+	}
+	return util.BuildZstdRawDictionary(samples, maxSize), len(samples), nil
+}
+
+// readIndexEntryNeedle reads the live needle of the n-th index entry, or nil if the entry is not live
+func (v *Volume) readIndexEntryNeedle(i int64) (*needle.Needle, error) {
+	v.dataFileAccessLock.RLock()
+	defer v.dataFileAccessLock.RUnlock()
+
+	if v.nm == nil || v.DataBackend == nil {
+		return nil, fmt.Errorf("volume %d is closed", v.Id)
+	}
+	key, offset, size, err := v.nm.ReadIndexEntry(i)
+	if err != nil {
+		return nil, fmt.Errorf("read index entry %d: %v", i, err)
+	}
+	if offset.IsZero() || size.IsDeleted() {
+		return nil, nil
+	}
+	if nv, ok := v.nm.Get(key); !ok || nv.Offset != offset || nv.Size != size {
+		return nil, nil
+	}
+	n := new(needle.Needle)
+	if err = n.ReadData(v.DataBackend, offset.ToActualOffset(), size, v.Version()); err != nil {
+		if err != needle.ErrorSizeMismatch || OffsetSize != 4 {
+			return nil, nil
+		}
+		if err = n.ReadData(v.DataBackend, offset.ToActualOffset()+int64(MaxPossibleVolumeSize), size, v.Version()); err != nil {
+			return nil, nil
+		}
+	}
+	if err = v.encryptor.decrypt(n); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/storage/super_block"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestCompressNeedlesWhenWritingAndVacuuming(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compression")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	v, err := NewVolume(dir, dir, "", 1, NeedleMapInMemory, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+
+	data := make(map[uint64][]byte)
+	writeNeedles := func(from, to uint64) {
+		for i := from; i < to; i++ {
+			data[i] = []byte(fmt.Sprintf(`{"id":%d,"name":"file%d","content":"%s"}`, i, i, bytes.Repeat([]byte("abc"), int(i))))
+			n := newEmptyNeedle(i)
+			n.Data = data[i]
+			n.Checksum = needle.NewCRC(n.Data)
+			if _, _, _, err := v.writeNeedle2(v.compressor.compress(n), false); err != nil {
+				t.Fatalf("write needle %d: %v", i, err)
+			}
+		}
+	}
+	assertNeedles := func(expectCompressed bool) {
+		for i, expected := range data {
+			n := newEmptyNeedle(i)
+			if _, err := v.readNeedle(n, nil); err != nil {
+				t.Fatalf("read needle %d: %v", i, err)
+			}
+			if n.IsZstdCompressed() != expectCompressed {
+				t.Fatalf("needle %d compressed: %v", i, n.IsZstdCompressed())
+			}
+			if err := UncompressNeedleData(n); err != nil {
+				t.Fatalf("uncompress needle %d: %v", i, err)
+			}
+			if !bytes.Equal(n.Data, expected) {
+				t.Fatalf("needle %d has unexpected data", i)
+			}
+		}
+	}
+
+	writeNeedles(10, 20)
+	assertNeedles(false)
+
+	// enable compression with a dictionary
+	var samples [][]byte
+	for _, d := range data {
+		samples = append(samples, d)
+	}
+	if err = ioutil.WriteFile(v.FileName(".zdict"), util.BuildZstdRawDictionary(samples, 4096), 0644); err != nil {
+		t.Fatalf("write dictionary: %v", err)
+	}
+	v.volumeInfo.Compression = CompressionZstd
+	if err = v.SaveVolumeInfo(); err != nil {
+		t.Fatalf("save volume info: %v", err)
+	}
+	if err = v.loadCompression(); err != nil {
+		t.Fatalf("load compression: %v", err)
+	}
+	if v.compressor == nil || v.compressor.dictId == 0 {
+		t.Fatalf("compression with dictionary is not loaded")
+	}
+
+	// vacuum compresses the existing needles
+	if err = v.Compact2(0, 0); err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+	if err = v.CommitCompact(); err != nil {
+		t.Fatalf("commit compact: %v", err)
+	}
+	assertNeedles(true)
+
+	// new writes are compressed
+	writeNeedles(20, 30)
+	assertNeedles(true)
+
+	v.Close()
+}
+
+func TestDecodeNeedleData(t *testing.T) {
+	data := bytes.Repeat([]byte(`{"name":"file","content":"abc"}`), 10)
+	zstdData, _ := util.ZstdData(data, 0)
+	gzipData, _ := util.GzipData(data)
+
+	for _, stored := range [][]byte{data, zstdData, gzipData} {
+		n := newEmptyNeedle(1)
+		n.Data = stored
+		n.Checksum = needle.NewCRC(stored)
+		if !bytes.Equal(stored, data) {
+			n.SetIsCompressed()
+		}
+		if err := DecodeNeedleData(n); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if !bytes.Equal(n.Data, data) || n.IsCompressed() {
+			t.Fatalf("needle is not decoded, flags %x", n.Flags)
+		}
+	}
+}
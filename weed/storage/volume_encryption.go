@@ -0,0 +1,306 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+const EncryptionAes256Gcm = "aes256gcm"
+
+var (
+	// encryptionMasterKey wraps the keys of the volumes, which are saved in the .vif files
+	encryptionMasterKey util.CipherKey
+	unwrappedKeys       sync.Map // wrapped key => util.CipherKey
+)
+
+// the encrypted needle data is: key id(4), nonce, sealed data
+const encryptedDataHeaderSize = 4
+
+// needleEncryptor encrypts the needle data of volumes configured with encryption.
+// All keys of the volume are kept to read the needles written before the last key rotation.
+type needleEncryptor struct {
+	keys         map[uint32]util.CipherKey
+	currentKeyId uint32 // 0 if not encrypting new writes
+}
+
+// LoadEncryptionMasterKey loads the master key, which has 32 bytes, either raw or as 64 hex characters
+func LoadEncryptionMasterKey(keyFile string) error {
+	data, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return fmt.Errorf("read encryption key file %s: %v", keyFile, err)
+	}
+	var key []byte
+	if trimmed := bytes.TrimSpace(data); len(trimmed) == 64 {
+		if key, err = hex.DecodeString(string(trimmed)); err != nil {
+			return fmt.Errorf("encryption key file %s: %v", keyFile, err)
+		}
+	} else if len(data) == 32 {
+		key = data
+	} else {
+		return fmt.Errorf("encryption key file %s should have 32 bytes or 64 hex characters", keyFile)
+	}
+	encryptionMasterKey = util.CipherKey(key)
+	return nil
+}
+
+func (v *Volume) Encryption() string {
+	if v.volumeInfo == nil {
+		return ""
+	}
+	return v.volumeInfo.Encryption
+}
+
+// EncryptionKeys returns the wrapped keys of the volume
+func (v *Volume) EncryptionKeys() []*volume_server_pb.VolumeEncryptionKey {
+	if v.volumeInfo == nil {
+		return nil
+	}
+	return v.volumeInfo.EncryptionKeys
+}
+
+func (v *Volume) loadEncryption() error {
+	v.encryptor = nil
+	if v.volumeInfo == nil {
+		return nil
+	}
+	encryptor, err := newNeedleEncryptor(v.volumeInfo.Encryption, v.volumeInfo.EncryptionKeys)
+	if err != nil {
+		return fmt.Errorf("volume %d: %v", v.Id, err)
+	}
+	v.encryptor = encryptor
+	return nil
+}
+
+// newNeedleEncryptor unwraps the keys of a volume, and encrypts new writes with the latest key if encryption is on
+func newNeedleEncryptor(encryption string, wrappedKeys []*volume_server_pb.VolumeEncryptionKey) (*needleEncryptor, error) {
+	if encryption != "" && encryption != EncryptionAes256Gcm {
+		return nil, fmt.Errorf("unknown encryption %s", encryption)
+	}
+	if len(wrappedKeys) == 0 {
+		if encryption != "" {
+			return nil, fmt.Errorf("no encryption key")
+		}
+		return nil, nil
+	}
+	e := &needleEncryptor{keys: make(map[uint32]util.CipherKey)}
+	var lastKeyId uint32
+	for _, wrappedKey := range wrappedKeys {
+		key, err := unwrapKey(wrappedKey.WrappedKey)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %d: %v", wrappedKey.Id, err)
+		}
+		e.keys[wrappedKey.Id] = key
+		if wrappedKey.Id > lastKeyId {
+			lastKeyId = wrappedKey.Id
+		}
+	}
+	if encryption != "" {
+		e.currentKeyId = lastKeyId
+	}
+	return e, nil
+}
+
+func unwrapKey(wrappedKey []byte) (util.CipherKey, error) {
+	if encryptionMasterKey == nil {
+		return nil, fmt.Errorf("no encryption master key, see -encryption.keyFile")
+	}
+	if key, found := unwrappedKeys.Load(string(wrappedKey)); found {
+		return key.(util.CipherKey), nil
+	}
+	key, err := util.Decrypt(wrappedKey, encryptionMasterKey)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap: %v", err)
+	}
+	unwrappedKeys.Store(string(wrappedKey), util.CipherKey(key))
+	return key, nil
+}
+
+// newVolumeKey generates a random key, and returns it wrapped by the master key, which is the only form saved.
+// Each replica has its own key, since the needles are encrypted by each volume server anyway.
+func newVolumeKey(keyId uint32) (*volume_server_pb.VolumeEncryptionKey, error) {
+	if encryptionMasterKey == nil {
+		return nil, fmt.Errorf("no encryption master key, see -encryption.keyFile")
+	}
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("generate key: %v", err)
+	}
+	wrappedKey, err := util.Encrypt(key, encryptionMasterKey)
+	if err != nil {
+		return nil, fmt.Errorf("wrap key: %v", err)
+	}
+	return &volume_server_pb.VolumeEncryptionKey{Id: keyId, WrappedKey: wrappedKey}, nil
+}
+
+// inheritEncryption makes a new volume encrypt like the other volumes of the same collection on this server
+func (s *Store) inheritEncryption(v *Volume) error {
+	var template *Volume
+	for _, location := range s.Locations {
+		location.volumesLock.RLock()
+		for _, other := range location.volumes {
+			if other.Collection == v.Collection && other.Encryption() != "" {
+				template = other
+				break
+			}
+		}
+		location.volumesLock.RUnlock()
+		if template != nil {
+			break
+		}
+	}
+	if template == nil {
+		return nil
+	}
+	key, err := newVolumeKey(1)
+	if err != nil {
+		return fmt.Errorf("volume %d: %v", v.Id, err)
+	}
+	v.volumeInfo.Encryption = template.Encryption()
+	v.volumeInfo.EncryptionKeys = []*volume_server_pb.VolumeEncryptionKey{key}
+	if err := v.SaveVolumeInfo(); err != nil {
+		return fmt.Errorf("save volume info of volume %d: %v", v.Id, err)
+	}
+	return v.loadEncryption()
+}
+
+// encrypt returns an encrypted copy of the needle, or the needle itself if not encrypting.
+// The caller's needle is not changed, so the original data can still be sent to the replicas.
+func (e *needleEncryptor) encrypt(n *needle.Needle) (*needle.Needle, error) {
+	if e == nil || e.currentKeyId == 0 || len(n.Data) == 0 {
+		return n, nil
+	}
+	header := make([]byte, encryptedDataHeaderSize)
+	binary.BigEndian.PutUint32(header[0:4], e.currentKeyId)
+	gcm, err := newGcm(e.keys[e.currentKeyId])
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("encrypt needle %s: %v", n.Id, err)
+	}
+	data := append(header, nonce...)
+	data = gcm.Seal(data, nonce, n.Data, encryptedDataAad(header, n.Id))
+
+	encryptedNeedle := *n
+	encryptedNeedle.Data = data
+	encryptedNeedle.Checksum = needle.NewCRC(data)
+	encryptedNeedle.SetIsEncrypted()
+	return &encryptedNeedle, nil
+}
+
+// decrypt restores the needle data, flags and checksum in place. Needles not marked as encrypted are kept as is.
+func (e *needleEncryptor) decrypt(n *needle.Needle) error {
+	if !n.IsEncrypted() {
+		return nil
+	}
+	if len(n.Data) < encryptedDataHeaderSize {
+		return fmt.Errorf("decrypt needle %s: data too short", n.Id)
+	}
+	header, sealed := n.Data[:encryptedDataHeaderSize], n.Data[encryptedDataHeaderSize:]
+	keyId := binary.BigEndian.Uint32(header[0:4])
+	var key util.CipherKey
+	if e != nil {
+		key = e.keys[keyId]
+	}
+	if key == nil {
+		return fmt.Errorf("decrypt needle %s: encryption key %d not found", n.Id, keyId)
+	}
+	gcm, err := newGcm(key)
+	if err != nil {
+		return err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return fmt.Errorf("decrypt needle %s: data too short", n.Id)
+	}
+	data, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], encryptedDataAad(header, n.Id))
+	if err != nil {
+		return fmt.Errorf("decrypt needle %s: %v", n.Id, err)
+	}
+	n.Data = data
+	n.Flags = n.Flags &^ needle.FlagIsEncrypted
+	// the checksum of the encrypted data differs on each replica, because of the random nonce
+	n.Checksum = needle.NewCRC(data)
+	return nil
+}
+
+func newGcm(key util.CipherKey) (cipher.AEAD, error) {
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("aes cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(c)
+	if err != nil {
+		return nil, fmt.Errorf("gcm: %v", err)
+	}
+	return gcm, nil
+}
+
+// encryptedDataAad binds the sealed data to the needle id, so it can not be swapped with the data of other needles
+func encryptedDataAad(header []byte, id NeedleId) []byte {
+	aad := make([]byte, encryptedDataHeaderSize+NeedleIdSize)
+	copy(aad, header)
+	NeedleIdToBytes(aad[encryptedDataHeaderSize:], id)
+	return aad
+}
+
+// recodeNeedle rewrites a needle with the current compression and encryption of the volume during vacuum
+func recodeNeedle(n *needle.Needle, compressor *needleCompressor, encryptor *needleEncryptor) (*needle.Needle, error) {
+	if err := encryptor.decrypt(n); err != nil {
+		return nil, err
+	}
+	return encryptor.encrypt(compressor.compress(n))
+}
+
+// DecryptNeedleData restores the original data of a needle encrypted with one of the wrapped keys
+func DecryptNeedleData(n *needle.Needle, wrappedKeys []*volume_server_pb.VolumeEncryptionKey) error {
+	encryptor, err := newNeedleEncryptor("", wrappedKeys)
+	if err != nil {
+		return err
+	}
+	return encryptor.decrypt(n)
+}
+
+func (e *needleEncryptor) currentKey() uint32 {
+	if e == nil {
+		return 0
+	}
+	return e.currentKeyId
+}
+
+// pruneEncryptionKeys drops the keys older than the one used by the last compaction,
+// since all needles were re-encrypted then, and the later writes use the same or newer keys.
+func (v *Volume) pruneEncryptionKeys() error {
+	if v.lastCompactKeyId == 0 || v.volumeInfo == nil {
+		return nil
+	}
+	var keys []*volume_server_pb.VolumeEncryptionKey
+	for _, key := range v.volumeInfo.EncryptionKeys {
+		if key.Id >= v.lastCompactKeyId {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == len(v.volumeInfo.EncryptionKeys) {
+		return nil
+	}
+	glog.V(0).Infof("volume %d drops %d old encryption keys", v.Id, len(v.volumeInfo.EncryptionKeys)-len(keys))
+	v.volumeInfo.EncryptionKeys = keys
+	if err := v.SaveVolumeInfo(); err != nil {
+		return fmt.Errorf("save volume info of volume %d: %v", v.Id, err)
+	}
+	return v.loadEncryption()
+}
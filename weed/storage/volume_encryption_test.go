@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/storage/super_block"
+)
+
+func TestEncryptNeedlesAndRotateKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encryption")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	keyFile := filepath.Join(dir, "master.key")
+	if err = ioutil.WriteFile(keyFile, []byte(hex.EncodeToString(bytes.Repeat([]byte{7}, 32))+"\n"), 0600); err != nil {
+		t.Fatalf("write key file: %v", err)
+	}
+	if err = LoadEncryptionMasterKey(keyFile); err != nil {
+		t.Fatalf("load master key: %v", err)
+	}
+	defer func() {
+		encryptionMasterKey = nil
+	}()
+
+	v, err := NewVolume(dir, dir, "", 1, NeedleMapInMemory, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+
+	data := make(map[uint64][]byte)
+	writeNeedles := func(from, to uint64) {
+		for i := from; i < to; i++ {
+			data[i] = []byte(fmt.Sprintf("file %d", i))
+			n := newEmptyNeedle(i)
+			n.Data = data[i]
+			n.Checksum = needle.NewCRC(n.Data)
+			stored, err := v.encryptor.encrypt(n)
+			if err != nil {
+				t.Fatalf("encrypt needle %d: %v", i, err)
+			}
+			if _, _, _, err := v.writeNeedle2(stored, false); err != nil {
+				t.Fatalf("write needle %d: %v", i, err)
+			}
+		}
+	}
+	assertNeedles := func(expectedKeyId uint32) {
+		for i, expected := range data {
+			n := newEmptyNeedle(i)
+			if _, err := v.readNeedle(n, nil); err != nil {
+				t.Fatalf("read needle %d: %v", i, err)
+			}
+			if keyId := encryptedKeyId(n); keyId != expectedKeyId {
+				t.Fatalf("needle %d encrypted by key %d, expected %d", i, keyId, expectedKeyId)
+			}
+			if err := v.encryptor.decrypt(n); err != nil {
+				t.Fatalf("decrypt needle %d: %v", i, err)
+			}
+			if !bytes.Equal(n.Data, expected) {
+				t.Fatalf("needle %d has unexpected data", i)
+			}
+		}
+	}
+	addKey := func(keyId uint32) {
+		key, err := newVolumeKey(keyId)
+		if err != nil {
+			t.Fatalf("new key: %v", err)
+		}
+		v.volumeInfo.Encryption = EncryptionAes256Gcm
+		v.volumeInfo.EncryptionKeys = append(v.volumeInfo.EncryptionKeys, key)
+		if err = v.SaveVolumeInfo(); err != nil {
+			t.Fatalf("save volume info: %v", err)
+		}
+		if err = v.loadEncryption(); err != nil {
+			t.Fatalf("load encryption: %v", err)
+		}
+	}
+
+	writeNeedles(10, 20)
+	// only needles flagged as encrypted are decrypted, whatever their data looks like
+	data[9] = append([]byte{0xff, 'S', 'W', 'E', 0, 0, 0, 1}, bytes.Repeat([]byte("x"), 40)...)
+	plain := newEmptyNeedle(9)
+	plain.Data = data[9]
+	plain.Checksum = needle.NewCRC(plain.Data)
+	if _, _, _, err = v.writeNeedle2(plain, false); err != nil {
+		t.Fatalf("write needle 9: %v", err)
+	}
+	assertNeedles(0)
+
+	// vacuum encrypts the existing needles
+	addKey(1)
+	if err = v.Compact2(0, 0); err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+	if err = v.CommitCompact(); err != nil {
+		t.Fatalf("commit compact: %v", err)
+	}
+	assertNeedles(1)
+
+	// after rotation, new writes use the new key, and vacuum re-encrypts the others and drops the old key
+	addKey(2)
+	writeNeedles(20, 30)
+	if err = v.Compact(0, 0); err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+	if err = v.CommitCompact(); err != nil {
+		t.Fatalf("commit compact: %v", err)
+	}
+	assertNeedles(2)
+	if len(v.EncryptionKeys()) != 1 || v.EncryptionKeys()[0].Id != 2 {
+		t.Fatalf("unexpected keys %v", v.EncryptionKeys())
+	}
+
+	// each key is generated randomly, so only the volume's own keys decrypt its needles
+	otherKey, err := newVolumeKey(2)
+	if err != nil {
+		t.Fatalf("new key: %v", err)
+	}
+	if bytes.Equal(otherKey.WrappedKey, v.EncryptionKeys()[0].WrappedKey) {
+		t.Fatalf("the same key is generated twice")
+	}
+	otherEncryptor, err := newNeedleEncryptor(EncryptionAes256Gcm, []*volume_server_pb.VolumeEncryptionKey{otherKey})
+	if err != nil {
+		t.Fatalf("other encryption: %v", err)
+	}
+	n := newEmptyNeedle(10)
+	if _, err = v.readNeedle(n, nil); err != nil {
+		t.Fatalf("read needle: %v", err)
+	}
+	if err = otherEncryptor.decrypt(n); err == nil {
+		t.Fatalf("needle decrypted by another key")
+	}
+	if err = v.encryptor.decrypt(n); err != nil || !bytes.Equal(n.Data, data[10]) {
+		t.Fatalf("decrypt: %v", err)
+	}
+	// the checksum, used as the etag, is the same on all replicas despite the random nonces
+	if n.Checksum != needle.NewCRC(data[10]) || n.IsEncrypted() {
+		t.Fatalf("decrypted needle has checksum %x, flags %x", n.Checksum, n.Flags)
+	}
+
+	v.Close()
+}
+
+func encryptedKeyId(n *needle.Needle) uint32 {
+	if !n.IsEncrypted() || len(n.Data) < encryptedDataHeaderSize {
+		return 0
+	}
+	return uint32(n.Data[0])<<24 | uint32(n.Data[1])<<16 | uint32(n.Data[2])<<8 | uint32(n.Data[3])
+}
+
+func TestEncryptKeepsCompressionFlags(t *testing.T) {
+	encryptionMasterKey = bytes.Repeat([]byte{7}, 32)
+	defer func() {
+		encryptionMasterKey = nil
+	}()
+	key, err := newVolumeKey(1)
+	if err != nil {
+		t.Fatalf("new key: %v", err)
+	}
+	encryptor, err := newNeedleEncryptor(EncryptionAes256Gcm, []*volume_server_pb.VolumeEncryptionKey{key})
+	if err != nil {
+		t.Fatalf("encryption: %v", err)
+	}
+
+	for _, flags := range []byte{0, needle.FlagIsCompressed, needle.FlagIsCompressed | needle.FlagHasName} {
+		n := newEmptyNeedle(1)
+		n.Data = []byte("compressed data")
+		n.Flags = flags
+		encrypted, err := encryptor.encrypt(n)
+		if err != nil {
+			t.Fatalf("encrypt: %v", err)
+		}
+		if !encrypted.IsEncrypted() || encrypted.Flags&^needle.FlagIsEncrypted != flags || encrypted.IsZstdCompressed() {
+			t.Fatalf("encrypted needle has flags %x", encrypted.Flags)
+		}
+		if err = encryptor.decrypt(encrypted); err != nil {
+			t.Fatalf("decrypt: %v", err)
+		}
+		if encrypted.Flags != flags || !bytes.Equal(encrypted.Data, n.Data) {
+			t.Fatalf("decrypted needle has flags %x, expected %x", encrypted.Flags, flags)
+		}
+	}
+}
@@ -15,7 +15,7 @@ import (
 )
 
 func loadVolumeWithoutIndex(dirname string, collection string, id needle.VolumeId, needleMapKind NeedleMapKind) (v *Volume, err error) {
-	v = &Volume{dir: dirname, Collection: collection, Id: id}
+	v = &Volume{dir: dirname, dirIdx: dirname, Collection: collection, Id: id}
 	v.SuperBlock = super_block.SuperBlock{}
 	v.needleMapKind = needleMapKind
 	err = v.load(false, false, needleMapKind, 0)
@@ -32,6 +32,10 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 				v.nm.Close()
 				v.nm = nil
 			}
+			if v.versions != nil {
+				v.versions.close()
+				v.versions = nil
+			}
 			if v.DataBackend != nil {
 				v.DataBackend.Close()
 				v.DataBackend = nil
@@ -40,6 +44,21 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 	}()
 
 	hasVolumeInfoFile := v.maybeLoadVolumeInfo() && v.volumeInfo.Version != 0
+	if err = v.loadCompression(); err != nil {
+		return err
+	}
+	if err = v.loadEncryption(); err != nil {
+		return err
+	}
+	if err = v.recoverInPlaceCompaction(); err != nil {
+		return fmt.Errorf("volume %d: %v", v.Id, err)
+	}
+	if alsoLoadIndex {
+		// the previous versions are only read through the index
+		if err = v.loadNeedleVersions(); err != nil {
+			return fmt.Errorf("volume %d: %v", v.Id, err)
+		}
+	}
 
 	if v.HasRemoteFile() {
 		v.noWriteCanDelete = true
@@ -158,6 +177,11 @@ func (v *Volume) load(alsoLoadIndex bool, createDatIfMissing bool, needleMapKind
 				if v.nm, err = NewLevelDbNeedleMap(v.FileName(".ldb"), indexFile, opts); err != nil {
 					glog.V(0).Infof("loading leveldb %s error: %v", v.FileName(".ldb"), err)
 				}
+			case NeedleMapSortedIndex:
+				glog.V(0).Infoln("loading sorted index", v.FileName(".smx"))
+				if v.nm, err = NewSortedIndexNeedleMap(v.FileName(".smx"), indexFile); err != nil {
+					glog.V(0).Infof("loading sorted index %s error: %v", v.FileName(".smx"), err)
+				}
 			}
 		}
 	}
@@ -0,0 +1,283 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/storage/backend"
+	"github.com/chrislusf/seaweedfs/weed/storage/idx"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle_map"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+/*
+When a needle is overwritten or deleted, the index entry of its previous version is appended to the .vdx file,
+which has the same format as the .idx file. The last KeepNeedleVersions versions of each needle are kept in memory,
+and can be read with ReadOption.Version, counting back from the latest version, i.e., 1 is the previous version.
+
+Vacuum copies the kept versions into the compacted .dat file, before the live needles, and writes their new
+index entries into the .cpv file, which replaces the .vdx file when the vacuum is committed.
+*/
+
+// KeepNeedleVersions is the number of previous versions kept for each overwritten or deleted needle.
+// With 0, no versions are kept, and the existing ones are dropped by the next vacuum.
+var KeepNeedleVersions = 0
+
+type needleVersions struct {
+	sync.RWMutex
+	file       *os.File
+	fileOffset int64
+	limit      int
+	chains     map[NeedleId][]needle_map.NeedleValue // oldest version first
+}
+
+func loadNeedleVersions(fileName string, limit int) (*needleVersions, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	file, err := os.OpenFile(fileName, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %v", fileName, err)
+	}
+	vs := &needleVersions{file: file, limit: limit, chains: make(map[NeedleId][]needle_map.NeedleValue)}
+	err = idx.WalkIndexFile(file, func(key NeedleId, offset Offset, size Size) error {
+		vs.fileOffset += NeedleMapEntrySize
+		vs.keep(needle_map.NeedleValue{Key: key, Offset: offset, Size: size})
+		return nil
+	})
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("load %s: %v", fileName, err)
+	}
+	return vs, nil
+}
+
+func (vs *needleVersions) keep(nv needle_map.NeedleValue) {
+	chain := append(vs.chains[nv.Key], nv)
+	if len(chain) > vs.limit {
+		chain = append(chain[:0:0], chain[len(chain)-vs.limit:]...)
+	}
+	vs.chains[nv.Key] = chain
+}
+
+func (vs *needleVersions) add(key NeedleId, offset Offset, size Size) error {
+	vs.Lock()
+	defer vs.Unlock()
+	nv := needle_map.NeedleValue{Key: key, Offset: offset, Size: size}
+	written, err := vs.file.WriteAt(nv.ToBytes(), vs.fileOffset)
+	vs.fileOffset += int64(written)
+	if err != nil {
+		return fmt.Errorf("write %s: %v", vs.file.Name(), err)
+	}
+	vs.keep(nv)
+	return nil
+}
+
+// get returns the version counting back from the latest version
+func (vs *needleVersions) get(key NeedleId, version int) (nv needle_map.NeedleValue, found bool) {
+	vs.RLock()
+	defer vs.RUnlock()
+	chain := vs.chains[key]
+	if version <= 0 || version > len(chain) {
+		return
+	}
+	return chain[len(chain)-version], true
+}
+
+// list returns the kept versions, latest first
+func (vs *needleVersions) list(key NeedleId) (versions []needle_map.NeedleValue) {
+	vs.RLock()
+	defer vs.RUnlock()
+	chain := vs.chains[key]
+	for i := len(chain) - 1; i >= 0; i-- {
+		versions = append(versions, chain[i])
+	}
+	return
+}
+
+// snapshot returns all kept versions, and the .vdx file size they cover
+func (vs *needleVersions) snapshot() (versions []needle_map.NeedleValue, fileOffset int64) {
+	vs.RLock()
+	defer vs.RUnlock()
+	for _, chain := range vs.chains {
+		versions = append(versions, chain...)
+	}
+	return versions, vs.fileOffset
+}
+
+func (vs *needleVersions) close() {
+	if err := vs.file.Close(); err != nil {
+		glog.Warningf("close %s failed: %v", vs.file.Name(), err)
+	}
+}
+
+func (v *Volume) loadNeedleVersions() (err error) {
+	if v.versions != nil {
+		v.versions.close()
+		v.versions = nil
+	}
+	v.versions, err = loadNeedleVersions(v.FileName(".vdx"), KeepNeedleVersions)
+	return
+}
+
+// keepNeedleVersion records the current version of the needle, before it is overwritten or deleted
+func (v *Volume) keepNeedleVersion(nv *needle_map.NeedleValue) {
+	if v.versions == nil || nv.Offset.IsZero() || !nv.Size.IsValid() {
+		return
+	}
+	if err := v.versions.add(nv.Key, nv.Offset, nv.Size); err != nil {
+		glog.V(0).Infof("volume %d keeps needle %s version: %v", v.Id, nv.Key, err)
+	}
+}
+
+func (v *Volume) hasNeedleVersions() bool {
+	return v.versions != nil
+}
+
+// NeedleVersions reads the latest and the kept previous versions of the needle without their data, latest first.
+// The latest version is nil if the needle is deleted.
+func (v *Volume) NeedleVersions(key NeedleId) (versions []*needle.Needle, err error) {
+	v.dataFileAccessLock.RLock()
+	defer v.dataFileAccessLock.RUnlock()
+
+	var values []needle_map.NeedleValue
+	if nv, ok := v.nm.Get(key); ok && !nv.Offset.IsZero() && nv.Size.IsValid() {
+		values = append(values, *nv)
+	} else {
+		versions = append(versions, nil)
+	}
+	if v.versions != nil {
+		values = append(values, v.versions.list(key)...)
+	}
+	for _, nv := range values {
+		n := new(needle.Needle)
+		if err = n.ReadData(v.DataBackend, nv.Offset.ToActualOffset(), nv.Size, v.Version()); err != nil {
+			return nil, fmt.Errorf("read needle %s at %d: %v", key, nv.Offset.ToActualOffset(), err)
+		}
+		if err = v.encryptor.decrypt(n); err != nil {
+			return nil, err
+		}
+		n.Data = nil
+		versions = append(versions, n)
+	}
+	if len(versions) == 1 && versions[0] == nil {
+		return nil, ErrorNotFound
+	}
+	return versions, nil
+}
+
+// copyNeedleVersions appends the needles of the kept versions to the compacted .dat file,
+// and writes their new index entries to the .cpv file.
+func (v *Volume) copyNeedleVersions(srcDatBackend, dstDatBackend backend.BackendStorageFile, versions []needle_map.NeedleValue, dstVersionsName string, newOffset int64, now uint64) (int64, error) {
+	if v.versions == nil {
+		return newOffset, nil
+	}
+	dst, err := os.OpenFile(dstVersionsName, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return newOffset, err
+	}
+	defer dst.Close()
+	for _, nv := range versions {
+		if err = v.copyNeedleVersion(srcDatBackend, dstDatBackend, dst, nv, now); err != nil {
+			return newOffset, err
+		}
+	}
+	if newOffset, _, err = dstDatBackend.GetStat(); err != nil {
+		return newOffset, err
+	}
+	return newOffset, dst.Sync()
+}
+
+// makeupNeedleVersionsDiff copies the versions kept during the vacuum, after the kept versions at its start.
+func (v *Volume) makeupNeedleVersionsDiff(newDatFileName, newVersionsFileName string) error {
+	if v.versions == nil {
+		return nil
+	}
+	v.versions.RLock()
+	fileOffset := v.versions.fileOffset
+	v.versions.RUnlock()
+	if fileOffset <= v.lastCompactVersionsOffset {
+		return nil
+	}
+	var changed []needle_map.NeedleValue
+	err := idx.WalkIndexFile(io.NewSectionReader(v.versions.file, v.lastCompactVersionsOffset, fileOffset-v.lastCompactVersionsOffset), func(key NeedleId, offset Offset, size Size) error {
+		changed = append(changed, needle_map.NeedleValue{Key: key, Offset: offset, Size: size})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	dstDatFile, err := os.OpenFile(newDatFileName, os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	dstDatBackend := backend.NewDiskFile(dstDatFile)
+	defer dstDatBackend.Close()
+	dst, err := os.OpenFile(newVersionsFileName, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	now := uint64(time.Now().Unix())
+	for _, nv := range changed {
+		if err = v.copyNeedleVersion(v.DataBackend, dstDatBackend, dst, nv, now); err != nil {
+			return err
+		}
+	}
+	return dst.Sync()
+}
+
+func (v *Volume) copyNeedleVersion(srcDatBackend, dstDatBackend backend.BackendStorageFile, dst *os.File, nv needle_map.NeedleValue, now uint64) error {
+	n := new(needle.Needle)
+	if err := n.ReadData(srcDatBackend, nv.Offset.ToActualOffset(), nv.Size, v.Version()); err != nil {
+		glog.V(0).Infof("volume %d drops needle %s version at %d: %v", v.Id, nv.Key, nv.Offset.ToActualOffset(), err)
+		return nil
+	}
+	if n.HasTtl() && now >= n.LastModified+uint64(v.Ttl.Minutes()*60) {
+		return nil
+	}
+	n, err := recodeNeedle(n, v.compressor, v.encryptor)
+	if err != nil {
+		return err
+	}
+	offset, _, _, err := n.Append(dstDatBackend, v.Version())
+	if err != nil {
+		return fmt.Errorf("cannot append needle: %s", err)
+	}
+	_, err = dst.Write(needle_map.ToBytes(n.Id, ToOffset(int64(offset)), n.Size))
+	return err
+}
+
+// snapshotNeedleVersions returns the kept versions to copy by vacuum, and remembers the .vdx file size they cover
+func (v *Volume) snapshotNeedleVersions() (versions []needle_map.NeedleValue) {
+	v.lastCompactVersionsOffset = 0
+	if v.versions == nil {
+		return nil
+	}
+	versions, v.lastCompactVersionsOffset = v.versions.snapshot()
+	return versions
+}
+
+// commitNeedleVersions replaces the .vdx file with the versions copied by vacuum,
+// or removes it if no versions are kept any more.
+func (v *Volume) commitNeedleVersions() error {
+	if v.versions != nil {
+		v.versions.close()
+		v.versions = nil
+	}
+	if _, err := os.Stat(v.FileName(".cpv")); os.IsNotExist(err) {
+		os.Remove(v.FileName(".vdx"))
+		return nil
+	}
+	if err := os.Rename(v.FileName(".cpv"), v.FileName(".vdx")); err != nil {
+		return fmt.Errorf("rename %s: %v", v.FileName(".cpv"), err)
+	}
+	return nil
+}
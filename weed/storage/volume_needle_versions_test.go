@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/storage/super_block"
+)
+
+func TestKeepNeedleVersions(t *testing.T) {
+	dir := t.TempDir()
+	KeepNeedleVersions = 2
+	defer func() {
+		KeepNeedleVersions = 0
+	}()
+
+	v, err := NewVolume(dir, dir, "", 1, NeedleMapInMemory, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	write := func(id uint64, content string) {
+		n := newEmptyNeedle(id)
+		n.Data = []byte(content)
+		n.Checksum = needle.NewCRC(n.Data)
+		if _, _, _, err := v.writeNeedle2(n, false); err != nil {
+			t.Fatalf("write needle %d: %v", id, err)
+		}
+	}
+	assertVersion := func(id uint64, version int, expected string) {
+		n := newEmptyNeedle(id)
+		_, err := v.readNeedle(n, &ReadOption{Version: version})
+		if expected == "" {
+			if err == nil {
+				t.Fatalf("needle %d version %d: unexpected %s", id, version, n.Data)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("needle %d version %d: %v", id, version, err)
+		}
+		if !bytes.Equal(n.Data, []byte(expected)) {
+			t.Fatalf("needle %d version %d: %s, expected %s", id, version, n.Data, expected)
+		}
+	}
+	assertVersions := func() {
+		assertVersion(1, 0, "")
+		assertVersion(1, 1, "content 4")
+		assertVersion(1, 2, "content 3")
+		assertVersion(1, 3, "")
+		assertVersion(2, 0, "other 2")
+		assertVersion(2, 1, "other 1")
+	}
+
+	for i := 1; i <= 4; i++ {
+		write(1, fmt.Sprintf("content %d", i))
+	}
+	assertVersion(1, 0, "content 4")
+	assertVersion(1, 1, "content 3")
+	assertVersion(1, 2, "content 2")
+	assertVersion(1, 3, "")
+
+	// the deleted needle can be read from the previous versions
+	if _, err = v.deleteNeedle2(newEmptyNeedle(1)); err != nil {
+		t.Fatalf("delete needle: %v", err)
+	}
+	write(2, "other 1")
+	write(2, "other 2")
+	assertVersions()
+
+	versions, err := v.NeedleVersions(1)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(versions) != 3 || versions[0] != nil || versions[1].Size == 0 || versions[1].AppendAtNs <= versions[2].AppendAtNs {
+		t.Fatalf("unexpected versions %+v", versions)
+	}
+
+	// vacuum keeps the versions
+	if err = v.Compact(0, 0); err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+	write(3, "third 1")
+	write(3, "third 2")
+	if err = v.CommitCompact(); err != nil {
+		t.Fatalf("commit compact: %v", err)
+	}
+	assertVersions()
+	assertVersion(3, 1, "third 1")
+
+	if err = v.Compact2(0, 0); err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+	if err = v.CommitCompact(); err != nil {
+		t.Fatalf("commit compact: %v", err)
+	}
+	assertVersions()
+	assertVersion(3, 1, "third 1")
+	v.Close()
+
+	// and so does reloading
+	v, err = NewVolume(dir, dir, "", 1, NeedleMapInMemory, nil, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("reload volume: %v", err)
+	}
+	assertVersions()
+	assertVersion(3, 0, "third 2")
+	assertVersion(3, 1, "third 1")
+	v.Close()
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/chrislusf/seaweedfs/weed/stats"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util/read_cache"
+)
+
+// SetReadCache caches the needles read from remote tier volumes and volumes on hard drives
+func (s *Store) SetReadCache(cache *read_cache.ReadCache) {
+	for _, location := range s.Locations {
+		location.readCache = cache
+	}
+}
+
+func (v *Volume) readCache() *read_cache.ReadCache {
+	if v.location == nil || v.location.readCache == nil {
+		return nil
+	}
+	if v.HasRemoteFile() || v.location.DiskType == HardDriveType {
+		return v.location.readCache
+	}
+	return nil
+}
+
+// readCacheKey identifies a cached needle. The cache entries of a volume are invalid after vacuum,
+// so the compaction revision is part of the key. In place compaction moves needles to lower offsets
+// within the same revision, so the needle id is part of the key too.
+func (v *Volume) readCacheKey(id NeedleId, offset int64, size Size) string {
+	return fmt.Sprintf("%d_%s_%d_%d", v.SuperBlock.CompactionRevision, id, offset, size)
+}
+
+// readNeedleData reads the needle at the offset, through the read cache if the volume is cached.
+func (v *Volume) readNeedleData(n *needle.Needle, offset int64, size Size) error {
+	cache := v.readCache()
+	if cache == nil {
+		return n.ReadData(v.DataBackend, offset, size, v.Version())
+	}
+
+	id := n.Id
+	group := v.Id.String()
+	key := v.readCacheKey(id, offset, size)
+	if bytes, found := cache.Get(group, key); found {
+		if err := n.ReadBytes(bytes, offset, size, v.Version()); err == nil && n.Id == id {
+			stats.VolumeServerReadCacheCounter.WithLabelValues(v.Collection, group, "hit").Inc()
+			return nil
+		}
+		cache.Delete(group, key)
+	}
+	stats.VolumeServerReadCacheCounter.WithLabelValues(v.Collection, group, "miss").Inc()
+
+	bytes, err := needle.ReadNeedleBlob(v.DataBackend, offset, size, v.Version())
+	if err != nil {
+		return err
+	}
+	if err = n.ReadBytes(bytes, offset, size, v.Version()); err != nil {
+		return err
+	}
+	if n.Id == id {
+		cache.Set(group, key, bytes)
+	}
+	return nil
+}
+
+// dropCachedNeedle removes the cached needle at the offset, after another needle is moved there
+func (v *Volume) dropCachedNeedle(id NeedleId, offset int64, size Size) {
+	if cache := v.readCache(); cache != nil {
+		cache.Delete(v.Id.String(), v.readCacheKey(id, offset, size))
+	}
+}
+
+// dropReadCache removes the cached needles and the hit ratio metrics of the volume
+func (v *Volume) dropReadCache() {
+	if v.location == nil || v.location.readCache == nil {
+		return
+	}
+	v.location.readCache.DeleteGroup(v.Id.String())
+	stats.VolumeServerReadCacheCounter.DeleteLabelValues(v.Collection, v.Id.String(), "hit")
+	stats.VolumeServerReadCacheCounter.DeleteLabelValues(v.Collection, v.Id.String(), "miss")
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	readCounterBucketSeconds = 600
+	readCounterBucketCount   = 6
+)
+
+// readCounter counts the reads of a volume during the recent hour, in buckets of 10 minutes.
+// It is reported to the master, to decide which volumes are hot or cold.
+type readCounter struct {
+	sync.Mutex
+	buckets     [readCounterBucketCount]uint64
+	lastBucket  int64 // unix time in seconds divided by readCounterBucketSeconds
+	startedAtNs int64
+}
+
+func (c *readCounter) add(now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	c.advance(now)
+	c.buckets[c.lastBucket%readCounterBucketCount]++
+}
+
+// recentReads returns the number of reads, and the length of the period they are counted,
+// which is shorter than one hour if the volume is loaded recently.
+func (c *readCounter) recentReads(now time.Time) (count uint64, seconds uint32) {
+	c.Lock()
+	defer c.Unlock()
+	c.advance(now)
+	for _, n := range c.buckets {
+		count += n
+	}
+	elapsed := (now.UnixNano() - c.startedAtNs) / int64(time.Second)
+	if window := (readCounterBucketCount-1)*readCounterBucketSeconds + now.Unix()%readCounterBucketSeconds; elapsed > window {
+		elapsed = window
+	}
+	return count, uint32(elapsed)
+}
+
+func (c *readCounter) advance(now time.Time) {
+	bucket := now.Unix() / readCounterBucketSeconds
+	if c.startedAtNs == 0 {
+		c.startedAtNs = now.UnixNano()
+		c.lastBucket = bucket
+		return
+	}
+	for c.lastBucket < bucket {
+		c.lastBucket++
+		c.buckets[c.lastBucket%readCounterBucketCount] = 0
+		if bucket-c.lastBucket >= readCounterBucketCount {
+			c.buckets = [readCounterBucketCount]uint64{}
+			c.lastBucket = bucket
+		}
+	}
+}
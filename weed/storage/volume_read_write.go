@@ -68,6 +68,7 @@ func (v *Volume) Destroy() (err error) {
 		}
 	}
 	v.Close()
+	v.dropReadCache()
 	removeVolumeFiles(v.DataFileName())
 	removeVolumeFiles(v.IndexFileName())
 	return
@@ -78,6 +79,7 @@ func removeVolumeFiles(filename string) {
 	os.Remove(filename + ".dat")
 	os.Remove(filename + ".idx")
 	os.Remove(filename + ".vif")
+	os.Remove(filename + ".zdict")
 	// sorted index file
 	os.Remove(filename + ".sdx")
 	// compaction
@@ -85,6 +87,11 @@ func removeVolumeFiles(filename string) {
 	os.Remove(filename + ".cpx")
 	// level db indx file
 	os.RemoveAll(filename + ".ldb")
+	// needle versions
+	os.Remove(filename + ".vdx")
+	os.Remove(filename + ".cpv")
+	// sorted index needle map file
+	os.Remove(filename + ".smx")
 	// marker for damaged or incomplete volume
 	os.Remove(filename + ".note")
 }
@@ -137,6 +144,9 @@ func (v *Volume) syncWrite(n *needle.Needle) (offset uint64, size Size, isUnchan
 
 	// add to needle map
 	if !ok || uint64(nv.Offset.ToActualOffset()) < offset {
+		if ok {
+			v.keepNeedleVersion(nv)
+		}
 		if err = v.nm.Put(n.Id, ToOffset(int64(offset)), n.Size); err != nil {
 			glog.V(4).Infof("failed to save in needle map %d: %v", n.Id, err)
 		}
@@ -202,6 +212,9 @@ func (v *Volume) doWriteRequest(n *needle.Needle) (offset uint64, size Size, isU
 
 	// add to needle map
 	if !ok || uint64(nv.Offset.ToActualOffset()) < offset {
+		if ok {
+			v.keepNeedleVersion(nv)
+		}
 		if err = v.nm.Put(n.Id, ToOffset(int64(offset)), n.Size); err != nil {
 			glog.V(4).Infof("failed to save in needle map %d: %v", n.Id, err)
 		}
@@ -235,6 +248,7 @@ func (v *Volume) syncDelete(n *needle.Needle) (Size, error) {
 			return size, err
 		}
 		v.lastAppendAtNs = n.AppendAtNs
+		v.keepNeedleVersion(nv)
 		if err = v.nm.Delete(n.Id, ToOffset(int64(offset))); err != nil {
 			return size, err
 		}
@@ -274,6 +288,7 @@ func (v *Volume) doDeleteRequest(n *needle.Needle) (Size, error) {
 			return size, err
 		}
 		v.lastAppendAtNs = n.AppendAtNs
+		v.keepNeedleVersion(nv)
 		if err = v.nm.Delete(n.Id, ToOffset(int64(offset))); err != nil {
 			return size, err
 		}
@@ -288,6 +303,13 @@ func (v *Volume) readNeedle(n *needle.Needle, readOption *ReadOption) (int, erro
 	defer v.dataFileAccessLock.RUnlock()
 
 	nv, ok := v.nm.Get(n.Id)
+	if readOption != nil && readOption.Version > 0 {
+		if v.versions == nil {
+			return -1, ErrorNotFound
+		}
+		version, found := v.versions.get(n.Id, readOption.Version)
+		nv, ok = &version, found
+	}
 	if !ok || nv.Offset.IsZero() {
 		return -1, ErrorNotFound
 	}
@@ -303,9 +325,9 @@ func (v *Volume) readNeedle(n *needle.Needle, readOption *ReadOption) (int, erro
 	if readSize == 0 {
 		return 0, nil
 	}
-	err := n.ReadData(v.DataBackend, nv.Offset.ToActualOffset(), readSize, v.Version())
+	err := v.readNeedleData(n, nv.Offset.ToActualOffset(), readSize)
 	if err == needle.ErrorSizeMismatch && OffsetSize == 4 {
-		err = n.ReadData(v.DataBackend, nv.Offset.ToActualOffset()+int64(MaxPossibleVolumeSize), readSize, v.Version())
+		err = v.readNeedleData(n, nv.Offset.ToActualOffset()+int64(MaxPossibleVolumeSize), readSize)
 	}
 	v.checkReadWriteError(err)
 	if err != nil {
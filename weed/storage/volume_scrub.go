@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+)
+
+// ScrubbedNeedle is the result of verifying one index entry against the data file
+type ScrubbedNeedle struct {
+	Key    NeedleId
+	Offset Offset
+	Size   Size
+	Err    error // not nil if the needle is corrupted
+}
+
+// IndexEntryCount is the number of entries in the .idx file, including the overwritten and deleted ones
+func (v *Volume) IndexEntryCount() int64 {
+	v.dataFileAccessLock.RLock()
+	defer v.dataFileAccessLock.RUnlock()
+	if v.nm == nil {
+		return 0
+	}
+	return int64(v.nm.IndexFileSize()) / NeedleMapEntrySize
+}
+
+// ScrubIndexEntry verifies the needle of the n-th index entry: the data file has the needle
+// with the same id and size at the offset, and the CRC matches the data.
+// Entries for deleted needles, or overwritten by later entries, are skipped with a nil result.
+func (v *Volume) ScrubIndexEntry(n int64) (*ScrubbedNeedle, error) {
+	v.dataFileAccessLock.RLock()
+	defer v.dataFileAccessLock.RUnlock()
+
+	if v.nm == nil || v.DataBackend == nil {
+		return nil, fmt.Errorf("volume %d is closed", v.Id)
+	}
+	key, offset, size, err := v.nm.ReadIndexEntry(n)
+	if err != nil {
+		return nil, fmt.Errorf("read index entry %d: %v", n, err)
+	}
+	if offset.IsZero() || size.IsDeleted() {
+		return nil, nil
+	}
+	nv, ok := v.nm.Get(key)
+	if !ok || nv.Offset != offset || nv.Size != size {
+		return nil, nil
+	}
+
+	result := &ScrubbedNeedle{Key: key, Offset: offset, Size: size}
+	result.Err = v.verifyNeedle(key, offset, size)
+	return result, nil
+}
+
+func (v *Volume) verifyNeedle(key NeedleId, offset Offset, size Size) error {
+	n := new(needle.Needle)
+	err := n.ReadData(v.DataBackend, offset.ToActualOffset(), size, v.Version())
+	if err == needle.ErrorSizeMismatch && OffsetSize == 4 {
+		err = n.ReadData(v.DataBackend, offset.ToActualOffset()+int64(MaxPossibleVolumeSize), size, v.Version())
+	}
+	if err != nil {
+		return err
+	}
+	if n.Id != key {
+		return fmt.Errorf("index has needle %s but data file has needle %s at offset %d", key, n.Id, offset.ToActualOffset())
+	}
+	return nil
+}
+
+// ReadNeedleBlob reads the current raw needle, after verifying it is not corrupted
+func (v *Volume) ReadNeedleBlob(key NeedleId) ([]byte, error) {
+	v.dataFileAccessLock.RLock()
+	defer v.dataFileAccessLock.RUnlock()
+
+	if v.nm == nil || v.DataBackend == nil {
+		return nil, fmt.Errorf("volume %d is closed", v.Id)
+	}
+	nv, ok := v.nm.Get(key)
+	if !ok || nv.Offset.IsZero() {
+		return nil, ErrorNotFound
+	}
+	if nv.Size.IsDeleted() {
+		return nil, ErrorDeleted
+	}
+	if err := v.verifyNeedle(key, nv.Offset, nv.Size); err != nil {
+		return nil, err
+	}
+	return needle.ReadNeedleBlob(v.DataBackend, nv.Offset.ToActualOffset(), nv.Size, v.Version())
+}
+
+// RepairNeedle replaces the corrupted needle with the needle blob read from a healthy replica.
+// The needle is appended as a new write, so the index and the data file stay consistent,
+// and the corrupted copy becomes garbage to be vacuumed. This works on read only volumes too.
+func (v *Volume) RepairNeedle(corrupted *ScrubbedNeedle, blob []byte) error {
+	if len(blob) < NeedleHeaderSize {
+		return fmt.Errorf("unexpected needle blob size %d", len(blob))
+	}
+	n := new(needle.Needle)
+	n.ParseNeedleHeader(blob)
+	if int64(len(blob)) != needle.GetActualSize(n.Size, v.Version()) {
+		return fmt.Errorf("unexpected needle blob size %d for needle size %d", len(blob), n.Size)
+	}
+	if err := n.ReadBytes(blob, 0, n.Size, v.Version()); err != nil {
+		return fmt.Errorf("parse needle blob: %v", err)
+	}
+	if n.Id != corrupted.Key {
+		return fmt.Errorf("needle blob has needle %s instead of %s", n.Id, corrupted.Key)
+	}
+
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+
+	if v.isCompacting {
+		return fmt.Errorf("volume %d is compacting", v.Id)
+	}
+	if v.nm == nil || v.DataBackend == nil {
+		return fmt.Errorf("volume %d is closed", v.Id)
+	}
+	if nv, ok := v.nm.Get(corrupted.Key); !ok || nv.Offset != corrupted.Offset || nv.Size != corrupted.Size {
+		// changed since scrubbed
+		return nil
+	}
+
+	n.AppendAtNs = uint64(time.Now().UnixNano())
+	offset, _, _, err := n.Append(v.DataBackend, v.Version())
+	v.checkReadWriteError(err)
+	if err != nil {
+		return fmt.Errorf("append repaired needle: %v", err)
+	}
+	v.lastAppendAtNs = n.AppendAtNs
+	if err = v.nm.Put(n.Id, ToOffset(int64(offset)), n.Size); err != nil {
+		return fmt.Errorf("index repaired needle: %v", err)
+	}
+	glog.V(0).Infof("volume %d repaired needle %s from offset %d to %d", v.Id, n.Id, corrupted.Offset.ToActualOffset(), offset)
+	return nil
+}
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/storage/erasure_coding"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/storage/super_block"
+	"github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+func TestScrubAndRepairNeedle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scrub")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// two replicas with the same needles
+	var replicas []*Volume
+	for _, name := range []string{"a", "b"} {
+		replicaDir := dir + "/" + name
+		os.Mkdir(replicaDir, 0755)
+		v, err := NewVolume(replicaDir, replicaDir, "", 1, NeedleMapInMemory, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, 0)
+		if err != nil {
+			t.Fatalf("volume creation: %v", err)
+		}
+		defer v.Close()
+		replicas = append(replicas, v)
+	}
+	data := make(map[uint64][]byte)
+	for i := uint64(1); i <= 20; i++ {
+		data[i] = make([]byte, 100+rand.Intn(1000))
+		rand.Read(data[i])
+		for _, v := range replicas {
+			n := newEmptyNeedle(i)
+			n.Data = data[i]
+			n.Checksum = needle.NewCRC(n.Data)
+			if _, _, _, err := v.writeNeedle2(n, false); err != nil {
+				t.Fatalf("write needle %d: %v", i, err)
+			}
+		}
+	}
+
+	// flip one byte of the data of needle 7
+	v := replicas[0]
+	nv, _ := v.nm.Get(types.Uint64ToNeedleId(7))
+	corruptAt := nv.Offset.ToActualOffset() + types.NeedleHeaderSize + 4 + 10
+	b := make([]byte, 1)
+	v.DataBackend.ReadAt(b, corruptAt)
+	b[0] ^= 0xff
+	v.DataBackend.WriteAt(b, corruptAt)
+
+	corrupted := scrubAllEntries(t, v)
+	if len(corrupted) != 1 || corrupted[0].Key != types.Uint64ToNeedleId(7) || corrupted[0].Err != needle.ErrorCRC {
+		t.Fatalf("unexpected corrupted needles: %+v", corrupted)
+	}
+
+	// the corrupted needle can not be used for repairing
+	if _, err := v.ReadNeedleBlob(types.Uint64ToNeedleId(7)); err != needle.ErrorCRC {
+		t.Fatalf("read corrupted needle blob: %v", err)
+	}
+
+	blob, err := replicas[1].ReadNeedleBlob(types.Uint64ToNeedleId(7))
+	if err != nil {
+		t.Fatalf("read needle blob from replica: %v", err)
+	}
+	if err = v.RepairNeedle(corrupted[0], blob); err != nil {
+		t.Fatalf("repair needle: %v", err)
+	}
+
+	if corrupted = scrubAllEntries(t, v); len(corrupted) != 0 {
+		t.Fatalf("still corrupted after repairing: %+v", corrupted)
+	}
+	for i, expected := range data {
+		n := newEmptyNeedle(i)
+		if _, err := v.readNeedle(n, nil); err != nil {
+			t.Fatalf("read needle %d: %v", i, err)
+		}
+		if !bytes.Equal(n.Data, expected) {
+			t.Fatalf("needle %d has unexpected data", i)
+		}
+	}
+}
+
+func TestScrubAndRepairEcParity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scrub_ec")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	v, err := NewVolume(dir, dir, "", 1, NeedleMapInMemory, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	for i := uint64(1); i <= 50; i++ {
+		n := newEmptyNeedle(i)
+		n.Data = make([]byte, 1000+rand.Intn(20000))
+		rand.Read(n.Data)
+		n.Checksum = needle.NewCRC(n.Data)
+		if _, _, _, err := v.writeNeedle2(n, false); err != nil {
+			t.Fatalf("write needle %d: %v", i, err)
+		}
+	}
+	v.Close()
+
+	// all shards are local
+	baseFileName := erasure_coding.EcShardFileName("", dir, 1)
+	if err = erasure_coding.WriteSortedFileFromIdx(baseFileName, ".ecx"); err != nil {
+		t.Fatalf("write ecx: %v", err)
+	}
+	if err = erasure_coding.WriteEcFiles(baseFileName, erasure_coding.DefaultEcScheme); err != nil {
+		t.Fatalf("write ec files: %v", err)
+	}
+	ev, err := erasure_coding.NewEcVolume(types.HardDriveType, dir, dir, "", 1)
+	if err != nil {
+		t.Fatalf("ec volume creation: %v", err)
+	}
+	defer ev.Close()
+	for shardId := 0; shardId < erasure_coding.DefaultEcScheme.TotalShards(); shardId++ {
+		shard, err := erasure_coding.NewEcVolumeShard(types.HardDriveType, dir, "", 1, erasure_coding.ShardId(shardId))
+		if err != nil {
+			t.Fatalf("ec shard creation: %v", err)
+		}
+		ev.AddEcVolumeShard(shard)
+	}
+
+	sc := &scrubber{store: &Store{}, throttler: util.NewWriteThrottler(0)}
+	defer func(stripeSize int64) {
+		ecScrubStripeSize = stripeSize
+	}(ecScrubStripeSize)
+	ecScrubStripeSize = 64 * 1024
+
+	// a parity shard and a data shard, corrupted in different stripes
+	for _, corruption := range []struct {
+		shardId int
+		offset  int64
+	}{{12, 100}, {3, 200 * 1024}} {
+		shardFileName := baseFileName + erasure_coding.ToExt(corruption.shardId)
+		original, err := ioutil.ReadFile(shardFileName)
+		if err != nil {
+			t.Fatalf("read shard: %v", err)
+		}
+		corrupted := append([]byte(nil), original...)
+		corrupted[corruption.offset] ^= 0xff
+		if err = ioutil.WriteFile(shardFileName, corrupted, 0644); err != nil {
+			t.Fatalf("corrupt shard: %v", err)
+		}
+
+		corruptCount, repairedCount := sc.corruptNeedleCount, sc.repairedNeedleCount
+		if err = sc.scrubEcParity(ev); err != nil {
+			t.Fatalf("scrub ec parity: %v", err)
+		}
+		if sc.corruptNeedleCount != corruptCount+1 || sc.repairedNeedleCount != repairedCount+1 {
+			t.Fatalf("shard %d: %d corrupted, %d repaired", corruption.shardId, sc.corruptNeedleCount-corruptCount, sc.repairedNeedleCount-repairedCount)
+		}
+		if repaired, err := ioutil.ReadFile(shardFileName); err != nil || !bytes.Equal(repaired, original) {
+			t.Fatalf("shard %d is not repaired: %v", corruption.shardId, err)
+		}
+	}
+
+	// nothing is corrupted any more
+	if err = sc.scrubEcParity(ev); err != nil || sc.corruptNeedleCount != 2 {
+		t.Fatalf("scrub ec parity: %d corrupted, %v", sc.corruptNeedleCount, err)
+	}
+}
+
+func scrubAllEntries(t *testing.T, v *Volume) (corrupted []*ScrubbedNeedle) {
+	for i := int64(0); i < v.IndexEntryCount(); i++ {
+		scrubbed, err := v.ScrubIndexEntry(i)
+		if err != nil {
+			t.Fatalf("scrub index entry %d: %v", i, err)
+		}
+		if scrubbed != nil && scrubbed.Err != nil {
+			corrupted = append(corrupted, scrubbed)
+		}
+	}
+	return
+}
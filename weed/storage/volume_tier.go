@@ -5,6 +5,9 @@ import (
 	"github.com/chrislusf/seaweedfs/weed/pb"
 	"github.com/chrislusf/seaweedfs/weed/pb/volume_server_pb"
 	"github.com/chrislusf/seaweedfs/weed/storage/backend"
+	_ "github.com/chrislusf/seaweedfs/weed/storage/backend/azure_backend"
+	_ "github.com/chrislusf/seaweedfs/weed/storage/backend/gcs_backend"
+	_ "github.com/chrislusf/seaweedfs/weed/storage/backend/local_backend"
 	_ "github.com/chrislusf/seaweedfs/weed/storage/backend/s3_backend"
 )
 
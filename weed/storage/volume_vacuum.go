@@ -51,6 +51,8 @@ func (v *Volume) Compact(preallocate int64, compactionBytePerSecond int64) error
 
 	v.lastCompactIndexOffset = v.IndexFileSize()
 	v.lastCompactRevision = v.SuperBlock.CompactionRevision
+	v.lastCompactKeyId = v.encryptor.currentKey()
+	versions := v.snapshotNeedleVersions()
 	glog.V(3).Infof("creating copies for volume %d ,last offset %d...", v.Id, v.lastCompactIndexOffset)
 	if err := v.DataBackend.Sync(); err != nil {
 		glog.V(0).Infof("compact fail to sync volume %d", v.Id)
@@ -58,7 +60,7 @@ func (v *Volume) Compact(preallocate int64, compactionBytePerSecond int64) error
 	if err := v.nm.Sync(); err != nil {
 		glog.V(0).Infof("compact fail to sync volume idx %d", v.Id)
 	}
-	return v.copyDataAndGenerateIndexFile(v.FileName(".cpd"), v.FileName(".cpx"), preallocate, compactionBytePerSecond)
+	return v.copyDataAndGenerateIndexFile(v.FileName(".cpd"), v.FileName(".cpx"), versions, preallocate, compactionBytePerSecond)
 }
 
 // compact a volume based on deletions in .idx files
@@ -76,6 +78,8 @@ func (v *Volume) Compact2(preallocate int64, compactionBytePerSecond int64) erro
 
 	v.lastCompactIndexOffset = v.IndexFileSize()
 	v.lastCompactRevision = v.SuperBlock.CompactionRevision
+	v.lastCompactKeyId = v.encryptor.currentKey()
+	versions := v.snapshotNeedleVersions()
 	glog.V(3).Infof("creating copies for volume %d ...", v.Id)
 	if err := v.DataBackend.Sync(); err != nil {
 		glog.V(0).Infof("compact2 fail to sync volume dat %d: %v", v.Id, err)
@@ -83,13 +87,20 @@ func (v *Volume) Compact2(preallocate int64, compactionBytePerSecond int64) erro
 	if err := v.nm.Sync(); err != nil {
 		glog.V(0).Infof("compact2 fail to sync volume idx %d: %v", v.Id, err)
 	}
-	return copyDataBasedOnIndexFile(v.FileName(".dat"), v.FileName(".idx"), v.FileName(".cpd"), v.FileName(".cpx"), v.SuperBlock, v.Version(), preallocate, compactionBytePerSecond)
+	copyVersions := func(srcDatBackend, dstDatBackend backend.BackendStorageFile, newOffset int64) (int64, error) {
+		return v.copyNeedleVersions(srcDatBackend, dstDatBackend, versions, v.FileName(".cpv"), newOffset, uint64(time.Now().Unix()))
+	}
+	return copyDataBasedOnIndexFile(v.FileName(".dat"), v.FileName(".idx"), v.FileName(".cpd"), v.FileName(".cpx"), v.SuperBlock, v.Version(), preallocate, compactionBytePerSecond, v.compressor, v.encryptor, copyVersions)
 }
 
 func (v *Volume) CommitCompact() error {
 	if v.MemoryMapMaxSizeMb != 0 { //it makes no sense to compact in memory
 		return nil
 	}
+	if v.isCompactedInPlace {
+		v.isCompactedInPlace = false
+		return nil
+	}
 	glog.V(0).Infof("Committing volume %d vacuuming...", v.Id)
 
 	v.isCompacting = true
@@ -101,6 +112,7 @@ func (v *Volume) CommitCompact() error {
 	defer v.dataFileAccessLock.Unlock()
 
 	glog.V(3).Infof("Got volume %d committing lock...", v.Id)
+	versionsErr := v.makeupNeedleVersionsDiff(v.FileName(".cpd"), v.FileName(".cpv"))
 	v.nm.Close()
 	if v.DataBackend != nil {
 		if err := v.DataBackend.Close(); err != nil {
@@ -109,10 +121,18 @@ func (v *Volume) CommitCompact() error {
 	}
 	v.DataBackend = nil
 	stats.VolumeServerVolumeCounter.WithLabelValues(v.Collection, "volume").Dec()
+	if v.location != nil {
+		// the cached needles of the previous compaction revision are no longer read
+		v.location.readCache.DeleteGroup(v.Id.String())
+	}
 
 	var e error
-	if e = v.makeupDiff(v.FileName(".cpd"), v.FileName(".cpx"), v.FileName(".dat"), v.FileName(".idx")); e != nil {
+	if e = versionsErr; e == nil {
+		e = v.makeupDiff(v.FileName(".cpd"), v.FileName(".cpx"), v.FileName(".dat"), v.FileName(".idx"))
+	}
+	if e != nil {
 		glog.V(0).Infof("makeupDiff in CommitCompact volume %d failed %v", v.Id, e)
+		os.Remove(v.FileName(".cpv"))
 		e = os.Remove(v.FileName(".cpd"))
 		if e != nil {
 			return e
@@ -139,25 +159,34 @@ func (v *Volume) CommitCompact() error {
 		if e = os.Rename(v.FileName(".cpx"), v.FileName(".idx")); e != nil {
 			return fmt.Errorf("rename %s: %v", v.FileName(".cpx"), e)
 		}
+		if e = v.commitNeedleVersions(); e != nil {
+			return e
+		}
 	}
 
 	//glog.V(3).Infof("Pretending to be vacuuming...")
 	//time.Sleep(20 * time.Second)
 
 	os.RemoveAll(v.FileName(".ldb"))
+	os.Remove(v.FileName(".smx"))
 
 	glog.V(3).Infof("Loading volume %d commit file...", v.Id)
 	if e = v.load(true, false, v.needleMapKind, 0); e != nil {
 		return e
 	}
-	return nil
+	return v.pruneEncryptionKeys()
 }
 
 func (v *Volume) cleanupCompact() error {
+	if v.isCompactedInPlace {
+		v.isCompactedInPlace = false
+		return nil
+	}
 	glog.V(0).Infof("Cleaning up volume %d vacuuming...", v.Id)
 
 	e1 := os.Remove(v.FileName(".cpd"))
 	e2 := os.Remove(v.FileName(".cpx"))
+	os.Remove(v.FileName(".cpv"))
 	if e1 != nil {
 		return e1
 	}
@@ -316,6 +345,7 @@ type VolumeFileScanner4Vacuum struct {
 	v              *Volume
 	dstBackend     backend.BackendStorageFile
 	nm             *needle_map.MemDb
+	versions       []needle_map.NeedleValue
 	newOffset      int64
 	now            uint64
 	writeThrottler *util.WriteThrottler
@@ -324,8 +354,11 @@ type VolumeFileScanner4Vacuum struct {
 func (scanner *VolumeFileScanner4Vacuum) VisitSuperBlock(superBlock super_block.SuperBlock) error {
 	scanner.version = superBlock.Version
 	superBlock.CompactionRevision++
-	_, err := scanner.dstBackend.WriteAt(superBlock.Bytes(), 0)
-	scanner.newOffset = int64(superBlock.BlockSize())
+	if _, err := scanner.dstBackend.WriteAt(superBlock.Bytes(), 0); err != nil {
+		return err
+	}
+	var err error
+	scanner.newOffset, err = scanner.v.copyNeedleVersions(scanner.v.DataBackend, scanner.dstBackend, scanner.versions, scanner.v.FileName(".cpv"), int64(superBlock.BlockSize()), scanner.now)
 	return err
 
 }
@@ -340,12 +373,16 @@ func (scanner *VolumeFileScanner4Vacuum) VisitNeedle(n *needle.Needle, offset in
 	nv, ok := scanner.v.nm.Get(n.Id)
 	glog.V(4).Infoln("needle expected offset ", offset, "ok", ok, "nv", nv)
 	if ok && nv.Offset.ToActualOffset() == offset && nv.Size > 0 && nv.Size.IsValid() {
-		if err := scanner.nm.Set(n.Id, ToOffset(scanner.newOffset), n.Size); err != nil {
-			return fmt.Errorf("cannot put needle: %s", err)
+		n, err := recodeNeedle(n, scanner.v.compressor, scanner.v.encryptor)
+		if err != nil {
+			return err
 		}
 		if _, _, _, err := n.Append(scanner.dstBackend, scanner.v.Version()); err != nil {
 			return fmt.Errorf("cannot append needle: %s", err)
 		}
+		if err := scanner.nm.Set(n.Id, ToOffset(scanner.newOffset), n.Size); err != nil {
+			return fmt.Errorf("cannot put needle: %s", err)
+		}
 		delta := n.DiskSize(scanner.version)
 		scanner.newOffset += delta
 		scanner.writeThrottler.MaybeSlowdown(delta)
@@ -354,7 +391,7 @@ func (scanner *VolumeFileScanner4Vacuum) VisitNeedle(n *needle.Needle, offset in
 	return nil
 }
 
-func (v *Volume) copyDataAndGenerateIndexFile(dstName, idxName string, preallocate int64, compactionBytePerSecond int64) (err error) {
+func (v *Volume) copyDataAndGenerateIndexFile(dstName, idxName string, versions []needle_map.NeedleValue, preallocate int64, compactionBytePerSecond int64) (err error) {
 	var (
 		dst backend.BackendStorageFile
 	)
@@ -370,19 +407,22 @@ func (v *Volume) copyDataAndGenerateIndexFile(dstName, idxName string, prealloca
 		v:              v,
 		now:            uint64(time.Now().Unix()),
 		nm:             nm,
+		versions:       versions,
 		dstBackend:     dst,
 		writeThrottler: util.NewWriteThrottler(compactionBytePerSecond),
 	}
 	err = ScanVolumeFile(v.dir, v.Collection, v.Id, v.needleMapKind, scanner)
 	if err != nil {
-		return nil
+		return err
 	}
 
 	err = nm.SaveToIdx(idxName)
 	return
 }
 
-func copyDataBasedOnIndexFile(srcDatName, srcIdxName, dstDatName, datIdxName string, sb super_block.SuperBlock, version needle.Version, preallocate int64, compactionBytePerSecond int64) (err error) {
+// copyVersions copies the kept needle versions before the live needles, and returns the new offset after them
+func copyDataBasedOnIndexFile(srcDatName, srcIdxName, dstDatName, datIdxName string, sb super_block.SuperBlock, version needle.Version, preallocate int64, compactionBytePerSecond int64, compressor *needleCompressor, encryptor *needleEncryptor,
+	copyVersions func(srcDatBackend, dstDatBackend backend.BackendStorageFile, newOffset int64) (int64, error)) (err error) {
 	var (
 		srcDatBackend, dstDatBackend backend.BackendStorageFile
 		dataFile                     *os.File
@@ -410,10 +450,13 @@ func copyDataBasedOnIndexFile(srcDatName, srcIdxName, dstDatName, datIdxName str
 	sb.CompactionRevision++
 	dstDatBackend.WriteAt(sb.Bytes(), 0)
 	newOffset := int64(sb.BlockSize())
+	if newOffset, err = copyVersions(srcDatBackend, dstDatBackend, newOffset); err != nil {
+		return err
+	}
 
 	writeThrottler := util.NewWriteThrottler(compactionBytePerSecond)
 
-	oldNm.AscendingVisit(func(value needle_map.NeedleValue) error {
+	err = oldNm.AscendingVisit(func(value needle_map.NeedleValue) error {
 
 		offset, size := value.Offset, value.Size
 
@@ -431,12 +474,15 @@ func copyDataBasedOnIndexFile(srcDatName, srcIdxName, dstDatName, datIdxName str
 			return nil
 		}
 
-		if err = newNm.Set(n.Id, ToOffset(newOffset), n.Size); err != nil {
-			return fmt.Errorf("cannot put needle: %s", err)
+		if n, err = recodeNeedle(n, compressor, encryptor); err != nil {
+			return err
 		}
 		if _, _, _, err = n.Append(dstDatBackend, sb.Version); err != nil {
 			return fmt.Errorf("cannot append needle: %s", err)
 		}
+		if err = newNm.Set(n.Id, ToOffset(newOffset), n.Size); err != nil {
+			return fmt.Errorf("cannot put needle: %s", err)
+		}
 		delta := n.DiskSize(version)
 		newOffset += delta
 		writeThrottler.MaybeSlowdown(delta)
@@ -444,6 +490,9 @@ func copyDataBasedOnIndexFile(srcDatName, srcIdxName, dstDatName, datIdxName str
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
 
 	newNm.SaveToIdx(datIdxName)
 
@@ -0,0 +1,434 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+	"github.com/chrislusf/seaweedfs/weed/stats"
+	"github.com/chrislusf/seaweedfs/weed/storage/backend"
+	"github.com/chrislusf/seaweedfs/weed/storage/idx"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/storage/needle_map"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util"
+)
+
+/*
+In place compaction vacuums a volume without a full copy of the live data, for nearly full disks.
+
+The live needles are moved towards the beginning of the .dat file, in the order of their offsets,
+and the file is truncated after the last one. The needles are moved in small batches, each holding
+the volume lock for a short time. Before a batch is written, it is saved to the .cpj journal file,
+so the only extra disk space needed is one batch. If the server crashes, the journal is replayed
+when the volume is loaded again.
+
+The needles are moved as is, so the compression and encryption of the existing needles are not changed.
+*/
+
+const inPlaceCompactionJournalHeaderSize = 29
+
+var inPlaceCompactionBatchSize = 4 * 1024 * 1024
+
+// inPlaceCompactionJournal is one batch of moved needles, or the final truncation if truncateAt is not 0
+type inPlaceCompactionJournal struct {
+	truncateAt int64
+	dstOffset  int64
+	data       []byte
+	entries    []needle_map.NeedleValue
+	tail       *needle_map.NeedleValue // the last index entry, appended again after the moved needles
+}
+
+// CompactInPlace moves the live needles over the deleted ones, and truncates the .dat file
+func (v *Volume) CompactInPlace(compactionBytePerSecond int64) error {
+
+	if v.MemoryMapMaxSizeMb != 0 { //it makes no sense to compact in memory
+		return nil
+	}
+	if _, isDiskFile := v.DataBackend.(*backend.DiskFile); !isDiskFile {
+		return fmt.Errorf("volume %d is not on local disk", v.Id)
+	}
+	glog.V(0).Infof("Compacting volume %d in place ...", v.Id)
+
+	v.isCompacting = true
+	defer func() {
+		v.isCompacting = false
+	}()
+	v.lastCompactKeyId = 0
+	v.isCompactedInPlace = true
+
+	// the offsets are changed from now on
+	v.dataFileAccessLock.Lock()
+	v.SuperBlock.CompactionRevision++
+	_, err := v.DataBackend.WriteAt(v.SuperBlock.Bytes(), 0)
+	if err == nil {
+		err = v.DataBackend.Sync()
+	}
+	v.dataFileAccessLock.Unlock()
+	if err != nil {
+		return fmt.Errorf("volume %d update compaction revision: %v", v.Id, err)
+	}
+	if v.location != nil {
+		v.location.readCache.DeleteGroup(v.Id.String())
+	}
+
+	writeThrottler := util.NewWriteThrottler(compactionBytePerSecond)
+	writeOffset := int64(v.SuperBlock.BlockSize())
+
+	// move the needles written before, with new writes still going on
+	entries, err := v.liveEntriesSortedByOffset(writeOffset)
+	if err != nil {
+		return err
+	}
+	for len(entries) > 0 {
+		v.dataFileAccessLock.Lock()
+		var moved int64
+		writeOffset, entries, moved, err = v.moveNeedles(writeOffset, entries)
+		v.dataFileAccessLock.Unlock()
+		if err != nil {
+			return err
+		}
+		writeThrottler.MaybeSlowdown(moved)
+	}
+
+	// move the needles written meanwhile, and truncate
+	v.dataFileAccessLock.Lock()
+	defer v.dataFileAccessLock.Unlock()
+
+	if entries, err = v.liveEntriesSortedByOffset(writeOffset); err != nil {
+		return err
+	}
+	for len(entries) > 0 {
+		if writeOffset, entries, _, err = v.moveNeedles(writeOffset, entries); err != nil {
+			return err
+		}
+	}
+
+	journal := &inPlaceCompactionJournal{truncateAt: writeOffset}
+	if err = v.saveInPlaceCompactionJournal(journal); err != nil {
+		return err
+	}
+	v.nm.Close()
+	if err = v.DataBackend.Close(); err != nil {
+		glog.V(0).Infof("fail to close volume %d", v.Id)
+	}
+	v.DataBackend = nil
+	stats.VolumeServerVolumeCounter.WithLabelValues(v.Collection, "volume").Dec()
+	if err = v.applyInPlaceCompactionJournal(journal); err != nil {
+		return err
+	}
+	os.RemoveAll(v.FileName(".ldb"))
+	os.Remove(v.FileName(".smx"))
+
+	glog.V(0).Infof("Loading volume %d compacted in place ...", v.Id)
+	return v.load(true, false, v.needleMapKind, 0)
+}
+
+// liveEntriesSortedByOffset lists the live needles at or after the offset, from the .idx file
+func (v *Volume) liveEntriesSortedByOffset(fromOffset int64) ([]needle_map.NeedleValue, error) {
+	entries, err := readLiveEntriesSortedByOffset(v.FileName(".idx"), fromOffset)
+	if err != nil {
+		return nil, fmt.Errorf("volume %d load index: %v", v.Id, err)
+	}
+	return entries, nil
+}
+
+func readLiveEntriesSortedByOffset(idxFileName string, fromOffset int64) (entries []needle_map.NeedleValue, err error) {
+	nm := needle_map.NewMemDb()
+	defer nm.Close()
+	if err = nm.LoadFromIdx(idxFileName); err != nil {
+		return nil, err
+	}
+	err = nm.AscendingVisit(func(value needle_map.NeedleValue) error {
+		if value.Offset.ToActualOffset() >= fromOffset {
+			entries = append(entries, value)
+		}
+		return nil
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Offset.ToActualOffset() < entries[j].Offset.ToActualOffset()
+	})
+	return entries, err
+}
+
+// moveNeedles moves one batch of the needles to the write offset, and returns the remaining ones.
+// The caller holds the volume lock.
+func (v *Volume) moveNeedles(writeOffset int64, entries []needle_map.NeedleValue) (newWriteOffset int64, remaining []needle_map.NeedleValue, moved int64, err error) {
+	journal, position, entries, err := v.collectNeedlesToMove(writeOffset, entries)
+	if err != nil {
+		return writeOffset, nil, 0, err
+	}
+	if len(journal.entries) == 0 {
+		return position, entries, 0, nil
+	}
+
+	if err = v.saveInPlaceCompactionJournal(journal); err != nil {
+		return writeOffset, nil, 0, err
+	}
+	if _, err = v.DataBackend.WriteAt(journal.data, journal.dstOffset); err != nil {
+		return writeOffset, nil, 0, fmt.Errorf("volume %d write moved needles: %v", v.Id, err)
+	}
+	if err = v.DataBackend.Sync(); err != nil {
+		return writeOffset, nil, 0, fmt.Errorf("volume %d sync moved needles: %v", v.Id, err)
+	}
+	for _, entry := range journal.entries {
+		if err = v.nm.Put(entry.Key, entry.Offset, entry.Size); err != nil {
+			return writeOffset, nil, 0, fmt.Errorf("volume %d index moved needle %s: %v", v.Id, entry.Key, err)
+		}
+		// an older version of the same needle may have been cached at the new offset
+		v.dropCachedNeedle(entry.Key, entry.Offset.ToActualOffset(), entry.Size)
+	}
+	if tail := journal.tail; tail != nil {
+		if tail.Size.IsDeleted() {
+			// the needle is deleted already, and some needle maps skip deleting it again
+			err = v.appendIndexEntry(tail.Key, tail.Offset, TombstoneFileSize)
+		} else {
+			err = v.nm.Put(tail.Key, tail.Offset, tail.Size)
+		}
+		if err != nil {
+			return writeOffset, nil, 0, fmt.Errorf("volume %d index last needle %s: %v", v.Id, tail.Key, err)
+		}
+	}
+	if err = v.nm.Sync(); err != nil {
+		return writeOffset, nil, 0, fmt.Errorf("volume %d sync index: %v", v.Id, err)
+	}
+	if err = os.Remove(v.FileName(".cpj")); err != nil {
+		return writeOffset, nil, 0, fmt.Errorf("volume %d remove compaction journal: %v", v.Id, err)
+	}
+	return position, entries, int64(len(journal.data)), nil
+}
+
+// appendIndexEntry appends the entry to the .idx file, without changing the needle map
+func (v *Volume) appendIndexEntry(key NeedleId, offset Offset, size Size) error {
+	appender, ok := v.nm.(interface {
+		appendToIndexFile(key NeedleId, offset Offset, size Size) error
+	})
+	if !ok {
+		return fmt.Errorf("volume %d needle map can not append index entries", v.Id)
+	}
+	return appender.appendToIndexFile(key, offset, size)
+}
+
+// collectNeedlesToMove reads the next batch of live needles, skipping the ones already in place
+func (v *Volume) collectNeedlesToMove(writeOffset int64, entries []needle_map.NeedleValue) (journal *inPlaceCompactionJournal, position int64, remaining []needle_map.NeedleValue, err error) {
+	journal = &inPlaceCompactionJournal{dstOffset: writeOffset}
+	position = writeOffset
+	for len(entries) > 0 && len(journal.data) < inPlaceCompactionBatchSize {
+		entry := entries[0]
+		entries = entries[1:]
+		if nv, ok := v.nm.Get(entry.Key); !ok || nv.Offset != entry.Offset || nv.Size != entry.Size {
+			// deleted or overwritten since listed
+			continue
+		}
+		actualSize := needle.GetActualSize(entry.Size, v.Version())
+		if entry.Offset.ToActualOffset() == position {
+			if len(journal.data) > 0 {
+				// keep the batch contiguous
+				entries = append([]needle_map.NeedleValue{entry}, entries...)
+				break
+			}
+			position += actualSize
+			journal.dstOffset = position
+			continue
+		}
+		blob, readErr := needle.ReadNeedleBlob(v.DataBackend, entry.Offset.ToActualOffset(), entry.Size, v.Version())
+		if readErr != nil {
+			return nil, 0, nil, fmt.Errorf("volume %d read needle %s: %v", v.Id, entry.Key, readErr)
+		}
+		journal.data = append(journal.data, blob...)
+		journal.entries = append(journal.entries, needle_map.NeedleValue{Key: entry.Key, Offset: ToOffset(position), Size: entry.Size})
+		position += actualSize
+	}
+	if len(journal.entries) == 0 {
+		return journal, position, entries, nil
+	}
+
+	// When loading the volume, the .dat file is truncated after the needle of the last index entry.
+	// So the last index entry is appended again after the moved ones, unless it is moved itself,
+	// in which case nothing after it is needed.
+	if indexEntryCount := int64(v.nm.IndexFileSize()) / NeedleMapEntrySize; indexEntryCount > 0 {
+		key, offset, size, readErr := v.nm.ReadIndexEntry(indexEntryCount - 1)
+		if readErr != nil {
+			return nil, 0, nil, fmt.Errorf("volume %d read last index entry: %v", v.Id, readErr)
+		}
+		isMoved := false
+		for _, entry := range journal.entries {
+			if nv, ok := v.nm.Get(entry.Key); ok && entry.Key == key && nv.Offset == offset {
+				isMoved = true
+			}
+		}
+		if !isMoved {
+			journal.tail = &needle_map.NeedleValue{Key: key, Offset: offset, Size: size}
+		}
+	}
+	return journal, position, entries, nil
+}
+
+func (v *Volume) saveInPlaceCompactionJournal(journal *inPlaceCompactionJournal) error {
+	entries := journal.entries
+	if journal.tail != nil {
+		entries = append(entries[:len(entries):len(entries)], *journal.tail)
+	}
+	buf := make([]byte, inPlaceCompactionJournalHeaderSize, inPlaceCompactionJournalHeaderSize+len(journal.data)+len(entries)*NeedleMapEntrySize+4)
+	binary.BigEndian.PutUint64(buf[0:8], uint64(journal.truncateAt))
+	binary.BigEndian.PutUint64(buf[8:16], uint64(journal.dstOffset))
+	binary.BigEndian.PutUint64(buf[16:24], uint64(len(journal.data)))
+	binary.BigEndian.PutUint32(buf[24:28], uint32(len(journal.entries)))
+	if journal.tail != nil {
+		buf[28] = 1
+	}
+	buf = append(buf, journal.data...)
+	for _, entry := range entries {
+		buf = append(buf, entry.ToBytes()...)
+	}
+	crc := make([]byte, 4)
+	binary.BigEndian.PutUint32(crc, crc32.ChecksumIEEE(buf))
+	buf = append(buf, crc...)
+
+	f, err := os.OpenFile(v.FileName(".cpj"), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("volume %d create compaction journal: %v", v.Id, err)
+	}
+	defer f.Close()
+	if _, err = f.Write(buf); err != nil {
+		return fmt.Errorf("volume %d write compaction journal: %v", v.Id, err)
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("volume %d sync compaction journal: %v", v.Id, err)
+	}
+	return nil
+}
+
+// loadInPlaceCompactionJournal returns nil if the journal was not completely written, since nothing was changed then
+func loadInPlaceCompactionJournal(journalFileName string) (*inPlaceCompactionJournal, error) {
+	buf, err := ioutil.ReadFile(journalFileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(buf) < inPlaceCompactionJournalHeaderSize+4 {
+		return nil, nil
+	}
+	if crc32.ChecksumIEEE(buf[:len(buf)-4]) != binary.BigEndian.Uint32(buf[len(buf)-4:]) {
+		return nil, nil
+	}
+	journal := &inPlaceCompactionJournal{
+		truncateAt: int64(binary.BigEndian.Uint64(buf[0:8])),
+		dstOffset:  int64(binary.BigEndian.Uint64(buf[8:16])),
+	}
+	dataSize := int64(binary.BigEndian.Uint64(buf[16:24]))
+	entryCount := int64(binary.BigEndian.Uint32(buf[24:28]))
+	if buf[28] == 1 {
+		entryCount++
+	}
+	if inPlaceCompactionJournalHeaderSize+dataSize+entryCount*NeedleMapEntrySize+4 != int64(len(buf)) {
+		return nil, fmt.Errorf("compaction journal %s has unexpected size %d", journalFileName, len(buf))
+	}
+	journal.data = buf[inPlaceCompactionJournalHeaderSize : inPlaceCompactionJournalHeaderSize+dataSize]
+	for entries := buf[inPlaceCompactionJournalHeaderSize+dataSize : len(buf)-4]; len(entries) > 0; entries = entries[NeedleMapEntrySize:] {
+		key, offset, size := idx.IdxFileEntry(entries[:NeedleMapEntrySize])
+		journal.entries = append(journal.entries, needle_map.NeedleValue{Key: key, Offset: offset, Size: size})
+	}
+	if buf[28] == 1 {
+		journal.tail = &journal.entries[len(journal.entries)-1]
+		journal.entries = journal.entries[:len(journal.entries)-1]
+	}
+	return journal, nil
+}
+
+// applyInPlaceCompactionJournal writes the batch again, or finishes the truncation, on the closed volume files.
+// It can be repeated, in case the server crashes again.
+func (v *Volume) applyInPlaceCompactionJournal(journal *inPlaceCompactionJournal) error {
+	datFile, err := os.OpenFile(v.FileName(".dat"), os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %v", v.FileName(".dat"), err)
+	}
+	defer datFile.Close()
+
+	if len(journal.data) > 0 {
+		if _, err = datFile.WriteAt(journal.data, journal.dstOffset); err != nil {
+			return fmt.Errorf("write %s: %v", datFile.Name(), err)
+		}
+		if err = datFile.Sync(); err != nil {
+			return fmt.Errorf("sync %s: %v", datFile.Name(), err)
+		}
+	}
+
+	if len(journal.entries) > 0 {
+		var buf bytes.Buffer
+		for _, entry := range journal.entries {
+			buf.Write(entry.ToBytes())
+		}
+		if journal.tail != nil {
+			buf.Write(journal.tail.ToBytes())
+		}
+		if err = appendToFile(v.FileName(".idx"), buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	if journal.truncateAt > 0 {
+		// the new index has only the live needles, all before the truncation offset,
+		// and in the order of their offsets, so the last one is at the end of the .dat file
+		entries, err := readLiveEntriesSortedByOffset(v.FileName(".idx"), 0)
+		if err != nil {
+			return fmt.Errorf("read %s: %v", v.FileName(".idx"), err)
+		}
+		var buf bytes.Buffer
+		for _, entry := range entries {
+			buf.Write(entry.ToBytes())
+		}
+		if err = ioutil.WriteFile(v.FileName(".cpx"), buf.Bytes(), 0644); err != nil {
+			return fmt.Errorf("write %s: %v", v.FileName(".cpx"), err)
+		}
+		if err = os.Rename(v.FileName(".cpx"), v.FileName(".idx")); err != nil {
+			return fmt.Errorf("rename %s: %v", v.FileName(".cpx"), err)
+		}
+		if err = datFile.Truncate(journal.truncateAt); err != nil {
+			return fmt.Errorf("truncate %s: %v", datFile.Name(), err)
+		}
+		if err = datFile.Sync(); err != nil {
+			return fmt.Errorf("sync %s: %v", datFile.Name(), err)
+		}
+	}
+
+	return os.Remove(v.FileName(".cpj"))
+}
+
+func appendToFile(fileName string, data []byte) error {
+	f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("open %s: %v", fileName, err)
+	}
+	defer f.Close()
+	if _, err = f.Write(data); err != nil {
+		return fmt.Errorf("append %s: %v", fileName, err)
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("sync %s: %v", fileName, err)
+	}
+	return nil
+}
+
+// recoverInPlaceCompaction replays the journal left by a crash during in place compaction
+func (v *Volume) recoverInPlaceCompaction() error {
+	journal, err := loadInPlaceCompactionJournal(v.FileName(".cpj"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if journal == nil {
+		glog.V(0).Infof("volume %d discards incomplete compaction journal", v.Id)
+		return os.Remove(v.FileName(".cpj"))
+	}
+	glog.V(0).Infof("volume %d recovers in place compaction", v.Id)
+	os.RemoveAll(v.FileName(".ldb"))
+	os.Remove(v.FileName(".smx"))
+	return v.applyInPlaceCompactionJournal(journal)
+}
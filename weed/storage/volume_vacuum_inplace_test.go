@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chrislusf/seaweedfs/weed/storage/needle"
+	"github.com/chrislusf/seaweedfs/weed/storage/super_block"
+	. "github.com/chrislusf/seaweedfs/weed/storage/types"
+	"github.com/chrislusf/seaweedfs/weed/util/read_cache"
+)
+
+func TestCompactInPlace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "inplace")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	defer setInPlaceCompactionBatchSize(16 * 1024)()
+
+	v, data := newVolumeWithDeletions(t, dir)
+	sizeBefore, _, _ := v.FileStat()
+
+	if err = v.CompactInPlace(0); err != nil {
+		t.Fatalf("compact in place: %v", err)
+	}
+	if err = v.CommitCompact(); err != nil {
+		t.Fatalf("commit compact: %v", err)
+	}
+	assertVolumeNeedles(t, v, data)
+
+	sizeAfter, _, _ := v.FileStat()
+	if sizeAfter >= sizeBefore {
+		t.Fatalf("size %d not reduced from %d", sizeAfter, sizeBefore)
+	}
+	if v.garbageLevel() != 0 || v.DeletedCount() != 0 || v.FileCount() != uint64(len(data)) {
+		t.Fatalf("garbage level %f, %d files, %d deleted", v.garbageLevel(), v.FileCount(), v.DeletedCount())
+	}
+	if v.SuperBlock.CompactionRevision != 1 {
+		t.Fatalf("compaction revision %d", v.SuperBlock.CompactionRevision)
+	}
+
+	// the volume still takes writes
+	writeTestNeedle(t, v, data, 1000)
+	assertVolumeNeedles(t, v, data)
+
+	v.Close()
+}
+
+func TestCompactInPlaceCrashRecovery(t *testing.T) {
+	dir, err := ioutil.TempDir("", "inplace")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	defer setInPlaceCompactionBatchSize(16 * 1024)()
+
+	v, data := newVolumeWithDeletions(t, dir)
+
+	// crash after the journal is saved, while the batch is partially written
+	entries, err := v.liveEntriesSortedByOffset(int64(v.SuperBlock.BlockSize()))
+	if err != nil {
+		t.Fatalf("list entries: %v", err)
+	}
+	journal, _, _, err := v.collectNeedlesToMove(int64(v.SuperBlock.BlockSize()), entries)
+	if err != nil {
+		t.Fatalf("collect needles: %v", err)
+	}
+	if len(journal.entries) == 0 || journal.tail == nil {
+		t.Fatalf("unexpected batch of %d needles", len(journal.entries))
+	}
+	if err = v.saveInPlaceCompactionJournal(journal); err != nil {
+		t.Fatalf("save journal: %v", err)
+	}
+	if _, err = v.DataBackend.WriteAt(make([]byte, len(journal.data)/2), journal.dstOffset); err != nil {
+		t.Fatalf("partial write: %v", err)
+	}
+	v.Close()
+
+	v, err = NewVolume(dir, dir, "", 1, NeedleMapInMemory, nil, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("reload volume: %v", err)
+	}
+	if _, err = os.Stat(v.FileName(".cpj")); !os.IsNotExist(err) {
+		t.Fatalf("journal not removed: %v", err)
+	}
+	assertVolumeNeedles(t, v, data)
+
+	// an incomplete journal is discarded
+	if err = ioutil.WriteFile(v.FileName(".cpj"), []byte("incomplete journal"), 0644); err != nil {
+		t.Fatalf("write journal: %v", err)
+	}
+	v.Close()
+	v, err = NewVolume(dir, dir, "", 1, NeedleMapInMemory, nil, nil, 0, 0)
+	if err != nil {
+		t.Fatalf("reload volume: %v", err)
+	}
+	assertVolumeNeedles(t, v, data)
+
+	// and the compaction can finish later
+	if err = v.CompactInPlace(0); err != nil {
+		t.Fatalf("compact in place: %v", err)
+	}
+	assertVolumeNeedles(t, v, data)
+	if v.DeletedCount() != 0 || v.FileCount() != uint64(len(data)) {
+		t.Fatalf("%d files, %d deleted", v.FileCount(), v.DeletedCount())
+	}
+
+	v.Close()
+}
+
+func TestCompactInPlaceReadCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "inplace")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	defer setInPlaceCompactionBatchSize(1024)()
+
+	// needles of the same size are moved to each other's offsets
+	v, err := NewVolume(dir, dir, "", 1, NeedleMapInMemory, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	data := make(map[uint64][]byte)
+	for i := uint64(1); i <= 100; i++ {
+		data[i] = bytes.Repeat([]byte(fmt.Sprintf("file %03d,", i)), 100)
+		n := newEmptyNeedle(i)
+		n.Data = data[i]
+		n.Checksum = needle.NewCRC(n.Data)
+		if _, _, _, err = v.writeNeedle2(n, false); err != nil {
+			t.Fatalf("write needle %d: %v", i, err)
+		}
+	}
+	for i := uint64(1); i <= 100; i += 3 {
+		if _, err = v.deleteNeedle2(newEmptyNeedle(i)); err != nil {
+			t.Fatalf("delete needle %d: %v", i, err)
+		}
+		delete(data, i)
+	}
+	cache, err := read_cache.NewReadCache(filepath.Join(dir, "cache"), 16*1024*1024)
+	if err != nil {
+		t.Fatalf("read cache creation: %v", err)
+	}
+	v.location = &DiskLocation{DiskType: HardDriveType, readCache: cache}
+
+	// the needles read between the batches are cached, while others are moved to their offsets
+	writeOffset := int64(v.SuperBlock.BlockSize())
+	entries, err := v.liveEntriesSortedByOffset(writeOffset)
+	if err != nil {
+		t.Fatalf("list entries: %v", err)
+	}
+	for len(entries) > 0 {
+		assertVolumeNeedles(t, v, data)
+		if writeOffset, entries, _, err = v.moveNeedles(writeOffset, entries); err != nil {
+			t.Fatalf("move needles: %v", err)
+		}
+	}
+	assertVolumeNeedles(t, v, data)
+
+	v.Close()
+}
+
+func TestCompactInPlaceDeletedTail(t *testing.T) {
+	for _, kind := range []NeedleMapKind{NeedleMapInMemory, NeedleMapLevelDb, NeedleMapSortedIndex} {
+		dir, err := ioutil.TempDir("", "inplace")
+		if err != nil {
+			t.Fatalf("temp dir creation: %v", err)
+		}
+		defer os.RemoveAll(dir)
+		defer setInPlaceCompactionBatchSize(16 * 1024)()
+
+		// the last index entry is a deletion
+		v, data := newVolumeWithDeletionsOfKind(t, dir, kind)
+		if _, err = v.deleteNeedle2(newEmptyNeedle(99)); err != nil {
+			t.Fatalf("delete needle: %v", err)
+		}
+		delete(data, 99)
+
+		// stop after one batch, and load the volume again
+		writeOffset := int64(v.SuperBlock.BlockSize())
+		entries, err := v.liveEntriesSortedByOffset(writeOffset)
+		if err != nil {
+			t.Fatalf("list entries: %v", err)
+		}
+		if _, entries, _, err = v.moveNeedles(writeOffset, entries); err != nil || len(entries) == 0 {
+			t.Fatalf("move needles: %d remaining, %v", len(entries), err)
+		}
+		v.Close()
+		if v, err = NewVolume(dir, dir, "", 1, kind, nil, nil, 0, 0); err != nil {
+			t.Fatalf("reload volume: %v", err)
+		}
+		assertVolumeNeedles(t, v, data)
+		v.Close()
+	}
+}
+
+func newVolumeWithDeletions(t *testing.T, dir string) (*Volume, map[uint64][]byte) {
+	return newVolumeWithDeletionsOfKind(t, dir, NeedleMapInMemory)
+}
+
+func newVolumeWithDeletionsOfKind(t *testing.T, dir string, kind NeedleMapKind) (*Volume, map[uint64][]byte) {
+	v, err := NewVolume(dir, dir, "", 1, kind, &super_block.ReplicaPlacement{}, &needle.TTL{}, 0, 0)
+	if err != nil {
+		t.Fatalf("volume creation: %v", err)
+	}
+	data := make(map[uint64][]byte)
+	for i := uint64(1); i <= 100; i++ {
+		writeTestNeedle(t, v, data, i)
+	}
+	for i := uint64(1); i <= 100; i += 3 {
+		if _, err := v.deleteNeedle2(newEmptyNeedle(i)); err != nil {
+			t.Fatalf("delete needle %d: %v", i, err)
+		}
+		delete(data, i)
+	}
+	// overwritten needles
+	for i := uint64(2); i <= 100; i += 10 {
+		writeTestNeedle(t, v, data, i)
+	}
+	return v, data
+}
+
+func writeTestNeedle(t *testing.T, v *Volume, data map[uint64][]byte, i uint64) {
+	data[i] = bytes.Repeat([]byte(fmt.Sprintf("file %d,", i)), int(i%7)*100+1)
+	n := newEmptyNeedle(i)
+	n.Data = data[i]
+	n.Checksum = needle.NewCRC(n.Data)
+	if _, _, _, err := v.writeNeedle2(n, false); err != nil {
+		t.Fatalf("write needle %d: %v", i, err)
+	}
+}
+
+func assertVolumeNeedles(t *testing.T, v *Volume, data map[uint64][]byte) {
+	for i, expected := range data {
+		n := newEmptyNeedle(i)
+		if _, err := v.readNeedle(n, nil); err != nil {
+			t.Fatalf("read needle %d: %v", i, err)
+		}
+		if !bytes.Equal(n.Data, expected) {
+			t.Fatalf("needle %d has unexpected data", i)
+		}
+	}
+}
+
+func setInPlaceCompactionBatchSize(batchSize int) (restore func()) {
+	previous := inPlaceCompactionBatchSize
+	inPlaceCompactionBatchSize = batchSize
+	return func() {
+		inPlaceCompactionBatchSize = previous
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"compress/flate"
 	"compress/gzip"
 	"fmt"
+	"hash/crc32"
 	"io/ioutil"
 	"strings"
+	"sync"
 
 	"github.com/chrislusf/seaweedfs/weed/glog"
-	// "github.com/klauspost/compress/zstd"
+	"github.com/klauspost/compress/zstd"
 )
 
 var (
@@ -85,17 +87,75 @@ func IsGzippedContent(data []byte) bool {
 	return data[0] == 31 && data[1] == 139
 }
 
-/*
-var zstdEncoder, _ = zstd.NewWriter(nil)
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil)
 
-func ZstdData(input []byte) ([]byte, error) {
-	return zstdEncoder.EncodeAll(input, nil), nil
+	zstdDictionaries     = make(map[uint32][]byte)
+	zstdDictEncoders     = make(map[uint32]*zstd.Encoder)
+	zstdDecoder, _       = zstd.NewReader(nil)
+	zstdDictionariesLock sync.RWMutex
+)
+
+// RegisterZstdDictionary makes a raw content dictionary available for ZstdData and UnzstdData.
+// The returned dictionary id is recorded in each compressed frame, so the frames can be decompressed
+// by any process having the same dictionary registered.
+func RegisterZstdDictionary(dict []byte) (dictId uint32, err error) {
+	if len(dict) == 0 {
+		return 0, fmt.Errorf("empty zstd dictionary")
+	}
+	dictId = crc32.ChecksumIEEE(dict)
+	if dictId == 0 {
+		dictId = 1
+	}
+
+	zstdDictionariesLock.Lock()
+	defer zstdDictionariesLock.Unlock()
+
+	if _, found := zstdDictionaries[dictId]; found {
+		return dictId, nil
+	}
+	// dictionaries do not help with the default level in the current zstd implementation
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderDictRaw(dictId, dict), zstd.WithEncoderLevel(zstd.SpeedFastest))
+	if err != nil {
+		return 0, fmt.Errorf("zstd dictionary encoder: %v", err)
+	}
+	decoderOptions := []zstd.DOption{zstd.WithDecoderDictRaw(dictId, dict)}
+	for id, d := range zstdDictionaries {
+		decoderOptions = append(decoderOptions, zstd.WithDecoderDictRaw(id, d))
+	}
+	decoder, err := zstd.NewReader(nil, decoderOptions...)
+	if err != nil {
+		return 0, fmt.Errorf("zstd dictionary decoder: %v", err)
+	}
+	zstdDictionaries[dictId] = dict
+	zstdDictEncoders[dictId] = encoder
+	zstdDecoder = decoder
+	return dictId, nil
 }
 
-var decoder, _ = zstd.NewReader(nil)
+// ZstdData compresses with the registered dictionary, or without any dictionary if dictId is 0
+func ZstdData(input []byte, dictId uint32) ([]byte, error) {
+	if dictId == 0 {
+		return zstdEncoder.EncodeAll(input, nil), nil
+	}
+	zstdDictionariesLock.RLock()
+	encoder, found := zstdDictEncoders[dictId]
+	zstdDictionariesLock.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("zstd dictionary %x not registered", dictId)
+	}
+	return encoder.EncodeAll(input, nil), nil
+}
 
-func unzstdData(input []byte) ([]byte, error) {
-	return decoder.DecodeAll(input, nil)
+func UnzstdData(input []byte) ([]byte, error) {
+	zstdDictionariesLock.RLock()
+	decoder := zstdDecoder
+	zstdDictionariesLock.RUnlock()
+	output, err := decoder.DecodeAll(input, nil)
+	if err != nil {
+		glog.V(2).Infof("error unzstd data: %v", err)
+	}
+	return output, err
 }
 
 func IsZstdContent(data []byte) bool {
@@ -104,7 +164,54 @@ func IsZstdContent(data []byte) bool {
 	}
 	return data[3] == 0xFD && data[2] == 0x2F && data[1] == 0xB5 && data[0] == 0x28
 }
-*/
+
+// ZstdDictionaryId returns the dictionary id in the zstd frame header, 0 if no dictionary is used
+func ZstdDictionaryId(data []byte) uint32 {
+	if !IsZstdContent(data) || len(data) < 5 {
+		return 0
+	}
+	descriptor := data[4]
+	dictIdStart := 5
+	if descriptor&0x20 == 0 {
+		// not single segment, skip the window descriptor
+		dictIdStart++
+	}
+	dictIdSize := []int{0, 1, 2, 4}[descriptor&0x03]
+	if len(data) < dictIdStart+dictIdSize {
+		return 0
+	}
+	var dictId uint32
+	for i := dictIdSize - 1; i >= 0; i-- {
+		dictId = dictId<<8 | uint32(data[dictIdStart+i])
+	}
+	return dictId
+}
+
+// BuildZstdRawDictionary concatenates the beginning of each sample into a raw content dictionary
+// of at most maxSize bytes. This is not zstd dictionary training: there are no entropy tables, and
+// the content is not selected by how often it repeats. zstd only uses it as prior history to match
+// against, which still helps small files of the same kind, since they usually share their leading bytes.
+// The samples at the end of the list are closer to the end of the dictionary, which zstd matches more efficiently.
+func BuildZstdRawDictionary(samples [][]byte, maxSize int) []byte {
+	if len(samples) == 0 || maxSize <= 0 {
+		return nil
+	}
+	perSample := maxSize / len(samples)
+	if perSample < 64 {
+		perSample = 64
+	}
+	var dict []byte
+	for _, sample := range samples {
+		if len(sample) > perSample {
+			sample = sample[:perSample]
+		}
+		dict = append(dict, sample...)
+	}
+	if len(dict) > maxSize {
+		dict = dict[len(dict)-maxSize:]
+	}
+	return dict
+}
 
 /*
 * Default not to compressed since compression can be done on client side.
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"bytes"
+	"fmt"
 	"testing"
 
 	"golang.org/x/tools/godoc/util"
@@ -19,3 +21,38 @@ func TestIsGzippable(t *testing.T) {
 
 	t.Logf("compressed size %d\n", len(compressed))
 }
+
+func TestZstdWithDictionary(t *testing.T) {
+	var samples [][]byte
+	for i := 0; i < 50; i++ {
+		samples = append(samples, []byte(fmt.Sprintf(`{"user_id":%d,"name":"user%d","email":"user%d@example.com","status":"active"}`, i, i, i)))
+	}
+	dict := BuildZstdRawDictionary(samples, 4096)
+	dictId, err := RegisterZstdDictionary(dict)
+	if err != nil {
+		t.Fatalf("register dictionary: %v", err)
+	}
+
+	input := []byte(`{"user_id":777,"name":"user777","email":"user777@example.com","status":"active"}`)
+	withoutDict, _ := ZstdData(input, 0)
+	withDict, err := ZstdData(input, dictId)
+	if err != nil {
+		t.Fatalf("zstd with dictionary: %v", err)
+	}
+	if len(withDict) >= len(withoutDict) {
+		t.Errorf("dictionary does not help: %d >= %d", len(withDict), len(withoutDict))
+	}
+	if ZstdDictionaryId(withDict) != dictId || ZstdDictionaryId(withoutDict) != 0 {
+		t.Errorf("unexpected dictionary ids %x %x", ZstdDictionaryId(withDict), ZstdDictionaryId(withoutDict))
+	}
+
+	for _, compressed := range [][]byte{withDict, withoutDict} {
+		if !IsZstdContent(compressed) {
+			t.Errorf("not zstd content")
+		}
+		output, err := UnzstdData(compressed)
+		if err != nil || !bytes.Equal(output, input) {
+			t.Errorf("unzstd: %v", err)
+		}
+	}
+}
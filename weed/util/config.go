@@ -3,6 +3,7 @@ package util
 import (
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 
@@ -80,12 +81,36 @@ func (vp *ViperProxy) GetInt(key string) int {
 	return vp.Viper.GetInt(key)
 }
 
+func (vp *ViperProxy) GetFloat64(key string) float64 {
+	vp.Lock()
+	defer vp.Unlock()
+	return vp.Viper.GetFloat64(key)
+}
+
+func (vp *ViperProxy) GetDuration(key string) time.Duration {
+	vp.Lock()
+	defer vp.Unlock()
+	return vp.Viper.GetDuration(key)
+}
+
+func (vp *ViperProxy) GetStringMap(key string) map[string]interface{} {
+	vp.Lock()
+	defer vp.Unlock()
+	return vp.Viper.GetStringMap(key)
+}
+
 func (vp *ViperProxy) GetStringSlice(key string) []string {
 	vp.Lock()
 	defer vp.Unlock()
 	return vp.Viper.GetStringSlice(key)
 }
 
+func (vp *ViperProxy) GetStringMapString(key string) map[string]string {
+	vp.Lock()
+	defer vp.Unlock()
+	return vp.Viper.GetStringMapString(key)
+}
+
 func GetViper() *ViperProxy {
 	vp.Lock()
 	defer vp.Unlock()
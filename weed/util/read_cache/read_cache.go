@@ -0,0 +1,231 @@
+package read_cache
+
+import (
+	"container/list"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chrislusf/seaweedfs/weed/glog"
+)
+
+// ReadCache is a size capped LRU cache of byte slices, kept as files in a local directory, usually on a SSD.
+// The entries are grouped, e.g. by volume, and can be removed by group.
+// It is persistent across restarts: the least recently used order is restored from the file modification times.
+type ReadCache struct {
+	dir       string
+	sizeLimit int64
+	sync.Mutex
+	size    int64
+	lru     *list.List // the front is the most recently used
+	entries map[string]map[string]*list.Element
+}
+
+type cacheEntry struct {
+	group     string
+	key       string
+	size      int64
+	touchedAt time.Time
+}
+
+const touchInterval = time.Minute
+
+func NewReadCache(dir string, sizeLimit int64) (*ReadCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create read cache dir %s: %v", dir, err)
+	}
+	c := &ReadCache{
+		dir:       dir,
+		sizeLimit: sizeLimit,
+		lru:       list.New(),
+		entries:   make(map[string]map[string]*list.Element),
+	}
+
+	groups, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read cache dir %s: %v", dir, err)
+	}
+	var loaded []*cacheEntry
+	for _, group := range groups {
+		if !group.IsDir() {
+			continue
+		}
+		files, err := ioutil.ReadDir(filepath.Join(dir, group.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read cache dir %s: %v", group.Name(), err)
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file.Name(), ".tmp") {
+				os.Remove(filepath.Join(dir, group.Name(), file.Name()))
+				continue
+			}
+			loaded = append(loaded, &cacheEntry{
+				group:     group.Name(),
+				key:       file.Name(),
+				size:      file.Size(),
+				touchedAt: file.ModTime(),
+			})
+		}
+	}
+	sort.Slice(loaded, func(i, j int) bool {
+		return loaded[i].touchedAt.Before(loaded[j].touchedAt)
+	})
+
+	c.Lock()
+	defer c.Unlock()
+	for _, e := range loaded {
+		c.add(e)
+	}
+	c.evict()
+
+	glog.V(0).Infof("loaded read cache %s: %d entries, %d/%d bytes", dir, c.lru.Len(), c.size, c.sizeLimit)
+
+	return c, nil
+}
+
+func (c *ReadCache) fileName(group, key string) string {
+	return filepath.Join(c.dir, group, key)
+}
+
+// Get returns the cached data, and makes the entry the most recently used
+func (c *ReadCache) Get(group, key string) (data []byte, found bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.Lock()
+	elem, found := c.entries[group][key]
+	var touch bool
+	if found {
+		c.lru.MoveToFront(elem)
+		e := elem.Value.(*cacheEntry)
+		if now := time.Now(); now.Sub(e.touchedAt) > touchInterval {
+			e.touchedAt, touch = now, true
+		}
+	}
+	c.Unlock()
+	if !found {
+		return nil, false
+	}
+
+	fileName := c.fileName(group, key)
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		glog.V(0).Infof("read cache %s: %v", fileName, err)
+		c.Delete(group, key)
+		return nil, false
+	}
+	if touch {
+		// keep the order after restarts
+		now := time.Now()
+		os.Chtimes(fileName, now, now)
+	}
+	return data, true
+}
+
+// Set adds the data as the most recently used entry, and evicts the least recently used entries over the size limit.
+// Data larger than 1/16 of the size limit is not cached.
+func (c *ReadCache) Set(group, key string, data []byte) {
+	if c == nil || int64(len(data))*16 > c.sizeLimit {
+		return
+	}
+
+	fileName := c.fileName(group, key)
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		glog.V(0).Infof("read cache %s: %v", fileName, err)
+		return
+	}
+	tmpFileName := fmt.Sprintf("%s.%d.tmp", fileName, time.Now().UnixNano())
+	if err := ioutil.WriteFile(tmpFileName, data, 0644); err != nil {
+		glog.V(0).Infof("read cache %s: %v", fileName, err)
+		os.Remove(tmpFileName)
+		return
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if err := os.Rename(tmpFileName, fileName); err != nil {
+		glog.V(0).Infof("read cache %s: %v", fileName, err)
+		os.Remove(tmpFileName)
+		return
+	}
+	if elem, found := c.entries[group][key]; found {
+		c.size -= elem.Value.(*cacheEntry).size
+		c.lru.Remove(elem)
+	}
+	c.add(&cacheEntry{group: group, key: key, size: int64(len(data)), touchedAt: time.Now()})
+	c.evict()
+}
+
+// Delete removes one entry
+func (c *ReadCache) Delete(group, key string) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	if elem, found := c.entries[group][key]; found {
+		c.remove(elem)
+	}
+}
+
+// DeleteGroup removes all entries of the group
+func (c *ReadCache) DeleteGroup(group string) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	for _, elem := range c.entries[group] {
+		c.size -= elem.Value.(*cacheEntry).size
+		c.lru.Remove(elem)
+	}
+	delete(c.entries, group)
+	if err := os.RemoveAll(filepath.Join(c.dir, group)); err != nil {
+		glog.V(0).Infof("read cache delete %s: %v", group, err)
+	}
+}
+
+// Size returns the total size of the cached entries
+func (c *ReadCache) Size() int64 {
+	if c == nil {
+		return 0
+	}
+	c.Lock()
+	defer c.Unlock()
+	return c.size
+}
+
+func (c *ReadCache) add(e *cacheEntry) {
+	keys, found := c.entries[e.group]
+	if !found {
+		keys = make(map[string]*list.Element)
+		c.entries[e.group] = keys
+	}
+	keys[e.key] = c.lru.PushFront(e)
+	c.size += e.size
+}
+
+func (c *ReadCache) remove(elem *list.Element) {
+	e := elem.Value.(*cacheEntry)
+	c.size -= e.size
+	c.lru.Remove(elem)
+	delete(c.entries[e.group], e.key)
+	if len(c.entries[e.group]) == 0 {
+		delete(c.entries, e.group)
+	}
+	if err := os.Remove(c.fileName(e.group, e.key)); err != nil && !os.IsNotExist(err) {
+		glog.V(0).Infof("read cache evict %s/%s: %v", e.group, e.key, err)
+	}
+}
+
+func (c *ReadCache) evict() {
+	for c.size > c.sizeLimit && c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+	}
+}
@@ -0,0 +1,67 @@
+package read_cache
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestReadCacheEvictionAndReload(t *testing.T) {
+	dir, err := ioutil.TempDir("", "read_cache")
+	if err != nil {
+		t.Fatalf("temp dir creation: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	data := bytes.Repeat([]byte("x"), 100)
+
+	c, err := NewReadCache(dir, 1600)
+	if err != nil {
+		t.Fatalf("new read cache: %v", err)
+	}
+	for i := 0; i < 16; i++ {
+		c.Set("1", fmt.Sprintf("%d", i), data)
+	}
+	// key 0 becomes the most recently used
+	if _, found := c.Get("1", "0"); !found {
+		t.Fatalf("key 0 not found")
+	}
+	c.Set("2", "0", data)
+	if _, found := c.Get("1", "1"); found {
+		t.Fatalf("key 1 should be evicted")
+	}
+	if _, found := c.Get("1", "0"); !found {
+		t.Fatalf("key 0 should not be evicted")
+	}
+	if c.Size() != 1600 {
+		t.Fatalf("size %d", c.Size())
+	}
+
+	// data larger than 1/16 of the limit is not cached
+	c.Set("1", "large", bytes.Repeat([]byte("x"), 101))
+	if _, found := c.Get("1", "large"); found {
+		t.Fatalf("large data should not be cached")
+	}
+
+	// entries are kept after reload
+	c, err = NewReadCache(dir, 1600)
+	if err != nil {
+		t.Fatalf("reload read cache: %v", err)
+	}
+	if got, found := c.Get("2", "0"); !found || !bytes.Equal(got, data) {
+		t.Fatalf("key 0 of group 2 not reloaded")
+	}
+	if c.Size() != 1600 {
+		t.Fatalf("reloaded size %d", c.Size())
+	}
+
+	c.DeleteGroup("1")
+	if _, found := c.Get("1", "0"); found {
+		t.Fatalf("group 1 should be deleted")
+	}
+	if c.Size() != 100 {
+		t.Fatalf("size after deleting group %d", c.Size())
+	}
+}